@@ -7,4 +7,4 @@ require (
 	github.com/lib/pq v1.10.9
 )
 
-require github.com/go-chi/chi/v5 v5.2.1 // indirect
+require github.com/go-chi/chi/v5 v5.2.1
@@ -0,0 +1,289 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	ticketUseCase "nox_tickets/internal/application/usecases/ticket"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/email"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// tamanho máximo do título gerado a partir do assunto
+const maxTitulo = 200
+
+// Input do caso de uso de receber e-mail: a mensagem bruta, como chegou
+type ReceberEmailInput struct {
+	Bruto []byte
+}
+
+// Output do caso de uso de receber e-mail
+type ReceberEmailOutput struct {
+	MessageID    string
+	Resultado    email.Resultado
+	TicketID     string
+	ObservacaoID string
+	Anexos       int
+	Motivo       string // por que o e-mail foi rejeitado ou por que parte dos anexos se perdeu
+	Duplicado    bool   // o e-mail já tinha sido processado antes
+}
+
+// Caso de uso que transforma um e-mail recebido em ticket ou, se for resposta a um
+// ticket existente, em observação. Roda sem usuário autenticado: o remetente é o autor.
+type ReceberEmailUseCase struct {
+	emailRepository            email.Repository
+	diretorio                  *usuario.Diretorio
+	roteamento                 *email.Roteamento
+	criarTicketUseCase         *ticketUseCase.CriarTicketUseCase
+	adicionarObservacaoUseCase *ticketUseCase.AdicionarObservacaoUseCase
+	adicionarAnexoUseCase      *ticketUseCase.AdicionarAnexoUseCase
+}
+
+// NewReceberEmailUseCase cria o caso de uso de receber e-mail
+func NewReceberEmailUseCase(
+	repo email.Repository,
+	diretorio *usuario.Diretorio,
+	roteamento *email.Roteamento,
+	criarTicketUseCase *ticketUseCase.CriarTicketUseCase,
+	adicionarObservacaoUseCase *ticketUseCase.AdicionarObservacaoUseCase,
+	adicionarAnexoUseCase *ticketUseCase.AdicionarAnexoUseCase,
+) *ReceberEmailUseCase {
+	return &ReceberEmailUseCase{
+		emailRepository:            repo,
+		diretorio:                  diretorio,
+		roteamento:                 roteamento,
+		criarTicketUseCase:         criarTicketUseCase,
+		adicionarObservacaoUseCase: adicionarObservacaoUseCase,
+		adicionarAnexoUseCase:      adicionarAnexoUseCase,
+	}
+}
+
+// Executa o caso de uso de receber e-mail. E-mails que não podem virar ticket nem
+// observação (remetente desativado, ticket inacessível, sem caixa de destino) são
+// registrados como rejeitados e não retornam erro; o erro indica falha temporária,
+// e a mesma mensagem deve ser entregue de novo mais tarde.
+func (uc *ReceberEmailUseCase) Execute(ctx context.Context, input ReceberEmailInput) (*ReceberEmailOutput, error) {
+	// 1. decodifica a mensagem; sem cabeçalhos válidos não há nem o que registrar
+	m, err := email.Analisar(input.Bruto)
+	if err != nil {
+		return &ReceberEmailOutput{Resultado: email.ResultadoRejeitado, Motivo: err.Error()}, nil
+	}
+
+	// 2. a mesma mensagem pode chegar mais de uma vez (reentrega, cópia para duas caixas): o Message-ID
+	// é reservado antes de criar qualquer coisa, e só a entrega que reserva processa a mensagem
+	recebido := &email.Recebido{
+		MessageID:       m.MessageID,
+		Remetente:       m.Remetente,
+		Assunto:         m.Assunto,
+		DataRecebimento: time.Now(),
+	}
+	reservado, err := uc.emailRepository.Reservar(recebido)
+	if err != nil {
+		return nil, err
+	}
+	if !reservado {
+		anterior, err := uc.emailRepository.BuscarRecebido(m.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		output := outputDe(anterior)
+		output.Duplicado = true
+		return output, nil
+	}
+
+	// 3. se nada foi criado, a falha libera a reserva para que a reentrega processe a mensagem; depois de
+	// criado o ticket ou a observação, a reserva fica, e a mensagem não é processada de novo
+	output, err := uc.processar(ctx, m, recebido)
+	if err != nil && recebido.TicketID == "" {
+		if errLiberar := uc.emailRepository.Liberar(m.MessageID); errLiberar != nil {
+			return nil, errors.Join(err, errLiberar)
+		}
+	}
+	return output, err
+}
+
+// processar transforma o e-mail reservado em ticket ou observação e registra o resultado
+func (uc *ReceberEmailUseCase) processar(ctx context.Context, m *email.Mensagem, recebido *email.Recebido) (*ReceberEmailOutput, error) {
+	// 1. respostas automáticas (férias, avisos de entrega) não viram ticket, e
+	// respondê-las criaria um laço com as notificações
+	if m.Automatica {
+		return uc.rejeitar(recebido, "resposta automática")
+	}
+
+	// 2. o remetente é o autor; quem nunca acessou a aplicação é cadastrado pelo e-mail.
+	// O e-mail não comprova papéis, então o remetente age sempre como solicitante.
+	autor, err := uc.diretorio.UsuarioDoEmail(m.Remetente, m.NomeRemetente)
+	if err != nil {
+		if erro, ok := ticket.ComoErro(err); ok {
+			return uc.rejeitar(recebido, erro.Mensagem)
+		}
+		return nil, err
+	}
+	ctx = auth.ComPrincipal(ctx, auth.Principal{
+		ID:     autor.ID,
+		Nome:   autor.Nome,
+		Email:  autor.Email,
+		Papeis: []string{string(acesso.PapelSolicitante)},
+	})
+
+	// 3. resposta a um ticket existente vira observação; o resto abre um ticket novo
+	ticketID, err := uc.ticketDaConversa(m)
+	if err != nil {
+		return nil, err
+	}
+	if ticketID != "" {
+		err = uc.adicionarObservacao(ctx, m, ticketID, recebido)
+	} else {
+		err = uc.criarTicket(ctx, m, recebido)
+	}
+	if err != nil {
+		if erro, ok := ticket.ComoErro(err); ok {
+			return uc.rejeitar(recebido, erro.Mensagem)
+		}
+		return nil, err
+	}
+
+	// 4. guarda os anexos; a partir daqui o ticket já existe, então falhas nos anexos
+	// não desfazem o processamento e ficam registradas no motivo
+	recebido.Anexos, recebido.Motivo = uc.salvarAnexos(ctx, m.Anexos, recebido.TicketID, recebido.ObservacaoID)
+
+	// 5. conclui o registro do e-mail, que encontra as respostas a ele
+	if err := uc.emailRepository.Registrar(recebido); err != nil {
+		return nil, err
+	}
+	return outputDe(recebido), nil
+}
+
+// ticketDaConversa encontra o ticket de que o e-mail trata: primeiro pelos e-mails
+// respondidos (enviados pela aplicação ou recebidos antes), depois pela referência
+// [#<id>] no assunto
+func (uc *ReceberEmailUseCase) ticketDaConversa(m *email.Mensagem) (string, error) {
+	anteriores := append(append([]string{}, m.InReplyTo...), m.References...)
+	for _, id := range anteriores {
+		if ticketID, ok := email.TicketDoIDMensagem(id); ok {
+			return ticketID, nil
+		}
+	}
+	ticketID, err := uc.emailRepository.TicketDasMensagens(anteriores)
+	if err != nil || ticketID != "" {
+		return ticketID, err
+	}
+	ticketID, _ = email.TicketDoAssunto(m.Assunto)
+	return ticketID, nil
+}
+
+func (uc *ReceberEmailUseCase) adicionarObservacao(ctx context.Context, m *email.Mensagem, ticketID string, recebido *email.Recebido) error {
+	// só o que foi escrito agora, sem a mensagem citada
+	texto := email.TextoDaResposta(m.Texto)
+	if texto == "" && len(m.Anexos) > 0 {
+		texto = fmt.Sprintf("(e-mail sem texto, com %d anexo(s))", len(m.Anexos))
+	}
+	if texto == "" {
+		return ticketUseCase.ErrDescricaoVazia
+	}
+
+	output, err := uc.adicionarObservacaoUseCase.Execute(ctx, ticketUseCase.AdicionarObservacaoInput{
+		ID:        ticketID,
+		Descricao: texto,
+	})
+	if err != nil {
+		return err
+	}
+	recebido.Resultado = email.ResultadoObservacao
+	recebido.TicketID = output.TicketID
+	recebido.ObservacaoID = output.ID
+	return nil
+}
+
+func (uc *ReceberEmailUseCase) criarTicket(ctx context.Context, m *email.Mensagem, recebido *email.Recebido) error {
+	// a caixa que recebeu o e-mail define a categoria
+	caixa, ok := uc.roteamento.CaixaPara(m.Destinatarios)
+	if !ok {
+		return email.ErrCaixaDesconhecida
+	}
+
+	titulo := email.TituloDoAssunto(m.Assunto)
+	if titulo == "" {
+		titulo = "(sem assunto)"
+	}
+	if utf8.RuneCountInString(titulo) > maxTitulo {
+		titulo = string([]rune(titulo)[:maxTitulo-1]) + "…"
+	}
+	descricao := m.Texto
+	if descricao == "" {
+		descricao = "(e-mail sem texto)"
+	}
+
+	output, err := uc.criarTicketUseCase.Execute(ctx, ticketUseCase.CriarTicketInput{
+		Titulo:       titulo,
+		Descricao:    descricao,
+		Categoria:    caixa.Categoria,
+		Subcategoria: caixa.Subcategoria,
+		Urgencia:     caixa.Urgencia,
+		Gravidade:    caixa.Gravidade,
+		Contato:      m.Remetente,
+
+		// o e-mail não traz o formulário da categoria; os obrigatórios são preenchidos no atendimento
+		SemObrigatorios: true,
+	})
+	if err != nil {
+		return err
+	}
+	recebido.Resultado = email.ResultadoTicketCriado
+	recebido.TicketID = output.ID
+	return nil
+}
+
+// salvarAnexos grava cada anexo no ticket, em nome do remetente, retornando quantos
+// foram gravados e, se algum falhou (tipo não permitido, tamanho), a descrição das falhas
+func (uc *ReceberEmailUseCase) salvarAnexos(ctx context.Context, arquivos []email.Arquivo, ticketID, observacaoID string) (int, string) {
+	gravados := 0
+	var falhas []string
+	for _, arquivo := range arquivos {
+		_, err := uc.adicionarAnexoUseCase.Execute(ctx, ticketUseCase.AdicionarAnexoInput{
+			ID:           ticketID,
+			ObservacaoID: observacaoID,
+			Nome:         arquivo.Nome,
+			Conteudo:     bytes.NewReader(arquivo.Conteudo),
+		})
+		if err != nil {
+			falhas = append(falhas, fmt.Sprintf("%s: %v", arquivo.Nome, err))
+			continue
+		}
+		gravados++
+	}
+
+	if len(falhas) == 0 {
+		return gravados, ""
+	}
+	return gravados, "anexos não gravados: " + strings.Join(falhas, "; ")
+}
+
+// rejeitar registra o e-mail como rejeitado, para que reentregas não sejam reprocessadas
+func (uc *ReceberEmailUseCase) rejeitar(recebido *email.Recebido, motivo string) (*ReceberEmailOutput, error) {
+	recebido.Resultado = email.ResultadoRejeitado
+	recebido.Motivo = motivo
+	if err := uc.emailRepository.Registrar(recebido); err != nil {
+		return nil, err
+	}
+	return outputDe(recebido), nil
+}
+
+func outputDe(r *email.Recebido) *ReceberEmailOutput {
+	return &ReceberEmailOutput{
+		MessageID:    r.MessageID,
+		Resultado:    r.Resultado,
+		TicketID:     r.TicketID,
+		ObservacaoID: r.ObservacaoID,
+		Anexos:       r.Anexos,
+		Motivo:       r.Motivo,
+	}
+}
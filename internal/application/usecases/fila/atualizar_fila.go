@@ -0,0 +1,95 @@
+package fila
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de atualizar fila; campos nulos não são alterados
+type AtualizarFilaInput struct {
+	ID                   string
+	Nome                 *string
+	Categoria            *ticket.Categoria
+	Subcategoria         *ticket.Subcategoria
+	EquipeID             *string
+	Estrategia           *fila.Estrategia
+	AtribuicaoAutomatica *bool
+	Ativa                *bool
+}
+
+// Caso de uso de atualizar fila
+type AtualizarFilaUseCase struct {
+	filaRepository fila.Repository
+	taxonomia      ticket.Taxonomia
+	autorizador    *acesso.Autorizador
+}
+
+// NewAtualizarFilaUseCase cria uma nova instância do caso de uso de atualizar fila
+func NewAtualizarFilaUseCase(repo fila.Repository, taxonomia ticket.Taxonomia, autorizador *acesso.Autorizador) *AtualizarFilaUseCase {
+	return &AtualizarFilaUseCase{
+		filaRepository: repo,
+		taxonomia:      taxonomia,
+		autorizador:    autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar fila
+func (uc *AtualizarFilaUseCase) Execute(ctx context.Context, input AtualizarFilaInput) (*FilaOutput, error) {
+	// 1. apenas administradores configuram filas
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. busca a fila existente
+	f, err := uc.filaRepository.Buscar(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. aplica as alterações informadas
+	if input.Nome != nil {
+		if err := f.SetNome(*input.Nome); err != nil {
+			return nil, err
+		}
+	}
+	if input.Categoria != nil || input.Subcategoria != nil {
+		categoria, subcategoria := f.Categoria, f.Subcategoria
+		if input.Categoria != nil {
+			categoria = *input.Categoria
+		}
+		if input.Subcategoria != nil {
+			subcategoria = *input.Subcategoria
+		}
+		if err := f.SetRecorte(categoria, subcategoria); err != nil {
+			return nil, err
+		}
+		if err := taxonomia.ValidarRecorte(uc.taxonomia, f.Categoria, f.Subcategoria); err != nil {
+			return nil, err
+		}
+	}
+	if input.EquipeID != nil {
+		if err := f.SetEquipe(*input.EquipeID); err != nil {
+			return nil, err
+		}
+	}
+	if input.Estrategia != nil {
+		if err := f.SetEstrategia(*input.Estrategia); err != nil {
+			return nil, err
+		}
+	}
+	if input.AtribuicaoAutomatica != nil {
+		f.AtribuicaoAutomatica = *input.AtribuicaoAutomatica
+	}
+	if input.Ativa != nil {
+		f.Ativa = *input.Ativa
+	}
+
+	// 4. persiste
+	if err := uc.filaRepository.Atualizar(f); err != nil {
+		return nil, err
+	}
+	return novaFilaOutput(f), nil
+}
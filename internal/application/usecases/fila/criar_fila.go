@@ -0,0 +1,86 @@
+package fila
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de criar fila
+type CriarFilaInput struct {
+	Nome                 string
+	Categoria            ticket.Categoria
+	Subcategoria         ticket.Subcategoria
+	EquipeID             string
+	Estrategia           fila.Estrategia
+	AtribuicaoAutomatica bool
+}
+
+// FilaOutput é a fila no formato de saída dos casos de uso
+type FilaOutput struct {
+	ID                   string
+	Nome                 string
+	Categoria            ticket.Categoria
+	Subcategoria         ticket.Subcategoria
+	EquipeID             string
+	Estrategia           fila.Estrategia
+	AtribuicaoAutomatica bool
+	Ativa                bool
+	DataCriacao          string
+}
+
+// Caso de uso de criar fila
+type CriarFilaUseCase struct {
+	filaRepository fila.Repository
+	taxonomia      ticket.Taxonomia
+	autorizador    *acesso.Autorizador
+}
+
+// NewCriarFilaUseCase cria uma nova instância do caso de uso de criar fila
+func NewCriarFilaUseCase(repo fila.Repository, taxonomia ticket.Taxonomia, autorizador *acesso.Autorizador) *CriarFilaUseCase {
+	return &CriarFilaUseCase{
+		filaRepository: repo,
+		taxonomia:      taxonomia,
+		autorizador:    autorizador,
+	}
+}
+
+// Executa o caso de uso de criar fila
+func (uc *CriarFilaUseCase) Execute(ctx context.Context, input CriarFilaInput) (*FilaOutput, error) {
+	// 1. apenas administradores configuram filas
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. cria a fila pela entidade de domínio
+	f, err := fila.NovaFila(input.Nome, input.Categoria, input.Subcategoria, input.EquipeID, input.Estrategia)
+	if err != nil {
+		return nil, err
+	}
+	if err := taxonomia.ValidarRecorte(uc.taxonomia, f.Categoria, f.Subcategoria); err != nil {
+		return nil, err
+	}
+	f.AtribuicaoAutomatica = input.AtribuicaoAutomatica
+
+	// 3. persiste
+	if err := uc.filaRepository.Criar(f); err != nil {
+		return nil, err
+	}
+	return novaFilaOutput(f), nil
+}
+
+func novaFilaOutput(f *fila.Fila) *FilaOutput {
+	return &FilaOutput{
+		ID:                   f.ID,
+		Nome:                 f.Nome,
+		Categoria:            f.Categoria,
+		Subcategoria:         f.Subcategoria,
+		EquipeID:             f.EquipeID,
+		Estrategia:           f.Estrategia,
+		AtribuicaoAutomatica: f.AtribuicaoAutomatica,
+		Ativa:                f.Ativa,
+		DataCriacao:          f.DataCriacao.Format("2006-01-02 15:04:05"),
+	}
+}
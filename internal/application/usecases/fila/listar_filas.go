@@ -0,0 +1,40 @@
+package fila
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/fila"
+)
+
+// input do caso de uso de listar filas
+type ListarFilasInput struct {
+	ApenasAtivas bool
+}
+
+// Caso de uso de listar filas
+type ListarFilasUseCase struct {
+	filaRepository fila.Repository
+}
+
+// NewListarFilasUseCase cria uma nova instância do caso de uso de listar filas
+func NewListarFilasUseCase(repo fila.Repository) *ListarFilasUseCase {
+	return &ListarFilasUseCase{filaRepository: repo}
+}
+
+// Executa o caso de uso de listar filas
+func (uc *ListarFilasUseCase) Execute(ctx context.Context, input ListarFilasInput) ([]*FilaOutput, error) {
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	filas, err := uc.filaRepository.Listar(input.ApenasAtivas)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*FilaOutput, len(filas))
+	for i, f := range filas {
+		output[i] = novaFilaOutput(f)
+	}
+	return output, nil
+}
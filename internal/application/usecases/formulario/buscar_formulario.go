@@ -0,0 +1,68 @@
+package formulario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/formulario"
+	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+// input do caso de uso de buscar o formulário de uma classificação
+type BuscarFormularioInput struct {
+	Categoria    ticket.Categoria
+	Subcategoria ticket.Subcategoria
+}
+
+// output com o formulário e a ordem de exibição dos campos
+type FormularioOutput struct {
+	Categoria       ticket.Categoria
+	Subcategoria    ticket.Subcategoria
+	Esquema         *formulario.Esquema
+	Campos          []string // nomes dos campos na ordem de exibição
+	DataAtualizacao string
+}
+
+func novoFormularioOutput(f *formulario.Formulario) *FormularioOutput {
+	return &FormularioOutput{
+		Categoria:       f.Categoria,
+		Subcategoria:    f.Subcategoria,
+		Esquema:         f.Esquema,
+		Campos:          f.Esquema.CamposOrdenados(),
+		DataAtualizacao: f.DataAtualizacao.Format(time.DateTime),
+	}
+}
+
+// Caso de uso de buscar o formulário, para o frontend renderizar a abertura e a edição de tickets
+type BuscarFormularioUseCase struct {
+	validador *formulario.Validador
+	taxonomia ticket.Taxonomia
+}
+
+// NewBuscarFormularioUseCase cria uma nova instância do caso de uso de buscar formulário
+func NewBuscarFormularioUseCase(validador *formulario.Validador, taxonomia ticket.Taxonomia) *BuscarFormularioUseCase {
+	return &BuscarFormularioUseCase{
+		validador: validador,
+		taxonomia: taxonomia,
+	}
+}
+
+// Executa o caso de uso; classificações sem formulário cadastrado têm um formulário vazio
+func (uc *BuscarFormularioUseCase) Execute(ctx context.Context, input BuscarFormularioInput) (*FormularioOutput, error) {
+	// 1. apenas usuários autenticados
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	// 2. a classificação precisa estar ativa na taxonomia
+	if err := uc.taxonomia.ValidarClassificacao(input.Categoria, input.Subcategoria); err != nil {
+		return nil, err
+	}
+
+	// 3. busca o formulário
+	f, err := uc.validador.Formulario(input.Categoria, input.Subcategoria)
+	if err != nil {
+		return nil, err
+	}
+	return novoFormularioOutput(f), nil
+}
@@ -0,0 +1,55 @@
+package formulario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/formulario"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de salvar o formulário de uma classificação
+type SalvarFormularioInput struct {
+	Categoria    ticket.Categoria
+	Subcategoria ticket.Subcategoria
+	Esquema      *formulario.Esquema
+}
+
+// Caso de uso de criar ou substituir o formulário. Os tickets existentes não são alterados:
+// eles passam a seguir o novo formulário na próxima edição
+type SalvarFormularioUseCase struct {
+	formularioRepository formulario.Repository
+	taxonomia            ticket.Taxonomia
+	autorizador          *acesso.Autorizador
+}
+
+// NewSalvarFormularioUseCase cria uma nova instância do caso de uso de salvar formulário
+func NewSalvarFormularioUseCase(repo formulario.Repository, taxonomia ticket.Taxonomia, autorizador *acesso.Autorizador) *SalvarFormularioUseCase {
+	return &SalvarFormularioUseCase{
+		formularioRepository: repo,
+		taxonomia:            taxonomia,
+		autorizador:          autorizador,
+	}
+}
+
+// Executa o caso de uso de salvar formulário
+func (uc *SalvarFormularioUseCase) Execute(ctx context.Context, input SalvarFormularioInput) (*FormularioOutput, error) {
+	// 1. apenas administradores mantêm os formulários
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. a classificação precisa existir na taxonomia
+	if err := uc.taxonomia.ValidarClassificacao(input.Categoria, input.Subcategoria); err != nil {
+		return nil, err
+	}
+
+	// 3. valida o esquema e persiste
+	f, err := formulario.NovoFormulario(input.Categoria, input.Subcategoria, input.Esquema)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.formularioRepository.Salvar(f); err != nil {
+		return nil, err
+	}
+	return novoFormularioOutput(f), nil
+}
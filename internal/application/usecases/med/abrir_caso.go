@@ -0,0 +1,154 @@
+package med
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/med"
+	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+// input do caso de uso de abrir o caso MED de um ticket
+type AbrirCasoInput struct {
+	TicketID string
+	Dados    med.Dados
+}
+
+// CasoOutput é o caso MED com os prazos; o histórico vem apenas na busca
+type CasoOutput struct {
+	TicketID        string
+	EndToEndID      string
+	IDNotificacao   string
+	Valor           int64
+	Pagador         med.Participante
+	Recebedor       med.Participante
+	DataTransacao   string
+	DataNotificacao string
+	Situacao        med.Situacao
+	ValorBloqueado  int64
+	ValorDevolvido  int64
+	PrazoAnalise    string
+	PrazoDevolucao  string
+	PrazoVigente    string // prazo da etapa atual, vazio nos casos encerrados
+	Vencido         bool
+	DataBloqueio    string
+	DataConclusao   string
+
+	Historico        []RegistroOutput
+	HistoricoIntegro bool
+}
+
+// RegistroOutput é uma entrada do histórico do caso
+type RegistroOutput struct {
+	Sequencia        int
+	Acao             med.Acao
+	SituacaoAnterior med.Situacao
+	SituacaoNova     med.Situacao
+	Valor            int64
+	Justificativa    string
+	Dados            string // JSON dos dados do caso após a ação
+	ForaDoPrazo      bool
+	UsuarioID        string
+	Data             string
+	HashAnterior     string
+	Hash             string
+}
+
+func novoCasoOutput(c *med.Caso, agora time.Time) *CasoOutput {
+	output := &CasoOutput{
+		TicketID:        c.TicketID,
+		EndToEndID:      c.EndToEndID,
+		IDNotificacao:   c.IDNotificacao,
+		Valor:           c.Valor,
+		Pagador:         c.Pagador,
+		Recebedor:       c.Recebedor,
+		DataTransacao:   c.DataTransacao.Format(time.DateTime),
+		DataNotificacao: c.DataNotificacao.Format(time.DateTime),
+		Situacao:        c.Situacao,
+		ValorBloqueado:  c.ValorBloqueado,
+		ValorDevolvido:  c.ValorDevolvido,
+		PrazoAnalise:    c.PrazoAnalise.Format(time.DateTime),
+		PrazoDevolucao:  c.PrazoDevolucao.Format(time.DateTime),
+		Vencido:         c.Vencido(agora),
+	}
+	if prazo := c.PrazoVigente(); prazo != nil {
+		output.PrazoVigente = prazo.Format(time.DateTime)
+	}
+	if c.DataBloqueio != nil {
+		output.DataBloqueio = c.DataBloqueio.Format(time.DateTime)
+	}
+	if c.DataConclusao != nil {
+		output.DataConclusao = c.DataConclusao.Format(time.DateTime)
+	}
+	return output
+}
+
+// comHistorico acrescenta ao output o histórico e a verificação da cadeia de hashes
+func (o *CasoOutput) comHistorico(c *med.Caso) *CasoOutput {
+	o.Historico = make([]RegistroOutput, 0, len(c.Historico))
+	for _, r := range c.Historico {
+		o.Historico = append(o.Historico, RegistroOutput{
+			Sequencia:        r.Sequencia,
+			Acao:             r.Acao,
+			SituacaoAnterior: r.SituacaoAnterior,
+			SituacaoNova:     r.SituacaoNova,
+			Valor:            r.Valor,
+			Justificativa:    r.Justificativa,
+			Dados:            r.Dados,
+			ForaDoPrazo:      r.ForaDoPrazo,
+			UsuarioID:        r.UsuarioID,
+			Data:             r.Data.Format(time.DateTime),
+			HashAnterior:     r.HashAnterior,
+			Hash:             r.Hash,
+		})
+	}
+	o.HistoricoIntegro = c.VerificarHistorico() == nil
+	return o
+}
+
+// Caso de uso de abrir o caso MED de um ticket da categoria meds
+type AbrirCasoUseCase struct {
+	ticketRepository ticket.Repository
+	medRepository    med.Repository
+	prazos           med.Prazos
+	autorizador      *acesso.Autorizador
+}
+
+// NewAbrirCasoUseCase cria uma nova instância do caso de uso de abrir caso MED
+func NewAbrirCasoUseCase(repo ticket.Repository, medRepo med.Repository, prazos med.Prazos, autorizador *acesso.Autorizador) *AbrirCasoUseCase {
+	return &AbrirCasoUseCase{
+		ticketRepository: repo,
+		medRepository:    medRepo,
+		prazos:           prazos,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de abrir caso MED; quem pode alterar o ticket pode abrir o caso
+func (uc *AbrirCasoUseCase) Execute(ctx context.Context, input AbrirCasoInput) (*CasoOutput, error) {
+	// 1. identifica o usuário e busca o ticket
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarEscrita(ator, t); err != nil {
+		return nil, err
+	}
+
+	// 2. valida os dados e calcula os prazos
+	agora := time.Now()
+	c, err := med.NovoCaso(t, input.Dados, uc.prazos, ator.ID, agora)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. persiste o caso com o registro de abertura
+	if err := uc.medRepository.Criar(c); err != nil {
+		return nil, err
+	}
+	return novoCasoOutput(c, agora).comHistorico(c), nil
+}
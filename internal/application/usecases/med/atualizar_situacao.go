@@ -0,0 +1,92 @@
+package med
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/med"
+	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+var (
+	ErrSituacaoDesconhecida = ticket.NovoErroValidacao("situacao", "situacao_invalida", "situação do caso MED inválida")
+)
+
+// input do caso de uso de mudar a situação do caso MED
+type AtualizarSituacaoInput struct {
+	TicketID      string
+	Situacao      med.Situacao
+	Valor         int64 // em centavos: bloqueado ou devolvido
+	Justificativa string
+}
+
+// Caso de uso de decidir o caso MED: bloquear os fundos, devolver ou rejeitar
+type AtualizarSituacaoUseCase struct {
+	ticketRepository ticket.Repository
+	medRepository    med.Repository
+	autorizador      *acesso.Autorizador
+}
+
+// NewAtualizarSituacaoUseCase cria uma nova instância do caso de uso de atualizar a situação do caso MED
+func NewAtualizarSituacaoUseCase(repo ticket.Repository, medRepo med.Repository, autorizador *acesso.Autorizador) *AtualizarSituacaoUseCase {
+	return &AtualizarSituacaoUseCase{
+		ticketRepository: repo,
+		medRepository:    medRepo,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar a situação do caso MED
+func (uc *AtualizarSituacaoUseCase) Execute(ctx context.Context, input AtualizarSituacaoInput) (*CasoOutput, error) {
+	if !med.SituacaoValida(input.Situacao) {
+		return nil, ErrSituacaoDesconhecida
+	}
+	decisao := med.Decisao{Valor: input.Valor, Justificativa: input.Justificativa}
+	return alterarCaso(ctx, uc.ticketRepository, uc.medRepository, uc.autorizador, input.TicketID,
+		func(c *med.Caso, usuarioID string, agora time.Time) error {
+			return c.Aplicar(input.Situacao, decisao, usuarioID, agora)
+		},
+	)
+}
+
+// alterarCaso aplica a mudança ao caso de um ticket que o usuário pode alterar e persiste os registros novos
+func alterarCaso(
+	ctx context.Context,
+	ticketRepository ticket.Repository,
+	medRepository med.Repository,
+	autorizador *acesso.Autorizador,
+	ticketID string,
+	mudar func(c *med.Caso, usuarioID string, agora time.Time) error,
+) (*CasoOutput, error) {
+	// 1. identifica o usuário e confere a permissão no ticket
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ticketRepository.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := autorizador.VerificarEscrita(ator, t); err != nil {
+		return nil, err
+	}
+
+	// 2. busca o caso; um histórico adulterado não recebe novos registros
+	c, err := medRepository.BuscarPorTicket(t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.VerificarHistorico(); err != nil {
+		return nil, err
+	}
+
+	// 3. aplica a mudança e persiste
+	agora := time.Now()
+	if err := mudar(c, ator.ID, agora); err != nil {
+		return nil, err
+	}
+	if err := medRepository.Atualizar(c); err != nil {
+		return nil, err
+	}
+	return novoCasoOutput(c, agora).comHistorico(c), nil
+}
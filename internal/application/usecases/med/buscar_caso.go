@@ -0,0 +1,51 @@
+package med
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/med"
+	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+// input do caso de uso de buscar o caso MED de um ticket
+type BuscarCasoInput struct {
+	TicketID string
+}
+
+// Caso de uso de buscar o caso MED com o histórico
+type BuscarCasoUseCase struct {
+	ticketRepository ticket.Repository
+	medRepository    med.Repository
+	autorizador      *acesso.Autorizador
+}
+
+// NewBuscarCasoUseCase cria uma nova instância do caso de uso de buscar caso MED
+func NewBuscarCasoUseCase(repo ticket.Repository, medRepo med.Repository, autorizador *acesso.Autorizador) *BuscarCasoUseCase {
+	return &BuscarCasoUseCase{
+		ticketRepository: repo,
+		medRepository:    medRepo,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de buscar caso MED; quem pode ver o ticket vê o caso e o histórico
+func (uc *BuscarCasoUseCase) Execute(ctx context.Context, input BuscarCasoInput) (*CasoOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return nil, err
+	}
+
+	c, err := uc.medRepository.BuscarPorTicket(t.ID)
+	if err != nil {
+		return nil, err
+	}
+	return novoCasoOutput(c, time.Now()).comHistorico(c), nil
+}
@@ -0,0 +1,43 @@
+package med
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/med"
+	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+// input do caso de uso de corrigir os dados do caso MED
+type CorrigirCasoInput struct {
+	TicketID      string
+	Dados         med.Dados
+	Justificativa string
+}
+
+// Caso de uso de corrigir os dados do caso MED enquanto ele está em análise
+type CorrigirCasoUseCase struct {
+	ticketRepository ticket.Repository
+	medRepository    med.Repository
+	prazos           med.Prazos
+	autorizador      *acesso.Autorizador
+}
+
+// NewCorrigirCasoUseCase cria uma nova instância do caso de uso de corrigir caso MED
+func NewCorrigirCasoUseCase(repo ticket.Repository, medRepo med.Repository, prazos med.Prazos, autorizador *acesso.Autorizador) *CorrigirCasoUseCase {
+	return &CorrigirCasoUseCase{
+		ticketRepository: repo,
+		medRepository:    medRepo,
+		prazos:           prazos,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de corrigir caso MED; os dados são substituídos por inteiro
+func (uc *CorrigirCasoUseCase) Execute(ctx context.Context, input CorrigirCasoInput) (*CasoOutput, error) {
+	return alterarCaso(ctx, uc.ticketRepository, uc.medRepository, uc.autorizador, input.TicketID,
+		func(c *med.Caso, usuarioID string, agora time.Time) error {
+			return c.Corrigir(input.Dados, uc.prazos, input.Justificativa, usuarioID, agora)
+		},
+	)
+}
@@ -0,0 +1,65 @@
+package med
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/med"
+	"time"
+)
+
+// input do caso de uso de listar os casos MED
+type ListarCasosInput struct {
+	Situacoes []med.Situacao
+	Vencidos  bool       // apenas os casos com o prazo da etapa atual vencido
+	PrazoAte  *time.Time // apenas os casos cujo prazo vigente vence até o instante
+}
+
+// Caso de uso de listar os casos MED pelo prazo, para o acompanhamento dos prazos regulatórios
+type ListarCasosUseCase struct {
+	medRepository med.Repository
+	autorizador   *acesso.Autorizador
+}
+
+// NewListarCasosUseCase cria uma nova instância do caso de uso de listar casos MED
+func NewListarCasosUseCase(medRepo med.Repository, autorizador *acesso.Autorizador) *ListarCasosUseCase {
+	return &ListarCasosUseCase{
+		medRepository: medRepo,
+		autorizador:   autorizador,
+	}
+}
+
+// Executa o caso de uso de listar casos MED, limitado aos tickets que o usuário pode ver
+func (uc *ListarCasosUseCase) Execute(ctx context.Context, input ListarCasosInput) ([]CasoOutput, error) {
+	// 1. valida os filtros
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range input.Situacoes {
+		if !med.SituacaoValida(s) {
+			return nil, ErrSituacaoDesconhecida
+		}
+	}
+
+	// 2. casos vencidos são os de prazo vigente até agora
+	agora := time.Now()
+	prazoAte := input.PrazoAte
+	if input.Vencidos && (prazoAte == nil || prazoAte.After(agora)) {
+		prazoAte = &agora
+	}
+
+	// 3. busca os casos
+	casos, err := uc.medRepository.Listar(med.Filtro{
+		Situacoes: input.Situacoes,
+		PrazoAte:  prazoAte,
+		Escopo:    uc.autorizador.Escopo(ator),
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := make([]CasoOutput, 0, len(casos))
+	for _, c := range casos {
+		output = append(output, *novoCasoOutput(c, agora))
+	}
+	return output, nil
+}
@@ -0,0 +1,19 @@
+package notificacao
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// exigirProprioOuAdmin permite que cada usuário gerencie as próprias notificações; o admin gerencia as de todos
+func exigirProprioOuAdmin(ctx context.Context, autorizador *acesso.Autorizador, usuarioID string) error {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return err
+	}
+	if ator.ID != usuarioID && !autorizador.Permissoes(ator).TemPapel(acesso.PapelAdmin) {
+		return ticket.ErrProibido
+	}
+	return nil
+}
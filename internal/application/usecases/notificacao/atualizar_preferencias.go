@@ -0,0 +1,58 @@
+package notificacao
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de atualizar as preferências; os tipos ausentes não são alterados
+type AtualizarPreferenciasInput struct {
+	UsuarioID    string
+	Notificacoes map[notificacao.Tipo]bool
+}
+
+// Caso de uso de ativar e desativar as notificações de um usuário
+type AtualizarPreferenciasUseCase struct {
+	notificacaoRepository notificacao.Repository
+	usuarioRepository     usuario.Repository
+	autorizador           *acesso.Autorizador
+}
+
+// NewAtualizarPreferenciasUseCase cria uma nova instância do caso de uso de atualizar preferências
+func NewAtualizarPreferenciasUseCase(repo notificacao.Repository, usuarioRepo usuario.Repository, autorizador *acesso.Autorizador) *AtualizarPreferenciasUseCase {
+	return &AtualizarPreferenciasUseCase{
+		notificacaoRepository: repo,
+		usuarioRepository:     usuarioRepo,
+		autorizador:           autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar preferências
+func (uc *AtualizarPreferenciasUseCase) Execute(ctx context.Context, input AtualizarPreferenciasInput) (*PreferenciasOutput, error) {
+	// 1. cada usuário altera as próprias preferências
+	if err := exigirProprioOuAdmin(ctx, uc.autorizador, input.UsuarioID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.usuarioRepository.BuscarUsuario(input.UsuarioID); err != nil {
+		return nil, err
+	}
+
+	// 2. aplica as mudanças sobre as preferências gravadas
+	preferencias, err := uc.notificacaoRepository.BuscarPreferencias(input.UsuarioID)
+	if err != nil {
+		return nil, err
+	}
+	for tipo, ativa := range input.Notificacoes {
+		if err := preferencias.Definir(tipo, ativa); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. persiste
+	if err := uc.notificacaoRepository.SalvarPreferencias(preferencias); err != nil {
+		return nil, err
+	}
+	return novasPreferenciasOutput(preferencias), nil
+}
@@ -0,0 +1,61 @@
+package notificacao
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de buscar as preferências de notificação
+type BuscarPreferenciasInput struct {
+	UsuarioID string
+}
+
+// PreferenciasOutput indica, para cada tipo de notificação, se o usuário a recebe
+type PreferenciasOutput struct {
+	UsuarioID    string
+	Notificacoes map[notificacao.Tipo]bool
+}
+
+// Caso de uso de buscar as preferências de notificação de um usuário
+type BuscarPreferenciasUseCase struct {
+	notificacaoRepository notificacao.Repository
+	usuarioRepository     usuario.Repository
+	autorizador           *acesso.Autorizador
+}
+
+// NewBuscarPreferenciasUseCase cria uma nova instância do caso de uso de buscar preferências
+func NewBuscarPreferenciasUseCase(repo notificacao.Repository, usuarioRepo usuario.Repository, autorizador *acesso.Autorizador) *BuscarPreferenciasUseCase {
+	return &BuscarPreferenciasUseCase{
+		notificacaoRepository: repo,
+		usuarioRepository:     usuarioRepo,
+		autorizador:           autorizador,
+	}
+}
+
+// Executa o caso de uso de buscar preferências
+func (uc *BuscarPreferenciasUseCase) Execute(ctx context.Context, input BuscarPreferenciasInput) (*PreferenciasOutput, error) {
+	// 1. cada usuário consulta as próprias preferências
+	if err := exigirProprioOuAdmin(ctx, uc.autorizador, input.UsuarioID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.usuarioRepository.BuscarUsuario(input.UsuarioID); err != nil {
+		return nil, err
+	}
+
+	// 2. busca as preferências gravadas
+	preferencias, err := uc.notificacaoRepository.BuscarPreferencias(input.UsuarioID)
+	if err != nil {
+		return nil, err
+	}
+	return novasPreferenciasOutput(preferencias), nil
+}
+
+func novasPreferenciasOutput(p *notificacao.Preferencias) *PreferenciasOutput {
+	output := &PreferenciasOutput{UsuarioID: p.UsuarioID, Notificacoes: map[notificacao.Tipo]bool{}}
+	for _, tipo := range notificacao.Tipos {
+		output.Notificacoes[tipo] = p.Permite(tipo)
+	}
+	return output
+}
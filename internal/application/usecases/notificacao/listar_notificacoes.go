@@ -0,0 +1,83 @@
+package notificacao
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/notificacao"
+)
+
+// quantidade de envios retornados quando o limite não é informado, e o máximo aceito
+const (
+	limitePadraoNotificacoes = 50
+	limiteMaximoNotificacoes = 500
+)
+
+// input do caso de uso de consultar o log de notificações
+type ListarNotificacoesInput struct {
+	UsuarioID string
+	TicketID  string
+	Limite    int
+}
+
+// NotificacaoOutput é um envio registrado no log
+type NotificacaoOutput struct {
+	ID        string
+	EventoID  string
+	TicketID  string
+	UsuarioID string
+	Email     string
+	Tipo      notificacao.Tipo
+	Assunto   string
+	Status    notificacao.StatusEnvio
+	Erro      string
+	Data      string
+}
+
+// Caso de uso do log de notificações enviadas
+type ListarNotificacoesUseCase struct {
+	notificacaoRepository notificacao.Repository
+	autorizador           *acesso.Autorizador
+}
+
+// NewListarNotificacoesUseCase cria uma nova instância do caso de uso de listar notificações
+func NewListarNotificacoesUseCase(repo notificacao.Repository, autorizador *acesso.Autorizador) *ListarNotificacoesUseCase {
+	return &ListarNotificacoesUseCase{
+		notificacaoRepository: repo,
+		autorizador:           autorizador,
+	}
+}
+
+// Executa o caso de uso de listar notificações
+func (uc *ListarNotificacoesUseCase) Execute(ctx context.Context, input ListarNotificacoesInput) ([]*NotificacaoOutput, error) {
+	// 1. apenas administradores consultam o log, que traz os e-mails dos usuários
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+	if input.Limite <= 0 {
+		input.Limite = limitePadraoNotificacoes
+	}
+	input.Limite = min(input.Limite, limiteMaximoNotificacoes)
+
+	// 2. busca os envios mais recentes
+	registros, err := uc.notificacaoRepository.ListarRegistros(notificacao.FiltrosRegistro(input))
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*NotificacaoOutput, len(registros))
+	for i, r := range registros {
+		output[i] = &NotificacaoOutput{
+			ID:        r.ID,
+			EventoID:  r.EventoID,
+			TicketID:  r.TicketID,
+			UsuarioID: r.UsuarioID,
+			Email:     r.Email,
+			Tipo:      r.Tipo,
+			Assunto:   r.Assunto,
+			Status:    r.Status,
+			Erro:      r.Erro,
+			Data:      r.Data.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,60 @@
+package taxonomia
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de atualizar categoria; campos nulos não são alterados
+type AtualizarCategoriaInput struct {
+	Codigo ticket.Categoria
+	Nome   *string
+	Ativa  *bool
+}
+
+// Caso de uso de atualizar categoria. Desativar uma categoria impede novos tickets nela,
+// mas não altera os tickets existentes.
+type AtualizarCategoriaUseCase struct {
+	taxonomiaRepository taxonomia.Repository
+	autorizador         *acesso.Autorizador
+}
+
+// NewAtualizarCategoriaUseCase cria uma nova instância do caso de uso de atualizar categoria
+func NewAtualizarCategoriaUseCase(repo taxonomia.Repository, autorizador *acesso.Autorizador) *AtualizarCategoriaUseCase {
+	return &AtualizarCategoriaUseCase{
+		taxonomiaRepository: repo,
+		autorizador:         autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar categoria
+func (uc *AtualizarCategoriaUseCase) Execute(ctx context.Context, input AtualizarCategoriaInput) (*CategoriaOutput, error) {
+	// 1. apenas administradores mantêm a taxonomia
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. busca a categoria existente
+	c, err := uc.taxonomiaRepository.Buscar(input.Codigo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. aplica as alterações informadas
+	if input.Nome != nil {
+		if err := c.SetNome(*input.Nome); err != nil {
+			return nil, err
+		}
+	}
+	if input.Ativa != nil {
+		c.Ativa = *input.Ativa
+	}
+
+	// 4. persiste
+	if err := uc.taxonomiaRepository.Atualizar(c); err != nil {
+		return nil, err
+	}
+	return novaCategoriaOutput(c), nil
+}
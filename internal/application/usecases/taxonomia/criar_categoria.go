@@ -0,0 +1,90 @@
+package taxonomia
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// SubcategoriaInput é uma subcategoria informada na criação da categoria
+type SubcategoriaInput struct {
+	Codigo ticket.Subcategoria
+	Nome   string
+}
+
+// input do caso de uso de criar categoria
+type CriarCategoriaInput struct {
+	Codigo        ticket.Categoria
+	Nome          string
+	Subcategorias []SubcategoriaInput
+}
+
+// SubcategoriaOutput é a subcategoria no formato de saída dos casos de uso
+type SubcategoriaOutput struct {
+	Codigo ticket.Subcategoria
+	Nome   string
+	Ativa  bool
+}
+
+// CategoriaOutput é a categoria no formato de saída dos casos de uso
+type CategoriaOutput struct {
+	Codigo        ticket.Categoria
+	Nome          string
+	Ativa         bool
+	Subcategorias []SubcategoriaOutput
+	DataCriacao   string
+}
+
+// Caso de uso de criar categoria
+type CriarCategoriaUseCase struct {
+	taxonomiaRepository taxonomia.Repository
+	autorizador         *acesso.Autorizador
+}
+
+// NewCriarCategoriaUseCase cria uma nova instância do caso de uso de criar categoria
+func NewCriarCategoriaUseCase(repo taxonomia.Repository, autorizador *acesso.Autorizador) *CriarCategoriaUseCase {
+	return &CriarCategoriaUseCase{
+		taxonomiaRepository: repo,
+		autorizador:         autorizador,
+	}
+}
+
+// Executa o caso de uso de criar categoria
+func (uc *CriarCategoriaUseCase) Execute(ctx context.Context, input CriarCategoriaInput) (*CategoriaOutput, error) {
+	// 1. apenas administradores mantêm a taxonomia
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. cria a categoria e as subcategorias pela entidade de domínio
+	c, err := taxonomia.NovaCategoria(input.Codigo, input.Nome)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range input.Subcategorias {
+		if _, err := c.AdicionarSubcategoria(s.Codigo, s.Nome); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. persiste
+	if err := uc.taxonomiaRepository.Criar(c); err != nil {
+		return nil, err
+	}
+	return novaCategoriaOutput(c), nil
+}
+
+func novaCategoriaOutput(c *taxonomia.Categoria) *CategoriaOutput {
+	subcategorias := make([]SubcategoriaOutput, len(c.Subcategorias))
+	for i, s := range c.Subcategorias {
+		subcategorias[i] = SubcategoriaOutput(s)
+	}
+	return &CategoriaOutput{
+		Codigo:        c.Codigo,
+		Nome:          c.Nome,
+		Ativa:         c.Ativa,
+		Subcategorias: subcategorias,
+		DataCriacao:   c.DataCriacao.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,82 @@
+package taxonomia
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de listar categorias
+type ListarCategoriasInput struct {
+	ApenasAtivas bool
+}
+
+// Caso de uso de listar categorias; qualquer usuário autenticado consulta a taxonomia para abrir tickets
+type ListarCategoriasUseCase struct {
+	taxonomiaRepository taxonomia.Repository
+}
+
+// NewListarCategoriasUseCase cria uma nova instância do caso de uso de listar categorias
+func NewListarCategoriasUseCase(repo taxonomia.Repository) *ListarCategoriasUseCase {
+	return &ListarCategoriasUseCase{taxonomiaRepository: repo}
+}
+
+// Executa o caso de uso de listar categorias; com ApenasAtivas, as subcategorias inativas também ficam de fora
+func (uc *ListarCategoriasUseCase) Execute(ctx context.Context, input ListarCategoriasInput) ([]*CategoriaOutput, error) {
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	categorias, err := uc.taxonomiaRepository.Listar(input.ApenasAtivas)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*CategoriaOutput, len(categorias))
+	for i, c := range categorias {
+		output[i] = novaCategoriaOutput(c)
+		if input.ApenasAtivas {
+			output[i].Subcategorias = apenasAtivas(output[i].Subcategorias)
+		}
+	}
+	return output, nil
+}
+
+// input do caso de uso de buscar categoria
+type BuscarCategoriaInput struct {
+	Codigo ticket.Categoria
+}
+
+// Caso de uso de buscar uma categoria com as subcategorias
+type BuscarCategoriaUseCase struct {
+	taxonomiaRepository taxonomia.Repository
+}
+
+// NewBuscarCategoriaUseCase cria uma nova instância do caso de uso de buscar categoria
+func NewBuscarCategoriaUseCase(repo taxonomia.Repository) *BuscarCategoriaUseCase {
+	return &BuscarCategoriaUseCase{taxonomiaRepository: repo}
+}
+
+// Executa o caso de uso de buscar categoria
+func (uc *BuscarCategoriaUseCase) Execute(ctx context.Context, input BuscarCategoriaInput) (*CategoriaOutput, error) {
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	c, err := uc.taxonomiaRepository.Buscar(input.Codigo)
+	if err != nil {
+		return nil, err
+	}
+	return novaCategoriaOutput(c), nil
+}
+
+func apenasAtivas(subcategorias []SubcategoriaOutput) []SubcategoriaOutput {
+	ativas := []SubcategoriaOutput{}
+	for _, s := range subcategorias {
+		if s.Ativa {
+			ativas = append(ativas, s)
+		}
+	}
+	return ativas
+}
@@ -0,0 +1,96 @@
+package taxonomia
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de adicionar subcategoria
+type AdicionarSubcategoriaInput struct {
+	Categoria ticket.Categoria
+	Codigo    ticket.Subcategoria
+	Nome      string
+}
+
+// Caso de uso de adicionar uma subcategoria a uma categoria existente
+type AdicionarSubcategoriaUseCase struct {
+	taxonomiaRepository taxonomia.Repository
+	autorizador         *acesso.Autorizador
+}
+
+// NewAdicionarSubcategoriaUseCase cria uma nova instância do caso de uso de adicionar subcategoria
+func NewAdicionarSubcategoriaUseCase(repo taxonomia.Repository, autorizador *acesso.Autorizador) *AdicionarSubcategoriaUseCase {
+	return &AdicionarSubcategoriaUseCase{
+		taxonomiaRepository: repo,
+		autorizador:         autorizador,
+	}
+}
+
+// Executa o caso de uso de adicionar subcategoria
+func (uc *AdicionarSubcategoriaUseCase) Execute(ctx context.Context, input AdicionarSubcategoriaInput) (*CategoriaOutput, error) {
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+	c, err := uc.taxonomiaRepository.Buscar(input.Categoria)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.AdicionarSubcategoria(input.Codigo, input.Nome); err != nil {
+		return nil, err
+	}
+	if err := uc.taxonomiaRepository.Atualizar(c); err != nil {
+		return nil, err
+	}
+	return novaCategoriaOutput(c), nil
+}
+
+// input do caso de uso de atualizar subcategoria; campos nulos não são alterados
+type AtualizarSubcategoriaInput struct {
+	Categoria ticket.Categoria
+	Codigo    ticket.Subcategoria
+	Nome      *string
+	Ativa     *bool
+}
+
+// Caso de uso de renomear, desativar ou reativar uma subcategoria
+type AtualizarSubcategoriaUseCase struct {
+	taxonomiaRepository taxonomia.Repository
+	autorizador         *acesso.Autorizador
+}
+
+// NewAtualizarSubcategoriaUseCase cria uma nova instância do caso de uso de atualizar subcategoria
+func NewAtualizarSubcategoriaUseCase(repo taxonomia.Repository, autorizador *acesso.Autorizador) *AtualizarSubcategoriaUseCase {
+	return &AtualizarSubcategoriaUseCase{
+		taxonomiaRepository: repo,
+		autorizador:         autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar subcategoria
+func (uc *AtualizarSubcategoriaUseCase) Execute(ctx context.Context, input AtualizarSubcategoriaInput) (*CategoriaOutput, error) {
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+	c, err := uc.taxonomiaRepository.Buscar(input.Categoria)
+	if err != nil {
+		return nil, err
+	}
+	s, err := c.Subcategoria(input.Codigo)
+	if err != nil {
+		return nil, err
+	}
+	if input.Nome != nil {
+		if err := s.SetNome(*input.Nome); err != nil {
+			return nil, err
+		}
+	}
+	if input.Ativa != nil {
+		s.Ativa = *input.Ativa
+	}
+	if err := uc.taxonomiaRepository.Atualizar(c); err != nil {
+		return nil, err
+	}
+	return novaCategoriaOutput(c), nil
+}
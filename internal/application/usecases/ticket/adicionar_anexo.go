@@ -0,0 +1,118 @@
+package ticket
+
+import (
+	"context"
+	"io"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/anexo"
+	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+var (
+	ErrObservacaoDoAnexo = ticket.NovoErroValidacao("observacao_id", "observacao_nao_encontrada", "a observação informada não pertence ao ticket")
+)
+
+// input do caso de uso de adicionar anexo
+type AdicionarAnexoInput struct {
+	ID           string
+	ObservacaoID string // opcional, liga o arquivo a uma observação do ticket
+	Nome         string
+	Conteudo     io.Reader
+}
+
+// AnexoOutput são os metadados de um arquivo do ticket
+type AnexoOutput struct {
+	ID           string
+	TicketID     string
+	ObservacaoID string
+	Nome         string
+	TipoMIME     string
+	Tamanho      int64
+	SHA256       string
+	EnviadoPor   string
+	DataCriacao  string
+}
+
+// Caso de uso de adicionar um arquivo ao ticket
+type AdicionarAnexoUseCase struct {
+	ticketRepository ticket.Repository
+	arquivista       *anexo.Arquivista
+	autorizador      *acesso.Autorizador
+}
+
+// NewAdicionarAnexoUseCase cria uma nova instância do caso de uso de adicionar anexo
+func NewAdicionarAnexoUseCase(repo ticket.Repository, arquivista *anexo.Arquivista, autorizador *acesso.Autorizador) *AdicionarAnexoUseCase {
+	return &AdicionarAnexoUseCase{
+		ticketRepository: repo,
+		arquivista:       arquivista,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de adicionar anexo; quem pode comentar no ticket pode anexar arquivos
+func (uc *AdicionarAnexoUseCase) Execute(ctx context.Context, input AdicionarAnexoInput) (*AnexoOutput, error) {
+	// 1. identifica o autor e busca o ticket
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return nil, err
+	}
+
+	// 2. a observação, se informada, precisa ser deste ticket
+	if input.ObservacaoID != "" && !temObservacao(t, input.ObservacaoID) {
+		return nil, ErrObservacaoDoAnexo
+	}
+
+	// 3. cria os metadados e registra a modificação
+	novo, err := anexo.NovoAnexo(t.ID, input.Nome, ator.ID)
+	if err != nil {
+		return nil, err
+	}
+	novo.ObservacaoID = input.ObservacaoID
+	if err := t.RegistrarAnexo(novo.Nome, ator.ID); err != nil {
+		return nil, err
+	}
+
+	// 4. valida e grava o conteúdo
+	if err := uc.arquivista.Guardar(ctx, novo, input.Conteudo); err != nil {
+		return nil, err
+	}
+
+	// 5. persiste a modificação; se falhar, o arquivo é descartado para não ficar fora do histórico
+	if err := uc.ticketRepository.Update(t); err != nil {
+		uc.arquivista.Descartar(ctx, novo)
+		return nil, err
+	}
+
+	return anexoOutputDe(novo), nil
+}
+
+func temObservacao(t *ticket.Ticket, observacaoID string) bool {
+	for _, o := range t.Observacoes {
+		if o.ID == observacaoID {
+			return true
+		}
+	}
+	return false
+}
+
+func anexoOutputDe(a *anexo.Anexo) *AnexoOutput {
+	return &AnexoOutput{
+		ID:           a.ID,
+		TicketID:     a.TicketID,
+		ObservacaoID: a.ObservacaoID,
+		Nome:         a.Nome,
+		TipoMIME:     a.TipoMIME,
+		Tamanho:      a.Tamanho,
+		SHA256:       a.SHA256,
+		EnviadoPor:   a.EnviadoPor,
+		DataCriacao:  a.DataCriacao.Format(time.DateTime),
+	}
+}
@@ -1,20 +1,20 @@
 package ticket
 
 import (
-	"errors"
+	"context"
+	"nox_tickets/internal/domain/acesso"
 	"nox_tickets/internal/domain/ticket"
 	"time"
 )
 
 var (
-	ErrDescricaoVazia = errors.New("descrição da observação é obrigatória")
+	ErrDescricaoVazia = ticket.NovoErroValidacao("descricao", "descricao_obrigatoria", "descrição da observação é obrigatória")
 )
 
 // input de usecase de adicionar observação
 type AdicionarObservacaoInput struct {
 	ID        string
 	Descricao string
-	UsuarioID string
 }
 
 // output de usecase de adicionar observação
@@ -29,18 +29,24 @@ type AdicionarObservacaoOutput struct {
 // usecase de adicionar observação
 type AdicionarObservacaoUseCase struct {
 	ticketRepository ticket.Repository
+	autorizador      *acesso.Autorizador
 }
 
 // construtor de usecase de adicionar observação
-func NewAdicionarObservacaoUseCase(repo ticket.Repository) *AdicionarObservacaoUseCase {
+func NewAdicionarObservacaoUseCase(repo ticket.Repository, autorizador *acesso.Autorizador) *AdicionarObservacaoUseCase {
 	return &AdicionarObservacaoUseCase{
 		ticketRepository: repo,
+		autorizador:      autorizador,
 	}
 }
 
 // executa a usecase de adicionar observação
-func (uc *AdicionarObservacaoUseCase) Execute(input AdicionarObservacaoInput) (*AdicionarObservacaoOutput, error) {
-	// 1. validacao basica da observacao
+func (uc *AdicionarObservacaoUseCase) Execute(ctx context.Context, input AdicionarObservacaoInput) (*AdicionarObservacaoOutput, error) {
+	// 1. identifica o autor e valida a observacao
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if input.Descricao == "" {
 		return nil, ErrDescricaoVazia
 	}
@@ -51,8 +57,13 @@ func (uc *AdicionarObservacaoUseCase) Execute(input AdicionarObservacaoInput) (*
 		return nil, err
 	}
 
+	// quem pode ver o ticket pode comentar nele
+	if err := uc.autorizador.VerificarLeitura(ator, ticketExistente); err != nil {
+		return nil, err
+	}
+
 	// 3. cria e adiciona a observação
-	err = ticketExistente.AdicionarObservacao(input.Descricao, input.UsuarioID)
+	err = ticketExistente.AdicionarObservacao(input.Descricao, ator.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -69,9 +80,9 @@ func (uc *AdicionarObservacaoUseCase) Execute(input AdicionarObservacaoInput) (*
 
 	// 6. prepara o output
 	return &AdicionarObservacaoOutput{
-		ID:          input.ID,
+		ID:          novaObservacao.ID,
 		TicketID:    input.ID,
-		UsuarioID:   input.UsuarioID,
+		UsuarioID:   ator.ID,
 		Descricao:   novaObservacao.Descricao,
 		DataCriacao: novaObservacao.DataCriacao.Format(time.DateTime),
 	}, nil
@@ -0,0 +1,104 @@
+package ticket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nox_tickets/internal/domain/sla"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+	"time"
+)
+
+// status em que os prazos de SLA ainda podem vencer
+var statusComPrazo = []ticket.Status{
+	ticket.StatusAberto,
+	ticket.StatusEmCurso,
+	ticket.StatusAguardandoCliente,
+	ticket.StatusAguardandoTerceiro,
+	ticket.StatusPausado,
+	ticket.StatusReaberto,
+}
+
+// Ticket alertado em uma execução, com os prazos prestes a vencer
+type TicketAlertadoOutput struct {
+	ID     string
+	Prazos []string
+}
+
+// Output de uma execução dos alertas de SLA
+type AlertarSLAOutput struct {
+	Avaliados int
+	Alertados []TicketAlertadoOutput
+
+	// falhas de tickets individuais, que não interrompem a execução
+	Erros []error
+}
+
+// Caso de uso que registra os alertas dos prazos de SLA prestes a vencer. Cada alerta gera um
+// evento ticket.sla_em_risco, que chega aos assinantes (notificações e webhooks) pelo outbox.
+// Roda em segundo plano, sem usuário autenticado: os alertas são registrados pelo usuário sistema.
+type AlertarSLAUseCase struct {
+	ticketRepository ticket.Repository
+	motorSLA         *sla.Motor
+	antecedencia     time.Duration
+}
+
+// Construtor do caso de uso; antecedencia é quanto tempo útil antes do vencimento o alerta é dado
+func NewAlertarSLAUseCase(repo ticket.Repository, motorSLA *sla.Motor, antecedencia time.Duration) *AlertarSLAUseCase {
+	return &AlertarSLAUseCase{
+		ticketRepository: repo,
+		motorSLA:         motorSLA,
+		antecedencia:     antecedencia,
+	}
+}
+
+// Executa uma rodada dos alertas de SLA
+func (uc *AlertarSLAUseCase) Execute(ctx context.Context) (*AlertarSLAOutput, error) {
+	output := &AlertarSLAOutput{}
+	agora := time.Now()
+
+	// 1. percorre os tickets com prazos em aberto ainda não alertados; o risco depende do
+	// calendário útil, então é calculado aqui, no ticket já carregado
+	filtros := ticket.TicketFiltros{Status: statusComPrazo, PrazoSemAlerta: true}
+	err := percorrerCompletos(ctx, uc.ticketRepository, filtros, func(t *ticket.Ticket) {
+		output.Avaliados++
+
+		prazos, err := uc.alertar(t, agora)
+		if err != nil {
+			output.Erros = append(output.Erros, fmt.Errorf("ticket %s: %w", t.ID, err))
+		}
+		if len(prazos) > 0 {
+			output.Alertados = append(output.Alertados, TicketAlertadoOutput{ID: t.ID, Prazos: prazos})
+		}
+	})
+	if err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+// alertar registra os alertas dos prazos ainda não alertados
+func (uc *AlertarSLAUseCase) alertar(t *ticket.Ticket, agora time.Time) ([]string, error) {
+	prazos := t.PrazosEmRisco(uc.motorSLA.Estado(t, agora), uc.antecedencia)
+	if len(prazos) == 0 {
+		return nil, nil
+	}
+
+	// 2. registra os alertas
+	for _, prazo := range prazos {
+		if err := t.RegistrarAlertaSLA(prazo, usuario.IDSistema); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. persiste; se alguém alterou o ticket nesse meio tempo, ele é reavaliado na próxima rodada
+	if err := uc.ticketRepository.Update(t); err != nil {
+		if errors.Is(err, ticket.ErrConflito) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return prazos, nil
+}
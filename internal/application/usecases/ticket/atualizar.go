@@ -1,25 +1,35 @@
 package ticket
 
 import (
-	"errors"
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/sla"
 	"nox_tickets/internal/domain/ticket"
 	"time"
 )
 
 // Input para atualizar um ticket
 type AtualizarTicketInput struct {
-	ID         string
-	Titulo     *string
-	Descricao  *string
-	Categoria  *ticket.Categoria
-	Urgencia   *int
-	Gravidade  *int
-	Merchant   *string
-	NoxID      *string
-	CPF        *string
-	Plataforma *string
-	Contato    *string
-	UsuarioID  string
+	ID           string
+	Titulo       *string
+	Descricao    *string
+	Categoria    *ticket.Categoria
+	Subcategoria *ticket.Subcategoria
+	Urgencia     *int
+	Gravidade    *int
+	Merchant     *string
+	NoxID        *string
+	CPF          *string
+	Plataforma   *string
+	Contato      *string
+
+	// campos personalizados alterados; um valor nulo remove o campo
+	Campos map[string]interface{}
+
+	// versão que o cliente leu (If-Match); quando informada, precisa ser a atual
+	VersaoEsperada *int
 }
 
 // Output para caso de uso de atualizar ticket
@@ -28,61 +38,97 @@ type AtualizarTicketOutput struct {
 	Titulo       string
 	Status       ticket.Status
 	Categoria    ticket.Categoria
+	Subcategoria ticket.Subcategoria
 	Urgencia     int
 	Gravidade    int
 	DataAbertura string
 	AbertoPor    string
 	Responsavel  string
+	FilaID       string
+	Versao       int
 }
 
 // Usecase de atualizar ticket
 type AtualizarTicketUseCase struct {
 	ticketRepository ticket.Repository
+	motorSLA         *sla.Motor
+	roteador         *fila.Roteador
+	taxonomia        ticket.Taxonomia
+	formularios      ticket.Formularios
+	autorizador      *acesso.Autorizador
 }
 
 // Contrutor do caso de uso
-func NewAtualizarTicketUseCase(repo ticket.Repository) *AtualizarTicketUseCase {
+func NewAtualizarTicketUseCase(repo ticket.Repository, motorSLA *sla.Motor, roteador *fila.Roteador, taxonomia ticket.Taxonomia, formularios ticket.Formularios, autorizador *acesso.Autorizador) *AtualizarTicketUseCase {
 	return &AtualizarTicketUseCase{
 		ticketRepository: repo,
+		motorSLA:         motorSLA,
+		roteador:         roteador,
+		taxonomia:        taxonomia,
+		formularios:      formularios,
+		autorizador:      autorizador,
 	}
 }
 
 // Executa o caso de uso de atualizar ticket
-func (uc *AtualizarTicketUseCase) Execute(input AtualizarTicketInput) (*AtualizarTicketOutput, error) {
-	// 1. busca o ticket existente
+func (uc *AtualizarTicketUseCase) Execute(ctx context.Context, input AtualizarTicketInput) (*AtualizarTicketOutput, error) {
+	// 1. identifica quem está alterando e busca o ticket existente
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
 	ticketExistente, err := uc.ticketRepository.GetByID(input.ID)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.autorizador.VerificarEscrita(ator, ticketExistente); err != nil {
+		return nil, err
+	}
+	if input.VersaoEsperada != nil {
+		if err := ticketExistente.VerificarVersao(*input.VersaoEsperada); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validação: não permite modificar tickets finalizados ou cancelados
 	if ticketExistente.Status == ticket.StatusFinalizado || ticketExistente.Status == ticket.StatusCancelado {
-		return nil, errors.New("não é possível modificar um ticket finalizado ou cancelado")
+		return nil, ticket.ErrTicketEncerrado
 	}
 
 	// 2. atualiza os campos fornecidos
 	if input.Titulo != nil {
-		if err := ticketExistente.SetTitulo(*input.Titulo, input.UsuarioID); err != nil {
+		if err := ticketExistente.SetTitulo(*input.Titulo, ator.ID); err != nil {
 			return nil, err
 		}
 	}
 	if input.Descricao != nil {
-		if err := ticketExistente.SetDescricao(*input.Descricao, input.UsuarioID); err != nil {
+		if err := ticketExistente.SetDescricao(*input.Descricao, ator.ID); err != nil {
 			return nil, err
 		}
 	}
-	if input.Categoria != nil {
-		if err := ticketExistente.SetCategoria(*input.Categoria, input.UsuarioID); err != nil {
-			return nil, err
-		}
+	reclassificado, err := uc.reclassificar(ticketExistente, input, ator)
+	if err != nil {
+		return nil, err
 	}
 	if input.Urgencia != nil {
-		if err := ticketExistente.SetUrgencia(*input.Urgencia, input.UsuarioID); err != nil {
+		if err := ticketExistente.SetUrgencia(*input.Urgencia, ator.ID); err != nil {
 			return nil, err
 		}
 	}
 	if input.Gravidade != nil {
-		if err := ticketExistente.SetGravidade(*input.Gravidade, input.UsuarioID); err != nil {
+		if err := ticketExistente.SetGravidade(*input.Gravidade, ator.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// recalcula os prazos de SLA quando algum campo da política mudou
+	if reclassificado || input.Urgencia != nil || input.Gravidade != nil {
+		uc.motorSLA.Aplicar(ticketExistente)
+	}
+
+	// o ticket reclassificado vai para a fila da nova classificação
+	if reclassificado {
+		if _, err := uc.roteador.Rerotear(ticketExistente); err != nil {
 			return nil, err
 		}
 	}
@@ -117,6 +163,14 @@ func (uc *AtualizarTicketUseCase) Execute(input AtualizarTicketInput) (*Atualiza
 		contato,
 	)
 
+	// os campos do ticket precisam atender o formulário da classificação, inclusive após reclassificar
+	if err := ticketExistente.SetCampos(input.Campos, ator.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.formularios.ValidarCampos(ticketExistente, true); err != nil {
+		return nil, err
+	}
+
 	// 4. Persiste as alteracoes
 	if err := uc.ticketRepository.Update(ticketExistente); err != nil {
 		return nil, err
@@ -128,10 +182,41 @@ func (uc *AtualizarTicketUseCase) Execute(input AtualizarTicketInput) (*Atualiza
 		Titulo:       ticketExistente.Titulo,
 		Status:       ticketExistente.Status,
 		Categoria:    ticketExistente.Categoria,
+		Subcategoria: ticketExistente.Subcategoria,
 		Urgencia:     ticketExistente.Urgencia,
 		Gravidade:    ticketExistente.Gravidade,
 		DataAbertura: ticketExistente.DataAbertura.Format(time.DateTime),
 		AbertoPor:    ticketExistente.AbertoPor,
 		Responsavel:  ticketExistente.Responsavel,
+		FilaID:       ticketExistente.FilaID,
+		Versao:       ticketExistente.Versao,
 	}, nil
 }
+
+// reclassificar aplica a categoria e/ou a subcategoria informadas, validando o par resultante.
+// Quem reclassifica precisa poder alterar o ticket também na nova classificação, para não mover
+// tickets para categorias restritas a que não tem acesso
+func (uc *AtualizarTicketUseCase) reclassificar(t *ticket.Ticket, input AtualizarTicketInput, ator auth.Principal) (bool, error) {
+	if input.Categoria == nil && input.Subcategoria == nil {
+		return false, nil
+	}
+	categoria, subcategoria := t.Categoria, t.Subcategoria
+	if input.Categoria != nil {
+		categoria = *input.Categoria
+	}
+	if input.Subcategoria != nil {
+		subcategoria = *input.Subcategoria
+	}
+
+	categoriaAnterior, subcategoriaAnterior := t.Categoria, t.Subcategoria
+	if err := t.Reclassificar(categoria, subcategoria, uc.taxonomia, ator.ID); err != nil {
+		return false, err
+	}
+	if t.Categoria == categoriaAnterior && t.Subcategoria == subcategoriaAnterior {
+		return false, nil
+	}
+	if err := uc.autorizador.VerificarEscrita(ator, t); err != nil {
+		return false, err
+	}
+	return true, nil
+}
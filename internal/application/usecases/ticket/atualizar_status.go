@@ -1,20 +1,25 @@
 package ticket
 
 import (
-	"errors"
+	"context"
+	"nox_tickets/internal/domain/acesso"
 	"nox_tickets/internal/domain/ticket"
+	"time"
 )
 
 var (
-	ErrStatusInvalido = errors.New("status invalido")
+	ErrStatusInvalido = ticket.NovoErroValidacao("status", "status_invalido", "status inválido")
 )
 
 // input do usecase de atualizar status
 type AtualizarStatusTicketInput struct {
 	ID          string
 	Status      ticket.Status
-	UsuarioID   string
 	Responsavel *string
+	Motivo      *string // obrigatório ao colocar o ticket em espera
+
+	// versão que o cliente leu (If-Match); quando informada, precisa ser a atual
+	VersaoEsperada *int
 }
 
 // output do usecase de atualizar status
@@ -24,49 +29,74 @@ type AtualizarStatusTicketOutput struct {
 	Responsavel   string
 	DataInicio    string
 	DataConclusao string
+	Versao        int
+
+	// próximos status possíveis, para o cliente saber quais ações exibir
+	TransicoesPermitidas []ticket.Status
 }
 
 // Usecase de atualizar status
 type AtualizarStatusUseCase struct {
 	ticketRepository ticket.Repository
+	maquina          *ticket.MaquinaDeEstados
+	autorizador      *acesso.Autorizador
 }
 
 // construtor do usecase de atualizar status
-func NewAtualizarStatusUseCase(repo ticket.Repository) *AtualizarStatusUseCase {
+func NewAtualizarStatusUseCase(repo ticket.Repository, maquina *ticket.MaquinaDeEstados, autorizador *acesso.Autorizador) *AtualizarStatusUseCase {
 	return &AtualizarStatusUseCase{
 		ticketRepository: repo,
+		maquina:          maquina,
+		autorizador:      autorizador,
 	}
 }
 
 // Executa o usecase de atualizar status
-func (uc *AtualizarStatusUseCase) Execute(input AtualizarStatusTicketInput) (*AtualizarStatusTicketOutput, error) {
-	// 1. busca o ticket existente
+func (uc *AtualizarStatusUseCase) Execute(ctx context.Context, input AtualizarStatusTicketInput) (*AtualizarStatusTicketOutput, error) {
+	// 1. identifica quem está alterando e busca o ticket existente
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
 	ticketExistente, err := uc.ticketRepository.GetByID(input.ID)
 	if err != nil {
 		return nil, err
 	}
-
-	// 2. aplica a mudança de status de acordo com o novo status
-	switch input.Status {
-	case ticket.StatusEmCurso:
-		if input.Responsavel == nil {
-			return nil, errors.New("responsavel é obrigatório para iniciar o atendimento")
+	if err := uc.autorizador.VerificarEscrita(ator, ticketExistente); err != nil {
+		return nil, err
+	}
+	if input.VersaoEsperada != nil {
+		if err := ticketExistente.VerificarVersao(*input.VersaoEsperada); err != nil {
+			return nil, err
 		}
-		err = ticketExistente.IniciarAtendimento(*input.Responsavel)
-
-	case ticket.StatusFinalizado:
-		err = ticketExistente.Concluir(input.UsuarioID)
-
-	case ticket.StatusCancelado:
-		err = ticketExistente.Cancelar(input.UsuarioID)
+	}
 
-	default:
+	// 2. aplica a mudança de status pela tabela de transições
+	if !uc.maquina.Conhece(input.Status) {
 		return nil, ErrStatusInvalido
 	}
 
-	if err != nil {
+	contexto := ticket.ContextoTransicao{
+		UsuarioID: ator.ID,
+		Agora:     time.Now(),
+	}
+	if input.Responsavel != nil {
+		contexto.Responsavel = *input.Responsavel
+	}
+	if input.Motivo != nil {
+		contexto.Motivo = *input.Motivo
+	}
+
+	responsavelAnterior := ticketExistente.Responsavel
+	if err := uc.maquina.Aplicar(ticketExistente, input.Status, contexto); err != nil {
 		return nil, err
 	}
+	// a troca de responsável fica registrada para os avisos de atribuição
+	if ticketExistente.Responsavel != responsavelAnterior {
+		if err := ticketExistente.RegistrarAtribuicao(responsavelAnterior, ator.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	// 3. persiste as alteracoes
 	if err := uc.ticketRepository.Update(ticketExistente); err != nil {
@@ -91,5 +121,8 @@ func (uc *AtualizarStatusUseCase) Execute(input AtualizarStatusTicketInput) (*At
 		Responsavel:   ticketExistente.Responsavel,
 		DataInicio:    dataInicio,
 		DataConclusao: dataFim,
+		Versao:        ticketExistente.Versao,
+
+		TransicoesPermitidas: uc.maquina.Permitidas(ticketExistente, time.Now()),
 	}, nil
 }
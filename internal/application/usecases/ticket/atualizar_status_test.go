@@ -0,0 +1,45 @@
+package ticket
+
+import (
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+func TestAtualizarStatus_Acesso(t *testing.T) {
+	casos := []struct {
+		nome      string
+		categoria ticket.Categoria
+		usuario   string
+		papeis    []string
+		esperado  error
+	}{
+		{"solicitante só lê o próprio ticket", ticket.CategoriaTI, "cliente", nil, ticket.ErrProibido},
+		{"solicitante não vê o ticket de outro", ticket.CategoriaTI, "outro_cliente", nil, ticket.ErrNaoEncontrado},
+		{"supervisor só lê ticket restrito", ticket.CategoriaCompliance, "supervisora", []string{"supervisor"}, ticket.ErrProibido},
+		{"analista não vê ticket restrito", ticket.CategoriaCompliance, "analista", []string{"analista"}, ticket.ErrNaoEncontrado},
+		{"concessão de escrita na categoria", ticket.CategoriaCompliance, "analista", []string{"analista", "categoria:compliance:escrita"}, nil},
+		{"analista altera ticket não restrito", ticket.CategoriaTI, "analista", []string{"analista"}, nil},
+	}
+	for _, c := range casos {
+		tk := ticketTeste(t, c.categoria, ticket.SubcategoriaBug, "cliente")
+		repo := novoRepositorioMemoria(tk)
+		uc := NewAtualizarStatusUseCase(repo, ticket.MaquinaDeEstadosPadrao(), autorizadorTeste)
+
+		responsavel := c.usuario
+		_, err := uc.Execute(contextoDe(c.usuario, c.papeis...), AtualizarStatusTicketInput{ID: tk.ID, Status: ticket.StatusEmCurso, Responsavel: &responsavel})
+		if !errors.Is(err, c.esperado) {
+			t.Errorf("%s: esperava %v, recebido %v", c.nome, c.esperado, err)
+			continue
+		}
+
+		// a recusa acontece antes de qualquer alteração
+		if c.esperado != nil && (repo.atualizacoes != 0 || tk.Status != ticket.StatusAberto) {
+			t.Errorf("%s: ticket alterado apesar da recusa (%d gravações, status %s)", c.nome, repo.atualizacoes, tk.Status)
+		}
+		if c.esperado == nil && (repo.atualizacoes != 1 || tk.Status != ticket.StatusEmCurso) {
+			t.Errorf("%s: esperava o ticket em curso e gravado, recebido %s com %d gravações", c.nome, tk.Status, repo.atualizacoes)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package ticket
+
+import (
+	"context"
+	"io"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/anexo"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input dos casos de uso de baixar e remover um anexo
+type AnexoInput struct {
+	ID      string
+	AnexoID string
+}
+
+// Output do caso de uso de baixar anexo; quem recebe deve fechar o Conteudo
+type BaixarAnexoOutput struct {
+	AnexoOutput
+	Conteudo io.ReadCloser
+}
+
+// Caso de uso de baixar o conteúdo de um anexo
+type BaixarAnexoUseCase struct {
+	ticketRepository ticket.Repository
+	anexoRepository  anexo.Repository
+	arquivista       *anexo.Arquivista
+	autorizador      *acesso.Autorizador
+}
+
+// NewBaixarAnexoUseCase cria uma nova instância do caso de uso de baixar anexo
+func NewBaixarAnexoUseCase(repo ticket.Repository, anexoRepo anexo.Repository, arquivista *anexo.Arquivista, autorizador *acesso.Autorizador) *BaixarAnexoUseCase {
+	return &BaixarAnexoUseCase{
+		ticketRepository: repo,
+		anexoRepository:  anexoRepo,
+		arquivista:       arquivista,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de baixar anexo
+func (uc *BaixarAnexoUseCase) Execute(ctx context.Context, input AnexoInput) (*BaixarAnexoOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return nil, err
+	}
+	a, err := buscarAnexoDoTicket(uc.anexoRepository, t.ID, input.AnexoID)
+	if err != nil {
+		return nil, err
+	}
+
+	conteudo, err := uc.arquivista.Abrir(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	return &BaixarAnexoOutput{AnexoOutput: *anexoOutputDe(a), Conteudo: conteudo}, nil
+}
+
+// buscarAnexoDoTicket busca o anexo e confere que ele é do ticket da URL, para que o acesso
+// a um ticket não dê acesso aos arquivos de outro
+func buscarAnexoDoTicket(repo anexo.Repository, ticketID, anexoID string) (*anexo.Anexo, error) {
+	a, err := repo.BuscarPorID(anexoID)
+	if err != nil {
+		return nil, err
+	}
+	if a.TicketID != ticketID {
+		return nil, anexo.ErrAnexoNaoEncontrado
+	}
+	return a, nil
+}
@@ -1,7 +1,11 @@
 package ticket
 
 import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/sla"
 	"nox_tickets/internal/domain/ticket"
+	"time"
 )
 
 // input do caso de uso de buscar ticket
@@ -25,6 +29,38 @@ type ModificacaoOutput struct {
 	DataModificacao string
 }
 
+// PausaOutput é um intervalo em que o atendimento ficou parado
+type PausaOutput struct {
+	ID        string
+	Status    ticket.Status
+	Motivo    string
+	UsuarioID string
+	Inicio    string
+	Fim       string
+	Duracao   string
+}
+
+// ExecucaoOutput separa o tempo de atendimento em tempo ativo e tempo em espera
+type ExecucaoOutput struct {
+	TempoAtivo  string
+	TempoEspera string
+	Pausas      []PausaOutput
+}
+
+// SLAOutput é a situação do SLA do ticket no momento da consulta
+type SLAOutput struct {
+	PrazoPrimeiraResposta         string
+	PrazoResolucao                string
+	DataPrimeiraResposta          string
+	Violado                       bool
+	TempoRestantePrimeiraResposta string
+	TempoRestanteResolucao        string
+
+	// tempos restantes em tempo corrido
+	TempoRestantePrimeiraRespostaCorrido string
+	TempoRestanteResolucaoCorrido        string
+}
+
 // Output do caso de uso de buscar ticket
 type BuscarTicketOutput struct {
 	ID              string
@@ -37,11 +73,23 @@ type BuscarTicketOutput struct {
 	Gravidade       int
 	AbertoPor       string
 	Responsavel     string
+	FilaID          string
 	DataAbertura    string
 	DataInicio      string
 	DataConclusao   string
 	DuracaoTotal    string
 	DuracaoExecucao string
+	SLA             SLAOutput
+
+	// durações em tempo corrido
+	DuracaoTotalCorrida    string
+	DuracaoExecucaoCorrida string
+
+	// tempo ativo, tempo em espera e cada pausa com seu motivo
+	Execucao ExecucaoOutput
+
+	// próximos status possíveis a partir do status atual
+	TransicoesPermitidas []ticket.Status
 
 	// Histórico de observações e modificações
 	Observacoes  []ObservacaoOutput
@@ -53,20 +101,37 @@ type BuscarTicketOutput struct {
 	CPF        *string
 	Plataforma *string
 	Contato    string
+
+	// campos personalizados do formulário da categoria/subcategoria
+	Campos map[string]interface{}
+
+	// versão atual, usada como ETag
+	Versao int
 }
 
 // Caso de uso de buscar ticket
 type BuscarTicketUseCase struct {
 	ticketRepository ticket.Repository
+	maquina          *ticket.MaquinaDeEstados
+	motorSLA         *sla.Motor
+	autorizador      *acesso.Autorizador
 }
 
 // Executa o caso de uso de buscar ticket
-func (uc *BuscarTicketUseCase) Execute(input BuscarTicketInput) (*BuscarTicketOutput, error) {
+func (uc *BuscarTicketUseCase) Execute(ctx context.Context, input BuscarTicketInput) (*BuscarTicketOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. Busca o ticket no banco
 	ticket, err := uc.ticketRepository.GetByID(input.ID)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.autorizador.VerificarLeitura(ator, ticket); err != nil {
+		return nil, err
+	}
 
 	// 2. Formata as datas (converte nil para string vazia quando necessário)
 	dataInicio := ""
@@ -103,7 +168,29 @@ func (uc *BuscarTicketUseCase) Execute(input BuscarTicketInput) (*BuscarTicketOu
 		}
 	}
 
-	// 5. Retorna todos os dados formatados
+	// 5. Calcula o tempo ativo e o tempo em espera, em tempo útil
+	agora := time.Now()
+	calendario := uc.maquina.Calendario()
+	resumo := ticket.ResumoExecucao(agora, calendario)
+
+	pausas := make([]PausaOutput, len(ticket.Pausas))
+	for i, p := range ticket.Pausas {
+		fim := agora
+		pausas[i] = PausaOutput{
+			ID:        p.ID,
+			Status:    p.Status,
+			Motivo:    p.Motivo,
+			UsuarioID: p.UsuarioID,
+			Inicio:    p.Inicio.Format("2006-01-02 15:04:05"),
+		}
+		if p.Fim != nil {
+			fim = *p.Fim
+			pausas[i].Fim = p.Fim.Format("2006-01-02 15:04:05")
+		}
+		pausas[i].Duracao = calendario.DuracaoUtil(p.Inicio, fim).Round(time.Second).String()
+	}
+
+	// 6. Retorna todos os dados formatados
 	return &BuscarTicketOutput{
 		ID:              ticket.ID,
 		Titulo:          ticket.Titulo,
@@ -115,11 +202,24 @@ func (uc *BuscarTicketUseCase) Execute(input BuscarTicketInput) (*BuscarTicketOu
 		Gravidade:       ticket.Gravidade,
 		AbertoPor:       ticket.AbertoPor,
 		Responsavel:     ticket.Responsavel,
+		FilaID:          ticket.FilaID,
 		DataAbertura:    ticket.DataAbertura.Format("2006-01-02 15:04:05"),
 		DataInicio:      dataInicio,
 		DataConclusao:   dataConclusao,
 		DuracaoTotal:    ticket.DuracaoTotal.String(),
 		DuracaoExecucao: ticket.DuracaoExecucao.String(),
+		SLA:             novoSLAOutput(uc.motorSLA.Estado(ticket, agora)),
+
+		DuracaoTotalCorrida:    ticket.DuracaoTotalCorrida.String(),
+		DuracaoExecucaoCorrida: ticket.DuracaoExecucaoCorrida.String(),
+
+		Execucao: ExecucaoOutput{
+			TempoAtivo:  resumo.TempoAtivo.Round(time.Second).String(),
+			TempoEspera: resumo.TempoEspera.Round(time.Second).String(),
+			Pausas:      pausas,
+		},
+
+		TransicoesPermitidas: uc.maquina.Permitidas(ticket, agora),
 
 		// adiciona as observações e modificações
 		Observacoes:  observacoes,
@@ -131,12 +231,44 @@ func (uc *BuscarTicketUseCase) Execute(input BuscarTicketInput) (*BuscarTicketOu
 		CPF:        ticket.CPF,
 		Plataforma: ticket.Plataforma,
 		Contato:    ticket.Contato,
+		Campos:     ticket.Campos,
+
+		Versao: ticket.Versao,
 	}, nil
 }
 
 // NewBuscarTicketUseCase cria uma nova instância do caso de uso de buscar ticket
-func NewBuscarTicketUseCase(ticketRepository ticket.Repository) *BuscarTicketUseCase {
+func NewBuscarTicketUseCase(ticketRepository ticket.Repository, maquina *ticket.MaquinaDeEstados, motorSLA *sla.Motor, autorizador *acesso.Autorizador) *BuscarTicketUseCase {
 	return &BuscarTicketUseCase{
 		ticketRepository: ticketRepository,
+		maquina:          maquina,
+		motorSLA:         motorSLA,
+		autorizador:      autorizador,
+	}
+}
+
+// novoSLAOutput formata o estado do SLA para a saída dos casos de uso
+func novoSLAOutput(estado ticket.EstadoSLA) SLAOutput {
+	formatar := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+
+	output := SLAOutput{
+		PrazoPrimeiraResposta: formatar(estado.PrazoPrimeiraResposta),
+		PrazoResolucao:        formatar(estado.PrazoResolucao),
+		DataPrimeiraResposta:  formatar(estado.DataPrimeiraResposta),
+		Violado:               estado.Violado,
+	}
+	if estado.PrazoPrimeiraResposta != nil {
+		output.TempoRestantePrimeiraResposta = estado.TempoRestantePrimeiraResposta.Round(time.Second).String()
+		output.TempoRestantePrimeiraRespostaCorrido = estado.TempoRestantePrimeiraRespostaCorrido.Round(time.Second).String()
+	}
+	if estado.PrazoResolucao != nil {
+		output.TempoRestanteResolucao = estado.TempoRestanteResolucao.Round(time.Second).String()
+		output.TempoRestanteResolucaoCorrido = estado.TempoRestanteResolucaoCorrido.Round(time.Second).String()
 	}
+	return output
 }
@@ -0,0 +1,119 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/aprovacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de buscar a aprovação de um ticket
+type BuscarAprovacoesInput struct {
+	ID string
+}
+
+// output com a situação da aprovação e todas as decisões do ticket
+type AprovacoesOutput struct {
+	TicketID       string
+	Exigida        bool  // falso quando nenhuma regra de aprovação se aplica ao ticket
+	Valor          int64 // em centavos
+	ValorInformado bool
+	Aprovada       bool
+	Rejeitada      bool
+	EtapaPendente  string
+	Etapas         []EtapaAprovacaoOutput
+	Decisoes       []DecisaoAprovacaoOutput
+}
+
+// output de uma etapa da aprovação
+type EtapaAprovacaoOutput struct {
+	Nome        string
+	Papeis      []string
+	Aprovacoes  int
+	Aprovadores []string
+	Concluida   bool
+}
+
+// output de uma decisão; as tomadas sobre outro valor do ticket não contam mais
+type DecisaoAprovacaoOutput struct {
+	ID         string
+	Etapa      string
+	Decisao    ticket.DecisaoAprovacao
+	Comentario string
+	Valor      int64 // em centavos
+	UsuarioID  string
+	Data       string
+	Vigente    bool
+}
+
+// novoAprovacoesOutput monta o output a partir da situação calculada pela política
+func novoAprovacoesOutput(politica *aprovacao.Politica, t *ticket.Ticket) *AprovacoesOutput {
+	output := &AprovacoesOutput{TicketID: t.ID}
+	s, ok := politica.Avaliar(t)
+	if ok {
+		output.Exigida = true
+		output.Valor = s.Valor
+		output.ValorInformado = s.ValorInformado
+		output.Aprovada = s.Aprovada
+		output.Rejeitada = s.Rejeitada
+		if s.Pendente != nil {
+			output.EtapaPendente = s.Pendente.Nome
+		}
+		for _, e := range s.Etapas {
+			output.Etapas = append(output.Etapas, EtapaAprovacaoOutput{
+				Nome:        e.Nome,
+				Papeis:      e.Papeis,
+				Aprovacoes:  e.Aprovacoes,
+				Aprovadores: e.Aprovadores,
+				Concluida:   e.Concluida,
+			})
+		}
+	}
+
+	for _, a := range t.Aprovacoes {
+		output.Decisoes = append(output.Decisoes, DecisaoAprovacaoOutput{
+			ID:         a.ID,
+			Etapa:      a.Etapa,
+			Decisao:    a.Decisao,
+			Comentario: a.Comentario,
+			Valor:      a.Valor,
+			UsuarioID:  a.UsuarioID,
+			Data:       a.Data.Format("2006-01-02 15:04:05"),
+			Vigente:    ok && a.Valor == s.Valor,
+		})
+	}
+	return output
+}
+
+// Caso de uso de buscar a aprovação de um ticket
+type BuscarAprovacoesUseCase struct {
+	ticketRepository ticket.Repository
+	politica         *aprovacao.Politica
+	autorizador      *acesso.Autorizador
+}
+
+// NewBuscarAprovacoesUseCase cria uma nova instância do caso de uso de buscar aprovações
+func NewBuscarAprovacoesUseCase(repo ticket.Repository, politica *aprovacao.Politica, autorizador *acesso.Autorizador) *BuscarAprovacoesUseCase {
+	return &BuscarAprovacoesUseCase{
+		ticketRepository: repo,
+		politica:         politica,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de buscar aprovações; quem pode ver o ticket vê a aprovação
+func (uc *BuscarAprovacoesUseCase) Execute(ctx context.Context, input BuscarAprovacoesInput) (*AprovacoesOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return nil, err
+	}
+
+	return novoAprovacoesOutput(uc.politica, t), nil
+}
@@ -0,0 +1,54 @@
+package ticket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/sla"
+	"nox_tickets/internal/domain/ticket"
+)
+
+func TestBuscarTicket_Acesso(t *testing.T) {
+	proprio := ticketTeste(t, ticket.CategoriaTI, ticket.SubcategoriaBug, "cliente")
+	restrito := ticketTeste(t, ticket.CategoriaCompliance, ticket.SubcategoriaBug, "outro_cliente")
+	repo := novoRepositorioMemoria(proprio, restrito)
+	uc := NewBuscarTicketUseCase(repo, ticket.MaquinaDeEstadosPadrao(), sla.NovoMotor(nil, nil), autorizadorTeste)
+
+	casos := []struct {
+		nome     string
+		ctx      context.Context
+		id       string
+		esperado error
+	}{
+		{"solicitante no próprio ticket", contextoDe("cliente"), proprio.ID, nil},
+		{"solicitante no ticket de outro", contextoDe("outro_cliente"), proprio.ID, ticket.ErrNaoEncontrado},
+		{"analista em ticket restrito", contextoDe("analista", "analista"), restrito.ID, ticket.ErrNaoEncontrado},
+		{"supervisor em ticket restrito", contextoDe("supervisora", "supervisor"), restrito.ID, nil},
+		{"concessão na categoria", contextoDe("analista", "analista", "categoria:compliance:leitura"), restrito.ID, nil},
+		{"ticket inexistente", contextoDe("analista", "analista"), "inexistente", ticket.ErrNaoEncontrado},
+		{"sem autenticação", context.Background(), proprio.ID, ticket.ErrNaoAutenticado},
+	}
+	for _, c := range casos {
+		output, err := uc.Execute(c.ctx, BuscarTicketInput{ID: c.id})
+		if !errors.Is(err, c.esperado) {
+			t.Errorf("%s: esperava %v, recebido %v", c.nome, c.esperado, err)
+			continue
+		}
+		if c.esperado == nil && output.ID != c.id {
+			t.Errorf("%s: esperava o ticket %s, recebido %+v", c.nome, c.id, output)
+		}
+	}
+}
+
+func TestBuscarTicket_SemAcessoIgualAInexistente(t *testing.T) {
+	restrito := ticketTeste(t, ticket.CategoriaFinanceiro, ticket.SubcategoriaFraude, "cliente")
+	uc := NewBuscarTicketUseCase(novoRepositorioMemoria(restrito), ticket.MaquinaDeEstadosPadrao(), sla.NovoMotor(nil, nil), autorizadorTeste)
+
+	// quem não pode ver o ticket recebe o mesmo erro de um ticket que não existe
+	_, errSemAcesso := uc.Execute(contextoDe("analista", "analista"), BuscarTicketInput{ID: restrito.ID})
+	_, errInexistente := uc.Execute(contextoDe("analista", "analista"), BuscarTicketInput{ID: "inexistente"})
+	if errSemAcesso != errInexistente {
+		t.Errorf("Esperava o mesmo erro, recebido %v e %v", errSemAcesso, errInexistente)
+	}
+}
@@ -1,7 +1,12 @@
 package ticket
 
 import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/sla"
 	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
 )
 
 // input do use case de criar ticket
@@ -10,7 +15,6 @@ type CriarTicketInput struct {
 	Descricao    string
 	Categoria    ticket.Categoria
 	Subcategoria ticket.Subcategoria
-	AbertoPor    string
 	Urgencia     int
 	Gravidade    int
 
@@ -21,6 +25,13 @@ type CriarTicketInput struct {
 	Plataforma  string
 	Contato     string
 	Responsavel string
+
+	// campos personalizados do formulário da categoria/subcategoria
+	Campos map[string]interface{}
+
+	// SemObrigatorios é usado pelos canais que não coletam o formulário, como o e-mail:
+	// os campos enviados são validados, mas os obrigatórios podem ficar para depois
+	SemObrigatorios bool
 }
 
 // output do use case de criar ticket
@@ -32,22 +43,51 @@ type CriarTicketOutput struct {
 	Titulo       string
 	Categoria    ticket.Categoria
 	Subcategoria ticket.Subcategoria
+
+	// fila para onde o ticket foi roteado e o responsável, quando atribuído
+	FilaID      string
+	Responsavel string
 }
 
 // use case de criar ticket
 type CriarTicketUseCase struct {
 	ticketRepository ticket.Repository
+	motorSLA         *sla.Motor
+	maquina          *ticket.MaquinaDeEstados
+	diretorio        *usuario.Diretorio
+	roteador         *fila.Roteador
+	taxonomia        ticket.Taxonomia
+	formularios      ticket.Formularios
+	autorizador      *acesso.Autorizador
 }
 
 // Construtor do use case de criar ticket
-func NewCriarTicketUseCase(repo ticket.Repository) *CriarTicketUseCase {
+func NewCriarTicketUseCase(repo ticket.Repository, motorSLA *sla.Motor, maquina *ticket.MaquinaDeEstados, diretorio *usuario.Diretorio, roteador *fila.Roteador, taxonomia ticket.Taxonomia, formularios ticket.Formularios, autorizador *acesso.Autorizador) *CriarTicketUseCase {
 	return &CriarTicketUseCase{
 		ticketRepository: repo,
+		motorSLA:         motorSLA,
+		maquina:          maquina,
+		diretorio:        diretorio,
+		roteador:         roteador,
+		taxonomia:        taxonomia,
+		formularios:      formularios,
+		autorizador:      autorizador,
 	}
 }
 
 // Executa o use case de criar ticket
-func (uc *CriarTicketUseCase) Execute(input CriarTicketInput) (*CriarTicketOutput, error) {
+func (uc *CriarTicketUseCase) Execute(ctx context.Context, input CriarTicketInput) (*CriarTicketOutput, error) {
+	// quem abre o ticket é o usuário autenticado
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// o autor precisa estar no diretório; no primeiro acesso ele é cadastrado com os dados do token
+	if _, err := uc.diretorio.GarantirUsuario(ator); err != nil {
+		return nil, err
+	}
+
 	// validações adicionais
 	if input.Urgencia < 1 || input.Urgencia > 5 {
 		return nil, ticket.ErrUrgenciaInvalida
@@ -62,16 +102,24 @@ func (uc *CriarTicketUseCase) Execute(input CriarTicketInput) (*CriarTicketOutpu
 		input.Descricao,
 		input.Categoria,
 		input.Subcategoria,
-		input.AbertoPor,
+		ator.ID,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// A categoria e a subcategoria precisam formar um par ativo da taxonomia
+	if err := uc.taxonomia.ValidarClassificacao(novoTicket.Categoria, novoTicket.Subcategoria); err != nil {
+		return nil, err
+	}
+
 	// Define a urgencia e a gravidade
 	novoTicket.Urgencia = input.Urgencia
 	novoTicket.Gravidade = input.Gravidade
 
+	// Calcula os prazos de SLA a partir da matriz urgência × gravidade
+	uc.motorSLA.Aplicar(novoTicket)
+
 	// Adiciona informações adicionais se fornecidas
 	novoTicket.SetInformacaoAdicional(
 		input.Merchant,
@@ -81,13 +129,31 @@ func (uc *CriarTicketUseCase) Execute(input CriarTicketInput) (*CriarTicketOutpu
 		input.Contato,
 	)
 
-	// Define o responsável se fornecido
+	// Campos personalizados, validados com as informações adicionais contra o formulário da classificação
+	if err := novoTicket.SetCampos(input.Campos, ator.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.formularios.ValidarCampos(novoTicket, !input.SemObrigatorios); err != nil {
+		return nil, err
+	}
+
+	// Define o responsável se fornecido; iniciar o atendimento exige permissão de escrita
+	// e a máquina de estados recusa responsáveis desconhecidos ou desativados
 	if input.Responsavel != "" {
-		if err := novoTicket.IniciarAtendimento(input.Responsavel); err != nil {
+		if !uc.autorizador.PodeEscrever(ator, novoTicket) {
+			return nil, ticket.ErrProibido
+		}
+		contexto := ticket.ContextoTransicao{UsuarioID: ator.ID, Responsavel: input.Responsavel}
+		if err := uc.maquina.Aplicar(novoTicket, ticket.StatusEmCurso, contexto); err != nil {
 			return nil, err
 		}
 	}
 
+	// Coloca o ticket na fila da categoria e, se a fila atribuir automaticamente, escolhe o responsável
+	if _, err := uc.roteador.Rotear(novoTicket); err != nil {
+		return nil, err
+	}
+
 	// Persiste o ticket usando o repositório
 	err = uc.ticketRepository.Create(novoTicket)
 	if err != nil {
@@ -103,5 +169,7 @@ func (uc *CriarTicketUseCase) Execute(input CriarTicketInput) (*CriarTicketOutpu
 		Titulo:       novoTicket.Titulo,
 		Categoria:    novoTicket.Categoria,
 		Subcategoria: novoTicket.Subcategoria,
+		FilaID:       novoTicket.FilaID,
+		Responsavel:  novoTicket.Responsavel,
 	}, nil
 }
@@ -0,0 +1,65 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/aprovacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de decidir uma etapa da aprovação
+type DecidirAprovacaoInput struct {
+	ID         string
+	Decisao    ticket.DecisaoAprovacao
+	Comentario string // obrigatório ao rejeitar
+}
+
+// Caso de uso de aprovar ou rejeitar a etapa pendente da aprovação de um ticket
+type DecidirAprovacaoUseCase struct {
+	ticketRepository ticket.Repository
+	politica         *aprovacao.Politica
+	autorizador      *acesso.Autorizador
+}
+
+// NewDecidirAprovacaoUseCase cria uma nova instância do caso de uso de decidir aprovação
+func NewDecidirAprovacaoUseCase(repo ticket.Repository, politica *aprovacao.Politica, autorizador *acesso.Autorizador) *DecidirAprovacaoUseCase {
+	return &DecidirAprovacaoUseCase{
+		ticketRepository: repo,
+		politica:         politica,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de decidir aprovação. Quem pode decidir é definido pelos papéis da etapa
+// pendente, e não pela permissão de escrita no ticket; quem abriu o ticket nunca decide
+func (uc *DecidirAprovacaoUseCase) Execute(ctx context.Context, input DecidirAprovacaoInput) (*AprovacoesOutput, error) {
+	// 1. identifica quem está decidindo e busca o ticket
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return nil, err
+	}
+
+	// 2. registra a decisão com os papéis reconhecidos do token
+	permissoes := uc.autorizador.Permissoes(ator)
+	aprovador := aprovacao.Aprovador{ID: ator.ID}
+	for _, p := range permissoes.Papeis {
+		aprovador.Papeis = append(aprovador.Papeis, string(p))
+	}
+	if err := uc.politica.Decidir(t, aprovador, input.Decisao, input.Comentario); err != nil {
+		return nil, err
+	}
+
+	// 3. persiste a decisão
+	if err := uc.ticketRepository.Update(t); err != nil {
+		return nil, err
+	}
+
+	return novoAprovacoesOutput(uc.politica, t), nil
+}
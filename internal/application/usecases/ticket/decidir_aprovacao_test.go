@@ -0,0 +1,81 @@
+package ticket
+
+import (
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/aprovacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// politicaAprovacaoTeste exige a aprovação de um supervisor nas solicitações de saque
+func politicaAprovacaoTeste(t *testing.T) *aprovacao.Politica {
+	p, err := aprovacao.NovaPolitica([]aprovacao.Regra{{
+		Categoria:    ticket.CategoriaFinanceiro,
+		Subcategoria: ticket.SubcategoriaSolicitacaoSaque,
+		Faixas: []aprovacao.Faixa{{Etapas: []aprovacao.Etapa{
+			{Nome: "supervisao", Papeis: []string{"supervisor"}, Aprovacoes: 1},
+		}}},
+	}})
+	if err != nil {
+		t.Fatalf("Erro ao criar política: %v", err)
+	}
+	return p
+}
+
+func saqueTeste(t *testing.T, abertoPor string) *ticket.Ticket {
+	tk := ticketTeste(t, ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, abertoPor)
+	tk.Campos = map[string]interface{}{"valor": 1500.0}
+	return tk
+}
+
+func TestDecidirAprovacao_AutoAprovacao(t *testing.T) {
+	// a supervisora abriu o próprio saque: ela vê o ticket e tem o papel da etapa, mas não decide
+	saque := saqueTeste(t, "supervisora")
+	repo := novoRepositorioMemoria(saque)
+	uc := NewDecidirAprovacaoUseCase(repo, politicaAprovacaoTeste(t), autorizadorTeste)
+
+	_, err := uc.Execute(contextoDe("supervisora", "supervisor"), DecidirAprovacaoInput{ID: saque.ID, Decisao: ticket.DecisaoAprovada})
+	if !errors.Is(err, aprovacao.ErrAutoAprovacao) {
+		t.Fatalf("Esperava ErrAutoAprovacao, recebido %v", err)
+	}
+	if repo.atualizacoes != 0 || len(saque.Aprovacoes) != 0 {
+		t.Errorf("Não esperava decisão gravada, recebido %d gravações e %+v", repo.atualizacoes, saque.Aprovacoes)
+	}
+
+	// outro supervisor aprova
+	output, err := uc.Execute(contextoDe("supervisor_2", "supervisor"), DecidirAprovacaoInput{ID: saque.ID, Decisao: ticket.DecisaoAprovada})
+	if err != nil {
+		t.Fatalf("Erro ao aprovar: %v", err)
+	}
+	if !output.Aprovada || output.Valor != 150000 || repo.atualizacoes != 1 {
+		t.Errorf("Esperava o saque de 150000 centavos aprovado e gravado, recebido %+v com %d gravações", output, repo.atualizacoes)
+	}
+}
+
+func TestDecidirAprovacao_Acesso(t *testing.T) {
+	saque := saqueTeste(t, "cliente")
+	repo := novoRepositorioMemoria(saque)
+	uc := NewDecidirAprovacaoUseCase(repo, politicaAprovacaoTeste(t), autorizadorTeste)
+
+	casos := []struct {
+		nome     string
+		usuario  string
+		papeis   []string
+		esperado error
+	}{
+		// quem não vê o ticket recebe 404, mesmo tendo o papel da etapa
+		{"sem acesso ao ticket", "outro_cliente", nil, ticket.ErrNaoEncontrado},
+		{"sem o papel da etapa", "analista", []string{"analista"}, aprovacao.ErrAprovadorSemPapel},
+		{"solicitante decide o próprio saque", "cliente", nil, aprovacao.ErrAutoAprovacao},
+	}
+	for _, c := range casos {
+		_, err := uc.Execute(contextoDe(c.usuario, c.papeis...), DecidirAprovacaoInput{ID: saque.ID, Decisao: ticket.DecisaoAprovada})
+		if !errors.Is(err, c.esperado) {
+			t.Errorf("%s: esperava %v, recebido %v", c.nome, c.esperado, err)
+		}
+	}
+	if repo.atualizacoes != 0 || len(saque.Aprovacoes) != 0 {
+		t.Errorf("Não esperava decisão gravada, recebido %d gravações e %+v", repo.atualizacoes, saque.Aprovacoes)
+	}
+}
@@ -0,0 +1,41 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Caso de uso de deixar de seguir um ticket
+type DeixarDeSeguirTicketUseCase struct {
+	ticketRepository      ticket.Repository
+	notificacaoRepository notificacao.Repository
+	autorizador           *acesso.Autorizador
+}
+
+// NewDeixarDeSeguirTicketUseCase cria uma nova instância do caso de uso de deixar de seguir ticket
+func NewDeixarDeSeguirTicketUseCase(repo ticket.Repository, notificacaoRepo notificacao.Repository, autorizador *acesso.Autorizador) *DeixarDeSeguirTicketUseCase {
+	return &DeixarDeSeguirTicketUseCase{
+		ticketRepository:      repo,
+		notificacaoRepository: notificacaoRepo,
+		autorizador:           autorizador,
+	}
+}
+
+// Executa o caso de uso de deixar de seguir ticket; remover outra pessoa exige permissão de escrita
+func (uc *DeixarDeSeguirTicketUseCase) Execute(ctx context.Context, input SeguirTicketInput) (*SeguidoresOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seguidor, err := verificarSeguidor(uc.ticketRepository, uc.autorizador, ator, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.notificacaoRepository.RemoverSeguidor(input.ID, seguidor); err != nil {
+		return nil, err
+	}
+	return listarSeguidores(uc.notificacaoRepository, input.ID)
+}
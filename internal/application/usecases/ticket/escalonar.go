@@ -0,0 +1,104 @@
+package ticket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nox_tickets/internal/domain/escalonamento"
+	"nox_tickets/internal/domain/sla"
+	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+// Ticket escalonado em uma execução, com as regras aplicadas
+type TicketEscalonadoOutput struct {
+	ID     string
+	Regras []string
+}
+
+// Output de uma execução do escalonamento
+type EscalonarTicketsOutput struct {
+	Avaliados   int
+	Escalonados []TicketEscalonadoOutput
+
+	// falhas de tickets individuais, que não interrompem a execução
+	Erros []error
+}
+
+// Caso de uso que aplica as regras de escalonamento aos tickets em andamento.
+// Roda em segundo plano, sem usuário autenticado: as alterações são feitas pelo usuário sistema.
+type EscalonarTicketsUseCase struct {
+	ticketRepository ticket.Repository
+	motor            *escalonamento.Motor
+	motorSLA         *sla.Motor
+}
+
+// Construtor do caso de uso
+func NewEscalonarTicketsUseCase(repo ticket.Repository, motor *escalonamento.Motor, motorSLA *sla.Motor) *EscalonarTicketsUseCase {
+	return &EscalonarTicketsUseCase{
+		ticketRepository: repo,
+		motor:            motor,
+		motorSLA:         motorSLA,
+	}
+}
+
+// Executa uma rodada de escalonamento
+func (uc *EscalonarTicketsUseCase) Execute(ctx context.Context) (*EscalonarTicketsOutput, error) {
+	output := &EscalonarTicketsOutput{}
+	agora := time.Now()
+
+	// 1. percorre, regra a regra, os tickets em que ela pode estar vencida; o banco já descarta
+	// os que estão fora do status, da urgência ou do tempo e os que já receberam a regra
+	avaliados := map[string]bool{}
+	for _, filtros := range uc.motor.Filtros(agora) {
+		err := percorrerCompletos(ctx, uc.ticketRepository, filtros, func(t *ticket.Ticket) {
+			// um ticket vencido em várias regras recebe todas na primeira avaliação
+			if avaliados[t.ID] {
+				return
+			}
+			avaliados[t.ID] = true
+			output.Avaliados++
+
+			regras, err := uc.escalonar(t, agora)
+			if err != nil {
+				output.Erros = append(output.Erros, fmt.Errorf("ticket %s: %w", t.ID, err))
+			}
+			if len(regras) > 0 {
+				output.Escalonados = append(output.Escalonados, TicketEscalonadoOutput{ID: t.ID, Regras: regras})
+			}
+		})
+		if err != nil {
+			return output, err
+		}
+	}
+
+	return output, nil
+}
+
+// escalonar aplica as regras vencidas ao ticket já carregado; as notificações saem do outbox, gravado junto com o ticket
+func (uc *EscalonarTicketsUseCase) escalonar(t *ticket.Ticket, agora time.Time) ([]string, error) {
+	urgencia := t.Urgencia
+
+	// 2. aplica as regras vencidas
+	resultado, err := uc.motor.Avaliar(t, agora)
+	if err != nil {
+		return nil, err
+	}
+	if len(resultado.Regras) == 0 {
+		return nil, nil
+	}
+
+	// recalcula os prazos de SLA se a urgência mudou
+	if t.Urgencia != urgencia {
+		uc.motorSLA.Aplicar(t)
+	}
+
+	// 3. persiste; se alguém alterou o ticket nesse meio tempo, ele é reavaliado na próxima rodada
+	if err := uc.ticketRepository.Update(t); err != nil {
+		if errors.Is(err, ticket.ErrConflito) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resultado.Regras, nil
+}
@@ -1,19 +1,31 @@
 package ticket
 
 import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/sla"
 	"nox_tickets/internal/domain/ticket"
+	"time"
+)
+
+// itens por página quando não informado, e o máximo aceito
+const (
+	itensPorPaginaPadrao = 10
+	itensPorPaginaMaximo = 100
 )
 
 // input - estrutura que define os filtros e paginação para listar tickets
 type ListarTicketsInput struct {
-	// Filtros opcionais
-	Status      *ticket.Status
-	Categoria   *ticket.Categoria
-	Responsavel *string
+	// Filtros opcionais (os campos de paginação e ordenação dos filtros são ignorados)
+	Filtros ticket.TicketFiltros
 
 	// paginação
-	Pagina         int // número da página (1-based)
-	ItensPorPagina int // número de itens por página
+	Pagina         int    // número da página (1-based)
+	ItensPorPagina int    // número de itens por página
+	Cursor         string // quando informado, substitui a página (paginação por keyset)
+
+	// ordenação
+	Ordenacao ticket.Ordenacao
 }
 
 // output resumido de cada ticket na listagem
@@ -27,6 +39,7 @@ type TicketResumoOutput struct {
 	AbertoPor    string
 	Responsavel  string
 	DataAbertura string
+	SLA          SLAOutput
 }
 
 // Output da listagem com paginação
@@ -35,73 +48,68 @@ type ListarTicketsOutput struct {
 	Total        int // número total de tickets
 	TotalPaginas int // número total de páginas
 	PaginaAtual  int // número da página atual
+
+	// cursor para buscar a próxima página, vazio na última página
+	ProximoCursor string
 }
 
 // Caso de uso de listar tickets
 type ListarTicketsUseCase struct {
 	ticketRepository ticket.Repository
+	motorSLA         *sla.Motor
+	autorizador      *acesso.Autorizador
 }
 
 // Construtor do usecase
-func NewListarTicketsUseCase(repo ticket.Repository) *ListarTicketsUseCase {
+func NewListarTicketsUseCase(repo ticket.Repository, motorSLA *sla.Motor, autorizador *acesso.Autorizador) *ListarTicketsUseCase {
 	return &ListarTicketsUseCase{
 		ticketRepository: repo,
+		motorSLA:         motorSLA,
+		autorizador:      autorizador,
 	}
 }
 
 // Execute executa o caso de uso de listar tickets
-func (uc *ListarTicketsUseCase) Execute(input ListarTicketsInput) (*ListarTicketsOutput, error) {
+func (uc *ListarTicketsUseCase) Execute(ctx context.Context, input ListarTicketsInput) (*ListarTicketsOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validação básica de paginação
 	if input.Pagina < 1 {
 		input.Pagina = 1
 	}
 	if input.ItensPorPagina < 1 {
-		input.ItensPorPagina = 10
+		input.ItensPorPagina = itensPorPaginaPadrao
 	}
+	input.ItensPorPagina = min(input.ItensPorPagina, itensPorPaginaMaximo)
 
 	// Cria filtro para a busca
-	filtros := ticket.TicketFiltros{
-		Status:      []ticket.Status{},
-		Categoria:   []ticket.Categoria{},
-		Responsavel: "",
-	}
+	filtros := input.Filtros
 
-	// Adiciona filtros se fornecidos
-	if input.Status != nil {
-		filtros.Status = append(filtros.Status, *input.Status)
-	}
-	if input.Categoria != nil {
-		filtros.Categoria = append(filtros.Categoria, *input.Categoria)
-	}
-	if input.Responsavel != nil {
-		filtros.Responsavel = *input.Responsavel
-	}
+	// só traz do banco os tickets que o usuário pode ver
+	filtros.Escopo = uc.autorizador.Escopo(ator)
 
-	// Busca os tickets com filtros
-	tickets, err := uc.ticketRepository.List(filtros)
-	if err != nil {
+	// Paginação e ordenação são feitas pelo repositório
+	if err := ticket.ValidarCampoOrdenacao(input.Ordenacao.Campo); err != nil {
 		return nil, err
 	}
+	filtros.Limite = input.ItensPorPagina
+	filtros.Deslocamento = (input.Pagina - 1) * input.ItensPorPagina
+	filtros.Cursor = input.Cursor
+	filtros.Ordenacao = input.Ordenacao
 
-	// implementa a paginação manual
-	total := len(tickets)
-	inicio := (input.Pagina - 1) * input.ItensPorPagina
-	fim := inicio + input.ItensPorPagina
-	if fim > total {
-		fim = total
-	}
-
-	// Aplica paginação
-	ticketsPaginados := tickets
-	if inicio < total {
-		ticketsPaginados = tickets[inicio:fim]
-	} else {
-		ticketsPaginados = []*ticket.Ticket{}
+	// Busca a página de tickets com filtros
+	resultado, err := uc.ticketRepository.List(filtros)
+	if err != nil {
+		return nil, err
 	}
 
 	// Converte para o formato de saída
-	ticketsOutput := make([]TicketResumoOutput, len(ticketsPaginados))
-	for i, t := range ticketsPaginados {
+	agora := time.Now()
+	ticketsOutput := make([]TicketResumoOutput, len(resultado.Tickets))
+	for i, t := range resultado.Tickets {
 		ticketsOutput[i] = TicketResumoOutput{
 			ID:           t.ID,
 			Titulo:       t.Titulo,
@@ -112,16 +120,18 @@ func (uc *ListarTicketsUseCase) Execute(input ListarTicketsInput) (*ListarTicket
 			AbertoPor:    t.AbertoPor,
 			Responsavel:  t.Responsavel,
 			DataAbertura: t.DataAbertura.Format("2006-01-02 15:04:05"),
+			SLA:          novoSLAOutput(uc.motorSLA.Estado(t, agora)),
 		}
 	}
 
 	// calcula total de páginas
-	totalPaginas := (total + input.ItensPorPagina - 1) / input.ItensPorPagina
+	totalPaginas := (resultado.Total + input.ItensPorPagina - 1) / input.ItensPorPagina
 
 	return &ListarTicketsOutput{
-		Tickets:      ticketsOutput,
-		Total:        total,
-		TotalPaginas: totalPaginas,
-		PaginaAtual:  input.Pagina,
+		Tickets:       ticketsOutput,
+		Total:         resultado.Total,
+		TotalPaginas:  totalPaginas,
+		PaginaAtual:   input.Pagina,
+		ProximoCursor: resultado.ProximoCursor,
 	}, nil
 }
@@ -0,0 +1,54 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/anexo"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de listar os anexos de um ticket
+type ListarAnexosInput struct {
+	ID string
+}
+
+// Caso de uso de listar os arquivos de um ticket
+type ListarAnexosUseCase struct {
+	ticketRepository ticket.Repository
+	anexoRepository  anexo.Repository
+	autorizador      *acesso.Autorizador
+}
+
+// NewListarAnexosUseCase cria uma nova instância do caso de uso de listar anexos
+func NewListarAnexosUseCase(repo ticket.Repository, anexoRepo anexo.Repository, autorizador *acesso.Autorizador) *ListarAnexosUseCase {
+	return &ListarAnexosUseCase{
+		ticketRepository: repo,
+		anexoRepository:  anexoRepo,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de listar anexos; quem pode ver o ticket vê os arquivos dele
+func (uc *ListarAnexosUseCase) Execute(ctx context.Context, input ListarAnexosInput) ([]AnexoOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return nil, err
+	}
+
+	anexos, err := uc.anexoRepository.ListarPorTicket(t.ID)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]AnexoOutput, 0, len(anexos))
+	for _, a := range anexos {
+		output = append(output, *anexoOutputDe(a))
+	}
+	return output, nil
+}
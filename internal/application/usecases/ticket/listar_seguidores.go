@@ -0,0 +1,46 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// input do caso de uso de listar os seguidores de um ticket
+type ListarSeguidoresInput struct {
+	ID string
+}
+
+// Caso de uso de listar os seguidores de um ticket
+type ListarSeguidoresUseCase struct {
+	ticketRepository      ticket.Repository
+	notificacaoRepository notificacao.Repository
+	autorizador           *acesso.Autorizador
+}
+
+// NewListarSeguidoresUseCase cria uma nova instância do caso de uso de listar seguidores
+func NewListarSeguidoresUseCase(repo ticket.Repository, notificacaoRepo notificacao.Repository, autorizador *acesso.Autorizador) *ListarSeguidoresUseCase {
+	return &ListarSeguidoresUseCase{
+		ticketRepository:      repo,
+		notificacaoRepository: notificacaoRepo,
+		autorizador:           autorizador,
+	}
+}
+
+// Executa o caso de uso de listar seguidores; quem pode ver o ticket vê quem o segue
+func (uc *ListarSeguidoresUseCase) Execute(ctx context.Context, input ListarSeguidoresInput) (*SeguidoresOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := uc.ticketRepository.GetByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return nil, err
+	}
+
+	return listarSeguidores(uc.notificacaoRepository, input.ID)
+}
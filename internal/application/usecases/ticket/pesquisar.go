@@ -0,0 +1,124 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/sla"
+	"nox_tickets/internal/domain/ticket"
+	"strings"
+	"time"
+)
+
+var ErrConsultaVazia = ticket.NovoErroValidacao("q", "consulta_obrigatoria", "consulta de pesquisa é obrigatória")
+
+// input - texto pesquisado, filtros opcionais e paginação
+type PesquisarTicketsInput struct {
+	Consulta string
+
+	// Filtros opcionais (os campos de paginação e ordenação dos filtros são ignorados)
+	Filtros ticket.TicketFiltros
+
+	// paginação
+	Pagina         int
+	ItensPorPagina int
+}
+
+// output de cada ticket encontrado, com relevância e trechos destacados
+type ResultadoPesquisaOutput struct {
+	Ticket          TicketResumoOutput
+	Relevancia      float64
+	TituloDestacado string
+	Trecho          string
+}
+
+// Output da pesquisa com paginação
+type PesquisarTicketsOutput struct {
+	Resultados   []ResultadoPesquisaOutput
+	Total        int
+	TotalPaginas int
+	PaginaAtual  int
+}
+
+// Caso de uso de pesquisar tickets por texto
+type PesquisarTicketsUseCase struct {
+	ticketRepository ticket.Repository
+	motorSLA         *sla.Motor
+	autorizador      *acesso.Autorizador
+}
+
+// Construtor do usecase
+func NewPesquisarTicketsUseCase(repo ticket.Repository, motorSLA *sla.Motor, autorizador *acesso.Autorizador) *PesquisarTicketsUseCase {
+	return &PesquisarTicketsUseCase{
+		ticketRepository: repo,
+		motorSLA:         motorSLA,
+		autorizador:      autorizador,
+	}
+}
+
+// Execute executa o caso de uso de pesquisar tickets
+func (uc *PesquisarTicketsUseCase) Execute(ctx context.Context, input PesquisarTicketsInput) (*PesquisarTicketsOutput, error) {
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	consulta := strings.TrimSpace(input.Consulta)
+	if consulta == "" {
+		return nil, ErrConsultaVazia
+	}
+
+	// Validação básica de paginação
+	if input.Pagina < 1 {
+		input.Pagina = 1
+	}
+	if input.ItensPorPagina < 1 {
+		input.ItensPorPagina = itensPorPaginaPadrao
+	}
+	input.ItensPorPagina = min(input.ItensPorPagina, itensPorPaginaMaximo)
+
+	// Os resultados são sempre ordenados por relevância
+	filtros := input.Filtros
+
+	// só traz do banco os tickets que o usuário pode ver
+	filtros.Escopo = uc.autorizador.Escopo(ator)
+	filtros.Limite = input.ItensPorPagina
+	filtros.Deslocamento = (input.Pagina - 1) * input.ItensPorPagina
+	filtros.Cursor = ""
+	filtros.Ordenacao = ticket.Ordenacao{}
+
+	resultado, err := uc.ticketRepository.Pesquisar(consulta, filtros)
+	if err != nil {
+		return nil, err
+	}
+
+	// Converte para o formato de saída
+	agora := time.Now()
+	resultados := make([]ResultadoPesquisaOutput, len(resultado.Itens))
+	for i, item := range resultado.Itens {
+		t := item.Ticket
+		resultados[i] = ResultadoPesquisaOutput{
+			Ticket: TicketResumoOutput{
+				ID:           t.ID,
+				Titulo:       t.Titulo,
+				Status:       t.Status,
+				Categoria:    t.Categoria,
+				Urgencia:     t.Urgencia,
+				Gravidade:    t.Gravidade,
+				AbertoPor:    t.AbertoPor,
+				Responsavel:  t.Responsavel,
+				DataAbertura: t.DataAbertura.Format("2006-01-02 15:04:05"),
+				SLA:          novoSLAOutput(uc.motorSLA.Estado(t, agora)),
+			},
+			Relevancia:      item.Relevancia,
+			TituloDestacado: item.TituloDestacado,
+			Trecho:          item.Trecho,
+		}
+	}
+
+	return &PesquisarTicketsOutput{
+		Resultados:   resultados,
+		Total:        resultado.Total,
+		TotalPaginas: (resultado.Total + input.ItensPorPagina - 1) / input.ItensPorPagina,
+		PaginaAtual:  input.Pagina,
+	}, nil
+}
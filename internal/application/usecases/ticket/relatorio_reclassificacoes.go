@@ -0,0 +1,146 @@
+package ticket
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// periodoPadraoReclassificacoes é o período do relatório quando o início não é informado
+const periodoPadraoReclassificacoes = 30 * 24 * time.Hour
+
+// Input do relatório de reclassificações de uma categoria
+type RelatorioReclassificacoesInput struct {
+	Categoria  ticket.Categoria
+	DataInicio time.Time // zero: 30 dias antes do fim
+	DataFim    time.Time // zero: agora
+}
+
+// Uma reclassificação do relatório
+type ReclassificacaoOutput struct {
+	TicketID             string
+	Titulo               string
+	UsuarioID            string
+	Data                 string
+	CategoriaAnterior    ticket.Categoria
+	SubcategoriaAnterior ticket.Subcategoria
+	CategoriaNova        ticket.Categoria
+	SubcategoriaNova     ticket.Subcategoria
+}
+
+// Quantidade de reclassificações entre duas classificações, no formato categoria/subcategoria
+type ParReclassificacaoOutput struct {
+	De         string
+	Para       string
+	Quantidade int
+}
+
+// Output do relatório: os totais por direção, os pares mais frequentes primeiro e o histórico
+type RelatorioReclassificacoesOutput struct {
+	Categoria  ticket.Categoria
+	DataInicio string
+	DataFim    string
+
+	// Entradas vieram de outra categoria, Saidas foram para outra e Internas só trocaram a subcategoria
+	Entradas int
+	Saidas   int
+	Internas int
+
+	Pares            []ParReclassificacaoOutput
+	Reclassificacoes []ReclassificacaoOutput
+}
+
+// Usecase do relatório de reclassificações por categoria
+type RelatorioReclassificacoesUseCase struct {
+	ticketRepository ticket.Repository
+	autorizador      *acesso.Autorizador
+}
+
+// Construtor do caso de uso
+func NewRelatorioReclassificacoesUseCase(repo ticket.Repository, autorizador *acesso.Autorizador) *RelatorioReclassificacoesUseCase {
+	return &RelatorioReclassificacoesUseCase{
+		ticketRepository: repo,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o relatório; só entram os tickets que o usuário pode ver
+func (uc *RelatorioReclassificacoesUseCase) Execute(ctx context.Context, input RelatorioReclassificacoesInput) (*RelatorioReclassificacoesOutput, error) {
+	// 1. identifica quem pede o relatório e normaliza o período
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categoria := ticket.Categoria(strings.ToLower(strings.TrimSpace(string(input.Categoria))))
+	if categoria == "" {
+		return nil, ticket.ErrCategoriaObrigatoria
+	}
+	fim := input.DataFim
+	if fim.IsZero() {
+		fim = time.Now()
+	}
+	inicio := input.DataInicio
+	if inicio.IsZero() {
+		inicio = fim.Add(-periodoPadraoReclassificacoes)
+	}
+
+	// 2. busca as reclassificações no histórico de modificações
+	reclassificacoes, err := uc.ticketRepository.ListarReclassificacoes(ticket.FiltroReclassificacoes{
+		Categoria:  categoria,
+		DataInicio: inicio,
+		DataFim:    fim,
+		Escopo:     uc.autorizador.Escopo(ator),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. totaliza por direção e por par
+	output := &RelatorioReclassificacoesOutput{
+		Categoria:        categoria,
+		DataInicio:       inicio.Format(time.DateTime),
+		DataFim:          fim.Format(time.DateTime),
+		Pares:            []ParReclassificacaoOutput{},
+		Reclassificacoes: make([]ReclassificacaoOutput, len(reclassificacoes)),
+	}
+	pares := map[[2]string]int{}
+	for i, r := range reclassificacoes {
+		switch {
+		case r.CategoriaAnterior == r.CategoriaNova:
+			output.Internas++
+		case r.CategoriaNova == categoria:
+			output.Entradas++
+		default:
+			output.Saidas++
+		}
+		de := string(r.CategoriaAnterior) + "/" + string(r.SubcategoriaAnterior)
+		para := string(r.CategoriaNova) + "/" + string(r.SubcategoriaNova)
+		pares[[2]string{de, para}]++
+
+		output.Reclassificacoes[i] = ReclassificacaoOutput{
+			TicketID:             r.TicketID,
+			Titulo:               r.Titulo,
+			UsuarioID:            r.UsuarioID,
+			Data:                 r.Data.Format(time.DateTime),
+			CategoriaAnterior:    r.CategoriaAnterior,
+			SubcategoriaAnterior: r.SubcategoriaAnterior,
+			CategoriaNova:        r.CategoriaNova,
+			SubcategoriaNova:     r.SubcategoriaNova,
+		}
+	}
+	for par, quantidade := range pares {
+		output.Pares = append(output.Pares, ParReclassificacaoOutput{De: par[0], Para: par[1], Quantidade: quantidade})
+	}
+	sort.Slice(output.Pares, func(i, j int) bool {
+		if output.Pares[i].Quantidade != output.Pares[j].Quantidade {
+			return output.Pares[i].Quantidade > output.Pares[j].Quantidade
+		}
+		return output.Pares[i].De+output.Pares[i].Para < output.Pares[j].De+output.Pares[j].Para
+	})
+
+	return output, nil
+}
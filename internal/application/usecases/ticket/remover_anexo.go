@@ -0,0 +1,60 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/anexo"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Caso de uso de remover um arquivo do ticket
+type RemoverAnexoUseCase struct {
+	ticketRepository ticket.Repository
+	anexoRepository  anexo.Repository
+	arquivista       *anexo.Arquivista
+	autorizador      *acesso.Autorizador
+}
+
+// NewRemoverAnexoUseCase cria uma nova instância do caso de uso de remover anexo
+func NewRemoverAnexoUseCase(repo ticket.Repository, anexoRepo anexo.Repository, arquivista *anexo.Arquivista, autorizador *acesso.Autorizador) *RemoverAnexoUseCase {
+	return &RemoverAnexoUseCase{
+		ticketRepository: repo,
+		anexoRepository:  anexoRepo,
+		arquivista:       arquivista,
+		autorizador:      autorizador,
+	}
+}
+
+// Executa o caso de uso de remover anexo; quem enviou o arquivo ou pode alterar o ticket pode removê-lo
+func (uc *RemoverAnexoUseCase) Execute(ctx context.Context, input AnexoInput) error {
+	// 1. identifica o autor e confere o acesso ao ticket e ao anexo
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return err
+	}
+	t, err := uc.ticketRepository.GetByID(input.ID)
+	if err != nil {
+		return err
+	}
+	if err := uc.autorizador.VerificarLeitura(ator, t); err != nil {
+		return err
+	}
+	a, err := buscarAnexoDoTicket(uc.anexoRepository, t.ID, input.AnexoID)
+	if err != nil {
+		return err
+	}
+	if a.EnviadoPor != ator.ID && !uc.autorizador.PodeEscrever(ator, t) {
+		return ticket.ErrProibido
+	}
+
+	// 2. registra e persiste a modificação antes de apagar, para que a remoção nunca fique fora do histórico
+	if err := t.RegistrarRemocaoAnexo(a.Nome, ator.ID); err != nil {
+		return err
+	}
+	if err := uc.ticketRepository.Update(t); err != nil {
+		return err
+	}
+
+	// 3. apaga os metadados e o conteúdo
+	return uc.arquivista.Descartar(ctx, a)
+}
@@ -0,0 +1,102 @@
+package ticket
+
+import (
+	"context"
+	"testing"
+
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// repositorioMemoria guarda os tickets em memória e conta as gravações, para os testes dos casos de uso
+type repositorioMemoria struct {
+	tickets      map[string]*ticket.Ticket
+	atualizacoes int
+}
+
+func novoRepositorioMemoria(tickets ...*ticket.Ticket) *repositorioMemoria {
+	r := &repositorioMemoria{tickets: map[string]*ticket.Ticket{}}
+	for _, t := range tickets {
+		r.Create(t)
+	}
+	return r
+}
+
+func (r *repositorioMemoria) Create(t *ticket.Ticket) error {
+	t.Versao = 1
+	t.MarcarComoPersistido()
+	r.tickets[t.ID] = t
+	return nil
+}
+
+func (r *repositorioMemoria) GetByID(id string) (*ticket.Ticket, error) {
+	t, ok := r.tickets[id]
+	if !ok {
+		return nil, ticket.ErrNaoEncontrado
+	}
+	return t, nil
+}
+
+func (r *repositorioMemoria) List(filtros ticket.TicketFiltros) (*ticket.ResultadoLista, error) {
+	resultado := &ticket.ResultadoLista{Tickets: []*ticket.Ticket{}}
+	for _, t := range r.tickets {
+		resultado.Tickets = append(resultado.Tickets, t)
+	}
+	resultado.Total = len(resultado.Tickets)
+	return resultado, nil
+}
+
+func (r *repositorioMemoria) ListarCompletos(filtros ticket.TicketFiltros) (*ticket.ResultadoLista, error) {
+	return r.List(filtros)
+}
+
+func (r *repositorioMemoria) Pesquisar(consulta string, filtros ticket.TicketFiltros) (*ticket.ResultadoPesquisa, error) {
+	return &ticket.ResultadoPesquisa{Itens: []ticket.ItemPesquisa{}}, nil
+}
+
+func (r *repositorioMemoria) Update(t *ticket.Ticket) error {
+	r.atualizacoes++
+	t.Versao++
+	t.MarcarComoPersistido()
+	r.tickets[t.ID] = t
+	return nil
+}
+
+func (r *repositorioMemoria) Delete(id string) error {
+	delete(r.tickets, id)
+	return nil
+}
+
+func (r *repositorioMemoria) ListarObservacoes(ticketID string) ([]*ticket.Observacao, error) {
+	return nil, nil
+}
+
+func (r *repositorioMemoria) ListarModificacoes(ticketID string) ([]*ticket.Modificacao, error) {
+	return nil, nil
+}
+
+func (r *repositorioMemoria) ListarReclassificacoes(filtro ticket.FiltroReclassificacoes) ([]*ticket.Reclassificacao, error) {
+	return nil, nil
+}
+
+func (r *repositorioMemoria) ListarPorStatus(status ticket.Status) ([]*ticket.Ticket, error) {
+	return nil, nil
+}
+
+// autorizadorTeste usa a política da aplicação
+var autorizadorTeste = acesso.NovoAutorizador(acesso.PoliticaPadrao())
+
+// contextoDe retorna o contexto de uma requisição autenticada com os papéis informados
+func contextoDe(id string, papeis ...string) context.Context {
+	return auth.ComPrincipal(context.Background(), auth.Principal{ID: id, Papeis: papeis})
+}
+
+// ticketTeste cria um ticket aberto pelo usuário, na categoria e subcategoria informadas
+func ticketTeste(t *testing.T, categoria ticket.Categoria, subcategoria ticket.Subcategoria, abertoPor string) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Ticket de Teste", "Descrição do ticket de teste", categoria, subcategoria, abertoPor)
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket de teste: %v", err)
+	}
+	return tk
+}
@@ -0,0 +1,32 @@
+package ticket
+
+import (
+	"context"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// tamanho das páginas percorridas pelas rotinas em segundo plano
+const tamanhoPaginaRotina = 100
+
+// percorrerCompletos visita, em páginas e na ordem de abertura, os tickets completos que atendem aos filtros.
+// O cursor não pula tickets quando os já visitados deixam de atender aos filtros depois de alterados.
+func percorrerCompletos(ctx context.Context, repo ticket.Repository, filtros ticket.TicketFiltros, visitar func(t *ticket.Ticket)) error {
+	filtros.Limite = tamanhoPaginaRotina
+	filtros.Ordenacao = ticket.Ordenacao{Campo: ticket.OrdenarPorDataAbertura}
+	for {
+		pagina, err := repo.ListarCompletos(filtros)
+		if err != nil {
+			return err
+		}
+		for _, t := range pagina.Tickets {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			visitar(t)
+		}
+		if pagina.ProximoCursor == "" {
+			return nil
+		}
+		filtros.Cursor = pagina.ProximoCursor
+	}
+}
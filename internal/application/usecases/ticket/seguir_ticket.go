@@ -0,0 +1,107 @@
+package ticket
+
+import (
+	"context"
+	"errors"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+var (
+	ErrSeguidorInvalido = ticket.NovoErroValidacao("usuario_id", "seguidor_invalido", "usuário não existe ou está desativado")
+)
+
+// input dos casos de uso de seguir e deixar de seguir um ticket; sem UsuarioID, vale para o próprio usuário
+type SeguirTicketInput struct {
+	ID        string
+	UsuarioID string
+}
+
+// SeguidoresOutput são os usuários que seguem o ticket
+type SeguidoresOutput struct {
+	TicketID   string
+	Seguidores []string
+}
+
+// Caso de uso de seguir um ticket, para receber as notificações de mudança de status, observações e alertas de SLA
+type SeguirTicketUseCase struct {
+	ticketRepository      ticket.Repository
+	notificacaoRepository notificacao.Repository
+	diretorio             *usuario.Diretorio
+	autorizador           *acesso.Autorizador
+}
+
+// NewSeguirTicketUseCase cria uma nova instância do caso de uso de seguir ticket
+func NewSeguirTicketUseCase(repo ticket.Repository, notificacaoRepo notificacao.Repository, diretorio *usuario.Diretorio, autorizador *acesso.Autorizador) *SeguirTicketUseCase {
+	return &SeguirTicketUseCase{
+		ticketRepository:      repo,
+		notificacaoRepository: notificacaoRepo,
+		diretorio:             diretorio,
+		autorizador:           autorizador,
+	}
+}
+
+// Executa o caso de uso de seguir ticket
+func (uc *SeguirTicketUseCase) Execute(ctx context.Context, input SeguirTicketInput) (*SeguidoresOutput, error) {
+	// 1. quem pode ver o ticket pode segui-lo; incluir outra pessoa exige permissão de escrita
+	ator, err := acesso.AtorDoContexto(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seguidor, err := verificarSeguidor(uc.ticketRepository, uc.autorizador, ator, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. o seguidor precisa estar no diretório: o próprio usuário é cadastrado no primeiro acesso
+	if seguidor == ator.ID {
+		_, err = uc.diretorio.GarantirUsuario(ator)
+	} else {
+		_, err = uc.diretorio.BuscarAtivo(seguidor)
+		if errors.Is(err, usuario.ErrUsuarioNaoEncontrado) || errors.Is(err, usuario.ErrUsuarioDesativado) {
+			err = ErrSeguidorInvalido
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. grava e retorna os seguidores
+	if err := uc.notificacaoRepository.AdicionarSeguidor(input.ID, seguidor); err != nil {
+		return nil, err
+	}
+	return listarSeguidores(uc.notificacaoRepository, input.ID)
+}
+
+// verificarSeguidor confere o acesso ao ticket e retorna quem vai seguir ou deixar de seguir o ticket
+func verificarSeguidor(repo ticket.Repository, autorizador *acesso.Autorizador, ator auth.Principal, input SeguirTicketInput) (string, error) {
+	t, err := repo.GetByID(input.ID)
+	if err != nil {
+		return "", err
+	}
+	if err := autorizador.VerificarLeitura(ator, t); err != nil {
+		return "", err
+	}
+
+	seguidor := input.UsuarioID
+	if seguidor == "" {
+		seguidor = ator.ID
+	}
+	if seguidor != ator.ID {
+		if err := autorizador.VerificarEscrita(ator, t); err != nil {
+			return "", err
+		}
+	}
+	return seguidor, nil
+}
+
+func listarSeguidores(repo notificacao.Repository, ticketID string) (*SeguidoresOutput, error) {
+	seguidores, err := repo.ListarSeguidores(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return &SeguidoresOutput{TicketID: ticketID, Seguidores: seguidores}, nil
+}
@@ -0,0 +1,63 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+	"strings"
+)
+
+// input do caso de uso de atualizar equipe; campos nulos não são alterados
+type AtualizarEquipeInput struct {
+	ID        string
+	Nome      *string
+	Descricao *string
+	Ativa     *bool
+}
+
+// Caso de uso de atualizar equipe, usado também para desativá-la
+type AtualizarEquipeUseCase struct {
+	usuarioRepository usuario.Repository
+	autorizador       *acesso.Autorizador
+}
+
+// NewAtualizarEquipeUseCase cria uma nova instância do caso de uso de atualizar equipe
+func NewAtualizarEquipeUseCase(repo usuario.Repository, autorizador *acesso.Autorizador) *AtualizarEquipeUseCase {
+	return &AtualizarEquipeUseCase{
+		usuarioRepository: repo,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar equipe
+func (uc *AtualizarEquipeUseCase) Execute(ctx context.Context, input AtualizarEquipeInput) (*EquipeOutput, error) {
+	// 1. apenas administradores alteram equipes
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. busca a equipe existente
+	e, err := uc.usuarioRepository.BuscarEquipe(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. aplica as alterações informadas
+	if input.Nome != nil {
+		if err := e.SetNome(*input.Nome); err != nil {
+			return nil, err
+		}
+	}
+	if input.Descricao != nil {
+		e.Descricao = strings.TrimSpace(*input.Descricao)
+	}
+	if input.Ativa != nil {
+		e.Ativa = *input.Ativa
+	}
+
+	// 4. persiste
+	if err := uc.usuarioRepository.AtualizarEquipe(e); err != nil {
+		return nil, err
+	}
+	return novaEquipeOutput(e), nil
+}
@@ -0,0 +1,75 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de atualizar usuário; campos nulos não são alterados
+type AtualizarUsuarioInput struct {
+	ID          string
+	Nome        *string
+	Email       *string
+	Ativo       *bool
+	Equipes     *[]string
+	Habilidades *[]string
+}
+
+// Caso de uso de atualizar usuário, usado também para desativá-lo
+type AtualizarUsuarioUseCase struct {
+	usuarioRepository usuario.Repository
+	autorizador       *acesso.Autorizador
+}
+
+// NewAtualizarUsuarioUseCase cria uma nova instância do caso de uso de atualizar usuário
+func NewAtualizarUsuarioUseCase(repo usuario.Repository, autorizador *acesso.Autorizador) *AtualizarUsuarioUseCase {
+	return &AtualizarUsuarioUseCase{
+		usuarioRepository: repo,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar usuário
+func (uc *AtualizarUsuarioUseCase) Execute(ctx context.Context, input AtualizarUsuarioInput) (*UsuarioOutput, error) {
+	// 1. apenas administradores alteram usuários
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. busca o usuário existente
+	u, err := uc.usuarioRepository.BuscarUsuario(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. aplica as alterações informadas
+	if input.Nome != nil {
+		if err := u.SetNome(*input.Nome); err != nil {
+			return nil, err
+		}
+	}
+	if input.Email != nil {
+		if err := u.SetEmail(*input.Email); err != nil {
+			return nil, err
+		}
+	}
+	if input.Ativo != nil {
+		u.Ativo = *input.Ativo
+	}
+	if input.Equipes != nil {
+		if err := validarEquipes(uc.usuarioRepository, *input.Equipes); err != nil {
+			return nil, err
+		}
+		u.Equipes = *input.Equipes
+	}
+	if input.Habilidades != nil {
+		u.SetHabilidades(*input.Habilidades)
+	}
+
+	// 4. persiste
+	if err := uc.usuarioRepository.AtualizarUsuario(u); err != nil {
+		return nil, err
+	}
+	return novoUsuarioOutput(u), nil
+}
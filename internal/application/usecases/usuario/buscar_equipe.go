@@ -0,0 +1,54 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de buscar equipe
+type BuscarEquipeInput struct {
+	ID string
+}
+
+// EquipeOutput é a equipe no formato de saída dos casos de uso
+type EquipeOutput struct {
+	ID          string
+	Nome        string
+	Descricao   string
+	Ativa       bool
+	DataCriacao string
+}
+
+// Caso de uso de buscar equipe
+type BuscarEquipeUseCase struct {
+	usuarioRepository usuario.Repository
+}
+
+// NewBuscarEquipeUseCase cria uma nova instância do caso de uso de buscar equipe
+func NewBuscarEquipeUseCase(repo usuario.Repository) *BuscarEquipeUseCase {
+	return &BuscarEquipeUseCase{usuarioRepository: repo}
+}
+
+// Executa o caso de uso de buscar equipe
+func (uc *BuscarEquipeUseCase) Execute(ctx context.Context, input BuscarEquipeInput) (*EquipeOutput, error) {
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	e, err := uc.usuarioRepository.BuscarEquipe(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return novaEquipeOutput(e), nil
+}
+
+func novaEquipeOutput(e *usuario.Equipe) *EquipeOutput {
+	return &EquipeOutput{
+		ID:          e.ID,
+		Nome:        e.Nome,
+		Descricao:   e.Descricao,
+		Ativa:       e.Ativa,
+		DataCriacao: e.DataCriacao.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,58 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de buscar usuário
+type BuscarUsuarioInput struct {
+	ID string
+}
+
+// UsuarioOutput é o usuário no formato de saída dos casos de uso
+type UsuarioOutput struct {
+	ID          string
+	Nome        string
+	Email       string
+	Ativo       bool
+	Equipes     []string
+	Habilidades []string
+	DataCriacao string
+}
+
+// Caso de uso de buscar usuário
+type BuscarUsuarioUseCase struct {
+	usuarioRepository usuario.Repository
+}
+
+// NewBuscarUsuarioUseCase cria uma nova instância do caso de uso de buscar usuário
+func NewBuscarUsuarioUseCase(repo usuario.Repository) *BuscarUsuarioUseCase {
+	return &BuscarUsuarioUseCase{usuarioRepository: repo}
+}
+
+// Executa o caso de uso de buscar usuário; qualquer usuário autenticado pode consultar o diretório
+func (uc *BuscarUsuarioUseCase) Execute(ctx context.Context, input BuscarUsuarioInput) (*UsuarioOutput, error) {
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	u, err := uc.usuarioRepository.BuscarUsuario(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return novoUsuarioOutput(u), nil
+}
+
+func novoUsuarioOutput(u *usuario.Usuario) *UsuarioOutput {
+	return &UsuarioOutput{
+		ID:          u.ID,
+		Nome:        u.Nome,
+		Email:       u.Email,
+		Ativo:       u.Ativo,
+		Equipes:     u.Equipes,
+		Habilidades: u.Habilidades,
+		DataCriacao: u.DataCriacao.Format("2006-01-02 15:04:05"),
+	}
+}
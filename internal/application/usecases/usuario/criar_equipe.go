@@ -0,0 +1,45 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de criar equipe
+type CriarEquipeInput struct {
+	Nome      string
+	Descricao string
+}
+
+// Caso de uso de criar equipe
+type CriarEquipeUseCase struct {
+	usuarioRepository usuario.Repository
+	autorizador       *acesso.Autorizador
+}
+
+// NewCriarEquipeUseCase cria uma nova instância do caso de uso de criar equipe
+func NewCriarEquipeUseCase(repo usuario.Repository, autorizador *acesso.Autorizador) *CriarEquipeUseCase {
+	return &CriarEquipeUseCase{
+		usuarioRepository: repo,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de criar equipe
+func (uc *CriarEquipeUseCase) Execute(ctx context.Context, input CriarEquipeInput) (*EquipeOutput, error) {
+	// 1. apenas administradores cadastram equipes
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. cria e persiste a equipe
+	e, err := usuario.NovaEquipe(input.Nome, input.Descricao)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.usuarioRepository.CriarEquipe(e); err != nil {
+		return nil, err
+	}
+	return novaEquipeOutput(e), nil
+}
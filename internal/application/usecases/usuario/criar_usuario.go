@@ -0,0 +1,57 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de criar usuário
+type CriarUsuarioInput struct {
+	ID          string // mesmo valor da claim sub do token; gerado quando vazio
+	Nome        string
+	Email       string
+	Equipes     []string
+	Habilidades []string
+}
+
+// Caso de uso de criar usuário
+type CriarUsuarioUseCase struct {
+	usuarioRepository usuario.Repository
+	autorizador       *acesso.Autorizador
+}
+
+// NewCriarUsuarioUseCase cria uma nova instância do caso de uso de criar usuário
+func NewCriarUsuarioUseCase(repo usuario.Repository, autorizador *acesso.Autorizador) *CriarUsuarioUseCase {
+	return &CriarUsuarioUseCase{
+		usuarioRepository: repo,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de criar usuário
+func (uc *CriarUsuarioUseCase) Execute(ctx context.Context, input CriarUsuarioInput) (*UsuarioOutput, error) {
+	// 1. apenas administradores cadastram usuários
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. cria o usuário pela entidade de domínio
+	u, err := usuario.NovoUsuario(input.ID, input.Nome, input.Email)
+	if err != nil {
+		return nil, err
+	}
+	if err := validarEquipes(uc.usuarioRepository, input.Equipes); err != nil {
+		return nil, err
+	}
+	if input.Equipes != nil {
+		u.Equipes = input.Equipes
+	}
+	u.SetHabilidades(input.Habilidades)
+
+	// 3. persiste
+	if err := uc.usuarioRepository.CriarUsuario(u); err != nil {
+		return nil, err
+	}
+	return novoUsuarioOutput(u), nil
+}
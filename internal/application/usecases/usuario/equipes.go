@@ -0,0 +1,20 @@
+package usuario
+
+import (
+	"errors"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// validarEquipes garante que todas as equipes informadas existem e estão ativas
+func validarEquipes(repo usuario.Repository, equipes []string) error {
+	for _, id := range equipes {
+		e, err := repo.BuscarEquipe(id)
+		if errors.Is(err, usuario.ErrEquipeNaoEncontrada) || (err == nil && !e.Ativa) {
+			return usuario.ErrEquipeInvalida
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
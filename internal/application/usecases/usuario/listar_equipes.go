@@ -0,0 +1,40 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de listar equipes
+type ListarEquipesInput struct {
+	ApenasAtivas bool
+}
+
+// Caso de uso de listar equipes
+type ListarEquipesUseCase struct {
+	usuarioRepository usuario.Repository
+}
+
+// NewListarEquipesUseCase cria uma nova instância do caso de uso de listar equipes
+func NewListarEquipesUseCase(repo usuario.Repository) *ListarEquipesUseCase {
+	return &ListarEquipesUseCase{usuarioRepository: repo}
+}
+
+// Executa o caso de uso de listar equipes
+func (uc *ListarEquipesUseCase) Execute(ctx context.Context, input ListarEquipesInput) ([]*EquipeOutput, error) {
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	equipes, err := uc.usuarioRepository.ListarEquipes(input.ApenasAtivas)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*EquipeOutput, len(equipes))
+	for i, e := range equipes {
+		output[i] = novaEquipeOutput(e)
+	}
+	return output, nil
+}
@@ -0,0 +1,44 @@
+package usuario
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// input do caso de uso de listar usuários
+type ListarUsuariosInput struct {
+	EquipeID     string
+	ApenasAtivos bool
+}
+
+// Caso de uso de listar usuários
+type ListarUsuariosUseCase struct {
+	usuarioRepository usuario.Repository
+}
+
+// NewListarUsuariosUseCase cria uma nova instância do caso de uso de listar usuários
+func NewListarUsuariosUseCase(repo usuario.Repository) *ListarUsuariosUseCase {
+	return &ListarUsuariosUseCase{usuarioRepository: repo}
+}
+
+// Executa o caso de uso de listar usuários
+func (uc *ListarUsuariosUseCase) Execute(ctx context.Context, input ListarUsuariosInput) ([]*UsuarioOutput, error) {
+	if _, err := acesso.AtorDoContexto(ctx); err != nil {
+		return nil, err
+	}
+
+	usuarios, err := uc.usuarioRepository.ListarUsuarios(usuario.FiltrosUsuario{
+		EquipeID:     input.EquipeID,
+		ApenasAtivos: input.ApenasAtivos,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*UsuarioOutput, len(usuarios))
+	for i, u := range usuarios {
+		output[i] = novoUsuarioOutput(u)
+	}
+	return output, nil
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/webhook"
+)
+
+// input do caso de uso de atualizar webhook; campos nulos não são alterados
+type AtualizarWebhookInput struct {
+	ID         string
+	URL        *string
+	Eventos    *[]ticket.TipoEvento
+	Categorias *[]ticket.Categoria
+	Ativo      *bool
+
+	// gera um novo segredo, retornado na resposta
+	RenovarSegredo bool
+}
+
+// Caso de uso de atualizar webhook
+type AtualizarWebhookUseCase struct {
+	webhookRepository webhook.Repository
+	taxonomia         ticket.Taxonomia
+	autorizador       *acesso.Autorizador
+}
+
+// NewAtualizarWebhookUseCase cria uma nova instância do caso de uso de atualizar webhook
+func NewAtualizarWebhookUseCase(repo webhook.Repository, taxonomia ticket.Taxonomia, autorizador *acesso.Autorizador) *AtualizarWebhookUseCase {
+	return &AtualizarWebhookUseCase{
+		webhookRepository: repo,
+		taxonomia:         taxonomia,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de atualizar webhook
+func (uc *AtualizarWebhookUseCase) Execute(ctx context.Context, input AtualizarWebhookInput) (*WebhookOutput, error) {
+	// 1. apenas administradores alteram webhooks
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	// 2. busca a assinatura existente
+	a, err := uc.webhookRepository.BuscarAssinatura(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. aplica as alterações informadas
+	if input.URL != nil {
+		if err := a.SetURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.Eventos != nil {
+		if err := a.SetEventos(*input.Eventos); err != nil {
+			return nil, err
+		}
+	}
+	if input.Categorias != nil {
+		if err := a.SetCategorias(*input.Categorias); err != nil {
+			return nil, err
+		}
+		if err := validarCategorias(uc.taxonomia, a.Categorias); err != nil {
+			return nil, err
+		}
+	}
+	if input.Ativo != nil {
+		a.Ativa = *input.Ativo
+	}
+	if input.RenovarSegredo {
+		a.Segredo = webhook.GerarSegredo()
+	}
+
+	// 4. persiste
+	if err := uc.webhookRepository.AtualizarAssinatura(a); err != nil {
+		return nil, err
+	}
+	return novoWebhookOutput(a, input.RenovarSegredo), nil
+}
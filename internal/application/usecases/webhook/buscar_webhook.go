@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/webhook"
+)
+
+// input do caso de uso de buscar webhook
+type BuscarWebhookInput struct {
+	ID string
+}
+
+// Caso de uso de buscar webhook
+type BuscarWebhookUseCase struct {
+	webhookRepository webhook.Repository
+	autorizador       *acesso.Autorizador
+}
+
+// NewBuscarWebhookUseCase cria uma nova instância do caso de uso de buscar webhook
+func NewBuscarWebhookUseCase(repo webhook.Repository, autorizador *acesso.Autorizador) *BuscarWebhookUseCase {
+	return &BuscarWebhookUseCase{
+		webhookRepository: repo,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de buscar webhook
+func (uc *BuscarWebhookUseCase) Execute(ctx context.Context, input BuscarWebhookInput) (*WebhookOutput, error) {
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	a, err := uc.webhookRepository.BuscarAssinatura(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return novoWebhookOutput(a, false), nil
+}
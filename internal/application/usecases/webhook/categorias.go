@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"nox_tickets/internal/domain/ticket"
+)
+
+// validarCategorias confere as categorias assinadas contra a taxonomia
+func validarCategorias(taxonomia ticket.Taxonomia, categorias []ticket.Categoria) error {
+	for _, c := range categorias {
+		if err := taxonomia.ValidarCategoria(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
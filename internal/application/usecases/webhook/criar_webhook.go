@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+	"nox_tickets/internal/domain/webhook"
+)
+
+// input do caso de uso de criar webhook
+type CriarWebhookInput struct {
+	URL        string
+	Eventos    []ticket.TipoEvento
+	Categorias []ticket.Categoria
+}
+
+// WebhookOutput é a assinatura no formato de saída dos casos de uso.
+// O segredo só é informado na criação e quando é renovado.
+type WebhookOutput struct {
+	ID          string
+	URL         string
+	Segredo     string
+	Eventos     []ticket.TipoEvento
+	Categorias  []ticket.Categoria
+	Ativo       bool
+	CriadoPor   string
+	DataCriacao string
+}
+
+// Caso de uso de criar webhook
+type CriarWebhookUseCase struct {
+	webhookRepository webhook.Repository
+	diretorio         *usuario.Diretorio
+	taxonomia         ticket.Taxonomia
+	autorizador       *acesso.Autorizador
+}
+
+// NewCriarWebhookUseCase cria uma nova instância do caso de uso de criar webhook
+func NewCriarWebhookUseCase(repo webhook.Repository, diretorio *usuario.Diretorio, taxonomia ticket.Taxonomia, autorizador *acesso.Autorizador) *CriarWebhookUseCase {
+	return &CriarWebhookUseCase{
+		webhookRepository: repo,
+		diretorio:         diretorio,
+		taxonomia:         taxonomia,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de criar webhook
+func (uc *CriarWebhookUseCase) Execute(ctx context.Context, input CriarWebhookInput) (*WebhookOutput, error) {
+	// 1. apenas administradores cadastram webhooks; o autor precisa estar no diretório
+	ator, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.diretorio.GarantirUsuario(ator); err != nil {
+		return nil, err
+	}
+
+	// 2. cria a assinatura, com um segredo novo
+	a, err := webhook.NovaAssinatura(input.URL, input.Eventos, input.Categorias, ator.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validarCategorias(uc.taxonomia, a.Categorias); err != nil {
+		return nil, err
+	}
+
+	// 3. persiste
+	if err := uc.webhookRepository.CriarAssinatura(a); err != nil {
+		return nil, err
+	}
+	return novoWebhookOutput(a, true), nil
+}
+
+func novoWebhookOutput(a *webhook.Assinatura, comSegredo bool) *WebhookOutput {
+	output := &WebhookOutput{
+		ID:          a.ID,
+		URL:         a.URL,
+		Eventos:     a.Eventos,
+		Categorias:  a.Categorias,
+		Ativo:       a.Ativa,
+		CriadoPor:   a.CriadoPor,
+		DataCriacao: a.DataCriacao.Format("2006-01-02 15:04:05"),
+	}
+	if comSegredo {
+		output.Segredo = a.Segredo
+	}
+	return output
+}
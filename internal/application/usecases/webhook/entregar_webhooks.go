@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"nox_tickets/internal/domain/webhook"
+	"strconv"
+	"time"
+)
+
+// Output de uma rodada de entregas
+type EntregarWebhooksOutput struct {
+	Enviadas    int
+	Concluidas  int
+	Descartadas int
+
+	// falhas ao gravar o resultado, que não interrompem a rodada
+	Erros []error
+}
+
+// Caso de uso que envia as entregas pendentes da fila de webhooks.
+// Roda em segundo plano, sem usuário autenticado.
+type EntregarWebhooksUseCase struct {
+	webhookRepository webhook.Repository
+	cliente           webhook.Cliente
+	lote              int
+}
+
+// NewEntregarWebhooksUseCase cria o caso de uso; lote é o máximo de entregas por rodada
+func NewEntregarWebhooksUseCase(repo webhook.Repository, cliente webhook.Cliente, lote int) *EntregarWebhooksUseCase {
+	return &EntregarWebhooksUseCase{
+		webhookRepository: repo,
+		cliente:           cliente,
+		lote:              lote,
+	}
+}
+
+// Executa uma rodada de entregas
+func (uc *EntregarWebhooksUseCase) Execute(ctx context.Context) (*EntregarWebhooksOutput, error) {
+	// 1. reserva as entregas vencidas
+	entregas, err := uc.webhookRepository.Pendentes(time.Now(), uc.lote)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &EntregarWebhooksOutput{}
+	assinaturas := map[string]*webhook.Assinatura{}
+	for _, e := range entregas {
+		if err := ctx.Err(); err != nil {
+			return output, err
+		}
+
+		// 2. busca a assinatura uma única vez por rodada
+		a, ok := assinaturas[e.AssinaturaID]
+		if !ok {
+			a, err = uc.webhookRepository.BuscarAssinatura(e.AssinaturaID)
+			if err != nil {
+				output.Erros = append(output.Erros, fmt.Errorf("entrega %s: %w", e.ID, err))
+				continue
+			}
+			assinaturas[e.AssinaturaID] = a
+		}
+
+		// 3. envia e registra o resultado no log; assinaturas desativadas depois do
+		// enfileiramento não recebem mais nada e a entrega vai direto para a dead-letter
+		var tentativa webhook.Tentativa
+		if a.Ativa {
+			tentativa = e.RegistrarTentativa(uc.enviar(ctx, a, e))
+			output.Enviadas++
+		} else {
+			tentativa = e.Descartar("webhook desativado", time.Now())
+		}
+		switch e.Status {
+		case webhook.EntregaConcluida:
+			output.Concluidas++
+		case webhook.EntregaDescartada:
+			output.Descartadas++
+		}
+		if err := uc.webhookRepository.SalvarTentativa(e, tentativa); err != nil {
+			output.Erros = append(output.Erros, fmt.Errorf("entrega %s: %w", e.ID, err))
+		}
+	}
+
+	return output, nil
+}
+
+// enviar faz um envio da entrega, assinado com o segredo atual da assinatura
+func (uc *EntregarWebhooksUseCase) enviar(ctx context.Context, a *webhook.Assinatura, e *webhook.Entrega) webhook.Tentativa {
+	inicio := time.Now()
+	timestamp := inicio.Unix()
+	cabecalhos := map[string]string{
+		webhook.CabecalhoEvento:     string(e.Tipo),
+		webhook.CabecalhoEntrega:    e.ID,
+		webhook.CabecalhoTimestamp:  strconv.FormatInt(timestamp, 10),
+		webhook.CabecalhoAssinatura: webhook.Assinar(a.Segredo, timestamp, e.Payload),
+	}
+
+	status, err := uc.cliente.Enviar(ctx, a.URL, cabecalhos, e.Payload)
+	tentativa := webhook.Tentativa{StatusHTTP: status, Duracao: time.Since(inicio), Data: inicio}
+	if err != nil {
+		tentativa.Erro = err.Error()
+	}
+	return tentativa
+}
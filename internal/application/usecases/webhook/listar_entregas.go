@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/webhook"
+)
+
+var (
+	ErrStatusEntregaInvalido = ticket.NovoErroValidacao("status", "status_invalido", "status de entrega inválido")
+)
+
+// quantidade de entregas retornadas quando o limite não é informado, e o máximo aceito
+const (
+	limitePadraoEntregas = 50
+	limiteMaximoEntregas = 500
+)
+
+// input do caso de uso de listar as entregas de um webhook
+type ListarEntregasInput struct {
+	WebhookID string
+	Status    webhook.StatusEntrega // vazio traz todas
+	Limite    int
+}
+
+// EntregaOutput é uma entrega com o log das tentativas
+type EntregaOutput struct {
+	ID               string
+	EventoID         string
+	Tipo             ticket.TipoEvento
+	TicketID         string
+	Status           webhook.StatusEntrega
+	Tentativas       int
+	ProximaTentativa string
+	UltimoErro       string
+	DataCriacao      string
+	DataConclusao    string
+	Payload          []byte
+	Historico        []TentativaOutput
+}
+
+// TentativaOutput é um envio registrado no log
+type TentativaOutput struct {
+	Numero     int
+	StatusHTTP int
+	Erro       string
+	DuracaoMs  int64
+	Data       string
+}
+
+// Caso de uso do log de entregas de um webhook
+type ListarEntregasUseCase struct {
+	webhookRepository webhook.Repository
+	autorizador       *acesso.Autorizador
+}
+
+// NewListarEntregasUseCase cria uma nova instância do caso de uso de listar entregas
+func NewListarEntregasUseCase(repo webhook.Repository, autorizador *acesso.Autorizador) *ListarEntregasUseCase {
+	return &ListarEntregasUseCase{
+		webhookRepository: repo,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de listar entregas
+func (uc *ListarEntregasUseCase) Execute(ctx context.Context, input ListarEntregasInput) ([]*EntregaOutput, error) {
+	// 1. apenas administradores consultam o log, que traz os dados dos tickets
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+	switch input.Status {
+	case "", webhook.EntregaPendente, webhook.EntregaConcluida, webhook.EntregaDescartada:
+	default:
+		return nil, ErrStatusEntregaInvalido
+	}
+	if input.Limite <= 0 {
+		input.Limite = limitePadraoEntregas
+	}
+	input.Limite = min(input.Limite, limiteMaximoEntregas)
+
+	// 2. garante que o webhook existe
+	if _, err := uc.webhookRepository.BuscarAssinatura(input.WebhookID); err != nil {
+		return nil, err
+	}
+
+	// 3. busca as entregas mais recentes
+	entregas, err := uc.webhookRepository.ListarEntregas(webhook.FiltrosEntrega{
+		AssinaturaID: input.WebhookID,
+		Status:       input.Status,
+		Limite:       input.Limite,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*EntregaOutput, len(entregas))
+	for i, e := range entregas {
+		output[i] = novaEntregaOutput(e)
+	}
+	return output, nil
+}
+
+func novaEntregaOutput(e *webhook.Entrega) *EntregaOutput {
+	output := &EntregaOutput{
+		ID:          e.ID,
+		EventoID:    e.EventoID,
+		Tipo:        e.Tipo,
+		TicketID:    e.TicketID,
+		Status:      e.Status,
+		Tentativas:  e.Tentativas,
+		UltimoErro:  e.UltimoErro,
+		DataCriacao: e.DataCriacao.Format("2006-01-02 15:04:05"),
+		Payload:     e.Payload,
+		Historico:   make([]TentativaOutput, len(e.Historico)),
+	}
+	if e.Status == webhook.EntregaPendente {
+		output.ProximaTentativa = e.ProximaTentativa.Format("2006-01-02 15:04:05")
+	}
+	if e.DataConclusao != nil {
+		output.DataConclusao = e.DataConclusao.Format("2006-01-02 15:04:05")
+	}
+	for i, t := range e.Historico {
+		output.Historico[i] = TentativaOutput{
+			Numero:     t.Numero,
+			StatusHTTP: t.StatusHTTP,
+			Erro:       t.Erro,
+			DuracaoMs:  t.Duracao.Milliseconds(),
+			Data:       t.Data.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return output
+}
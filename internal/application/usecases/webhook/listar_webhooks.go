@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"nox_tickets/internal/domain/acesso"
+	"nox_tickets/internal/domain/webhook"
+)
+
+// input do caso de uso de listar webhooks
+type ListarWebhooksInput struct {
+	ApenasAtivos bool
+}
+
+// Caso de uso de listar webhooks
+type ListarWebhooksUseCase struct {
+	webhookRepository webhook.Repository
+	autorizador       *acesso.Autorizador
+}
+
+// NewListarWebhooksUseCase cria uma nova instância do caso de uso de listar webhooks
+func NewListarWebhooksUseCase(repo webhook.Repository, autorizador *acesso.Autorizador) *ListarWebhooksUseCase {
+	return &ListarWebhooksUseCase{
+		webhookRepository: repo,
+		autorizador:       autorizador,
+	}
+}
+
+// Executa o caso de uso de listar webhooks
+func (uc *ListarWebhooksUseCase) Execute(ctx context.Context, input ListarWebhooksInput) ([]*WebhookOutput, error) {
+	if _, err := uc.autorizador.ExigirPapel(ctx, acesso.PapelAdmin); err != nil {
+		return nil, err
+	}
+
+	assinaturas, err := uc.webhookRepository.ListarAssinaturas(input.ApenasAtivos)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*WebhookOutput, len(assinaturas))
+	for i, a := range assinaturas {
+		output[i] = novoWebhookOutput(a, false)
+	}
+	return output, nil
+}
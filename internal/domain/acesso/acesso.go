@@ -0,0 +1,260 @@
+package acesso
+
+import (
+	"context"
+	"strings"
+
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Papel é o perfil de acesso do usuário, informado no token
+type Papel string
+
+const (
+	PapelSolicitante Papel = "solicitante"
+	PapelAnalista    Papel = "analista"
+	PapelSupervisor  Papel = "supervisor"
+	PapelAdmin       Papel = "admin"
+)
+
+// Nivel é o que o usuário pode fazer com um ticket
+type Nivel int
+
+const (
+	NivelNenhum Nivel = iota
+	NivelLeitura
+	NivelEscrita
+)
+
+// prefixo das concessões por categoria no token: "categoria:<categoria>:<leitura|escrita>"
+const prefixoConcessao = "categoria:"
+
+// DefinicaoPapel define o acesso de um papel a cada grupo de tickets
+type DefinicaoPapel struct {
+	Proprios     Nivel // tickets abertos pelo usuário ou atribuídos a ele
+	NaoRestritos Nivel // tickets fora das categorias e subcategorias restritas
+	Restritos    Nivel // tickets restritos, além das concessões por categoria
+}
+
+// Politica reúne os papéis e o que é restrito
+type Politica struct {
+	Papeis                 map[Papel]DefinicaoPapel
+	CategoriasRestritas    []ticket.Categoria
+	SubcategoriasRestritas []ticket.Subcategoria
+}
+
+// PoliticaPadrao é a política usada pela aplicação: compliance, KYC e fraude
+// só ficam visíveis para quem tem concessão explícita na categoria do ticket.
+// O supervisor acompanha os tickets restritos em leitura, sem alterá-los.
+func PoliticaPadrao() Politica {
+	return Politica{
+		Papeis: map[Papel]DefinicaoPapel{
+			PapelSolicitante: {Proprios: NivelLeitura},
+			PapelAnalista:    {Proprios: NivelEscrita, NaoRestritos: NivelEscrita},
+			PapelSupervisor:  {Proprios: NivelEscrita, NaoRestritos: NivelEscrita, Restritos: NivelLeitura},
+			PapelAdmin:       {Proprios: NivelEscrita, NaoRestritos: NivelEscrita, Restritos: NivelEscrita},
+		},
+		CategoriasRestritas:    []ticket.Categoria{ticket.CategoriaCompliance},
+		SubcategoriasRestritas: []ticket.Subcategoria{ticket.SubcategoriaKYC, ticket.SubcategoriaFraude},
+	}
+}
+
+// Permissoes é o acesso efetivo de um usuário, combinando seus papéis e concessões
+type Permissoes struct {
+	UsuarioID    string
+	Papeis       []Papel
+	Proprios     Nivel
+	NaoRestritos Nivel
+	Restritos    Nivel
+	Categorias   map[ticket.Categoria]Nivel
+}
+
+// Autorizador decide o que cada usuário pode fazer com os tickets
+type Autorizador struct {
+	politica Politica
+}
+
+// NovoAutorizador cria o autorizador com a política informada
+func NovoAutorizador(politica Politica) *Autorizador {
+	return &Autorizador{politica: politica}
+}
+
+// Permissoes calcula o acesso efetivo do usuário. Quem não tem nenhum papel é tratado como solicitante.
+func (a *Autorizador) Permissoes(p auth.Principal) Permissoes {
+	perm := Permissoes{
+		UsuarioID:  p.ID,
+		Categorias: make(map[ticket.Categoria]Nivel),
+	}
+
+	for _, valor := range p.Papeis {
+		// concessão por categoria
+		if resto, ok := strings.CutPrefix(valor, prefixoConcessao); ok {
+			categoria, nivel, ok := lerConcessao(resto)
+			if ok {
+				perm.Categorias[categoria] = maior(perm.Categorias[categoria], nivel)
+			}
+			continue
+		}
+
+		// papel
+		papel := Papel(strings.ToLower(valor))
+		definicao, ok := a.politica.Papeis[papel]
+		if !ok {
+			continue
+		}
+		perm.Papeis = append(perm.Papeis, papel)
+		perm.Proprios = maior(perm.Proprios, definicao.Proprios)
+		perm.NaoRestritos = maior(perm.NaoRestritos, definicao.NaoRestritos)
+		perm.Restritos = maior(perm.Restritos, definicao.Restritos)
+	}
+
+	if len(perm.Papeis) == 0 {
+		definicao := a.politica.Papeis[PapelSolicitante]
+		perm.Papeis = []Papel{PapelSolicitante}
+		perm.Proprios = definicao.Proprios
+		perm.NaoRestritos = definicao.NaoRestritos
+		perm.Restritos = definicao.Restritos
+	}
+
+	return perm
+}
+
+// TemPapel indica se o usuário tem o papel (admin tem todos)
+func (perm Permissoes) TemPapel(papel Papel) bool {
+	for _, p := range perm.Papeis {
+		if p == papel || p == PapelAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Nivel retorna o acesso do usuário a um ticket
+func (a *Autorizador) Nivel(p auth.Principal, t *ticket.Ticket) Nivel {
+	perm := a.Permissoes(p)
+
+	nivel := perm.Categorias[t.Categoria]
+	if t.AbertoPor == p.ID || t.Responsavel == p.ID {
+		nivel = maior(nivel, perm.Proprios)
+	}
+	if a.restrito(t.Categoria, t.Subcategoria) {
+		nivel = maior(nivel, perm.Restritos)
+	} else {
+		nivel = maior(nivel, perm.NaoRestritos)
+	}
+	return nivel
+}
+
+// PodeLer indica se o usuário pode ver o ticket
+func (a *Autorizador) PodeLer(p auth.Principal, t *ticket.Ticket) bool {
+	return a.Nivel(p, t) >= NivelLeitura
+}
+
+// PodeEscrever indica se o usuário pode alterar o ticket
+func (a *Autorizador) PodeEscrever(p auth.Principal, t *ticket.Ticket) bool {
+	return a.Nivel(p, t) >= NivelEscrita
+}
+
+// AtorDoContexto retorna o usuário autenticado que está executando o caso de uso
+func AtorDoContexto(ctx context.Context) (auth.Principal, error) {
+	ator, ok := auth.PrincipalDe(ctx)
+	if !ok {
+		return auth.Principal{}, ticket.ErrNaoAutenticado
+	}
+	return ator, nil
+}
+
+// ExigirPapel retorna o usuário autenticado quando ele tem o papel (admin tem todos)
+func (a *Autorizador) ExigirPapel(ctx context.Context, papel Papel) (auth.Principal, error) {
+	ator, err := AtorDoContexto(ctx)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	if !a.Permissoes(ator).TemPapel(papel) {
+		return auth.Principal{}, ticket.ErrProibido
+	}
+	return ator, nil
+}
+
+// VerificarLeitura impede o acesso a tickets que o usuário não pode ver.
+// O erro é o mesmo de ticket inexistente, para não revelar que o ticket existe.
+func (a *Autorizador) VerificarLeitura(p auth.Principal, t *ticket.Ticket) error {
+	if !a.PodeLer(p, t) {
+		return ticket.ErrNaoEncontrado
+	}
+	return nil
+}
+
+// VerificarEscrita impede alterações de quem não tem permissão de escrita no ticket
+func (a *Autorizador) VerificarEscrita(p auth.Principal, t *ticket.Ticket) error {
+	if err := a.VerificarLeitura(p, t); err != nil {
+		return err
+	}
+	if !a.PodeEscrever(p, t) {
+		return ticket.ErrProibido
+	}
+	return nil
+}
+
+// Escopo converte as permissões de leitura do usuário em filtro para o repositório
+func (a *Autorizador) Escopo(p auth.Principal) *ticket.EscopoAcesso {
+	perm := a.Permissoes(p)
+
+	// acesso total dispensa o filtro
+	if perm.NaoRestritos >= NivelLeitura && perm.Restritos >= NivelLeitura {
+		return nil
+	}
+
+	escopo := &ticket.EscopoAcesso{
+		NaoRestritos:           perm.NaoRestritos >= NivelLeitura,
+		CategoriasRestritas:    a.politica.CategoriasRestritas,
+		SubcategoriasRestritas: a.politica.SubcategoriasRestritas,
+	}
+	if perm.Proprios >= NivelLeitura {
+		escopo.UsuarioID = p.ID
+	}
+	for categoria, nivel := range perm.Categorias {
+		if nivel >= NivelLeitura {
+			escopo.Categorias = append(escopo.Categorias, categoria)
+		}
+	}
+	return escopo
+}
+
+func (a *Autorizador) restrito(categoria ticket.Categoria, subcategoria ticket.Subcategoria) bool {
+	for _, c := range a.politica.CategoriasRestritas {
+		if c == categoria {
+			return true
+		}
+	}
+	for _, s := range a.politica.SubcategoriasRestritas {
+		if s == subcategoria {
+			return true
+		}
+	}
+	return false
+}
+
+// lerConcessao interpreta "<categoria>:<leitura|escrita>"
+func lerConcessao(valor string) (ticket.Categoria, Nivel, bool) {
+	categoria, nivel, ok := strings.Cut(valor, ":")
+	if !ok || categoria == "" {
+		return "", NivelNenhum, false
+	}
+	switch strings.ToLower(nivel) {
+	case "leitura":
+		return ticket.Categoria(strings.ToLower(categoria)), NivelLeitura, true
+	case "escrita":
+		return ticket.Categoria(strings.ToLower(categoria)), NivelEscrita, true
+	default:
+		return "", NivelNenhum, false
+	}
+}
+
+func maior(a, b Nivel) Nivel {
+	if a > b {
+		return a
+	}
+	return b
+}
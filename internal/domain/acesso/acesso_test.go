@@ -0,0 +1,111 @@
+package acesso
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/ticket"
+)
+
+func novoTicket(t *testing.T, categoria ticket.Categoria, subcategoria ticket.Subcategoria, abertoPor string) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Ticket de Teste", "Descrição do ticket de teste", categoria, subcategoria, abertoPor)
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket de teste: %v", err)
+	}
+	return tk
+}
+
+func TestAutorizador_Niveis(t *testing.T) {
+	a := NovoAutorizador(PoliticaPadrao())
+
+	comum := novoTicket(t, ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, "cliente")
+	kyc := novoTicket(t, ticket.CategoriaOnboarding, ticket.SubcategoriaKYC, "cliente")
+	compliance := novoTicket(t, ticket.CategoriaCompliance, ticket.SubcategoriaUncompliant, "cliente")
+
+	solicitante := auth.Principal{ID: "cliente", Papeis: []string{"solicitante"}}
+	outroSolicitante := auth.Principal{ID: "outro"}
+	analista := auth.Principal{ID: "analista", Papeis: []string{"analista"}}
+	analistaCompliance := auth.Principal{ID: "analista_c", Papeis: []string{"analista", "categoria:compliance:escrita"}}
+	supervisor := auth.Principal{ID: "supervisor", Papeis: []string{"supervisor"}}
+	auditor := auth.Principal{ID: "auditor", Papeis: []string{"categoria:compliance:leitura"}}
+	admin := auth.Principal{ID: "admin", Papeis: []string{"admin"}}
+
+	casos := []struct {
+		nome      string
+		principal auth.Principal
+		ticket    *ticket.Ticket
+		esperado  Nivel
+	}{
+		{"solicitante lê o próprio ticket", solicitante, comum, NivelLeitura},
+		{"solicitante lê o próprio ticket restrito", solicitante, kyc, NivelLeitura},
+		{"sem papel não vê ticket de outro", outroSolicitante, comum, NivelNenhum},
+		{"analista escreve em ticket comum", analista, comum, NivelEscrita},
+		{"analista não vê KYC", analista, kyc, NivelNenhum},
+		{"analista não vê compliance", analista, compliance, NivelNenhum},
+		{"supervisor escreve em ticket comum", supervisor, comum, NivelEscrita},
+		{"supervisor lê KYC sem alterar", supervisor, kyc, NivelLeitura},
+		{"supervisor lê compliance sem alterar", supervisor, compliance, NivelLeitura},
+		{"concessão libera compliance", analistaCompliance, compliance, NivelEscrita},
+		{"concessão de compliance não libera KYC de outra categoria", analistaCompliance, kyc, NivelNenhum},
+		{"concessão de leitura", auditor, compliance, NivelLeitura},
+		{"admin escreve em tudo", admin, kyc, NivelEscrita},
+	}
+
+	for _, c := range casos {
+		if nivel := a.Nivel(c.principal, c.ticket); nivel != c.esperado {
+			t.Errorf("%s: esperado %d, recebido %d", c.nome, c.esperado, nivel)
+		}
+	}
+}
+
+func TestAutorizador_Escopo(t *testing.T) {
+	a := NovoAutorizador(PoliticaPadrao())
+
+	if escopo := a.Escopo(auth.Principal{ID: "admin", Papeis: []string{"admin"}}); escopo != nil {
+		t.Errorf("Admin não deveria ter escopo, recebido %+v", escopo)
+	}
+
+	escopo := a.Escopo(auth.Principal{ID: "cliente"})
+	if escopo == nil || escopo.UsuarioID != "cliente" || escopo.NaoRestritos || len(escopo.Categorias) != 0 {
+		t.Errorf("Solicitante deveria ver apenas os próprios tickets, recebido %+v", escopo)
+	}
+
+	if escopo := a.Escopo(auth.Principal{ID: "supervisor", Papeis: []string{"supervisor"}}); escopo != nil {
+		t.Errorf("Supervisor lê todos os tickets e não deveria ter escopo, recebido %+v", escopo)
+	}
+
+	escopo = a.Escopo(auth.Principal{ID: "analista", Papeis: []string{"analista", "categoria:compliance:leitura"}})
+	if escopo == nil || !escopo.NaoRestritos || len(escopo.Categorias) != 1 || escopo.Categorias[0] != ticket.CategoriaCompliance {
+		t.Errorf("Analista deveria ver os não restritos e compliance, recebido %+v", escopo)
+	}
+}
+
+func TestAutorizador_Exigencias(t *testing.T) {
+	a := NovoAutorizador(PoliticaPadrao())
+	kyc := novoTicket(t, ticket.CategoriaOnboarding, ticket.SubcategoriaKYC, "cliente")
+
+	// sem usuário no contexto
+	if _, err := a.ExigirPapel(context.Background(), PapelAdmin); !errors.Is(err, ticket.ErrNaoAutenticado) {
+		t.Errorf("Esperava ErrNaoAutenticado, recebido %v", err)
+	}
+
+	// o papel é exigido, e admin tem todos
+	analista := auth.Principal{ID: "analista", Papeis: []string{"analista"}}
+	if _, err := a.ExigirPapel(auth.ComPrincipal(context.Background(), analista), PapelAdmin); !errors.Is(err, ticket.ErrProibido) {
+		t.Errorf("Esperava ErrProibido, recebido %v", err)
+	}
+	admin := auth.Principal{ID: "admin", Papeis: []string{"admin"}}
+	if ator, err := a.ExigirPapel(auth.ComPrincipal(context.Background(), admin), PapelSupervisor); err != nil || ator.ID != "admin" {
+		t.Errorf("Admin deveria ter o papel de supervisor, recebido %v", err)
+	}
+
+	// quem não pode ler recebe o mesmo erro de ticket inexistente; quem só lê não escreve
+	if err := a.VerificarEscrita(analista, kyc); !errors.Is(err, ticket.ErrNaoEncontrado) {
+		t.Errorf("Esperava ErrNaoEncontrado, recebido %v", err)
+	}
+	if err := a.VerificarEscrita(auth.Principal{ID: "cliente"}, kyc); !errors.Is(err, ticket.ErrProibido) {
+		t.Errorf("Esperava ErrProibido, recebido %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package anexo
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAnexoNaoEncontrado = ticket.NovoErroNaoEncontrado("anexo_nao_encontrado", "anexo não encontrado")
+	ErrNomeObrigatorio    = ticket.NovoErroValidacao("nome", "nome_obrigatorio", "nome do arquivo é obrigatório")
+	ErrAnexoVazio         = ticket.NovoErroValidacao("arquivo", "anexo_vazio", "o arquivo está vazio")
+	ErrAnexoGrande        = ticket.NovoErroValidacao("arquivo", "anexo_muito_grande", "o arquivo é maior que o permitido")
+	ErrTipoNaoPermitido   = ticket.NovoErroValidacao("arquivo", "tipo_nao_permitido", "tipo de arquivo não permitido")
+)
+
+// Anexo é um arquivo enviado em um ticket. O conteúdo fica no Storage, sob a Chave;
+// o banco guarda só os metadados.
+type Anexo struct {
+	ID           string
+	TicketID     string
+	ObservacaoID string // observação que trouxe o arquivo, quando houver
+	Nome         string
+	TipoMIME     string // detectado pelo conteúdo, não pelo que o cliente informou
+	Tamanho      int64
+	SHA256       string // checksum do conteúdo, em hexadecimal
+	Chave        string // caminho do conteúdo no Storage
+	EnviadoPor   string
+	DataCriacao  time.Time
+}
+
+// NovoAnexo cria os metadados de um arquivo do ticket; o nome é reduzido ao nome base,
+// sem diretórios. Tipo, tamanho e checksum são preenchidos ao guardar o conteúdo.
+func NovoAnexo(ticketID, nome, enviadoPor string) (*Anexo, error) {
+	nome = strings.TrimSpace(path.Base(strings.ReplaceAll(nome, `\`, "/")))
+	if nome == "" || nome == "." || nome == ".." || nome == "/" {
+		return nil, ErrNomeObrigatorio
+	}
+
+	id := uuid.New().String()
+	return &Anexo{
+		ID:          id,
+		TicketID:    ticketID,
+		Nome:        nome,
+		Chave:       "tickets/" + ticketID + "/" + id,
+		EnviadoPor:  enviadoPor,
+		DataCriacao: time.Now(),
+	}, nil
+}
+
+// Storage guarda o conteúdo dos anexos
+type Storage interface {
+	// Gravar o conteúdo do anexo sob a sua chave; tipo, tamanho e checksum já estão preenchidos
+	Salvar(ctx context.Context, a *Anexo, conteudo io.Reader) error
+
+	// Abrir o conteúdo da chave; falha com ErrAnexoNaoEncontrado
+	Abrir(ctx context.Context, chave string) (io.ReadCloser, error)
+
+	// Remover o conteúdo da chave; remover uma chave inexistente não é erro
+	Remover(ctx context.Context, chave string) error
+}
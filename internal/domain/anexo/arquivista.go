@@ -0,0 +1,74 @@
+package anexo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+// Arquivista guarda os anexos: valida o conteúdo contra os limites, calcula o checksum,
+// grava o conteúdo no Storage e os metadados no repositório
+type Arquivista struct {
+	storage Storage
+	repo    Repository
+	limites Limites
+}
+
+// NovoArquivista cria o arquivista com o armazenamento, o repositório e os limites informados
+func NovoArquivista(storage Storage, repo Repository, limites Limites) *Arquivista {
+	return &Arquivista{storage: storage, repo: repo, limites: limites}
+}
+
+// Guardar lê o conteúdo, valida tamanho e tipo e grava o anexo. O conteúdo é lido
+// inteiro antes de gravar, limitado ao tamanho máximo, para que o Storage receba o
+// tamanho e o checksum já conhecidos e nada seja gravado se o arquivo for recusado.
+func (a *Arquivista) Guardar(ctx context.Context, novo *Anexo, conteudo io.Reader) error {
+	// 1. lê no máximo um byte além do limite, para saber se ele foi ultrapassado
+	dados, err := io.ReadAll(io.LimitReader(conteudo, a.limites.TamanhoMaximo+1))
+	if err != nil {
+		return err
+	}
+	if len(dados) == 0 {
+		return ErrAnexoVazio
+	}
+	if int64(len(dados)) > a.limites.TamanhoMaximo {
+		return ErrAnexoGrande
+	}
+
+	// 2. tipo pelo conteúdo
+	tipo := DetectarTipo(novo.Nome, dados[:min(len(dados), 512)])
+	if !a.limites.Permite(tipo) {
+		return ErrTipoNaoPermitido
+	}
+
+	// 3. metadados e checksum
+	soma := sha256.Sum256(dados)
+	novo.TipoMIME = tipo
+	novo.Tamanho = int64(len(dados))
+	novo.SHA256 = hex.EncodeToString(soma[:])
+
+	// 4. grava o conteúdo e depois os metadados; se os metadados falharem, o conteúdo é apagado
+	if err := a.storage.Salvar(ctx, novo, bytes.NewReader(dados)); err != nil {
+		return err
+	}
+	if err := a.repo.Criar(novo); err != nil {
+		a.storage.Remover(ctx, novo.Chave)
+		return err
+	}
+	return nil
+}
+
+// Abrir retorna o conteúdo do anexo
+func (a *Arquivista) Abrir(ctx context.Context, anexo *Anexo) (io.ReadCloser, error) {
+	return a.storage.Abrir(ctx, anexo.Chave)
+}
+
+// Descartar apaga os metadados e o conteúdo do anexo
+func (a *Arquivista) Descartar(ctx context.Context, anexo *Anexo) error {
+	if err := a.repo.Remover(anexo.ID); err != nil {
+		return err
+	}
+	return a.storage.Remover(ctx, anexo.Chave)
+}
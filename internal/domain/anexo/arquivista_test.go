@@ -0,0 +1,211 @@
+package anexo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// storageEmMemoria guarda os conteúdos em um mapa
+type storageEmMemoria struct {
+	conteudos map[string][]byte
+	falhar    error
+}
+
+func (s *storageEmMemoria) Salvar(_ context.Context, a *Anexo, conteudo io.Reader) error {
+	if s.falhar != nil {
+		return s.falhar
+	}
+	dados, err := io.ReadAll(conteudo)
+	s.conteudos[a.Chave] = dados
+	return err
+}
+
+func (s *storageEmMemoria) Abrir(_ context.Context, chave string) (io.ReadCloser, error) {
+	dados, ok := s.conteudos[chave]
+	if !ok {
+		return nil, ErrAnexoNaoEncontrado
+	}
+	return io.NopCloser(bytes.NewReader(dados)), nil
+}
+
+func (s *storageEmMemoria) Remover(_ context.Context, chave string) error {
+	delete(s.conteudos, chave)
+	return nil
+}
+
+// repositorioEmMemoria guarda os metadados em um mapa
+type repositorioEmMemoria struct {
+	anexos map[string]*Anexo
+	falhar error
+}
+
+func (r *repositorioEmMemoria) Criar(a *Anexo) error {
+	if r.falhar != nil {
+		return r.falhar
+	}
+	r.anexos[a.ID] = a
+	return nil
+}
+
+func (r *repositorioEmMemoria) BuscarPorID(id string) (*Anexo, error) {
+	a, ok := r.anexos[id]
+	if !ok {
+		return nil, ErrAnexoNaoEncontrado
+	}
+	return a, nil
+}
+
+func (r *repositorioEmMemoria) ListarPorTicket(ticketID string) ([]*Anexo, error) {
+	var anexos []*Anexo
+	for _, a := range r.anexos {
+		if a.TicketID == ticketID {
+			anexos = append(anexos, a)
+		}
+	}
+	return anexos, nil
+}
+
+func (r *repositorioEmMemoria) Remover(id string) error {
+	delete(r.anexos, id)
+	return nil
+}
+
+func novoArquivistaDeTeste(limites Limites) (*Arquivista, *storageEmMemoria, *repositorioEmMemoria) {
+	storage := &storageEmMemoria{conteudos: map[string][]byte{}}
+	repo := &repositorioEmMemoria{anexos: map[string]*Anexo{}}
+	return NovoArquivista(storage, repo, limites), storage, repo
+}
+
+func TestNovoAnexo_NomeSemDiretorios(t *testing.T) {
+	a, err := NovoAnexo("ticket-1", `C:\Users\cliente\comprovante.pdf`, "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar anexo: %v", err)
+	}
+	if a.Nome != "comprovante.pdf" {
+		t.Errorf("Nome = %q, esperava comprovante.pdf", a.Nome)
+	}
+	if !strings.HasPrefix(a.Chave, "tickets/ticket-1/") {
+		t.Errorf("Chave = %q", a.Chave)
+	}
+
+	for _, nome := range []string{"", "  ", "../", "/"} {
+		if _, err := NovoAnexo("ticket-1", nome, "usuario_teste"); !errors.Is(err, ErrNomeObrigatorio) {
+			t.Errorf("NovoAnexo(%q): esperava ErrNomeObrigatorio, recebeu %v", nome, err)
+		}
+	}
+}
+
+func TestArquivista_Guardar(t *testing.T) {
+	arquivista, storage, repo := novoArquivistaDeTeste(LimitesPadrao())
+	a, _ := NovoAnexo("ticket-1", "extrato.csv", "usuario_teste")
+
+	if err := arquivista.Guardar(context.Background(), a, strings.NewReader("data;valor\n2024-01-02;10,00\n")); err != nil {
+		t.Fatalf("Erro ao guardar: %v", err)
+	}
+	if a.TipoMIME != "text/csv" || a.Tamanho != 28 {
+		t.Errorf("Metadados incorretos: tipo %q, tamanho %d", a.TipoMIME, a.Tamanho)
+	}
+	// sha256 de "data;valor\n2024-01-02;10,00\n"
+	if a.SHA256 != "aa21763c57fa1adff0f0bd665b7c0086f210b0ac62a2666fcb5ee9a2d35bed19" {
+		t.Errorf("SHA256 inválido: %q", a.SHA256)
+	}
+	if _, ok := storage.conteudos[a.Chave]; !ok {
+		t.Error("Conteúdo não foi gravado no storage")
+	}
+	if _, ok := repo.anexos[a.ID]; !ok {
+		t.Error("Metadados não foram gravados no repositório")
+	}
+
+	leitor, err := arquivista.Abrir(context.Background(), a)
+	if err != nil {
+		t.Fatalf("Erro ao abrir: %v", err)
+	}
+	lido, _ := io.ReadAll(leitor)
+	if string(lido) != "data;valor\n2024-01-02;10,00\n" {
+		t.Errorf("Conteúdo lido = %q", lido)
+	}
+
+	if err := arquivista.Descartar(context.Background(), a); err != nil {
+		t.Fatalf("Erro ao descartar: %v", err)
+	}
+	if len(storage.conteudos) != 0 || len(repo.anexos) != 0 {
+		t.Error("Descartar deveria apagar conteúdo e metadados")
+	}
+}
+
+func TestArquivista_Limites(t *testing.T) {
+	limites := LimitesPadrao()
+	limites.TamanhoMaximo = 16
+	arquivista, storage, repo := novoArquivistaDeTeste(limites)
+
+	casos := []struct {
+		nome     string
+		arquivo  string
+		conteudo string
+		esperado error
+	}{
+		{"vazio", "a.txt", "", ErrAnexoVazio},
+		{"maior que o limite", "a.txt", strings.Repeat("x", 17), ErrAnexoGrande},
+		{"no limite", "a.txt", strings.Repeat("x", 16), nil},
+		{"html não permitido", "pagina.html", "<html><body>oi</body></html>"[:16], ErrTipoNaoPermitido},
+		{"executável renomeado", "nota.pdf", "MZ\x90\x00\x03\x00\x00\x00\x04\x00", ErrTipoNaoPermitido},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			a, _ := NovoAnexo("ticket-1", c.arquivo, "usuario_teste")
+			err := arquivista.Guardar(context.Background(), a, strings.NewReader(c.conteudo))
+			if !errors.Is(err, c.esperado) {
+				t.Errorf("Esperava %v, recebeu %v", c.esperado, err)
+			}
+			if c.esperado != nil {
+				if _, ok := storage.conteudos[a.Chave]; ok {
+					t.Error("Arquivo recusado não deveria ser gravado")
+				}
+			}
+		})
+	}
+	if len(repo.anexos) != 1 {
+		t.Errorf("Esperava 1 anexo gravado, há %d", len(repo.anexos))
+	}
+}
+
+func TestArquivista_FalhaNosMetadadosApagaConteudo(t *testing.T) {
+	arquivista, storage, repo := novoArquivistaDeTeste(LimitesPadrao())
+	repo.falhar = errors.New("banco indisponível")
+
+	a, _ := NovoAnexo("ticket-1", "a.txt", "usuario_teste")
+	if err := arquivista.Guardar(context.Background(), a, strings.NewReader("conteúdo")); err == nil {
+		t.Fatal("Esperava erro do repositório")
+	}
+	if len(storage.conteudos) != 0 {
+		t.Error("O conteúdo deveria ser apagado quando os metadados não são gravados")
+	}
+}
+
+func TestDetectarTipo(t *testing.T) {
+	casos := []struct {
+		nome     string
+		inicio   string
+		esperado string
+	}{
+		{"comprovante.pdf", "%PDF-1.7\n", "application/pdf"},
+		{"print.png", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"extrato.csv", "data;valor\n", "text/csv"},
+		{"notas.txt", "texto simples", "text/plain"},
+		{"sem_extensao", "texto simples", "text/plain"},
+		{"contrato.docx", "PK\x03\x04", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"arquivo.zip", "PK\x03\x04", "application/zip"},
+		// a extensão não muda o tipo de um conteúdo que não é texto nem zip
+		{"falso.txt", "%PDF-1.7\n", "application/pdf"},
+		{"falso.pdf", "<html><body>", "text/html"},
+	}
+	for _, c := range casos {
+		if got := DetectarTipo(c.nome, []byte(c.inicio)); got != c.esperado {
+			t.Errorf("DetectarTipo(%q) = %q, esperava %q", c.nome, got, c.esperado)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package anexo
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Limites restringe o tamanho e o tipo dos arquivos aceitos
+type Limites struct {
+	TamanhoMaximo   int64    // em bytes
+	TiposPermitidos []string // tipos MIME aceitos; vazio aceita qualquer tipo
+}
+
+// LimitesPadrao aceita até 10 MiB de imagens, PDFs, textos, planilhas, documentos de
+// escritório e e-mails, que cobrem comprovantes, prints e documentos de KYC
+func LimitesPadrao() Limites {
+	return Limites{
+		TamanhoMaximo: 10 << 20,
+		TiposPermitidos: []string{
+			"image/png", "image/jpeg", "image/gif", "image/webp",
+			"application/pdf",
+			"text/plain", "text/csv",
+			"message/rfc822",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			"application/vnd.oasis.opendocument.text",
+			"application/vnd.oasis.opendocument.spreadsheet",
+		},
+	}
+}
+
+// Permite indica se o tipo MIME é aceito
+func (l Limites) Permite(tipo string) bool {
+	if len(l.TiposPermitidos) == 0 {
+		return true
+	}
+	for _, t := range l.TiposPermitidos {
+		if strings.EqualFold(t, tipo) {
+			return true
+		}
+	}
+	return false
+}
+
+// tipos por extensão que a tabela do Go não conhece em todos os sistemas
+var tiposPorExtensao = map[string]string{
+	".csv":  "text/csv",
+	".txt":  "text/plain",
+	".eml":  "message/rfc822",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".odt":  "application/vnd.oasis.opendocument.text",
+	".ods":  "application/vnd.oasis.opendocument.spreadsheet",
+}
+
+// DetectarTipo identifica o tipo MIME pelo início do conteúdo. A extensão do nome só
+// refina os tipos genéricos (texto e zip, que também é o formato dos documentos de
+// escritório), para que um executável renomeado para .pdf não passe como PDF.
+func DetectarTipo(nome string, inicio []byte) string {
+	detectado, _, _ := mime.ParseMediaType(http.DetectContentType(inicio))
+
+	ext := strings.ToLower(path.Ext(nome))
+	porExtensao, ok := tiposPorExtensao[ext]
+	if !ok {
+		porExtensao, _, _ = mime.ParseMediaType(mime.TypeByExtension(ext))
+	}
+
+	switch detectado {
+	case "text/plain":
+		if strings.HasPrefix(porExtensao, "text/") || porExtensao == "message/rfc822" {
+			return porExtensao
+		}
+	case "application/zip":
+		if strings.HasPrefix(porExtensao, "application/vnd.openxmlformats-") || strings.HasPrefix(porExtensao, "application/vnd.oasis.") {
+			return porExtensao
+		}
+	}
+	return detectado
+}
@@ -0,0 +1,15 @@
+package anexo
+
+type Repository interface {
+	// Registrar os metadados de um anexo já gravado no Storage
+	Criar(a *Anexo) error
+
+	// Buscar anexo por ID; falha com ErrAnexoNaoEncontrado
+	BuscarPorID(id string) (*Anexo, error)
+
+	// Listar os anexos do ticket, do mais antigo para o mais recente
+	ListarPorTicket(ticketID string) ([]*Anexo, error)
+
+	// Remover os metadados do anexo
+	Remover(id string) error
+}
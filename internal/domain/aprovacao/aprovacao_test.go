@@ -0,0 +1,252 @@
+package aprovacao
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// politicaTeste exige um supervisor até 10 mil e, a partir daí, um supervisor e depois um admin
+func politicaTeste(t *testing.T) *Politica {
+	p, err := NovaPolitica([]Regra{{
+		Categoria:    ticket.CategoriaFinanceiro,
+		Subcategoria: ticket.SubcategoriaSolicitacaoSaque,
+		Faixas: []Faixa{
+			{ValorMinimo: 1000000, Etapas: []Etapa{
+				{Nome: "supervisao", Papeis: []string{"supervisor"}, Aprovacoes: 1},
+				{Nome: "diretoria", Papeis: []string{"admin"}, Aprovacoes: 1},
+			}},
+			{ValorMinimo: 0, Etapas: []Etapa{
+				{Nome: "supervisao", Papeis: []string{"supervisor"}, Aprovacoes: 1},
+			}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Erro ao criar política: %v", err)
+	}
+	return p
+}
+
+func saqueTeste(t *testing.T, valor interface{}) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Saque", "Solicitação de saque", ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, "cliente")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	if valor != nil {
+		tk.Campos = map[string]interface{}{"valor": valor}
+	}
+	return tk
+}
+
+func TestNovaPolitica_Invalida(t *testing.T) {
+	etapas := []Etapa{{Nome: "supervisao", Papeis: []string{"supervisor"}, Aprovacoes: 1}}
+	casos := []struct {
+		nome   string
+		regras []Regra
+	}{
+		{"sem faixas", []Regra{{Categoria: ticket.CategoriaFinanceiro}}},
+		{"sem faixa a partir de zero", []Regra{{Categoria: ticket.CategoriaFinanceiro, Faixas: []Faixa{{ValorMinimo: 100, Etapas: etapas}}}}},
+		{"etapa sem aprovações", []Regra{{Categoria: ticket.CategoriaFinanceiro, Faixas: []Faixa{{Etapas: []Etapa{{Nome: "x", Papeis: []string{"admin"}}}}}}}},
+		{"regra repetida", []Regra{
+			{Categoria: ticket.CategoriaFinanceiro, Faixas: []Faixa{{Etapas: etapas}}},
+			{Categoria: ticket.CategoriaFinanceiro, Faixas: []Faixa{{Etapas: etapas}}},
+		}},
+	}
+	for _, c := range casos {
+		if _, err := NovaPolitica(c.regras); !errors.Is(err, ErrPoliticaInvalida) {
+			t.Errorf("%s: esperava ErrPoliticaInvalida, recebido %v", c.nome, err)
+		}
+	}
+}
+
+func TestPolitica_Avaliar(t *testing.T) {
+	p := politicaTeste(t)
+
+	casos := []struct {
+		nome   string
+		valor  interface{}
+		etapas int
+	}{
+		{"abaixo do limite", 500.0, 1},
+		{"no limite", 10000.0, 2},
+		{"sem valor vale a faixa mais alta", nil, 2},
+	}
+	for _, c := range casos {
+		s, ok := p.Avaliar(saqueTeste(t, c.valor))
+		if !ok || len(s.Etapas) != c.etapas || s.Pendente == nil || s.Pendente.Nome != "supervisao" || s.Aprovada {
+			t.Errorf("%s: situação inesperada %+v", c.nome, s)
+		}
+	}
+
+	outro, _ := ticket.NovoTicket("Bug", "Erro no sistema", ticket.CategoriaTI, ticket.SubcategoriaBug, "cliente")
+	if _, ok := p.Avaliar(outro); ok {
+		t.Errorf("Nenhuma regra deveria se aplicar a %s", outro.Categoria)
+	}
+}
+
+func TestPolitica_Decidir(t *testing.T) {
+	p := politicaTeste(t)
+	supervisor := Aprovador{ID: "supervisor1", Papeis: []string{"supervisor"}}
+	admin := Aprovador{ID: "admin1", Papeis: []string{"admin"}}
+
+	tk := saqueTeste(t, 25000.0)
+
+	// 1. quem abriu o ticket não aprova, nem com o papel exigido
+	if err := p.Decidir(tk, Aprovador{ID: "cliente", Papeis: []string{"supervisor"}}, ticket.DecisaoAprovada, ""); !errors.Is(err, ErrAutoAprovacao) {
+		t.Errorf("Esperava ErrAutoAprovacao, recebido %v", err)
+	}
+
+	// 2. a etapa exige o papel
+	if err := p.Decidir(tk, Aprovador{ID: "analista1", Papeis: []string{"analista"}}, ticket.DecisaoAprovada, ""); !errors.Is(err, ErrAprovadorSemPapel) {
+		t.Errorf("Esperava ErrAprovadorSemPapel, recebido %v", err)
+	}
+
+	// 3. a supervisão aprova e a mesma pessoa não decide a etapa seguinte
+	if err := p.Decidir(tk, supervisor, ticket.DecisaoAprovada, "conferido"); err != nil {
+		t.Fatalf("Erro ao aprovar supervisão: %v", err)
+	}
+	if err := p.Decidir(tk, Aprovador{ID: "supervisor1", Papeis: []string{"admin"}}, ticket.DecisaoAprovada, ""); !errors.Is(err, ErrAprovadorRepetido) {
+		t.Errorf("Esperava ErrAprovadorRepetido, recebido %v", err)
+	}
+	if err := p.Guarda()(tk, time.Now()); !errors.Is(err, ErrAprovacoesPendentes) {
+		t.Errorf("Esperava ErrAprovacoesPendentes com a diretoria pendente, recebido %v", err)
+	}
+
+	// 4. a diretoria conclui a aprovação
+	if err := p.Decidir(tk, admin, ticket.DecisaoAprovada, ""); err != nil {
+		t.Fatalf("Erro ao aprovar diretoria: %v", err)
+	}
+	if err := p.Guarda()(tk, time.Now()); err != nil {
+		t.Errorf("Ticket aprovado não deveria ser bloqueado: %v", err)
+	}
+	if err := p.Decidir(tk, Aprovador{ID: "admin2", Papeis: []string{"admin"}}, ticket.DecisaoAprovada, ""); !errors.Is(err, ErrAprovacaoEncerrada) {
+		t.Errorf("Esperava ErrAprovacaoEncerrada, recebido %v", err)
+	}
+	if len(tk.Aprovacoes) != 2 || len(tk.AprovacoesNovas()) != 2 {
+		t.Errorf("Esperava 2 decisões registradas, recebido %d", len(tk.Aprovacoes))
+	}
+
+	// 5. um novo valor recomeça a aprovação
+	tk.Campos["valor"] = 30000.0
+	if err := p.Guarda()(tk, time.Now()); !errors.Is(err, ErrAprovacoesPendentes) {
+		t.Errorf("Esperava a aprovação recomeçar com o novo valor, recebido %v", err)
+	}
+}
+
+func TestPolitica_Rejeitar(t *testing.T) {
+	p := politicaTeste(t)
+	supervisor := Aprovador{ID: "supervisor1", Papeis: []string{"supervisor"}}
+	tk := saqueTeste(t, 800.0)
+
+	if err := p.Decidir(tk, supervisor, ticket.DecisaoRejeitada, " "); !errors.Is(err, ErrComentarioRejeicao) {
+		t.Errorf("Esperava ErrComentarioRejeicao, recebido %v", err)
+	}
+	if err := p.Decidir(tk, supervisor, "talvez", ""); !errors.Is(err, ErrDecisaoInvalida) {
+		t.Errorf("Esperava ErrDecisaoInvalida, recebido %v", err)
+	}
+	if err := p.Decidir(tk, supervisor, ticket.DecisaoRejeitada, "conta de destino divergente"); err != nil {
+		t.Fatalf("Erro ao rejeitar: %v", err)
+	}
+
+	s, _ := p.Avaliar(tk)
+	if !s.Rejeitada || s.Aprovada {
+		t.Errorf("Esperava aprovação rejeitada, recebido %+v", s)
+	}
+	if err := p.Guarda()(tk, time.Now()); !errors.Is(err, ErrAprovacoesPendentes) {
+		t.Errorf("Ticket rejeitado não deveria ser concluído, recebido %v", err)
+	}
+	if tk.Aprovacoes[0].Comentario != "conta de destino divergente" {
+		t.Errorf("Comentário não registrado: %+v", tk.Aprovacoes[0])
+	}
+}
+
+// Teste do valor em centavos: diferenças de arredondamento não recomeçam a aprovação nem liberam nova decisão
+func TestPolitica_ValorEmCentavos(t *testing.T) {
+	p := politicaTeste(t)
+	supervisor := Aprovador{ID: "supervisor1", Papeis: []string{"supervisor"}}
+
+	tk := saqueTeste(t, 800.1)
+	if err := p.Decidir(tk, supervisor, ticket.DecisaoAprovada, ""); err != nil {
+		t.Fatalf("Erro ao aprovar: %v", err)
+	}
+	if tk.Aprovacoes[0].Valor != 80010 {
+		t.Errorf("Esperava o valor em centavos 80010, recebido %d", tk.Aprovacoes[0].Valor)
+	}
+
+	// o mesmo valor em reais, com resíduo de ponto flutuante, é o mesmo valor em centavos
+	tk.Campos["valor"] = 800.10000001
+	if err := p.Guarda()(tk, time.Now()); err != nil {
+		t.Errorf("Aprovação deveria continuar valendo, recebido %v", err)
+	}
+
+	// um centavo a mais recomeça a aprovação, e o supervisor pode decidir sobre o novo valor
+	tk.Campos["valor"] = 800.11
+	if err := p.Guarda()(tk, time.Now()); !errors.Is(err, ErrAprovacoesPendentes) {
+		t.Errorf("Esperava ErrAprovacoesPendentes com o novo valor, recebido %v", err)
+	}
+	if err := p.Decidir(tk, supervisor, ticket.DecisaoAprovada, ""); err != nil {
+		t.Errorf("Supervisor deveria decidir sobre o novo valor: %v", err)
+	}
+}
+
+// Teste da regra de toda a categoria: vale para as subcategorias sem regra própria
+func TestPolitica_RegraDaCategoria(t *testing.T) {
+	p, err := NovaPolitica([]Regra{
+		{Categoria: ticket.CategoriaFinanceiro, Faixas: []Faixa{{Etapas: []Etapa{
+			{Nome: "financeiro", Papeis: []string{"analista"}, Aprovacoes: 1},
+		}}}},
+		{Categoria: ticket.CategoriaFinanceiro, Subcategoria: ticket.SubcategoriaSolicitacaoSaque, Faixas: []Faixa{{Etapas: []Etapa{
+			{Nome: "supervisao", Papeis: []string{"supervisor"}, Aprovacoes: 1},
+		}}}},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao criar política: %v", err)
+	}
+
+	// a regra da subcategoria tem prioridade
+	if s, ok := p.Avaliar(saqueTeste(t, 100.0)); !ok || s.Pendente == nil || s.Pendente.Nome != "supervisao" {
+		t.Errorf("Esperava a regra da subcategoria, recebido %+v", s)
+	}
+
+	// as demais subcategorias da categoria seguem a regra da categoria e não podem ser concluídas sem ela
+	outro, _ := ticket.NovoTicket("Estorno", "Pedido de estorno", ticket.CategoriaFinanceiro, ticket.SubcategoriaBug, "cliente")
+	s, ok := p.Avaliar(outro)
+	if !ok || s.Pendente == nil || s.Pendente.Nome != "financeiro" {
+		t.Errorf("Esperava a regra da categoria, recebido %+v", s)
+	}
+	if err := p.Guarda()(outro, time.Now()); !errors.Is(err, ErrAprovacoesPendentes) {
+		t.Errorf("Esperava ErrAprovacoesPendentes, recebido %v", err)
+	}
+
+	// fora da categoria nenhuma regra se aplica
+	ti, _ := ticket.NovoTicket("Bug", "Erro no sistema", ticket.CategoriaTI, ticket.SubcategoriaBug, "cliente")
+	if _, ok := p.Avaliar(ti); ok {
+		t.Errorf("Nenhuma regra deveria se aplicar a %s", ti.Categoria)
+	}
+}
+
+// Teste da conclusão: a máquina de estados configurada com a guarda recusa finalizar sem as aprovações
+func TestPolitica_GuardaNaConclusao(t *testing.T) {
+	p := politicaTeste(t)
+	maquina := ticket.MaquinaDeEstadosPadrao().ComGuarda(ticket.StatusFinalizado, p.Guarda())
+
+	tk := saqueTeste(t, 500.0)
+	if err := maquina.Aplicar(tk, ticket.StatusEmCurso, ticket.ContextoTransicao{UsuarioID: "analista1", Responsavel: "analista1"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	if err := maquina.Aplicar(tk, ticket.StatusFinalizado, ticket.ContextoTransicao{UsuarioID: "analista1"}); !errors.Is(err, ErrAprovacoesPendentes) {
+		t.Fatalf("Esperava ErrAprovacoesPendentes, recebido %v", err)
+	}
+	if tk.Status != ticket.StatusEmCurso || tk.DataConclusao != nil {
+		t.Errorf("Ticket não deveria ser concluído, recebido status %s", tk.Status)
+	}
+
+	if err := p.Decidir(tk, Aprovador{ID: "supervisor1", Papeis: []string{"supervisor"}}, ticket.DecisaoAprovada, ""); err != nil {
+		t.Fatalf("Erro ao aprovar: %v", err)
+	}
+	if err := maquina.Aplicar(tk, ticket.StatusFinalizado, ticket.ContextoTransicao{UsuarioID: "analista1"}); err != nil {
+		t.Errorf("Ticket aprovado deveria ser concluído: %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package aprovacao
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+var (
+	ErrPoliticaInvalida    = ticket.NovoErroValidacao("politica", "politica_aprovacao_invalida", "política de aprovação inválida")
+	ErrSemAprovacao        = ticket.NovoErroValidacao("", "aprovacao_nao_exigida", "o ticket não passa por aprovação")
+	ErrDecisaoInvalida     = ticket.NovoErroValidacao("decisao", "decisao_invalida", "decisão inválida, use aprovada ou rejeitada")
+	ErrComentarioRejeicao  = ticket.NovoErroValidacao("comentario", "comentario_obrigatorio", "comentário é obrigatório ao rejeitar")
+	ErrAutoAprovacao       = ticket.NovoErroProibido("auto_aprovacao", "quem abriu o ticket não pode decidir a aprovação")
+	ErrAprovadorRepetido   = ticket.NovoErroProibido("aprovador_repetido", "cada aprovador decide uma única vez por valor")
+	ErrAprovadorSemPapel   = ticket.NovoErroProibido("aprovador_sem_papel", "o usuário não tem papel de aprovador na etapa atual")
+	ErrAprovacaoEncerrada  = ticket.NovoErroTransicao("aprovacao_encerrada", "a aprovação já foi concluída ou rejeitada")
+	ErrAprovacoesPendentes = ticket.NovoErroTransicao("aprovacoes_pendentes", "o ticket só pode ser concluído com as aprovações exigidas")
+)
+
+// CampoValorPadrao é o campo personalizado com o valor do ticket quando a regra não informa outro
+const CampoValorPadrao = "valor"
+
+// papel que atende a qualquer etapa, como no restante da aplicação
+const papelAdmin = "admin"
+
+// Etapa é um passo da aprovação: exige decisões de usuários com um dos papéis
+type Etapa struct {
+	Nome       string
+	Papeis     []string
+	Aprovacoes int // aprovações de pessoas diferentes exigidas na etapa
+}
+
+// Faixa define as etapas para os valores a partir de ValorMinimo
+type Faixa struct {
+	ValorMinimo int64 // em centavos
+	Etapas      []Etapa
+}
+
+// Regra define a aprovação dos tickets de uma categoria e subcategoria
+type Regra struct {
+	Categoria    ticket.Categoria
+	Subcategoria ticket.Subcategoria // vazia vale para toda a categoria
+	CampoValor   string              // campo personalizado com o valor
+	Faixas       []Faixa             // ordenadas pelo valor mínimo
+}
+
+// Politica reúne as regras de aprovação
+type Politica struct {
+	regras []Regra
+}
+
+// NovaPolitica valida as regras e ordena as faixas pelo valor mínimo
+func NovaPolitica(regras []Regra) (*Politica, error) {
+	vistas := make(map[string]bool)
+	for i := range regras {
+		r := &regras[i]
+		r.Categoria = ticket.Categoria(strings.ToLower(string(r.Categoria)))
+		r.Subcategoria = ticket.Subcategoria(strings.ToLower(string(r.Subcategoria)))
+		if r.CampoValor == "" {
+			r.CampoValor = CampoValorPadrao
+		}
+		chave := string(r.Categoria) + "/" + string(r.Subcategoria)
+		if r.Categoria == "" || vistas[chave] {
+			return nil, fmt.Errorf("%w: regra %s vazia ou repetida", ErrPoliticaInvalida, chave)
+		}
+		vistas[chave] = true
+
+		if len(r.Faixas) == 0 {
+			return nil, fmt.Errorf("%w: regra %s sem faixas", ErrPoliticaInvalida, chave)
+		}
+		sort.Slice(r.Faixas, func(a, b int) bool { return r.Faixas[a].ValorMinimo < r.Faixas[b].ValorMinimo })
+		if r.Faixas[0].ValorMinimo != 0 {
+			return nil, fmt.Errorf("%w: regra %s precisa de uma faixa a partir de 0", ErrPoliticaInvalida, chave)
+		}
+		for _, f := range r.Faixas {
+			if err := validarFaixa(f); err != nil {
+				return nil, fmt.Errorf("%w: regra %s: %v", ErrPoliticaInvalida, chave, err)
+			}
+		}
+	}
+	return &Politica{regras: regras}, nil
+}
+
+func validarFaixa(f Faixa) error {
+	if len(f.Etapas) == 0 {
+		return fmt.Errorf("faixa a partir de %v sem etapas", f.ValorMinimo)
+	}
+	nomes := make(map[string]bool)
+	for _, e := range f.Etapas {
+		if e.Nome == "" || nomes[e.Nome] {
+			return fmt.Errorf("etapa %q sem nome ou repetida", e.Nome)
+		}
+		nomes[e.Nome] = true
+		if len(e.Papeis) == 0 || e.Aprovacoes < 1 {
+			return fmt.Errorf("etapa %s precisa de papéis e de ao menos uma aprovação", e.Nome)
+		}
+	}
+	return nil
+}
+
+// ValidarClassificacoes confere as categorias e subcategorias das regras na taxonomia
+func (p *Politica) ValidarClassificacoes(taxonomia ticket.Taxonomia) error {
+	for _, r := range p.regras {
+		err := taxonomia.ValidarCategoria(r.Categoria)
+		if err == nil && r.Subcategoria != "" {
+			err = taxonomia.ValidarClassificacao(r.Categoria, r.Subcategoria)
+		}
+		if err != nil {
+			return fmt.Errorf("regra de aprovação %s/%s: %w", r.Categoria, r.Subcategoria, err)
+		}
+	}
+	return nil
+}
+
+// Regra retorna a regra que se aplica ao ticket, se houver; a regra da subcategoria tem
+// prioridade sobre a regra de toda a categoria
+func (p *Politica) Regra(t *ticket.Ticket) (*Regra, bool) {
+	var daCategoria *Regra
+	for i, r := range p.regras {
+		if r.Categoria != t.Categoria {
+			continue
+		}
+		if r.Subcategoria == t.Subcategoria {
+			return &p.regras[i], true
+		}
+		if r.Subcategoria == "" {
+			daCategoria = &p.regras[i]
+		}
+	}
+	return daCategoria, daCategoria != nil
+}
+
+// Situacao é o andamento da aprovação de um ticket
+type Situacao struct {
+	Valor          int64 // em centavos
+	ValorInformado bool  // sem valor, vale a faixa mais alta
+	Etapas         []SituacaoEtapa
+	Aprovada       bool
+	Rejeitada      bool
+	Pendente       *Etapa // etapa que aguarda decisões
+}
+
+// SituacaoEtapa é o andamento de uma etapa
+type SituacaoEtapa struct {
+	Etapa
+	Aprovadores []string // quem aprovou, na ordem das decisões
+	Concluida   bool
+}
+
+// Avaliar calcula a situação da aprovação do ticket; ok é falso quando nenhuma regra se aplica
+func (p *Politica) Avaliar(t *ticket.Ticket) (Situacao, bool) {
+	regra, ok := p.Regra(t)
+	if !ok {
+		return Situacao{}, false
+	}
+
+	// 1. a faixa é a de maior valor mínimo que o valor alcança
+	valor, informado := t.ValorEmCentavos(regra.CampoValor)
+	faixa := regra.Faixas[len(regra.Faixas)-1]
+	if informado {
+		for _, f := range regra.Faixas {
+			if valor >= f.ValorMinimo {
+				faixa = f
+			}
+		}
+	}
+	s := Situacao{Valor: valor, ValorInformado: informado}
+
+	// 2. apenas as decisões tomadas sobre o valor atual contam
+	aprovadores := make(map[string][]string)
+	for _, a := range t.Aprovacoes {
+		if a.Valor != valor {
+			continue
+		}
+		if a.Decisao == ticket.DecisaoRejeitada {
+			s.Rejeitada = true
+			continue
+		}
+		aprovadores[a.Etapa] = append(aprovadores[a.Etapa], a.UsuarioID)
+	}
+
+	// 3. as etapas são concluídas em ordem
+	for i, e := range faixa.Etapas {
+		se := SituacaoEtapa{Etapa: e, Aprovadores: aprovadores[e.Nome]}
+		se.Concluida = len(se.Aprovadores) >= e.Aprovacoes
+		s.Etapas = append(s.Etapas, se)
+		if !se.Concluida && s.Pendente == nil && !s.Rejeitada {
+			s.Pendente = &faixa.Etapas[i]
+		}
+	}
+	s.Aprovada = !s.Rejeitada && s.Pendente == nil
+	return s, true
+}
+
+// jaDecidiu indica se o usuário já decidiu sobre o valor atual, em qualquer etapa
+func jaDecidiu(t *ticket.Ticket, usuarioID string, valor int64) bool {
+	for _, a := range t.Aprovacoes {
+		if a.UsuarioID == usuarioID && a.Valor == valor {
+			return true
+		}
+	}
+	return false
+}
+
+// Aprovador é quem decide, com os papéis do token
+type Aprovador struct {
+	ID     string
+	Papeis []string
+}
+
+func (a Aprovador) temPapel(papeis []string) bool {
+	for _, p := range a.Papeis {
+		p = strings.ToLower(p)
+		if p == papelAdmin {
+			return true
+		}
+		for _, exigido := range papeis {
+			if p == strings.ToLower(exigido) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Decidir registra a decisão do aprovador na etapa pendente. Quem abriu o ticket não decide, e cada
+// pessoa decide uma única vez por valor, então as etapas exigem pessoas diferentes (quatro olhos)
+func (p *Politica) Decidir(t *ticket.Ticket, aprovador Aprovador, decisao ticket.DecisaoAprovacao, comentario string) error {
+	// 1. confere a decisão e a situação
+	if decisao != ticket.DecisaoAprovada && decisao != ticket.DecisaoRejeitada {
+		return ErrDecisaoInvalida
+	}
+	comentario = strings.TrimSpace(comentario)
+	if decisao == ticket.DecisaoRejeitada && comentario == "" {
+		return ErrComentarioRejeicao
+	}
+	s, ok := p.Avaliar(t)
+	if !ok {
+		return ErrSemAprovacao
+	}
+	if s.Aprovada || s.Rejeitada {
+		return ErrAprovacaoEncerrada
+	}
+
+	// 2. confere o aprovador
+	if aprovador.ID == t.AbertoPor {
+		return ErrAutoAprovacao
+	}
+	if jaDecidiu(t, aprovador.ID, s.Valor) {
+		return ErrAprovadorRepetido
+	}
+	if !aprovador.temPapel(s.Pendente.Papeis) {
+		return fmt.Errorf("%w: %s exige %s", ErrAprovadorSemPapel, s.Pendente.Nome, strings.Join(s.Pendente.Papeis, ", "))
+	}
+
+	// 3. registra a decisão no ticket
+	return t.RegistrarAprovacao(s.Pendente.Nome, decisao, comentario, s.Valor, aprovador.ID)
+}
+
+// Guarda impede a conclusão dos tickets com regra de aprovação enquanto a aprovação não estiver completa
+func (p *Politica) Guarda() ticket.Guarda {
+	return func(t *ticket.Ticket, agora time.Time) error {
+		s, ok := p.Avaliar(t)
+		if !ok || s.Aprovada {
+			return nil
+		}
+		if s.Rejeitada {
+			return fmt.Errorf("%w: a aprovação foi rejeitada", ErrAprovacoesPendentes)
+		}
+		return fmt.Errorf("%w: etapa %s", ErrAprovacoesPendentes, s.Pendente.Nome)
+	}
+}
@@ -0,0 +1,27 @@
+package auth
+
+import "context"
+
+// Principal é o usuário autenticado que está fazendo a requisição
+type Principal struct {
+	ID     string // identificador do usuário (claim sub do token)
+	Nome   string
+	Email  string
+	Papeis []string
+}
+
+type chaveContexto struct{}
+
+// ComPrincipal retorna um contexto que carrega o usuário autenticado
+func ComPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, chaveContexto{}, p)
+}
+
+// PrincipalDe retorna o usuário autenticado do contexto, se houver
+func PrincipalDe(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(chaveContexto{}).(Principal)
+	if !ok || p.ID == "" {
+		return Principal{}, false
+	}
+	return p, true
+}
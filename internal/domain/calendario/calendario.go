@@ -0,0 +1,148 @@
+package calendario
+
+import (
+	"fmt"
+	"time"
+)
+
+// Jornada é o expediente de um dia, em deslocamentos a partir da meia-noite
+type Jornada struct {
+	Inicio time.Duration
+	Fim    time.Duration
+}
+
+// Calendario conhece o expediente, o fuso horário e os feriados da operação
+type Calendario struct {
+	fuso     *time.Location
+	jornadas map[time.Weekday]Jornada
+	feriados []Feriado
+}
+
+// NovoCalendario cria um calendário de dias úteis. Cada jornada precisa ter duração positiva dentro
+// do dia; sem isso não haveria expediente para consumir e Adicionar nunca terminaria
+func NovoCalendario(fuso *time.Location, jornadas map[time.Weekday]Jornada, feriados []Feriado) (*Calendario, error) {
+	if fuso == nil {
+		fuso = time.UTC
+	}
+	for dia, j := range jornadas {
+		if j.Inicio < 0 || j.Fim > 24*time.Hour || j.Fim <= j.Inicio {
+			return nil, fmt.Errorf("jornada inválida em %s: o fim deve ser posterior ao início, dentro do dia", dia)
+		}
+	}
+	return &Calendario{
+		fuso:     fuso,
+		jornadas: jornadas,
+		feriados: feriados,
+	}, nil
+}
+
+// JornadaComercial é o expediente padrão: segunda a sexta, das 9h às 18h
+func JornadaComercial() map[time.Weekday]Jornada {
+	jornada := Jornada{Inicio: 9 * time.Hour, Fim: 18 * time.Hour}
+	return map[time.Weekday]Jornada{
+		time.Monday:    jornada,
+		time.Tuesday:   jornada,
+		time.Wednesday: jornada,
+		time.Thursday:  jornada,
+		time.Friday:    jornada,
+	}
+}
+
+// Fuso retorna o fuso horário do calendário
+func (c *Calendario) Fuso() *time.Location {
+	return c.fuso
+}
+
+// Feriado retorna o feriado que cai no dia informado, se houver
+func (c *Calendario) Feriado(dia time.Time) (Feriado, bool) {
+	dia = dia.In(c.fuso)
+	for _, f := range c.feriados {
+		if f.Ocorre(dia) {
+			return f, true
+		}
+	}
+	return Feriado{}, false
+}
+
+// EhDiaUtil indica se há expediente no dia informado
+func (c *Calendario) EhDiaUtil(dia time.Time) bool {
+	dia = dia.In(c.fuso)
+	if _, ok := c.jornadas[dia.Weekday()]; !ok {
+		return false
+	}
+	_, feriado := c.Feriado(dia)
+	return !feriado
+}
+
+// expediente retorna o início e o fim do expediente do dia, se for dia útil
+func (c *Calendario) expediente(dia time.Time) (time.Time, time.Time, bool) {
+	if !c.EhDiaUtil(dia) {
+		return time.Time{}, time.Time{}, false
+	}
+	jornada := c.jornadas[dia.In(c.fuso).Weekday()]
+	meiaNoite := inicioDoDia(dia, c.fuso)
+	return meiaNoite.Add(jornada.Inicio), meiaNoite.Add(jornada.Fim), true
+}
+
+// DuracaoUtil soma apenas o tempo dentro do expediente entre inicio e fim
+func (c *Calendario) DuracaoUtil(inicio, fim time.Time) time.Duration {
+	if !fim.After(inicio) {
+		return 0
+	}
+
+	var total time.Duration
+	for dia := inicioDoDia(inicio, c.fuso); dia.Before(fim); dia = proximoDia(dia, c.fuso) {
+		abre, fecha, ok := c.expediente(dia)
+		if !ok {
+			continue
+		}
+		if abre.Before(inicio) {
+			abre = inicio
+		}
+		if fecha.After(fim) {
+			fecha = fim
+		}
+		if fecha.After(abre) {
+			total += fecha.Sub(abre)
+		}
+	}
+	return total
+}
+
+// Adicionar avança d de tempo útil a partir de inicio e retorna o instante resultante
+func (c *Calendario) Adicionar(inicio time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return inicio
+	}
+	if len(c.jornadas) == 0 {
+		return inicio.Add(d)
+	}
+
+	restante := d
+	for dia := inicioDoDia(inicio, c.fuso); ; dia = proximoDia(dia, c.fuso) {
+		abre, fecha, ok := c.expediente(dia)
+		if !ok {
+			continue
+		}
+		if abre.Before(inicio) {
+			abre = inicio
+		}
+		if !fecha.After(abre) {
+			continue
+		}
+		if disponivel := fecha.Sub(abre); disponivel < restante {
+			restante -= disponivel
+			continue
+		}
+		return abre.Add(restante)
+	}
+}
+
+func inicioDoDia(t time.Time, fuso *time.Location) time.Time {
+	t = t.In(fuso)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, fuso)
+}
+
+func proximoDia(dia time.Time, fuso *time.Location) time.Time {
+	return time.Date(dia.Year(), dia.Month(), dia.Day()+1, 0, 0, 0, 0, fuso)
+}
@@ -0,0 +1,79 @@
+package calendario
+
+import (
+	"testing"
+	"time"
+)
+
+// Função auxiliar para criar o calendário comercial com alguns feriados nacionais
+func novoCalendarioTeste(t *testing.T) *Calendario {
+	fuso, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("Erro ao carregar fuso: %v", err)
+	}
+	sextaSanta := -2
+	feriados := []Feriado{
+		{Nome: "Natal", Dia: 25, Mes: time.December},
+		{Nome: "Sexta-feira Santa", Pascoa: &sextaSanta},
+	}
+	c, err := NovoCalendario(fuso, JornadaComercial(), feriados)
+	if err != nil {
+		t.Fatalf("Erro ao criar calendário: %v", err)
+	}
+	return c
+}
+
+func TestCalendario_DuracaoUtilFimDeSemana(t *testing.T) {
+	c := novoCalendarioTeste(t)
+
+	// sexta 17h até segunda 10h: 1h na sexta + 1h na segunda
+	inicio := time.Date(2025, 10, 17, 17, 0, 0, 0, c.Fuso())
+	fim := time.Date(2025, 10, 20, 10, 0, 0, 0, c.Fuso())
+
+	if got := c.DuracaoUtil(inicio, fim); got != 2*time.Hour {
+		t.Errorf("Duração útil diferente: esperado 2h, recebido %s", got)
+	}
+}
+
+func TestCalendario_Feriados(t *testing.T) {
+	c := novoCalendarioTeste(t)
+
+	if c.EhDiaUtil(time.Date(2025, 12, 25, 12, 0, 0, 0, c.Fuso())) {
+		t.Error("Natal não deveria ser dia útil")
+	}
+	// Páscoa de 2025 foi em 20/04, logo a Sexta-feira Santa foi em 18/04
+	if c.EhDiaUtil(time.Date(2025, 4, 18, 12, 0, 0, 0, c.Fuso())) {
+		t.Error("Sexta-feira Santa não deveria ser dia útil")
+	}
+	if !c.EhDiaUtil(time.Date(2025, 4, 17, 12, 0, 0, 0, c.Fuso())) {
+		t.Error("Quinta-feira anterior à Páscoa deveria ser dia útil")
+	}
+}
+
+func TestCalendario_Adicionar(t *testing.T) {
+	c := novoCalendarioTeste(t)
+
+	// quarta 24/12 às 17h + 4h úteis: 1h na quarta, pula o Natal, 3h na sexta
+	inicio := time.Date(2025, 12, 24, 17, 0, 0, 0, c.Fuso())
+	esperado := time.Date(2025, 12, 26, 12, 0, 0, 0, c.Fuso())
+
+	if got := c.Adicionar(inicio, 4*time.Hour); !got.Equal(esperado) {
+		t.Errorf("Prazo diferente: esperado %s, recebido %s", esperado, got)
+	}
+	if got := c.DuracaoUtil(inicio, esperado); got != 4*time.Hour {
+		t.Errorf("Duração útil diferente: esperado 4h, recebido %s", got)
+	}
+}
+
+func TestNovoCalendario_JornadaInvalida(t *testing.T) {
+	jornadas := []Jornada{
+		{Inicio: 18 * time.Hour, Fim: 9 * time.Hour},
+		{Inicio: 9 * time.Hour, Fim: 9 * time.Hour},
+		{Inicio: 9 * time.Hour, Fim: 25 * time.Hour},
+	}
+	for _, j := range jornadas {
+		if _, err := NovoCalendario(time.UTC, map[time.Weekday]Jornada{time.Monday: j}, nil); err == nil {
+			t.Errorf("Esperava erro para a jornada %+v", j)
+		}
+	}
+}
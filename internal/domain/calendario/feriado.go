@@ -0,0 +1,45 @@
+package calendario
+
+import "time"
+
+// Feriado pode ser fixo (Dia/Mes, todo ano), de um ano específico (Ano > 0)
+// ou móvel, definido em dias a partir do domingo de Páscoa
+type Feriado struct {
+	Nome   string
+	Regiao string // vazio para feriados nacionais
+	Dia    int
+	Mes    time.Month
+	Ano    int
+	Pascoa *int
+}
+
+// Ocorre indica se o feriado cai no dia informado
+func (f Feriado) Ocorre(dia time.Time) bool {
+	if f.Pascoa != nil {
+		data := Pascoa(dia.Year()).AddDate(0, 0, *f.Pascoa)
+		return data.Month() == dia.Month() && data.Day() == dia.Day()
+	}
+	if f.Ano != 0 && f.Ano != dia.Year() {
+		return false
+	}
+	return f.Mes == dia.Month() && f.Dia == dia.Day()
+}
+
+// Pascoa calcula o domingo de Páscoa do ano (algoritmo de Meeus/Jones/Butcher)
+func Pascoa(ano int) time.Time {
+	a := ano % 19
+	b := ano / 100
+	c := ano % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	mes := (h + l - 7*m + 114) / 31
+	dia := (h+l-7*m+114)%31 + 1
+	return time.Date(ano, time.Month(mes), dia, 0, 0, 0, 0, time.UTC)
+}
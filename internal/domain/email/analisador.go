@@ -0,0 +1,321 @@
+package email
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	regexIDMensagem  = regexp.MustCompile(`<([^<>\s]+)>`)
+	regexBlocoHTML   = regexp.MustCompile(`(?is)<(style|script|head)[^>]*>.*?</(style|script|head)>`)
+	regexQuebraHTML  = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|li|h[1-6])>`)
+	regexTagHTML     = regexp.MustCompile(`<[^>]*>`)
+	regexLinhasVazia = regexp.MustCompile(`\n{3,}`)
+)
+
+// decodificador de cabeçalhos codificados (RFC 2047), com os charsets mais comuns em e-mails brasileiros
+var decodificador = &mime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		conteudo, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		texto, err := decodificarCharset(charset, conteudo)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(texto), nil
+	},
+}
+
+// Analisar decodifica uma mensagem RFC 5322, com corpo MIME, em uma Mensagem
+func Analisar(bruto []byte) (*Mensagem, error) {
+	// 1. Ler cabeçalhos e corpo
+	msg, err := mail.ReadMessage(bytes.NewReader(bruto))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMensagemInvalida, err)
+	}
+	cabecalho := msg.Header
+
+	// 2. Remetente; sem ele não há a quem responder nem quem registrar como solicitante
+	remetente, err := lerEndereco(cabecalho.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: remetente inválido: %v", ErrMensagemInvalida, err)
+	}
+
+	m := &Mensagem{
+		MessageID:     primeiroID(cabecalho.Get("Message-ID")),
+		InReplyTo:     listarIDs(cabecalho.Get("In-Reply-To")),
+		References:    listarIDs(cabecalho.Get("References")),
+		Remetente:     strings.ToLower(remetente.Address),
+		NomeRemetente: remetente.Name,
+		Destinatarios: lerDestinatarios(cabecalho),
+		Assunto:       decodificarCabecalho(cabecalho.Get("Subject")),
+		Automatica:    automatica(cabecalho),
+	}
+	if data, err := cabecalho.Date(); err == nil {
+		m.Data = data
+	} else {
+		m.Data = time.Now()
+	}
+
+	// 3. Mensagens sem Message-ID ganham um ID derivado do conteúdo, para que a mesma
+	// mensagem entregue duas vezes continue sendo reconhecida
+	if m.MessageID == "" {
+		soma := sha256.Sum256(bruto)
+		m.MessageID = hex.EncodeToString(soma[:16]) + "@sem-message-id"
+	}
+
+	// 4. Percorrer as partes do corpo, separando texto e anexos
+	leitor := &leitorPartes{}
+	if err := leitor.ler(textproto.MIMEHeader(cabecalho), msg.Body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMensagemInvalida, err)
+	}
+	m.Texto = leitor.texto()
+	m.Anexos = leitor.anexos
+
+	return m, nil
+}
+
+// leitorPartes acumula o texto e os anexos encontrados ao percorrer a árvore MIME
+type leitorPartes struct {
+	textos []string
+	htmls  []string
+	anexos []Arquivo
+}
+
+func (l *leitorPartes) ler(cabecalho textproto.MIMEHeader, corpo io.Reader) error {
+	tipo, params, err := mime.ParseMediaType(cabecalho.Get("Content-Type"))
+	if err != nil {
+		// Content-Type ausente ou malformado: texto puro, como manda a RFC 2045
+		tipo, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(tipo, "multipart/") {
+		partes := multipart.NewReader(corpo, params["boundary"])
+		for {
+			parte, err := partes.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := l.ler(parte.Header, parte); err != nil {
+				return err
+			}
+		}
+	}
+
+	conteudo, err := io.ReadAll(decodificarTransferencia(cabecalho.Get("Content-Transfer-Encoding"), corpo))
+	if err != nil {
+		return err
+	}
+
+	disposicao, paramsDisposicao, _ := mime.ParseMediaType(cabecalho.Get("Content-Disposition"))
+	nome := paramsDisposicao["filename"]
+	if nome == "" {
+		nome = params["name"]
+	}
+	nome = decodificarCabecalho(nome)
+
+	// Texto do corpo: text/plain ou text/html sem nome e que não seja anexo
+	if disposicao != "attachment" && nome == "" && (tipo == "text/plain" || tipo == "text/html") {
+		texto, err := decodificarCharset(params["charset"], conteudo)
+		if err != nil {
+			return err
+		}
+		if tipo == "text/plain" {
+			l.textos = append(l.textos, texto)
+		} else {
+			l.htmls = append(l.htmls, texto)
+		}
+		return nil
+	}
+
+	if len(conteudo) == 0 {
+		return nil
+	}
+	if nome == "" {
+		nome = fmt.Sprintf("anexo-%d%s", len(l.anexos)+1, extensao(tipo))
+	}
+	l.anexos = append(l.anexos, Arquivo{Nome: nome, TipoMIME: tipo, Conteudo: conteudo})
+	return nil
+}
+
+// texto do corpo; na falta de text/plain, o HTML é convertido para texto
+func (l *leitorPartes) texto() string {
+	if len(l.textos) > 0 {
+		return normalizarTexto(strings.Join(l.textos, "\n\n"))
+	}
+	if len(l.htmls) > 0 {
+		return normalizarTexto(textoDoHTML(strings.Join(l.htmls, "\n\n")))
+	}
+	return ""
+}
+
+func decodificarTransferencia(codificacao string, corpo io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(codificacao)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &semEspacos{r: corpo})
+	case "quoted-printable":
+		return quotedprintable.NewReader(corpo)
+	default:
+		return corpo
+	}
+}
+
+// semEspacos remove quebras de linha e espaços do base64, que o decodificador padrão não aceita
+type semEspacos struct {
+	r io.Reader
+}
+
+func (s *semEspacos) Read(p []byte) (int, error) {
+	for {
+		n, err := s.r.Read(p)
+		j := 0
+		for _, b := range p[:n] {
+			if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+				p[j] = b
+				j++
+			}
+		}
+		if j > 0 || err != nil {
+			return j, err
+		}
+	}
+}
+
+// decodificarCharset converte o conteúdo para UTF-8; charsets desconhecidos são rejeitados
+func decodificarCharset(charset string, conteudo []byte) (string, error) {
+	switch strings.ToLower(strings.Trim(charset, `" `)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return strings.ToValidUTF8(string(conteudo), "�"), nil
+	case "iso-8859-1", "iso8859-1", "latin1", "iso-8859-15", "windows-1252", "cp1252":
+		// windows-1252 é um superconjunto prático do latin1; os bytes 0x80-0x9F
+		// que o latin1 não usa são mapeados pela tabela do windows-1252
+		var b strings.Builder
+		for _, c := range conteudo {
+			if c >= 0x80 && c <= 0x9F {
+				if r, ok := windows1252[c]; ok {
+					b.WriteRune(r)
+					continue
+				}
+			}
+			b.WriteRune(rune(c))
+		}
+		return b.String(), nil
+	default:
+		return "", fmt.Errorf("charset não suportado: %s", charset)
+	}
+}
+
+var windows1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž',
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+func decodificarCabecalho(valor string) string {
+	decodificado, err := decodificador.DecodeHeader(valor)
+	if err != nil {
+		return strings.TrimSpace(valor)
+	}
+	return strings.TrimSpace(decodificado)
+}
+
+func lerEndereco(valor string) (*mail.Address, error) {
+	parser := mail.AddressParser{WordDecoder: decodificador}
+	return parser.Parse(valor)
+}
+
+// destinatários da mensagem, sem repetição; Delivered-To e X-Original-To cobrem cópias ocultas
+func lerDestinatarios(cabecalho mail.Header) []string {
+	parser := mail.AddressParser{WordDecoder: decodificador}
+	vistos := map[string]bool{}
+	var destinatarios []string
+	for _, campo := range []string{"To", "Cc", "Delivered-To", "X-Original-To"} {
+		for _, valor := range cabecalho[campo] {
+			enderecos, err := parser.ParseList(valor)
+			if err != nil {
+				continue
+			}
+			for _, e := range enderecos {
+				endereco := strings.ToLower(e.Address)
+				if !vistos[endereco] {
+					vistos[endereco] = true
+					destinatarios = append(destinatarios, endereco)
+				}
+			}
+		}
+	}
+	return destinatarios
+}
+
+// automatica identifica respostas automáticas (RFC 3834) e mensagens em massa
+func automatica(cabecalho mail.Header) bool {
+	if v := strings.ToLower(strings.TrimSpace(cabecalho.Get("Auto-Submitted"))); v != "" && v != "no" {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(cabecalho.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	return cabecalho.Get("X-Autoreply") != "" || cabecalho.Get("X-Autorespond") != ""
+}
+
+func listarIDs(valor string) []string {
+	var ids []string
+	for _, m := range regexIDMensagem.FindAllStringSubmatch(valor, -1) {
+		ids = append(ids, m[1])
+	}
+	return ids
+}
+
+func primeiroID(valor string) string {
+	if ids := listarIDs(valor); len(ids) > 0 {
+		return ids[0]
+	}
+	return strings.Trim(strings.TrimSpace(valor), "<>")
+}
+
+func textoDoHTML(conteudo string) string {
+	conteudo = regexBlocoHTML.ReplaceAllString(conteudo, "")
+	conteudo = regexQuebraHTML.ReplaceAllString(conteudo, "\n")
+	conteudo = regexTagHTML.ReplaceAllString(conteudo, "")
+	return html.UnescapeString(conteudo)
+}
+
+func normalizarTexto(texto string) string {
+	texto = strings.ReplaceAll(texto, "\r\n", "\n")
+	linhas := strings.Split(texto, "\n")
+	for i, linha := range linhas {
+		linhas[i] = strings.TrimRight(linha, " \t\r ")
+	}
+	texto = regexLinhasVazia.ReplaceAllString(strings.Join(linhas, "\n"), "\n\n")
+	return strings.TrimSpace(texto)
+}
+
+func extensao(tipo string) string {
+	if extensoes, err := mime.ExtensionsByType(tipo); err == nil && len(extensoes) > 0 {
+		return extensoes[0]
+	}
+	if tipo == "message/rfc822" {
+		return ".eml"
+	}
+	return ".bin"
+}
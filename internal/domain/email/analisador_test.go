@@ -0,0 +1,108 @@
+package email
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mensagem multipart com texto em latin1 quoted-printable, HTML alternativo e um PDF em base64
+const mensagemMultipart = "From: =?ISO-8859-1?Q?Jo=E3o_Lima?= <Joao@Loja.com>\r\n" +
+	"To: Financeiro <financeiro@nox.com>\r\n" +
+	"Cc: gerente@loja.com\r\n" +
+	"Subject: =?UTF-8?Q?Saque_n=C3=A3o_creditado?=\r\n" +
+	"Date: Tue, 10 Mar 2026 14:30:00 -0300\r\n" +
+	"Message-ID: <CAF123@mail.loja.com>\r\n" +
+	"In-Reply-To: <anterior@nox.com>\r\n" +
+	"References: <primeira@nox.com>\r\n <anterior@nox.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"externo\"\r\n" +
+	"\r\n" +
+	"--externo\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"interno\"\r\n" +
+	"\r\n" +
+	"--interno\r\n" +
+	"Content-Type: text/plain; charset=ISO-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"O saque de ontem n=E3o foi creditado.\r\n" +
+	"Segue o comprovante.\r\n" +
+	"--interno\r\n" +
+	"Content-Type: text/html; charset=UTF-8\r\n" +
+	"\r\n" +
+	"<p>O saque de ontem <b>não</b> foi creditado.</p>\r\n" +
+	"--interno--\r\n" +
+	"--externo\r\n" +
+	"Content-Type: application/pdf; name=\"comprovante.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"comprovante.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0x\r\n" +
+	"LjQK\r\n" +
+	"--externo--\r\n"
+
+func TestAnalisar_Multipart(t *testing.T) {
+	m, err := Analisar([]byte(mensagemMultipart))
+	if err != nil {
+		t.Fatalf("Erro ao analisar: %v", err)
+	}
+
+	if m.MessageID != "CAF123@mail.loja.com" || m.Remetente != "joao@loja.com" || m.NomeRemetente != "João Lima" {
+		t.Errorf("Cabeçalhos diferentes: %q %q %q", m.MessageID, m.Remetente, m.NomeRemetente)
+	}
+	if m.Assunto != "Saque não creditado" {
+		t.Errorf("Assunto diferente: %q", m.Assunto)
+	}
+	if !reflect.DeepEqual(m.Destinatarios, []string{"financeiro@nox.com", "gerente@loja.com"}) {
+		t.Errorf("Destinatários diferentes: %v", m.Destinatarios)
+	}
+	if !reflect.DeepEqual(m.InReplyTo, []string{"anterior@nox.com"}) || !reflect.DeepEqual(m.References, []string{"primeira@nox.com", "anterior@nox.com"}) {
+		t.Errorf("Conversa diferente: %v %v", m.InReplyTo, m.References)
+	}
+	if m.Data.Year() != 2026 || m.Automatica {
+		t.Errorf("Data ou indicação de resposta automática incorretas: %v %v", m.Data, m.Automatica)
+	}
+
+	// o texto puro tem preferência sobre o HTML
+	if m.Texto != "O saque de ontem não foi creditado.\nSegue o comprovante." {
+		t.Errorf("Texto diferente: %q", m.Texto)
+	}
+	if len(m.Anexos) != 1 || m.Anexos[0].Nome != "comprovante.pdf" || m.Anexos[0].TipoMIME != "application/pdf" || string(m.Anexos[0].Conteudo) != "%PDF-1.4\n" {
+		t.Errorf("Anexos diferentes: %+v", m.Anexos)
+	}
+}
+
+func TestAnalisar_SoHTMLEAutomatica(t *testing.T) {
+	bruto := "From: ferias@loja.com\r\n" +
+		"To: suporte@nox.com\r\n" +
+		"Subject: Ausente\r\n" +
+		"Auto-Submitted: auto-replied\r\n" +
+		"Content-Type: text/html; charset=windows-1252\r\n" +
+		"\r\n" +
+		"<html><head><style>p{}</style></head><body><p>Estou de f\xe9rias \x96 volto dia 20</p><p>Att,<br>Maria &amp; equipe</p></body></html>\r\n"
+
+	m, err := Analisar([]byte(bruto))
+	if err != nil {
+		t.Fatalf("Erro ao analisar: %v", err)
+	}
+	if !m.Automatica {
+		t.Errorf("Esperava resposta automática")
+	}
+	if m.Texto != "Estou de férias – volto dia 20\nAtt,\nMaria & equipe" {
+		t.Errorf("Texto diferente: %q", m.Texto)
+	}
+	// sem Message-ID, o ID é derivado do conteúdo e estável
+	outra, _ := Analisar([]byte(bruto))
+	if m.MessageID == "" || m.MessageID != outra.MessageID {
+		t.Errorf("Message-ID derivado instável: %q %q", m.MessageID, outra.MessageID)
+	}
+}
+
+func TestAnalisar_MensagemInvalida(t *testing.T) {
+	for _, bruto := range []string{"isto não é um e-mail", "To: suporte@nox.com\r\n\r\nsem remetente\r\n"} {
+		if _, err := Analisar([]byte(bruto)); !errors.Is(err, ErrMensagemInvalida) {
+			t.Errorf("Esperava ErrMensagemInvalida para %q, recebido %v", strings.SplitN(bruto, "\r\n", 2)[0], err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Caixa é um endereço de entrada; os tickets abertos por e-mail enviado a ela recebem
+// a categoria, a subcategoria e a classificação da caixa
+type Caixa struct {
+	Endereco     string
+	Categoria    ticket.Categoria
+	Subcategoria ticket.Subcategoria
+	Urgencia     int
+	Gravidade    int
+}
+
+// Roteamento decide a caixa de cada e-mail recebido
+type Roteamento struct {
+	Caixas []Caixa
+	Padrao *Caixa // usada quando nenhum destinatário é uma caixa conhecida; sem ela, o e-mail é rejeitado
+}
+
+// CaixaPara retorna a primeira caixa entre os destinatários, na ordem em que aparecem
+// na mensagem; endereços com sufixo (suporte+qualquer@) contam como a caixa base
+func (r *Roteamento) CaixaPara(destinatarios []string) (*Caixa, bool) {
+	for _, d := range destinatarios {
+		endereco := enderecoBase(d)
+		for i := range r.Caixas {
+			if strings.EqualFold(r.Caixas[i].Endereco, endereco) {
+				return &r.Caixas[i], true
+			}
+		}
+	}
+	if r.Padrao != nil {
+		return r.Padrao, true
+	}
+	return nil, false
+}
+
+// Validar confere a categoria e a subcategoria de cada caixa contra a taxonomia, para que
+// uma configuração errada apareça ao iniciar, e não como e-mails rejeitados
+func (r *Roteamento) Validar(taxonomia ticket.Taxonomia) error {
+	caixas := r.Caixas
+	if r.Padrao != nil {
+		caixas = append(caixas[:len(caixas):len(caixas)], *r.Padrao)
+	}
+	for _, c := range caixas {
+		if err := taxonomia.ValidarClassificacao(c.Categoria, c.Subcategoria); err != nil {
+			nome := c.Endereco
+			if nome == "" {
+				nome = "padrao"
+			}
+			return fmt.Errorf("caixa %s: %w", nome, err)
+		}
+	}
+	return nil
+}
+
+// enderecoBase remove o sufixo +algo da parte local do endereço
+func enderecoBase(endereco string) string {
+	local, dominio, ok := strings.Cut(strings.ToLower(endereco), "@")
+	if !ok {
+		return endereco
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return local + "@" + dominio
+}
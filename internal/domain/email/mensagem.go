@@ -0,0 +1,62 @@
+package email
+
+import (
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+var (
+	ErrMensagemInvalida      = ticket.NovoErroValidacao("mensagem", "mensagem_invalida", "mensagem de e-mail inválida")
+	ErrRecebidoNaoEncontrado = ticket.NovoErroNaoEncontrado("email_nao_encontrado", "e-mail recebido não encontrado")
+	ErrCaixaDesconhecida     = ticket.NovoErroValidacao("destinatario", "caixa_desconhecida", "nenhum destinatário é uma caixa de entrada configurada")
+)
+
+// Mensagem é um e-mail recebido, já decodificado
+type Mensagem struct {
+	MessageID     string   // sem os sinais < >
+	InReplyTo     []string // IDs das mensagens respondidas
+	References    []string // IDs das mensagens anteriores da conversa
+	Remetente     string   // endereço, em minúsculas
+	NomeRemetente string
+	Destinatarios []string // endereços de To, Cc, Delivered-To e X-Original-To, em minúsculas
+	Assunto       string
+	Texto         string // corpo em texto puro; mensagens só em HTML são convertidas
+	Data          time.Time
+	Automatica    bool // resposta automática (Auto-Submitted, Precedence), que não deve virar ticket
+	Anexos        []Arquivo
+}
+
+// Arquivo é um anexo da mensagem
+type Arquivo struct {
+	Nome     string
+	TipoMIME string
+	Conteudo []byte
+}
+
+// Resultado é o que foi feito com um e-mail recebido
+type Resultado string
+
+const (
+	ResultadoTicketCriado Resultado = "ticket_criado"
+	ResultadoObservacao   Resultado = "observacao_adicionada"
+	ResultadoRejeitado    Resultado = "rejeitado"
+
+	// ResultadoEmProcessamento reserva o Message-ID antes de criar o ticket ou a observação. Uma mensagem
+	// que fica nele teve o processamento interrompido depois de criar algo e não é processada de novo
+	ResultadoEmProcessamento Resultado = "em_processamento"
+)
+
+// Recebido registra um e-mail já processado, para não processá-lo de novo e para
+// encontrar o ticket das respostas pelo In-Reply-To
+type Recebido struct {
+	MessageID       string
+	Remetente       string
+	Assunto         string
+	Resultado       Resultado
+	TicketID        string
+	ObservacaoID    string
+	Motivo          string // por que o e-mail foi rejeitado
+	Anexos          int
+	DataRecebimento time.Time
+}
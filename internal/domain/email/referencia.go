@@ -0,0 +1,88 @@
+package email
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const padraoUUID = `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`
+
+var (
+	regexReferencia   = regexp.MustCompile(`\[#(` + padraoUUID + `)\]`)
+	regexIDDoTicket   = regexp.MustCompile(`^ticket\.(` + padraoUUID + `)\.`)
+	regexPrefixoRe    = regexp.MustCompile(`(?i)^\s*(re|res|fw|fwd|enc|tr|aw|wg)\s*(\[\d+\])?\s*:\s*`)
+	regexCitacao      = regexp.MustCompile(`(?i)^\s*(em .+ escreveu|on .+ wrote|le .+ a écrit|el .+ escribió):?\s*$`)
+	regexOriginal     = regexp.MustCompile(`(?i)^\s*-{2,}\s*(mensagem original|original message|mensagem encaminhada|forwarded message)\s*-{2,}\s*$`)
+	regexSeparadorOut = regexp.MustCompile(`^_{20,}\s*$`)
+)
+
+// ReferenciaAssunto é a marca do ticket incluída no assunto dos e-mails enviados, como [#<id>]
+func ReferenciaAssunto(ticketID string) string {
+	return "[#" + ticketID + "]"
+}
+
+// TicketDoAssunto extrai o ticket da marca [#<id>] no assunto
+func TicketDoAssunto(assunto string) (string, bool) {
+	m := regexReferencia.FindStringSubmatch(assunto)
+	if m == nil {
+		return "", false
+	}
+	return strings.ToLower(m[1]), true
+}
+
+// IDMensagem gera o Message-ID de um e-mail enviado sobre um ticket; o ID do ticket fica
+// no próprio Message-ID, para que respostas sejam associadas pelo In-Reply-To mesmo que
+// o cliente de e-mail altere o assunto
+func IDMensagem(ticketID, dominio string) string {
+	if ticketID == "" {
+		return uuid.New().String() + "@" + dominio
+	}
+	return "ticket." + ticketID + "." + uuid.New().String() + "@" + dominio
+}
+
+// TicketDoIDMensagem extrai o ticket de um Message-ID gerado por IDMensagem
+func TicketDoIDMensagem(id string) (string, bool) {
+	m := regexIDDoTicket.FindStringSubmatch(strings.Trim(id, "<>"))
+	if m == nil {
+		return "", false
+	}
+	return strings.ToLower(m[1]), true
+}
+
+// TituloDoAssunto limpa o assunto para servir de título: sem prefixos de resposta ou
+// encaminhamento e sem a marca do ticket
+func TituloDoAssunto(assunto string) string {
+	titulo := regexReferencia.ReplaceAllString(assunto, "")
+	for {
+		limpo := regexPrefixoRe.ReplaceAllString(titulo, "")
+		if limpo == titulo {
+			break
+		}
+		titulo = limpo
+	}
+	return strings.Join(strings.Fields(titulo), " ")
+}
+
+// TextoDaResposta remove do corpo de uma resposta o trecho citado da mensagem anterior
+// e a assinatura, mantendo só o que foi escrito agora
+func TextoDaResposta(texto string) string {
+	var linhas []string
+	for _, linha := range strings.Split(texto, "\n") {
+		// Cabeçalho da citação ("Em ..., Fulano escreveu:"), mensagem original ou
+		// separador do Outlook: daqui para baixo é tudo texto anterior
+		if regexCitacao.MatchString(linha) || regexOriginal.MatchString(linha) || regexSeparadorOut.MatchString(linha) {
+			break
+		}
+		// Delimitador de assinatura (RFC 3676)
+		if linha == "-- " || linha == "--" {
+			break
+		}
+		if strings.HasPrefix(strings.TrimSpace(linha), ">") {
+			continue
+		}
+		linhas = append(linhas, linha)
+	}
+	return normalizarTexto(strings.Join(linhas, "\n"))
+}
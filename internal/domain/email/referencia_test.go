@@ -0,0 +1,66 @@
+package email
+
+import "testing"
+
+const ticketTeste = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+func TestReferencias(t *testing.T) {
+	// marca no assunto
+	assunto := "RE: [NOX Tickets] Nova observação: Saque não creditado " + ReferenciaAssunto(ticketTeste)
+	if id, ok := TicketDoAssunto(assunto); !ok || id != ticketTeste {
+		t.Errorf("Esperava o ticket no assunto, recebido %q", id)
+	}
+	if _, ok := TicketDoAssunto("Saque não creditado [#123]"); ok {
+		t.Errorf("Não esperava ticket em referência inválida")
+	}
+
+	// Message-ID das notificações
+	id := IDMensagem(ticketTeste, "smtp.nox.com")
+	if ticketID, ok := TicketDoIDMensagem("<" + id + ">"); !ok || ticketID != ticketTeste {
+		t.Errorf("Esperava o ticket no Message-ID %q, recebido %q", id, ticketID)
+	}
+	if _, ok := TicketDoIDMensagem(IDMensagem("", "smtp.nox.com")); ok {
+		t.Errorf("Não esperava ticket em Message-ID sem ticket")
+	}
+
+	// título sem prefixos de resposta e sem a marca
+	if titulo := TituloDoAssunto("Re: RES: Fwd: Saque  não creditado " + ReferenciaAssunto(ticketTeste)); titulo != "Saque não creditado" {
+		t.Errorf("Título diferente: %q", titulo)
+	}
+}
+
+func TestTextoDaResposta(t *testing.T) {
+	casos := map[string]string{
+		"Já enviei o comprovante.\n\nEm ter., 10 de mar. de 2026 às 14:30, NOX Tickets <nox@nox.com> escreveu:\n> Olá, João.\n> Precisamos do comprovante.": "Já enviei o comprovante.",
+		"Done, thanks.\n\nOn Tue, Mar 10, 2026 at 2:30 PM NOX <nox@nox.com> wrote:\n> Hi":                                                                   "Done, thanks.",
+		"Segue em anexo.\n-- \nJoão Lima\nLoja XPTO":                                                                                                        "Segue em anexo.",
+		"Ok\n\n-----Original Message-----\nFrom: NOX":                                                                                                       "Ok",
+		"> citação no topo\nresposta embaixo":                                                                                                               "resposta embaixo",
+	}
+	for texto, esperado := range casos {
+		if obtido := TextoDaResposta(texto); obtido != esperado {
+			t.Errorf("TextoDaResposta(%q) = %q, esperava %q", texto, obtido, esperado)
+		}
+	}
+}
+
+func TestRoteamento_CaixaPara(t *testing.T) {
+	r := &Roteamento{Caixas: []Caixa{
+		{Endereco: "financeiro@nox.com", Categoria: "financeiro"},
+		{Endereco: "ti@nox.com", Categoria: "ti"},
+	}}
+
+	// a primeira caixa entre os destinatários, aceitando sufixo +algo
+	if c, ok := r.CaixaPara([]string{"gerente@loja.com", "ti+urgente@nox.com", "financeiro@nox.com"}); !ok || c.Categoria != "ti" {
+		t.Errorf("Esperava a caixa de TI, recebido %+v", c)
+	}
+
+	// sem caixa conhecida, usa a padrão; sem padrão, nenhuma
+	if _, ok := r.CaixaPara([]string{"outro@nox.com"}); ok {
+		t.Errorf("Não esperava caixa sem padrão configurado")
+	}
+	r.Padrao = &Caixa{Categoria: "operacional"}
+	if c, ok := r.CaixaPara([]string{"outro@nox.com"}); !ok || c.Categoria != "operacional" {
+		t.Errorf("Esperava a caixa padrão, recebido %+v", c)
+	}
+}
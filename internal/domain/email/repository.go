@@ -0,0 +1,21 @@
+package email
+
+type Repository interface {
+	// Buscar um e-mail já processado pelo Message-ID; falha com ErrRecebidoNaoEncontrado
+	BuscarRecebido(messageID string) (*Recebido, error)
+
+	// Ticket do primeiro e-mail processado entre os IDs informados, ou vazio se nenhum gerou ticket
+	TicketDasMensagens(messageIDs []string) (string, error)
+
+	// Reservar o Message-ID antes de processar o e-mail, com o resultado em processamento; retorna falso
+	// se o e-mail já estava registrado ou reservado
+	Reservar(r *Recebido) (bool, error)
+
+	// Liberar a reserva de um e-mail cujo processamento falhou antes de criar qualquer coisa,
+	// para que a reentrega o processe
+	Liberar(messageID string) error
+
+	// Registrar o resultado do processamento de um e-mail, concluindo a reserva; registrar de novo
+	// um Message-ID já concluído não tem efeito
+	Registrar(r *Recebido) error
+}
@@ -0,0 +1,92 @@
+package escalonamento
+
+import (
+	"strconv"
+	"time"
+
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// Reatribuidor move o ticket para outra fila e escolhe o novo responsável
+type Reatribuidor interface {
+	Reatribuir(t *ticket.Ticket, fila string) (*fila.Decisao, error)
+}
+
+// Resultado reúne as regras aplicadas a um ticket
+type Resultado struct {
+	Regras []string
+}
+
+// Motor avalia as regras de escalonamento e aplica as ações nos tickets
+type Motor struct {
+	regras       []Regra
+	reatribuidor Reatribuidor
+}
+
+// NovoMotor cria o motor com as regras informadas
+func NovoMotor(regras []Regra, reatribuidor Reatribuidor) *Motor {
+	return &Motor{regras: regras, reatribuidor: reatribuidor}
+}
+
+// Filtros retorna, para cada regra, a consulta dos tickets em que ela pode estar vencida
+func (m *Motor) Filtros(agora time.Time) []ticket.TicketFiltros {
+	filtros := make([]ticket.TicketFiltros, 0, len(m.regras))
+	for _, r := range m.regras {
+		filtros = append(filtros, r.Filtro(agora))
+	}
+	return filtros
+}
+
+// Avaliar aplica ao ticket as regras vencidas. Cada ação executada e cada regra aplicada ficam
+// registradas nas modificações do ticket, feitas pelo usuário sistema; ao gravar o ticket, cada
+// regra gera um evento ticket.escalonado, que leva as notificações aos assinantes pelo outbox.
+func (m *Motor) Avaliar(t *ticket.Ticket, agora time.Time) (*Resultado, error) {
+	resultado := &Resultado{}
+	for _, r := range m.regras {
+		if !r.vencida(t, agora) {
+			continue
+		}
+		for _, a := range r.Acoes {
+			if err := m.executar(t, a); err != nil {
+				return nil, err
+			}
+		}
+		if err := t.RegistrarEscalonamento(r.Nome, usuario.IDSistema); err != nil {
+			return nil, err
+		}
+		resultado.Regras = append(resultado.Regras, r.Nome)
+	}
+	return resultado, nil
+}
+
+// executar aplica a ação e a registra no ticket; uma ação sem efeito (urgência já no nível) não é registrada
+func (m *Motor) executar(t *ticket.Ticket, a Acao) error {
+	switch a.Tipo {
+	case AcaoAumentarUrgencia:
+		urgencia := a.Urgencia
+		if urgencia == 0 {
+			urgencia = t.Urgencia + 1
+		}
+		if urgencia <= t.Urgencia || urgencia > 5 {
+			return nil
+		}
+		anterior := t.Urgencia
+		if err := t.SetUrgencia(urgencia, usuario.IDSistema); err != nil {
+			return err
+		}
+		return t.RegistrarAcaoEscalonamento(string(a.Tipo), strconv.Itoa(anterior), strconv.Itoa(urgencia), usuario.IDSistema)
+
+	case AcaoReatribuirFila:
+		anterior := t.FilaID
+		if _, err := m.reatribuidor.Reatribuir(t, a.Fila); err != nil {
+			return err
+		}
+		return t.RegistrarAcaoEscalonamento(string(a.Tipo), anterior, t.FilaID, usuario.IDSistema)
+
+	case AcaoNotificar:
+		return t.RegistrarAcaoEscalonamento(string(a.Tipo), "", a.Mensagem, usuario.IDSistema)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package escalonamento
+
+import (
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// reatribuidorTeste entrega o ticket ao supervisor da fila pedida
+type reatribuidorTeste struct{ filas []string }
+
+func (r *reatribuidorTeste) Reatribuir(t *ticket.Ticket, nome string) (*fila.Decisao, error) {
+	r.filas = append(r.filas, nome)
+	if err := t.Reatribuir("supervisor", usuario.IDSistema); err != nil {
+		return nil, err
+	}
+	return &fila.Decisao{Responsavel: "supervisor"}, nil
+}
+
+func novoTicketTeste(t *testing.T, urgencia int) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Ticket de Teste", "Descrição do ticket de teste", ticket.CategoriaTI, ticket.SubcategoriaBug, "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket de teste: %v", err)
+	}
+	tk.Urgencia = urgencia
+	return tk
+}
+
+func TestMotor_AbertoUrgente(t *testing.T) {
+	regra := Regra{
+		Nome:        "aberto_urgente",
+		Status:      []ticket.Status{ticket.StatusAberto},
+		UrgenciaMin: 4,
+		Apos:        30 * time.Minute,
+		Desde:       DesdeStatus,
+		Acoes: []Acao{
+			{Tipo: AcaoAumentarUrgencia},
+			{Tipo: AcaoNotificar, Mensagem: "Ticket urgente sem atendimento"},
+		},
+	}
+	if err := regra.Validar(); err != nil {
+		t.Fatalf("Regra inválida: %v", err)
+	}
+	motor := NovoMotor([]Regra{regra}, &reatribuidorTeste{})
+
+	// abaixo da urgência mínima a regra não se aplica
+	baixa := novoTicketTeste(t, 3)
+	resultado, err := motor.Avaliar(baixa, time.Now().Add(time.Hour))
+	if err != nil || len(resultado.Regras) != 0 {
+		t.Errorf("Não esperava escalonamento, recebido %+v (%v)", resultado, err)
+	}
+
+	tk := novoTicketTeste(t, 4)
+	// antes do limite nada acontece
+	if resultado, _ := motor.Avaliar(tk, time.Now().Add(10*time.Minute)); len(resultado.Regras) != 0 {
+		t.Errorf("Não esperava escalonamento antes do limite, recebido %+v", resultado.Regras)
+	}
+
+	agora := time.Now().Add(time.Hour)
+	resultado, err = motor.Avaliar(tk, agora)
+	if err != nil {
+		t.Fatalf("Erro ao avaliar ticket: %v", err)
+	}
+	if tk.Urgencia != 5 || len(resultado.Regras) != 1 {
+		t.Errorf("Esperava urgência 5 e uma regra aplicada, recebido %d e %+v", tk.Urgencia, resultado.Regras)
+	}
+
+	campos := map[string]string{}
+	for _, m := range tk.Modificacoes {
+		if m.UsuarioID != usuario.IDSistema {
+			t.Errorf("Esperava modificação do sistema, recebido %s", m.UsuarioID)
+		}
+		campos[m.CampoModificado] = m.ValorNovo
+	}
+	// cada ação fica registrada antes da regra
+	if campos["urgencia"] != "5" || campos["escalonamento.aumentar_urgencia"] != "5" ||
+		campos["escalonamento.notificar"] != "Ticket urgente sem atendimento" || campos["escalonamento"] != "aberto_urgente" {
+		t.Errorf("Modificações incompletas: %+v", tk.Modificacoes)
+	}
+	if ultima := tk.Modificacoes[len(tk.Modificacoes)-1]; ultima.CampoModificado != "escalonamento" {
+		t.Errorf("Esperava a regra depois das ações, recebido %+v", ultima)
+	}
+
+	// a regra é aplicada uma única vez enquanto o ticket continua aberto
+	if resultado, _ := motor.Avaliar(tk, agora.Add(time.Hour)); len(resultado.Regras) != 0 {
+		t.Errorf("Não esperava novo escalonamento, recebido %+v", resultado.Regras)
+	}
+}
+
+func TestMotor_EmCursoSemObservacao(t *testing.T) {
+	reatribuidor := &reatribuidorTeste{}
+	motor := NovoMotor([]Regra{{
+		Nome:   "em_curso_parado",
+		Status: []ticket.Status{ticket.StatusEmCurso},
+		Apos:   24 * time.Hour,
+		Desde:  DesdeObservacao,
+		Acoes:  []Acao{{Tipo: AcaoReatribuirFila, Fila: "Supervisão"}},
+	}}, reatribuidor)
+
+	tk := novoTicketTeste(t, 2)
+	if err := ticket.MaquinaDeEstadosPadrao().Aplicar(tk, ticket.StatusEmCurso, ticket.ContextoTransicao{UsuarioID: "analista", Responsavel: "analista"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	if err := tk.AdicionarObservacao("Aguardando retorno do cliente", "analista"); err != nil {
+		t.Fatalf("Erro ao adicionar observação: %v", err)
+	}
+
+	// a observação recente adia o escalonamento
+	ultima := tk.Observacoes[len(tk.Observacoes)-1].DataCriacao
+	if resultado, _ := motor.Avaliar(tk, ultima.Add(23*time.Hour)); len(resultado.Regras) != 0 {
+		t.Errorf("Não esperava escalonamento, recebido %+v", resultado.Regras)
+	}
+
+	resultado, err := motor.Avaliar(tk, ultima.Add(25*time.Hour))
+	if err != nil {
+		t.Fatalf("Erro ao avaliar ticket: %v", err)
+	}
+	if len(resultado.Regras) != 1 || tk.Responsavel != "supervisor" || len(reatribuidor.filas) != 1 || reatribuidor.filas[0] != "Supervisão" {
+		t.Errorf("Esperava reatribuição à fila de supervisão, recebido %q %+v", tk.Responsavel, reatribuidor.filas)
+	}
+	if filtros := motor.Filtros(ultima); len(filtros) != 1 || len(filtros[0].Status) != 1 || filtros[0].Status[0] != ticket.StatusEmCurso ||
+		!filtros[0].ParadosAntesDe.Equal(ultima.Add(-24*time.Hour)) || !filtros[0].ParadosSemObservacao || filtros[0].SemEscalonamento != "em_curso_parado" {
+		t.Errorf("Filtro incorreto: %+v", filtros)
+	}
+}
+
+func TestRegra_Validar(t *testing.T) {
+	regra := Regra{Nome: "r", Status: []ticket.Status{ticket.StatusAberto}, Apos: time.Minute, Desde: DesdeStatus, Acoes: []Acao{{Tipo: "apagar"}}}
+	if err := regra.Validar(); err == nil {
+		t.Error("Esperava erro para ação inválida")
+	}
+}
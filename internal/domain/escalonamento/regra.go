@@ -0,0 +1,147 @@
+package escalonamento
+
+import (
+	"fmt"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Referencia é o instante a partir do qual o tempo de uma regra é contado
+type Referencia string
+
+const (
+	DesdeStatus     Referencia = "status"     // entrada no status atual
+	DesdeObservacao Referencia = "observacao" // última observação, ou a entrada no status se for mais recente
+)
+
+// TipoAcao é o que acontece quando a regra é disparada
+type TipoAcao string
+
+const (
+	AcaoAumentarUrgencia TipoAcao = "aumentar_urgencia"
+	AcaoReatribuirFila   TipoAcao = "reatribuir_fila"
+	AcaoNotificar        TipoAcao = "notificar"
+)
+
+// Acao é um passo do escalonamento
+type Acao struct {
+	Tipo     TipoAcao
+	Urgencia int    // aumentar_urgencia: urgência final; zero aumenta um nível
+	Fila     string // reatribuir_fila: ID ou nome da fila de destino
+	Mensagem string // notificar: texto da notificação
+}
+
+// Regra dispara suas ações nos tickets parados em um dos status por mais tempo que o limite.
+// Cada regra é aplicada no máximo uma vez por período: voltar ao status ou registrar uma nova
+// observação (nas regras contadas desde a observação) reinicia a contagem.
+type Regra struct {
+	Nome        string
+	Status      []ticket.Status
+	UrgenciaMin int // zero atende qualquer urgência
+	Apos        time.Duration
+	Desde       Referencia
+	Acoes       []Acao
+}
+
+// Validar confere se a regra está completa
+func (r Regra) Validar() error {
+	if r.Nome == "" {
+		return fmt.Errorf("regra sem nome")
+	}
+	if len(r.Status) == 0 {
+		return fmt.Errorf("regra %s: informe ao menos um status", r.Nome)
+	}
+	if r.Apos <= 0 {
+		return fmt.Errorf("regra %s: o tempo limite deve ser positivo", r.Nome)
+	}
+	if r.Desde != DesdeStatus && r.Desde != DesdeObservacao {
+		return fmt.Errorf("regra %s: referência de tempo inválida %q", r.Nome, r.Desde)
+	}
+	if len(r.Acoes) == 0 {
+		return fmt.Errorf("regra %s: informe ao menos uma ação", r.Nome)
+	}
+	for _, a := range r.Acoes {
+		switch a.Tipo {
+		case AcaoAumentarUrgencia:
+			if a.Urgencia < 0 || a.Urgencia > 5 {
+				return fmt.Errorf("regra %s: urgência deve ser de 1 a 5", r.Nome)
+			}
+		case AcaoReatribuirFila:
+			if a.Fila == "" {
+				return fmt.Errorf("regra %s: informe a fila de destino", r.Nome)
+			}
+		case AcaoNotificar:
+		default:
+			return fmt.Errorf("regra %s: ação inválida %q", r.Nome, a.Tipo)
+		}
+	}
+	return nil
+}
+
+// Filtro descreve para o repositório os tickets em que a regra pode estar vencida: status, urgência
+// mínima, tempo parado e regra ainda não aplicada, as mesmas condições de vencida
+func (r Regra) Filtro(agora time.Time) ticket.TicketFiltros {
+	filtros := ticket.TicketFiltros{
+		Status:               r.Status,
+		ParadosAntesDe:       agora.Add(-r.Apos),
+		ParadosSemObservacao: r.Desde == DesdeObservacao,
+		SemEscalonamento:     r.Nome,
+	}
+	if r.UrgenciaMin > 0 {
+		urgenciaMin := r.UrgenciaMin
+		filtros.UrgenciaMin = &urgenciaMin
+	}
+	return filtros
+}
+
+// inicio retorna o instante a partir do qual o tempo da regra é contado no ticket
+func (r Regra) inicio(t *ticket.Ticket) time.Time {
+	inicio := entradaNoStatus(t)
+	if r.Desde == DesdeObservacao {
+		for _, o := range t.Observacoes {
+			if o.DataCriacao.After(inicio) {
+				inicio = o.DataCriacao
+			}
+		}
+	}
+	return inicio
+}
+
+// vencida indica se o ticket está há mais tempo que o limite na situação descrita pela regra
+// e se a regra ainda não foi aplicada desde o início da contagem
+func (r Regra) vencida(t *ticket.Ticket, agora time.Time) bool {
+	if t.Urgencia < r.UrgenciaMin || !contem(r.Status, t.Status) {
+		return false
+	}
+	inicio := r.inicio(t)
+	if agora.Sub(inicio) < r.Apos {
+		return false
+	}
+	for _, m := range t.Modificacoes {
+		if m.CampoModificado == "escalonamento" && m.ValorNovo == r.Nome && !m.DataModificacao.Before(inicio) {
+			return false
+		}
+	}
+	return true
+}
+
+// entradaNoStatus é a data da última mudança para o status atual, ou a abertura do ticket
+func entradaNoStatus(t *ticket.Ticket) time.Time {
+	entrada := t.DataAbertura
+	for _, m := range t.Modificacoes {
+		if m.CampoModificado == "status" && m.ValorNovo == string(t.Status) && m.DataModificacao.After(entrada) {
+			entrada = m.DataModificacao
+		}
+	}
+	return entrada
+}
+
+func contem(lista []ticket.Status, status ticket.Status) bool {
+	for _, s := range lista {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
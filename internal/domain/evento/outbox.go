@@ -0,0 +1,30 @@
+package evento
+
+import (
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Pendente é um evento gravado no outbox que ainda não foi publicado
+type Pendente struct {
+	Evento     ticket.Evento
+	Tentativas int
+}
+
+// Outbox é a tabela de eventos gravados junto com as alterações dos tickets.
+// Os eventos são gravados pelo repositório de tickets, na mesma transação; aqui ficam apenas as operações do relay.
+type Outbox interface {
+	// Pendentes reserva e retorna os eventos não publicados cuja próxima tentativa já venceu, na ordem
+	// em que foram gravados. Eventos com um evento anterior do mesmo ticket ainda aguardando ficam de fora.
+	Pendentes(agora time.Time, limite int) ([]Pendente, error)
+
+	// MarcarPublicado registra a publicação do evento
+	MarcarPublicado(id string, agora time.Time) error
+
+	// RegistrarFalha conta a tentativa, guarda o erro e a data da próxima tentativa
+	RegistrarFalha(id string, erro string, proximaTentativa time.Time) error
+
+	// Adiar libera o evento reservado para ser tentado na data informada, sem contar tentativa
+	Adiar(id string, proximaTentativa time.Time) error
+}
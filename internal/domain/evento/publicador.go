@@ -0,0 +1,69 @@
+package evento
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Publicador entrega os eventos de domínio gravados no outbox aos interessados.
+// A entrega é "pelo menos uma vez": um evento pode ser publicado de novo se o relay
+// falhar antes de marcá-lo como publicado, então os assinantes devem ser idempotentes pelo ID do evento.
+type Publicador interface {
+	Publicar(ctx context.Context, e ticket.Evento) error
+}
+
+// Assinante trata um evento publicado
+type Assinante func(ctx context.Context, e ticket.Evento) error
+
+// Barramento é o publicador em processo: repassa cada evento, em ordem, aos assinantes do tipo
+type Barramento struct {
+	mu         sync.RWMutex
+	assinantes []assinatura
+}
+
+type assinatura struct {
+	tipos     []ticket.TipoEvento
+	assinante Assinante
+}
+
+// NovoBarramento cria um barramento sem assinantes
+func NovoBarramento() *Barramento {
+	return &Barramento{}
+}
+
+// Assinar registra o assinante para os tipos informados; sem tipos ele recebe todos os eventos
+func (b *Barramento) Assinar(assinante Assinante, tipos ...ticket.TipoEvento) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.assinantes = append(b.assinantes, assinatura{tipos: tipos, assinante: assinante})
+}
+
+// Publicar chama todos os assinantes do tipo do evento e junta os erros.
+// Como o evento é publicado de novo quando há erro, quem já o tratou recebe uma repetição.
+func (b *Barramento) Publicar(ctx context.Context, e ticket.Evento) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var erros []error
+	for _, a := range b.assinantes {
+		if len(a.tipos) > 0 && !contem(a.tipos, e.Tipo) {
+			continue
+		}
+		if err := a.assinante(ctx, e); err != nil {
+			erros = append(erros, err)
+		}
+	}
+	return errors.Join(erros...)
+}
+
+func contem(tipos []ticket.TipoEvento, tipo ticket.TipoEvento) bool {
+	for _, t := range tipos {
+		if t == tipo {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package evento
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	atrasoInicial = 5 * time.Second
+	atrasoMaximo  = 10 * time.Minute
+)
+
+// ResultadoRelay resume uma rodada do relay
+type ResultadoRelay struct {
+	Publicados int
+	Falhas     []error
+}
+
+// Relay lê os eventos pendentes do outbox e os publica
+type Relay struct {
+	outbox     Outbox
+	publicador Publicador
+}
+
+// NovoRelay cria o relay do outbox para o publicador
+func NovoRelay(outbox Outbox, publicador Publicador) *Relay {
+	return &Relay{outbox: outbox, publicador: publicador}
+}
+
+// Repassar publica um lote de eventos pendentes. Se um evento falha, ele é reagendado com espera
+// exponencial e os eventos seguintes do mesmo ticket ficam para depois, para não saírem fora de ordem.
+func (r *Relay) Repassar(ctx context.Context, lote int) (*ResultadoRelay, error) {
+	agora := time.Now()
+	pendentes, err := r.outbox.Pendentes(agora, lote)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := &ResultadoRelay{}
+	atrasados := map[string]time.Time{} // próxima tentativa do evento que falhou, por ticket
+	for _, p := range pendentes {
+		if err := ctx.Err(); err != nil {
+			return resultado, err
+		}
+		e := p.Evento
+
+		// um evento anterior do ticket falhou: este espera por ele
+		if proxima, ok := atrasados[e.Ticket.ID]; ok {
+			if err := r.outbox.Adiar(e.ID, proxima); err != nil {
+				return resultado, err
+			}
+			continue
+		}
+
+		if err := r.publicador.Publicar(ctx, e); err != nil {
+			proxima := time.Now().Add(Atraso(p.Tentativas + 1))
+			atrasados[e.Ticket.ID] = proxima
+			resultado.Falhas = append(resultado.Falhas, fmt.Errorf("evento %s (%s): %w", e.ID, e.Tipo, err))
+			if err := r.outbox.RegistrarFalha(e.ID, err.Error(), proxima); err != nil {
+				return resultado, err
+			}
+			continue
+		}
+
+		if err := r.outbox.MarcarPublicado(e.ID, time.Now()); err != nil {
+			return resultado, err
+		}
+		resultado.Publicados++
+	}
+	return resultado, nil
+}
+
+// Atraso é a espera antes da tentativa seguinte à n-ésima falha: 5s, 10s, 20s... até 10min
+func Atraso(n int) time.Duration {
+	atraso := atrasoInicial
+	for i := 1; i < n && atraso < atrasoMaximo; i++ {
+		atraso *= 2
+	}
+	return min(atraso, atrasoMaximo)
+}
@@ -0,0 +1,125 @@
+package evento
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// outboxMemoria guarda os eventos em memória, na ordem de gravação
+type outboxMemoria struct {
+	registros []*registroMemoria
+}
+
+type registroMemoria struct {
+	evento     ticket.Evento
+	tentativas int
+	proxima    time.Time
+	publicado  bool
+}
+
+func (o *outboxMemoria) gravar(eventos ...ticket.Evento) {
+	for _, e := range eventos {
+		o.registros = append(o.registros, &registroMemoria{evento: e})
+	}
+}
+
+func (o *outboxMemoria) buscar(id string) *registroMemoria {
+	for _, r := range o.registros {
+		if r.evento.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+func (o *outboxMemoria) Pendentes(agora time.Time, limite int) ([]Pendente, error) {
+	pendentes := []Pendente{}
+	for _, r := range o.registros {
+		if !r.publicado && !r.proxima.After(agora) && len(pendentes) < limite {
+			pendentes = append(pendentes, Pendente{Evento: r.evento, Tentativas: r.tentativas})
+		}
+	}
+	return pendentes, nil
+}
+
+func (o *outboxMemoria) MarcarPublicado(id string, _ time.Time) error {
+	o.buscar(id).publicado = true
+	return nil
+}
+
+func (o *outboxMemoria) RegistrarFalha(id string, _ string, proxima time.Time) error {
+	r := o.buscar(id)
+	r.tentativas++
+	r.proxima = proxima
+	return nil
+}
+
+func (o *outboxMemoria) Adiar(id string, proxima time.Time) error {
+	o.buscar(id).proxima = proxima
+	return nil
+}
+
+func novoEvento(id, ticketID string, tipo ticket.TipoEvento) ticket.Evento {
+	return ticket.Evento{ID: id, Tipo: tipo, Ticket: ticket.ResumoTicket{ID: ticketID}}
+}
+
+func TestRelay_PublicaEmOrdemERetentaFalhas(t *testing.T) {
+	outbox := &outboxMemoria{}
+	outbox.gravar(
+		novoEvento("a1", "ticket-a", ticket.EventoTicketCriado),
+		novoEvento("a2", "ticket-a", ticket.EventoObservacaoAdicionada),
+		novoEvento("b1", "ticket-b", ticket.EventoTicketCriado),
+	)
+
+	recebidos := []string{}
+	falhar := true
+	barramento := NovoBarramento()
+	barramento.Assinar(func(_ context.Context, e ticket.Evento) error {
+		if e.ID == "a1" && falhar {
+			falhar = false
+			return errors.New("destino indisponível")
+		}
+		recebidos = append(recebidos, e.ID)
+		return nil
+	})
+	somenteCriados := 0
+	barramento.Assinar(func(context.Context, ticket.Evento) error { somenteCriados++; return nil }, ticket.EventoTicketCriado)
+
+	relay := NovoRelay(outbox, barramento)
+
+	// a1 falha: a2 espera por ele e b1, de outro ticket, segue
+	resultado, err := relay.Repassar(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Erro no relay: %v", err)
+	}
+	if resultado.Publicados != 1 || len(resultado.Falhas) != 1 || len(recebidos) != 1 || recebidos[0] != "b1" {
+		t.Fatalf("Esperava apenas b1 publicado, recebido %+v (%v)", recebidos, resultado.Falhas)
+	}
+	a1, a2 := outbox.buscar("a1"), outbox.buscar("a2")
+	if a1.tentativas != 1 || a2.tentativas != 0 || !a2.proxima.Equal(a1.proxima) {
+		t.Errorf("Esperava a2 adiado para a próxima tentativa de a1, recebido %+v e %+v", a1, a2)
+	}
+
+	// na próxima tentativa os dois saem, em ordem
+	a1.proxima, a2.proxima = time.Time{}, time.Time{}
+	if _, err := relay.Repassar(context.Background(), 10); err != nil {
+		t.Fatalf("Erro no relay: %v", err)
+	}
+	if len(recebidos) != 3 || recebidos[1] != "a1" || recebidos[2] != "a2" {
+		t.Errorf("Esperava a1 e a2 em ordem, recebido %+v", recebidos)
+	}
+	// o assinante filtrado não recebe a observação, e recebe a1 de novo na repetição (pelo menos uma vez)
+	if somenteCriados != 3 {
+		t.Errorf("Esperava 3 entregas de ticket.criado para o assinante filtrado, recebido %d", somenteCriados)
+	}
+}
+
+func TestAtraso(t *testing.T) {
+	if Atraso(1) != 5*time.Second || Atraso(3) != 20*time.Second || Atraso(30) != 10*time.Minute {
+		t.Errorf("Esperas incorretas: %v %v %v", Atraso(1), Atraso(3), Atraso(30))
+	}
+}
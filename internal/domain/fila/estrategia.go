@@ -0,0 +1,99 @@
+package fila
+
+import (
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Candidato é um membro ativo da equipe da fila, com a carga atual de trabalho
+type Candidato struct {
+	UsuarioID        string
+	Habilidades      []string
+	TicketsAbertos   int        // tickets sob sua responsabilidade ainda não finalizados nem cancelados
+	UltimaAtribuicao *time.Time // início do último atendimento que recebeu na fila
+}
+
+// Seletor escolhe o responsável de um ticket entre os candidatos
+type Seletor interface {
+	Escolher(t *ticket.Ticket, candidatos []Candidato) (string, bool)
+}
+
+// seletores implementa cada estratégia
+var seletores = map[Estrategia]Seletor{
+	EstrategiaRoundRobin:   RoundRobin{},
+	EstrategiaMenosTickets: MenosTickets{},
+	EstrategiaHabilidade:   PorHabilidade{},
+}
+
+// RoundRobin escolhe quem está há mais tempo sem receber ticket da fila; quem nunca recebeu vem primeiro
+type RoundRobin struct{}
+
+func (RoundRobin) Escolher(_ *ticket.Ticket, candidatos []Candidato) (string, bool) {
+	return melhor(candidatos, esperaMais)
+}
+
+// MenosTickets escolhe quem tem menos tickets em aberto; no empate, reveza como o RoundRobin
+type MenosTickets struct{}
+
+func (MenosTickets) Escolher(_ *ticket.Ticket, candidatos []Candidato) (string, bool) {
+	return melhor(candidatos, func(a, b Candidato) bool {
+		if a.TicketsAbertos != b.TicketsAbertos {
+			return a.TicketsAbertos < b.TicketsAbertos
+		}
+		return esperaMais(a, b)
+	})
+}
+
+// PorHabilidade escolhe, entre quem tem a subcategoria do ticket como habilidade (ou, se ninguém tiver,
+// a categoria), quem tem menos tickets em aberto. Sem ninguém habilitado o ticket fica na fila.
+type PorHabilidade struct{}
+
+func (PorHabilidade) Escolher(t *ticket.Ticket, candidatos []Candidato) (string, bool) {
+	for _, habilidade := range []string{string(t.Subcategoria), string(t.Categoria)} {
+		habilitados := []Candidato{}
+		for _, c := range candidatos {
+			if temHabilidade(c, habilidade) {
+				habilitados = append(habilitados, c)
+			}
+		}
+		if len(habilitados) > 0 {
+			return MenosTickets{}.Escolher(t, habilitados)
+		}
+	}
+	return "", false
+}
+
+// esperaMais indica se a está há mais tempo sem receber ticket do que b
+func esperaMais(a, b Candidato) bool {
+	if a.UltimaAtribuicao == nil || b.UltimaAtribuicao == nil {
+		return a.UltimaAtribuicao == nil && b.UltimaAtribuicao != nil
+	}
+	return a.UltimaAtribuicao.Before(*b.UltimaAtribuicao)
+}
+
+func temHabilidade(c Candidato, habilidade string) bool {
+	if habilidade == "" {
+		return false
+	}
+	for _, h := range c.Habilidades {
+		if h == habilidade {
+			return true
+		}
+	}
+	return false
+}
+
+// melhor retorna o candidato que vem antes pelo critério; no empate, o de menor ID, para a escolha ser estável
+func melhor(candidatos []Candidato, antes func(a, b Candidato) bool) (string, bool) {
+	if len(candidatos) == 0 {
+		return "", false
+	}
+	escolhido := candidatos[0]
+	for _, c := range candidatos[1:] {
+		if antes(c, escolhido) || (!antes(escolhido, c) && c.UsuarioID < escolhido.UsuarioID) {
+			escolhido = c
+		}
+	}
+	return escolhido.UsuarioID, true
+}
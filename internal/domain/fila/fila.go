@@ -0,0 +1,123 @@
+package fila
+
+import (
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+// Estrategia é o critério usado para escolher o responsável de um ticket novo
+type Estrategia string
+
+const (
+	EstrategiaRoundRobin   Estrategia = "round_robin"   // reveza entre os membros da equipe
+	EstrategiaMenosTickets Estrategia = "menos_tickets" // quem tem menos tickets em aberto
+	EstrategiaHabilidade   Estrategia = "habilidade"    // quem sabe atender a subcategoria ou categoria
+)
+
+var (
+	ErrFilaNaoEncontrada  = ticket.NovoErroNaoEncontrado("fila_nao_encontrada", "fila não encontrada")
+	ErrNomeObrigatorio    = ticket.NovoErroValidacao("nome", "nome_obrigatorio", "nome é obrigatório")
+	ErrEquipeObrigatoria  = ticket.NovoErroValidacao("equipe_id", "equipe_obrigatoria", "equipe é obrigatória")
+	ErrEquipeInvalida     = ticket.NovoErroValidacao("equipe_id", "equipe_invalida", "equipe não existe")
+	ErrEstrategiaInvalida = ticket.NovoErroValidacao("estrategia", "estrategia_invalida", "estratégia de atribuição inválida")
+	ErrFilaDuplicada      = ticket.NovoErroConflito("fila_duplicada", "já existe uma fila com este nome")
+)
+
+// Fila recebe os tickets de um recorte de categoria e subcategoria e os distribui entre os membros de uma equipe.
+// Categoria e subcategoria vazias funcionam como curinga.
+type Fila struct {
+	ID                   string
+	Nome                 string
+	Categoria            ticket.Categoria
+	Subcategoria         ticket.Subcategoria
+	EquipeID             string
+	Estrategia           Estrategia
+	AtribuicaoAutomatica bool // escolhe o responsável ao criar o ticket
+	Ativa                bool
+	DataCriacao          time.Time
+}
+
+// NovaFila cria uma fila ativa
+func NovaFila(nome string, categoria ticket.Categoria, subcategoria ticket.Subcategoria, equipeID string, estrategia Estrategia) (*Fila, error) {
+	f := &Fila{
+		ID:          uuid.New().String(),
+		Ativa:       true,
+		DataCriacao: time.Now(),
+	}
+	if err := f.SetNome(nome); err != nil {
+		return nil, err
+	}
+	if err := f.SetRecorte(categoria, subcategoria); err != nil {
+		return nil, err
+	}
+	if err := f.SetEquipe(equipeID); err != nil {
+		return nil, err
+	}
+	if err := f.SetEstrategia(estrategia); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SetNome altera o nome da fila
+func (f *Fila) SetNome(nome string) error {
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+	f.Nome = nome
+	return nil
+}
+
+// SetRecorte altera a categoria e a subcategoria que a fila recebe; vazias atendem qualquer valor.
+// O recorte é validado contra a taxonomia pelos casos de uso.
+func (f *Fila) SetRecorte(categoria ticket.Categoria, subcategoria ticket.Subcategoria) error {
+	f.Categoria = ticket.Categoria(strings.ToLower(strings.TrimSpace(string(categoria))))
+	f.Subcategoria = ticket.Subcategoria(strings.ToLower(strings.TrimSpace(string(subcategoria))))
+	return nil
+}
+
+// SetEquipe altera a equipe que atende a fila
+func (f *Fila) SetEquipe(equipeID string) error {
+	if strings.TrimSpace(equipeID) == "" {
+		return ErrEquipeObrigatoria
+	}
+	f.EquipeID = equipeID
+	return nil
+}
+
+// SetEstrategia altera o critério de atribuição
+func (f *Fila) SetEstrategia(estrategia Estrategia) error {
+	if _, ok := seletores[estrategia]; !ok {
+		return ErrEstrategiaInvalida
+	}
+	f.Estrategia = estrategia
+	return nil
+}
+
+// atende verifica se a fila recebe o ticket
+func (f *Fila) atende(t *ticket.Ticket) bool {
+	if f.Categoria != "" && f.Categoria != t.Categoria {
+		return false
+	}
+	if f.Subcategoria != "" && f.Subcategoria != t.Subcategoria {
+		return false
+	}
+	return true
+}
+
+// especificidade dá mais peso à subcategoria do que à categoria
+func (f *Fila) especificidade() int {
+	peso := 0
+	if f.Subcategoria != "" {
+		peso += 2
+	}
+	if f.Categoria != "" {
+		peso++
+	}
+	return peso
+}
@@ -0,0 +1,175 @@
+package fila
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// repositorioMemoria guarda filas e candidatos em memória para os testes do roteador
+type repositorioMemoria struct {
+	filas      []*Fila
+	candidatos map[string][]Candidato
+}
+
+func (r *repositorioMemoria) Criar(f *Fila) error { r.filas = append(r.filas, f); return nil }
+func (r *repositorioMemoria) Buscar(id string) (*Fila, error) {
+	for _, f := range r.filas {
+		if f.ID == id {
+			return f, nil
+		}
+	}
+	return nil, ErrFilaNaoEncontrada
+}
+func (r *repositorioMemoria) Listar(bool) ([]*Fila, error)              { return r.filas, nil }
+func (r *repositorioMemoria) Atualizar(*Fila) error                     { return nil }
+func (r *repositorioMemoria) Candidatos(id string) ([]Candidato, error) { return r.candidatos[id], nil }
+
+func novoTicketTeste(t *testing.T, categoria ticket.Categoria, subcategoria ticket.Subcategoria) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Ticket de Teste", "Descrição do ticket de teste", categoria, subcategoria, "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket de teste: %v", err)
+	}
+	return tk
+}
+
+func horasAtras(h int) *time.Time {
+	t := time.Now().Add(-time.Duration(h) * time.Hour)
+	return &t
+}
+
+func TestEstrategias(t *testing.T) {
+	tk := novoTicketTeste(t, ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque)
+	candidatos := []Candidato{
+		{UsuarioID: "ana", TicketsAbertos: 3, UltimaAtribuicao: horasAtras(5)},
+		{UsuarioID: "bia", TicketsAbertos: 1, UltimaAtribuicao: horasAtras(1), Habilidades: []string{"financeiro"}},
+		{UsuarioID: "caio", TicketsAbertos: 2, UltimaAtribuicao: horasAtras(2), Habilidades: []string{"solicitacao_de_saque"}},
+	}
+
+	casos := []struct {
+		seletor  Seletor
+		esperado string
+	}{
+		{RoundRobin{}, "ana"},
+		{MenosTickets{}, "bia"},
+		{PorHabilidade{}, "caio"},
+	}
+	for _, c := range casos {
+		escolhido, ok := c.seletor.Escolher(tk, candidatos)
+		if !ok || escolhido != c.esperado {
+			t.Errorf("%T: esperava %s, recebido %s", c.seletor, c.esperado, escolhido)
+		}
+	}
+
+	// quem nunca recebeu ticket tem a vez no rodízio
+	novato := append(candidatos, Candidato{UsuarioID: "davi"})
+	if escolhido, _ := (RoundRobin{}).Escolher(tk, novato); escolhido != "davi" {
+		t.Errorf("Esperava davi, recebido %s", escolhido)
+	}
+
+	// sem ninguém habilitado o ticket fica sem responsável
+	ti := novoTicketTeste(t, ticket.CategoriaTI, ticket.SubcategoriaBug)
+	if _, ok := (PorHabilidade{}).Escolher(ti, candidatos); ok {
+		t.Error("Não esperava responsável sem candidatos habilitados")
+	}
+}
+
+func TestRoteador_FilaMaisEspecificaComAtribuicao(t *testing.T) {
+	geral, _ := NovaFila("Financeiro", ticket.CategoriaFinanceiro, "", "equipe-1", EstrategiaRoundRobin)
+	saques, _ := NovaFila("Saques", ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, "equipe-2", EstrategiaMenosTickets)
+	saques.AtribuicaoAutomatica = true
+	repo := &repositorioMemoria{
+		filas:      []*Fila{geral, saques},
+		candidatos: map[string][]Candidato{saques.ID: {{UsuarioID: "ana", TicketsAbertos: 2}, {UsuarioID: "bia"}}},
+	}
+	roteador := NovoRoteador(repo, ticket.MaquinaDeEstadosPadrao())
+
+	tk := novoTicketTeste(t, ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque)
+	decisao, err := roteador.Rotear(tk)
+	if err != nil {
+		t.Fatalf("Erro ao rotear ticket: %v", err)
+	}
+	if decisao.Fila != saques || tk.FilaID != saques.ID {
+		t.Errorf("Esperava a fila de saques, recebido %+v", decisao.Fila)
+	}
+	if decisao.Responsavel != "bia" || tk.Responsavel != "bia" || tk.Status != ticket.StatusEmCurso {
+		t.Errorf("Esperava atendimento iniciado por bia, recebido %q em %s", tk.Responsavel, tk.Status)
+	}
+
+	// fila, status e responsável ficam no histórico, feitos pelo sistema
+	campos := map[string]bool{}
+	for _, m := range tk.Modificacoes {
+		if m.UsuarioID != usuario.IDSistema {
+			t.Errorf("Esperava modificação do sistema, recebido %s", m.UsuarioID)
+		}
+		campos[m.CampoModificado] = true
+	}
+	if !campos["fila"] || !campos["status"] || !campos["responsavel"] {
+		t.Errorf("Modificações incompletas: %+v", tk.Modificacoes)
+	}
+}
+
+func TestRoteador_SemAtribuicaoAutomatica(t *testing.T) {
+	geral, _ := NovaFila("Geral", "", "", "equipe-1", EstrategiaRoundRobin)
+	repo := &repositorioMemoria{filas: []*Fila{geral}}
+	roteador := NovoRoteador(repo, ticket.MaquinaDeEstadosPadrao())
+
+	tk := novoTicketTeste(t, ticket.CategoriaTI, ticket.SubcategoriaBug)
+	decisao, err := roteador.Rotear(tk)
+	if err != nil {
+		t.Fatalf("Erro ao rotear ticket: %v", err)
+	}
+	if decisao.Fila != geral || decisao.Responsavel != "" || tk.Status != ticket.StatusAberto {
+		t.Errorf("Esperava ticket aberto na fila geral, recebido %+v", decisao)
+	}
+}
+
+// taxonomiaAberta aceita qualquer classificação
+type taxonomiaAberta struct{}
+
+func (taxonomiaAberta) ValidarCategoria(ticket.Categoria) error                          { return nil }
+func (taxonomiaAberta) ValidarClassificacao(ticket.Categoria, ticket.Subcategoria) error { return nil }
+
+func TestRoteador_ReroteiaTicketReclassificado(t *testing.T) {
+	saques, _ := NovaFila("Saques", ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, "equipe-1", EstrategiaRoundRobin)
+	bugs, _ := NovaFila("Bugs", ticket.CategoriaTI, ticket.SubcategoriaBug, "equipe-2", EstrategiaMenosTickets)
+	bugs.AtribuicaoAutomatica = true
+	repo := &repositorioMemoria{
+		filas:      []*Fila{saques, bugs},
+		candidatos: map[string][]Candidato{bugs.ID: {{UsuarioID: "caio"}}},
+	}
+	roteador := NovoRoteador(repo, ticket.MaquinaDeEstadosPadrao())
+
+	tk := novoTicketTeste(t, ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque)
+	roteador.Rotear(tk)
+
+	// o ticket aberto sem responsável vai para a nova fila e passa pela atribuição automática
+	tk.Reclassificar(ticket.CategoriaTI, ticket.SubcategoriaBug, taxonomiaAberta{}, "supervisor")
+	decisao, err := roteador.Rerotear(tk)
+	if err != nil {
+		t.Fatalf("Erro ao rerotear ticket: %v", err)
+	}
+	if decisao.Fila != bugs || tk.FilaID != bugs.ID || tk.Responsavel != "caio" {
+		t.Errorf("Esperava a fila de bugs com caio, recebido fila %s e responsável %q", tk.FilaID, tk.Responsavel)
+	}
+
+	// sem fila para a nova classificação, o ticket sai da fila e mantém o responsável
+	tk.Reclassificar(ticket.CategoriaTI, ticket.SubcategoriaMelhoria, taxonomiaAberta{}, "supervisor")
+	decisao, err = roteador.Rerotear(tk)
+	if err != nil {
+		t.Fatalf("Erro ao rerotear ticket: %v", err)
+	}
+	if decisao != nil || tk.FilaID != "" || tk.Responsavel != "caio" {
+		t.Errorf("Esperava ticket fora das filas com caio, recebido fila %q e responsável %q", tk.FilaID, tk.Responsavel)
+	}
+}
+
+func TestNovaFila_EstrategiaInvalida(t *testing.T) {
+	_, err := NovaFila("Fila", "", "", "equipe-1", "sorteio")
+	if !errors.Is(err, ErrEstrategiaInvalida) {
+		t.Errorf("Esperava ErrEstrategiaInvalida, recebido %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package fila
+
+type Repository interface {
+	// Criar fila; falha com ErrFilaDuplicada se o nome já existir
+	Criar(f *Fila) error
+
+	// Buscar fila por ID
+	Buscar(id string) (*Fila, error)
+
+	// Listar filas ordenadas por nome
+	Listar(apenasAtivas bool) ([]*Fila, error)
+
+	// Atualizar a configuração da fila
+	Atualizar(f *Fila) error
+
+	// Candidatos retorna os membros ativos da equipe da fila, com a carga de cada um
+	Candidatos(filaID string) ([]Candidato, error)
+}
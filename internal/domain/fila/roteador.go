@@ -0,0 +1,148 @@
+package fila
+
+import (
+	"strings"
+
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// Decisao é o resultado do roteamento de um ticket
+type Decisao struct {
+	Fila        *Fila
+	Responsavel string // vazio quando ninguém foi atribuído
+}
+
+// Roteador coloca os tickets novos na fila mais específica e, se a fila pedir, escolhe o responsável
+type Roteador struct {
+	repo    Repository
+	maquina *ticket.MaquinaDeEstados
+}
+
+// NovoRoteador cria o roteador; a máquina de estados é usada para iniciar o atendimento do responsável escolhido
+func NovoRoteador(repo Repository, maquina *ticket.MaquinaDeEstados) *Roteador {
+	return &Roteador{repo: repo, maquina: maquina}
+}
+
+// Fila retorna a fila ativa mais específica que recebe o ticket
+func (r *Roteador) Fila(t *ticket.Ticket) (*Fila, error) {
+	filas, err := r.repo.Listar(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var escolhida *Fila
+	for _, f := range filas {
+		if !f.atende(t) {
+			continue
+		}
+		if escolhida == nil || f.especificidade() > escolhida.especificidade() {
+			escolhida = f
+		}
+	}
+	return escolhida, nil
+}
+
+// Rotear coloca o ticket na fila e, com atribuição automática, inicia o atendimento com o responsável
+// escolhido pela estratégia da fila. As decisões ficam registradas nas modificações do ticket, feitas
+// pelo usuário sistema. Sem fila para o ticket, retorna nil.
+func (r *Roteador) Rotear(t *ticket.Ticket) (*Decisao, error) {
+	// 1. escolhe a fila
+	f, err := r.Fila(t)
+	if err != nil || f == nil {
+		return nil, err
+	}
+	return r.colocarNaFila(t, f)
+}
+
+// Rerotear recoloca um ticket reclassificado na fila que atende a nova classificação; sem fila que o atenda,
+// o ticket sai da fila atual. O responsável é mantido: só tickets abertos sem responsável passam pela
+// atribuição automática da nova fila.
+func (r *Roteador) Rerotear(t *ticket.Ticket) (*Decisao, error) {
+	f, err := r.Fila(t)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, t.DefinirFila("", usuario.IDSistema)
+	}
+	return r.colocarNaFila(t, f)
+}
+
+// colocarNaFila define a fila do ticket e aplica a atribuição automática da fila
+func (r *Roteador) colocarNaFila(t *ticket.Ticket, f *Fila) (*Decisao, error) {
+	if err := t.DefinirFila(f.ID, usuario.IDSistema); err != nil {
+		return nil, err
+	}
+	decisao := &Decisao{Fila: f, Responsavel: t.Responsavel}
+
+	// atribuição automática apenas para tickets ainda sem responsável
+	if !f.AtribuicaoAutomatica || t.Responsavel != "" || t.Status != ticket.StatusAberto {
+		return decisao, nil
+	}
+	if err := r.atribuir(t, f, decisao); err != nil {
+		return nil, err
+	}
+	return decisao, nil
+}
+
+// Reatribuir move o ticket para a fila informada (pelo ID ou pelo nome) e passa o atendimento
+// para o membro da equipe escolhido pela estratégia da fila. Sem candidatos, o responsável atual é mantido.
+func (r *Roteador) Reatribuir(t *ticket.Ticket, fila string) (*Decisao, error) {
+	// 1. encontra a fila de destino
+	filas, err := r.repo.Listar(true)
+	if err != nil {
+		return nil, err
+	}
+	var destino *Fila
+	for _, f := range filas {
+		if f.ID == fila || strings.EqualFold(f.Nome, fila) {
+			destino = f
+			break
+		}
+	}
+	if destino == nil {
+		return nil, ErrFilaNaoEncontrada
+	}
+
+	// 2. move o ticket e escolhe o novo responsável
+	if err := t.DefinirFila(destino.ID, usuario.IDSistema); err != nil {
+		return nil, err
+	}
+	decisao := &Decisao{Fila: destino, Responsavel: t.Responsavel}
+	if err := r.atribuir(t, destino, decisao); err != nil {
+		return nil, err
+	}
+	return decisao, nil
+}
+
+// atribuir escolhe o responsável pela estratégia da fila; tickets abertos têm o atendimento iniciado
+func (r *Roteador) atribuir(t *ticket.Ticket, f *Fila, decisao *Decisao) error {
+	candidatos, err := r.repo.Candidatos(f.ID)
+	if err != nil {
+		return err
+	}
+	responsavel, ok := seletores[f.Estrategia].Escolher(t, candidatos)
+	if !ok {
+		return nil
+	}
+
+	if t.Status != ticket.StatusAberto {
+		if err := t.Reatribuir(responsavel, usuario.IDSistema); err != nil {
+			return err
+		}
+		decisao.Responsavel = responsavel
+		return nil
+	}
+
+	anterior := t.Responsavel
+	contexto := ticket.ContextoTransicao{UsuarioID: usuario.IDSistema, Responsavel: responsavel}
+	if err := r.maquina.Aplicar(t, ticket.StatusEmCurso, contexto); err != nil {
+		return err
+	}
+	if err := t.RegistrarAtribuicao(anterior, usuario.IDSistema); err != nil {
+		return err
+	}
+	decisao.Responsavel = responsavel
+	return nil
+}
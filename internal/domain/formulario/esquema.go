@@ -0,0 +1,370 @@
+package formulario
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Tipo é o tipo JSON de um campo do formulário
+type Tipo string
+
+const (
+	TipoTexto    Tipo = "string"
+	TipoNumero   Tipo = "number"
+	TipoInteiro  Tipo = "integer"
+	TipoBooleano Tipo = "boolean"
+	TipoLista    Tipo = "array"
+)
+
+// Formato restringe os campos de texto a um formato conhecido
+type Formato string
+
+const (
+	FormatoCPF      Formato = "cpf"
+	FormatoEmail    Formato = "email"
+	FormatoData     Formato = "date"      // AAAA-MM-DD
+	FormatoDataHora Formato = "date-time" // RFC 3339
+)
+
+// Esquema descreve o formulário em um subconjunto do JSON Schema, para o frontend renderizar os
+// campos e o backend validá-los com as mesmas regras. As propriedades com o nome de uma informação
+// adicional do ticket (ticket.CamposPadrao) são lidas dela; as demais são campos personalizados
+type Esquema struct {
+	Tipo         string                  `json:"type"` // sempre object
+	Titulo       string                  `json:"title,omitempty"`
+	Obrigatorios []string                `json:"required,omitempty"`
+	Propriedades map[string]*Propriedade `json:"properties"`
+
+	// Ordem de exibição dos campos; os que não aparecem nela vêm depois, em ordem alfabética
+	Ordem []string `json:"x-ordem,omitempty"`
+}
+
+// Propriedade é um campo do formulário
+type Propriedade struct {
+	Tipo      Tipo     `json:"type"`
+	Titulo    string   `json:"title,omitempty"`
+	Descricao string   `json:"description,omitempty"`
+	Formato   Formato  `json:"format,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+
+	// texto
+	MinTamanho *int   `json:"minLength,omitempty"`
+	MaxTamanho *int   `json:"maxLength,omitempty"`
+	Padrao     string `json:"pattern,omitempty"`
+
+	// número e inteiro
+	Minimo *float64 `json:"minimum,omitempty"`
+	Maximo *float64 `json:"maximum,omitempty"`
+
+	// lista
+	Itens    *Propriedade `json:"items,omitempty"`
+	MinItens *int         `json:"minItems,omitempty"`
+	MaxItens *int         `json:"maxItems,omitempty"`
+
+	padrao *regexp.Regexp
+}
+
+// EsquemaVazio é o formulário das classificações sem campos
+func EsquemaVazio() *Esquema {
+	return &Esquema{Tipo: "object", Propriedades: map[string]*Propriedade{}}
+}
+
+// formatoNome restringe os nomes dos campos, que aparecem nas URLs de erro e no histórico
+var formatoNome = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Validar confere se o esquema usa apenas o que o validador suporta e prepara os padrões
+func (e *Esquema) Validar() error {
+	if e.Tipo == "" {
+		e.Tipo = "object"
+	}
+	if e.Tipo != "object" {
+		return fmt.Errorf("%w: o tipo do formulário deve ser object", ErrEsquemaInvalido)
+	}
+	if e.Propriedades == nil {
+		e.Propriedades = map[string]*Propriedade{}
+	}
+
+	for nome, p := range e.Propriedades {
+		if !formatoNome.MatchString(nome) {
+			return fmt.Errorf("%w: nome de campo inválido: %q", ErrEsquemaInvalido, nome)
+		}
+		if p == nil {
+			return fmt.Errorf("%w: campo %s sem definição", ErrEsquemaInvalido, nome)
+		}
+		if err := p.validar(nome, true); err != nil {
+			return err
+		}
+		if campoPadrao(nome) && p.Tipo != TipoTexto {
+			return fmt.Errorf("%w: o campo %s é uma informação adicional do ticket e deve ser string", ErrEsquemaInvalido, nome)
+		}
+	}
+	for _, nome := range e.Obrigatorios {
+		if _, ok := e.Propriedades[nome]; !ok {
+			return fmt.Errorf("%w: campo obrigatório %s não está nas propriedades", ErrEsquemaInvalido, nome)
+		}
+	}
+	for _, nome := range e.Ordem {
+		if _, ok := e.Propriedades[nome]; !ok {
+			return fmt.Errorf("%w: campo %s da ordem não está nas propriedades", ErrEsquemaInvalido, nome)
+		}
+	}
+	return nil
+}
+
+func (p *Propriedade) validar(nome string, permiteLista bool) error {
+	switch p.Tipo {
+	case TipoTexto, TipoNumero, TipoInteiro, TipoBooleano:
+	case TipoLista:
+		if !permiteLista {
+			return fmt.Errorf("%w: campo %s: listas de listas não são suportadas", ErrEsquemaInvalido, nome)
+		}
+		if p.Itens == nil {
+			return fmt.Errorf("%w: campo %s: listas precisam de items", ErrEsquemaInvalido, nome)
+		}
+		if err := p.Itens.validar(nome, false); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: campo %s: tipo desconhecido %q", ErrEsquemaInvalido, nome, p.Tipo)
+	}
+
+	if p.Tipo != TipoTexto && (p.Formato != "" || len(p.Enum) > 0 || p.Padrao != "" || p.MinTamanho != nil || p.MaxTamanho != nil) {
+		return fmt.Errorf("%w: campo %s: format, enum, pattern e tamanhos só valem para string", ErrEsquemaInvalido, nome)
+	}
+	switch p.Formato {
+	case "", FormatoCPF, FormatoEmail, FormatoData, FormatoDataHora:
+	default:
+		return fmt.Errorf("%w: campo %s: formato desconhecido %q", ErrEsquemaInvalido, nome, p.Formato)
+	}
+	if p.Padrao != "" {
+		padrao, err := regexp.Compile(p.Padrao)
+		if err != nil {
+			return fmt.Errorf("%w: campo %s: pattern inválido", ErrEsquemaInvalido, nome)
+		}
+		p.padrao = padrao
+	}
+	return nil
+}
+
+// CamposOrdenados retorna os nomes dos campos na ordem de exibição
+func (e *Esquema) CamposOrdenados() []string {
+	nomes := append([]string(nil), e.Ordem...)
+	naOrdem := map[string]bool{}
+	for _, nome := range e.Ordem {
+		naOrdem[nome] = true
+	}
+	restantes := []string{}
+	for nome := range e.Propriedades {
+		if !naOrdem[nome] {
+			restantes = append(restantes, nome)
+		}
+	}
+	sort.Strings(restantes)
+	return append(nomes, restantes...)
+}
+
+// ValidarValores confere os valores do ticket. personalizados são os nomes dos campos personalizados
+// preenchidos, que precisam existir no esquema. Retorna um erro por campo inválido
+func (e *Esquema) ValidarValores(valores map[string]interface{}, personalizados []string, obrigatorios bool) []*ticket.Erro {
+	var erros []*ticket.Erro
+
+	// 1. campos personalizados que o formulário não conhece
+	for _, nome := range personalizados {
+		if _, ok := e.Propriedades[nome]; !ok {
+			erros = append(erros, ticket.NovoErroValidacao(nomeDoCampo(nome), "campo_desconhecido", "campo não existe no formulário da categoria"))
+		}
+	}
+
+	// 2. obrigatórios
+	if obrigatorios {
+		for _, nome := range e.Obrigatorios {
+			if vazio(valores[nome]) {
+				erros = append(erros, ticket.NovoErroValidacao(nomeDoCampo(nome), "campo_obrigatorio", "campo obrigatório no formulário da categoria"))
+			}
+		}
+	}
+
+	// 3. tipo e restrições de cada campo preenchido
+	for _, nome := range e.CamposOrdenados() {
+		valor, ok := valores[nome]
+		if !ok || valor == nil {
+			continue
+		}
+		if mensagem := e.Propriedades[nome].conferir(valor); mensagem != "" {
+			erros = append(erros, ticket.NovoErroValidacao(nomeDoCampo(nome), "campo_invalido", mensagem))
+		}
+	}
+	return erros
+}
+
+// conferir retorna a mensagem do problema do valor, ou vazio se ele é válido
+func (p *Propriedade) conferir(valor interface{}) string {
+	switch p.Tipo {
+	case TipoTexto:
+		texto, ok := valor.(string)
+		if !ok {
+			return "deve ser um texto"
+		}
+		return p.conferirTexto(texto)
+
+	case TipoNumero, TipoInteiro:
+		numero, ok := valor.(float64)
+		if !ok {
+			return "deve ser um número"
+		}
+		if p.Tipo == TipoInteiro && numero != math.Trunc(numero) {
+			return "deve ser um número inteiro"
+		}
+		if p.Minimo != nil && numero < *p.Minimo {
+			return fmt.Sprintf("deve ser no mínimo %v", *p.Minimo)
+		}
+		if p.Maximo != nil && numero > *p.Maximo {
+			return fmt.Sprintf("deve ser no máximo %v", *p.Maximo)
+		}
+
+	case TipoBooleano:
+		if _, ok := valor.(bool); !ok {
+			return "deve ser true ou false"
+		}
+
+	case TipoLista:
+		itens, ok := valor.([]interface{})
+		if !ok {
+			return "deve ser uma lista"
+		}
+		if p.MinItens != nil && len(itens) < *p.MinItens {
+			return fmt.Sprintf("deve ter no mínimo %d itens", *p.MinItens)
+		}
+		if p.MaxItens != nil && len(itens) > *p.MaxItens {
+			return fmt.Sprintf("deve ter no máximo %d itens", *p.MaxItens)
+		}
+		for i, item := range itens {
+			if mensagem := p.Itens.conferir(item); mensagem != "" {
+				return fmt.Sprintf("item %d: %s", i+1, mensagem)
+			}
+		}
+	}
+	return ""
+}
+
+func (p *Propriedade) conferirTexto(texto string) string {
+	tamanho := utf8.RuneCountInString(texto)
+	if p.MinTamanho != nil && tamanho < *p.MinTamanho {
+		return fmt.Sprintf("deve ter no mínimo %d caracteres", *p.MinTamanho)
+	}
+	if p.MaxTamanho != nil && tamanho > *p.MaxTamanho {
+		return fmt.Sprintf("deve ter no máximo %d caracteres", *p.MaxTamanho)
+	}
+	if len(p.Enum) > 0 {
+		aceito := false
+		for _, opcao := range p.Enum {
+			aceito = aceito || opcao == texto
+		}
+		if !aceito {
+			return "deve ser uma das opções: " + strings.Join(p.Enum, ", ")
+		}
+	}
+	if p.Padrao != "" {
+		if p.padrao == nil {
+			p.padrao = regexp.MustCompile(p.Padrao)
+		}
+		if !p.padrao.MatchString(texto) {
+			return "formato inválido"
+		}
+	}
+
+	switch p.Formato {
+	case FormatoCPF:
+		if !CPFValido(texto) {
+			return "CPF inválido"
+		}
+	case FormatoEmail:
+		if _, err := mail.ParseAddress(texto); err != nil {
+			return "e-mail inválido"
+		}
+	case FormatoData:
+		if _, err := time.Parse(time.DateOnly, texto); err != nil {
+			return "data inválida, use AAAA-MM-DD"
+		}
+	case FormatoDataHora:
+		if _, err := time.Parse(time.RFC3339, texto); err != nil {
+			return "data e hora inválidas, use RFC 3339"
+		}
+	}
+	return ""
+}
+
+// CPFValido confere os dígitos verificadores; aceita o CPF com ou sem pontuação
+func CPFValido(cpf string) bool {
+	digitos := make([]int, 0, 11)
+	for _, r := range cpf {
+		switch {
+		case r >= '0' && r <= '9':
+			digitos = append(digitos, int(r-'0'))
+		case r == '.' || r == '-' || r == ' ':
+		default:
+			return false
+		}
+	}
+	if len(digitos) != 11 {
+		return false
+	}
+
+	// sequências repetidas passam no cálculo, mas não são CPFs válidos
+	repetido := true
+	for _, d := range digitos[1:] {
+		repetido = repetido && d == digitos[0]
+	}
+	if repetido {
+		return false
+	}
+
+	for n := 9; n <= 10; n++ {
+		soma := 0
+		for i := 0; i < n; i++ {
+			soma += digitos[i] * (n + 1 - i)
+		}
+		verificador := soma * 10 % 11 % 10
+		if verificador != digitos[n] {
+			return false
+		}
+	}
+	return true
+}
+
+func campoPadrao(nome string) bool {
+	for _, padrao := range ticket.CamposPadrao {
+		if nome == padrao {
+			return true
+		}
+	}
+	return false
+}
+
+// nomeDoCampo é o nome do campo nos erros, igual ao da requisição do ticket
+func nomeDoCampo(nome string) string {
+	if campoPadrao(nome) {
+		return nome
+	}
+	return "campos." + nome
+}
+
+func vazio(valor interface{}) bool {
+	switch v := valor.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package formulario
+
+import (
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+var (
+	ErrFormularioNaoEncontrado = ticket.NovoErroNaoEncontrado("formulario_nao_encontrado", "formulário não encontrado")
+	ErrEsquemaInvalido         = ticket.NovoErroValidacao("esquema", "esquema_invalido", "esquema do formulário inválido")
+)
+
+// Formulario é o formulário dos tickets de uma categoria e subcategoria. A subcategoria vazia
+// é a das categorias sem subcategorias
+type Formulario struct {
+	Categoria       ticket.Categoria
+	Subcategoria    ticket.Subcategoria
+	Esquema         *Esquema
+	DataAtualizacao time.Time
+}
+
+// NovoFormulario cria o formulário da classificação, validando o esquema
+func NovoFormulario(categoria ticket.Categoria, subcategoria ticket.Subcategoria, esquema *Esquema) (*Formulario, error) {
+	if esquema == nil {
+		esquema = EsquemaVazio()
+	}
+	if err := esquema.Validar(); err != nil {
+		return nil, err
+	}
+	return &Formulario{
+		Categoria:       ticket.Categoria(strings.ToLower(string(categoria))),
+		Subcategoria:    ticket.Subcategoria(strings.ToLower(string(subcategoria))),
+		Esquema:         esquema,
+		DataAtualizacao: time.Now(),
+	}, nil
+}
@@ -0,0 +1,197 @@
+package formulario
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// repositorioEmMemoria guarda os formulários por classificação
+type repositorioEmMemoria map[string]*Formulario
+
+func (r repositorioEmMemoria) Buscar(categoria ticket.Categoria, subcategoria ticket.Subcategoria) (*Formulario, error) {
+	f, ok := r[string(categoria)+"/"+string(subcategoria)]
+	if !ok {
+		return nil, ErrFormularioNaoEncontrado
+	}
+	return f, nil
+}
+
+func (r repositorioEmMemoria) Salvar(f *Formulario) error {
+	r[string(f.Categoria)+"/"+string(f.Subcategoria)] = f
+	return nil
+}
+
+// esquema de saque como é enviado pelo administrador
+const esquemaSaque = `{
+	"type": "object",
+	"required": ["cpf", "nox_id", "valor"],
+	"properties": {
+		"cpf": {"type": "string", "format": "cpf"},
+		"nox_id": {"type": "string"},
+		"valor": {"type": "number", "minimum": 0.01},
+		"parcelas": {"type": "integer", "minimum": 1, "maximum": 12},
+		"banco": {"type": "string", "enum": ["itau", "nubank"]},
+		"ids_transacao": {"type": "array", "items": {"type": "string", "pattern": "^tx_"}, "maxItems": 2}
+	},
+	"x-ordem": ["valor", "cpf"]
+}`
+
+func novoValidadorTeste(t *testing.T) *Validador {
+	var esquema Esquema
+	if err := json.Unmarshal([]byte(esquemaSaque), &esquema); err != nil {
+		t.Fatalf("Erro ao ler esquema: %v", err)
+	}
+	f, err := NovoFormulario(ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, &esquema)
+	if err != nil {
+		t.Fatalf("Erro ao criar formulário: %v", err)
+	}
+	repo := repositorioEmMemoria{}
+	repo.Salvar(f)
+	return NovoValidador(repo)
+}
+
+func novoTicketSaque(t *testing.T) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Saque", "Saque não caiu", ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	return tk
+}
+
+// camposComErro retorna o campo e o código de cada erro do formulário
+func camposComErro(t *testing.T, err error) map[string]string {
+	e, ok := ticket.ComoErro(err)
+	if !ok || e.Codigo != codigoCamposInvalidos {
+		t.Fatalf("Esperava erro campos_invalidos, recebido %v", err)
+	}
+	campos := map[string]string{}
+	for _, d := range e.Detalhes {
+		campos[d.Campo] = d.Codigo
+	}
+	return campos
+}
+
+func TestValidador_ValidarCampos(t *testing.T) {
+	validador := novoValidadorTeste(t)
+
+	// sem nada preenchido, faltam os obrigatórios; sem exigir obrigatórios, passa
+	tk := novoTicketSaque(t)
+	campos := camposComErro(t, validador.ValidarCampos(tk, true))
+	for _, nome := range []string{"cpf", "nox_id", "campos.valor"} {
+		if campos[nome] != "campo_obrigatorio" {
+			t.Errorf("Esperava %s obrigatório, erros %v", nome, campos)
+		}
+	}
+	if err := validador.ValidarCampos(tk, false); err != nil {
+		t.Errorf("Sem obrigatórios não esperava erro: %v", err)
+	}
+
+	// valores inválidos e campo desconhecido
+	tk.SetInformacaoAdicional("", "NOX-1", "111.111.111-11", "", "")
+	tk.SetCampos(map[string]interface{}{
+		"valor":         0.0,
+		"parcelas":      1.5,
+		"banco":         "inter",
+		"ids_transacao": []interface{}{"tx_1", "abc"},
+		"cor":           "azul",
+	}, "usuario_teste")
+	campos = camposComErro(t, validador.ValidarCampos(tk, true))
+	esperados := map[string]string{
+		"cpf":                  "campo_invalido",
+		"campos.valor":         "campo_invalido",
+		"campos.parcelas":      "campo_invalido",
+		"campos.banco":         "campo_invalido",
+		"campos.ids_transacao": "campo_invalido",
+		"campos.cor":           "campo_desconhecido",
+	}
+	for nome, codigo := range esperados {
+		if campos[nome] != codigo {
+			t.Errorf("Esperava %s em %s, erros %v", codigo, nome, campos)
+		}
+	}
+	if len(campos) != len(esperados) {
+		t.Errorf("Erros a mais: %v", campos)
+	}
+
+	// corrigindo tudo, passa
+	cpf := "529.982.247-25"
+	tk.CPF = &cpf
+	tk.SetCampos(map[string]interface{}{
+		"valor":         150.0,
+		"parcelas":      3.0,
+		"banco":         "nubank",
+		"ids_transacao": []interface{}{"tx_1", "tx_2"},
+		"cor":           nil,
+	}, "usuario_teste")
+	if err := validador.ValidarCampos(tk, true); err != nil {
+		t.Errorf("Não esperava erro: %v", err)
+	}
+
+	// classificação sem formulário não aceita campos personalizados
+	ti, _ := ticket.NovoTicket("Bug", "Tela quebrada", ticket.CategoriaTI, ticket.SubcategoriaBug, "usuario_teste")
+	if err := validador.ValidarCampos(ti, true); err != nil {
+		t.Errorf("Formulário vazio não deveria exigir nada: %v", err)
+	}
+	ti.SetCampos(map[string]interface{}{"valor": 1.0}, "usuario_teste")
+	if campos := camposComErro(t, validador.ValidarCampos(ti, true)); campos["campos.valor"] != "campo_desconhecido" {
+		t.Errorf("Esperava campo desconhecido, erros %v", campos)
+	}
+}
+
+func TestEsquema_Validar(t *testing.T) {
+	casos := []struct {
+		nome    string
+		esquema string
+	}{
+		{"tipo desconhecido", `{"properties": {"a": {"type": "date"}}}`},
+		{"formato em número", `{"properties": {"a": {"type": "number", "format": "cpf"}}}`},
+		{"lista sem itens", `{"properties": {"a": {"type": "array"}}}`},
+		{"lista de listas", `{"properties": {"a": {"type": "array", "items": {"type": "array", "items": {"type": "string"}}}}}`},
+		{"obrigatório inexistente", `{"required": ["b"], "properties": {"a": {"type": "string"}}}`},
+		{"pattern inválido", `{"properties": {"a": {"type": "string", "pattern": "("}}}`},
+		{"nome inválido", `{"properties": {"Nome Completo": {"type": "string"}}}`},
+		{"informação adicional não texto", `{"properties": {"cpf": {"type": "number"}}}`},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			var esquema Esquema
+			if err := json.Unmarshal([]byte(c.esquema), &esquema); err != nil {
+				t.Fatalf("Erro ao ler esquema: %v", err)
+			}
+			if err := esquema.Validar(); !errors.Is(err, ErrEsquemaInvalido) {
+				t.Errorf("Esperava ErrEsquemaInvalido, recebido %v", err)
+			}
+		})
+	}
+
+	var esquema Esquema
+	json.Unmarshal([]byte(esquemaSaque), &esquema)
+	if err := esquema.Validar(); err != nil {
+		t.Fatalf("Esquema válido recusado: %v", err)
+	}
+	ordem := esquema.CamposOrdenados()
+	esperada := []string{"valor", "cpf", "banco", "ids_transacao", "nox_id", "parcelas"}
+	for i := range esperada {
+		if ordem[i] != esperada[i] {
+			t.Fatalf("Ordem = %v, esperava %v", ordem, esperada)
+		}
+	}
+}
+
+func TestCPFValido(t *testing.T) {
+	validos := []string{"529.982.247-25", "52998224725", "111.444.777-35"}
+	invalidos := []string{"529.982.247-24", "111.111.111-11", "1234567890", "529.982.247-2a", ""}
+	for _, cpf := range validos {
+		if !CPFValido(cpf) {
+			t.Errorf("CPF %q deveria ser válido", cpf)
+		}
+	}
+	for _, cpf := range invalidos {
+		if CPFValido(cpf) {
+			t.Errorf("CPF %q deveria ser inválido", cpf)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package formulario
+
+import "nox_tickets/internal/domain/ticket"
+
+type Repository interface {
+	// Buscar o formulário da classificação; ErrFormularioNaoEncontrado se não houver
+	Buscar(categoria ticket.Categoria, subcategoria ticket.Subcategoria) (*Formulario, error)
+
+	// Salvar cria ou substitui o formulário da classificação
+	Salvar(f *Formulario) error
+}
@@ -0,0 +1,53 @@
+package formulario
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// codigoCamposInvalidos é o código do erro que reúne, em Detalhes, os erros de cada campo do formulário
+const codigoCamposInvalidos = "campos_invalidos"
+
+// Validador valida os campos dos tickets contra os formulários do repositório; implementa ticket.Formularios
+type Validador struct {
+	repo Repository
+}
+
+// NovoValidador cria o validador de formulários
+func NovoValidador(repo Repository) *Validador {
+	return &Validador{repo: repo}
+}
+
+// Formulario retorna o formulário da classificação; sem formulário cadastrado, um formulário vazio
+func (v *Validador) Formulario(categoria ticket.Categoria, subcategoria ticket.Subcategoria) (*Formulario, error) {
+	categoria = ticket.Categoria(strings.ToLower(string(categoria)))
+	subcategoria = ticket.Subcategoria(strings.ToLower(string(subcategoria)))
+	f, err := v.repo.Buscar(categoria, subcategoria)
+	if errors.Is(err, ErrFormularioNaoEncontrado) {
+		return NovoFormulario(categoria, subcategoria, nil)
+	}
+	return f, err
+}
+
+// ValidarCampos confere os campos do ticket contra o formulário da classificação dele
+func (v *Validador) ValidarCampos(t *ticket.Ticket, obrigatorios bool) error {
+	f, err := v.Formulario(t.Categoria, t.Subcategoria)
+	if err != nil {
+		return err
+	}
+
+	personalizados := make([]string, 0, len(t.Campos))
+	for nome := range t.Campos {
+		personalizados = append(personalizados, nome)
+	}
+	sort.Strings(personalizados)
+
+	erros := f.Esquema.ValidarValores(t.ValoresFormulario(), personalizados, obrigatorios)
+	if len(erros) > 0 {
+		return ticket.NovoErroValidacaoCampos(codigoCamposInvalidos, "campos do formulário inválidos", erros)
+	}
+	return nil
+}
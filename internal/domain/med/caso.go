@@ -0,0 +1,352 @@
+package med
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/formulario"
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCasoNaoEncontrado   = ticket.NovoErroNaoEncontrado("med_nao_encontrado", "caso MED não encontrado")
+	ErrCasoJaExiste        = ticket.NovoErroConflito("med_ja_existe", "o ticket já tem um caso MED")
+	ErrNotificacaoRepetida = ticket.NovoErroConflito("notificacao_repetida", "a notificação de infração já está em outro caso MED")
+	ErrConflito            = ticket.NovoErroConflito("med_conflito", "o caso MED foi alterado por outra operação; busque-o novamente")
+	ErrTicketNaoMED        = ticket.NovoErroValidacao("categoria", "ticket_nao_med", "apenas tickets da categoria meds podem ter um caso MED")
+
+	ErrEndToEndInvalido       = ticket.NovoErroValidacao("end_to_end_id", "end_to_end_invalido", "end-to-end ID inválido, esperado E + ISPB + data e hora + 11 caracteres")
+	ErrNotificacaoInvalida    = ticket.NovoErroValidacao("id_notificacao", "notificacao_invalida", "ID da notificação de infração inválido, esperado um UUID")
+	ErrValorInvalido          = ticket.NovoErroValidacao("valor", "valor_invalido", "o valor deve ser maior que zero")
+	ErrDocumentoInvalido      = ticket.NovoErroValidacao("documento", "documento_invalido", "documento inválido, informe um CPF ou CNPJ")
+	ErrISPBInvalido           = ticket.NovoErroValidacao("ispb", "ispb_invalido", "ISPB inválido, deve ter 8 dígitos")
+	ErrNomeObrigatorio        = ticket.NovoErroValidacao("nome", "nome_obrigatorio", "nome é obrigatório")
+	ErrDataTransacaoInvalida  = ticket.NovoErroValidacao("data_transacao", "data_transacao_invalida", "data da transação é obrigatória e não pode ser posterior à notificação")
+	ErrDataNotificacaoFutura  = ticket.NovoErroValidacao("data_notificacao", "data_notificacao_invalida", "data da notificação é obrigatória e não pode estar no futuro")
+	ErrNotificacaoForaDoPrazo = ticket.NovoErroValidacao("data_notificacao", "notificacao_fora_do_prazo", "a notificação foi feita depois do prazo permitido após a transação")
+	ErrJustificativa          = ticket.NovoErroValidacao("justificativa", "justificativa_obrigatoria", "justificativa é obrigatória nas decisões do caso MED")
+	ErrValorBloqueio          = ticket.NovoErroValidacao("valor", "valor_bloqueio_invalido", "o valor bloqueado deve ser maior que zero e até o valor da transação")
+	ErrValorDevolucao         = ticket.NovoErroValidacao("valor", "valor_devolucao_invalido", "o valor devolvido deve ser maior que zero e até o valor bloqueado")
+
+	ErrSituacaoInvalida = ticket.NovoErroTransicao("med_transicao_invalida", "mudança de situação do caso MED inválida")
+	ErrCasoEncerrado    = ticket.NovoErroTransicao("med_encerrado", "o caso MED já foi encerrado")
+)
+
+// Situacao é a etapa do caso MED, independente do status do ticket
+type Situacao string
+
+const (
+	SituacaoEmAnalise        Situacao = "em_analise"
+	SituacaoFundosBloqueados Situacao = "fundos_bloqueados"
+	SituacaoDevolvido        Situacao = "devolvido"
+	SituacaoRejeitado        Situacao = "rejeitado"
+)
+
+// transicoes são as mudanças de situação permitidas; devolvido e rejeitado encerram o caso
+var transicoes = map[Situacao][]Situacao{
+	SituacaoEmAnalise:        {SituacaoFundosBloqueados, SituacaoRejeitado},
+	SituacaoFundosBloqueados: {SituacaoDevolvido, SituacaoRejeitado},
+}
+
+// SituacaoValida indica se a situação existe
+func SituacaoValida(s Situacao) bool {
+	switch s {
+	case SituacaoEmAnalise, SituacaoFundosBloqueados, SituacaoDevolvido, SituacaoRejeitado:
+		return true
+	}
+	return false
+}
+
+// Encerrada indica se a situação encerra o caso
+func (s Situacao) Encerrada() bool {
+	return s == SituacaoDevolvido || s == SituacaoRejeitado
+}
+
+// Prazos são contados em tempo corrido a partir da data da notificação de infração
+type Prazos struct {
+	Analise     time.Duration // para decidir entre bloquear os fundos ou rejeitar a notificação
+	Devolucao   time.Duration // para devolver os fundos bloqueados ou liberá-los
+	Notificacao time.Duration // janela, a partir da transação, em que a notificação é aceita
+}
+
+// PrazosPadrao são os prazos usados quando não configurados: 7 dias para a análise,
+// 96 horas após o fim da análise para a devolução e 80 dias entre a transação e a notificação
+func PrazosPadrao() Prazos {
+	return Prazos{
+		Analise:     7 * 24 * time.Hour,
+		Devolucao:   11 * 24 * time.Hour,
+		Notificacao: 80 * 24 * time.Hour,
+	}
+}
+
+// Participante é o pagador ou o recebedor da transação Pix
+type Participante struct {
+	Nome      string `json:"nome"`
+	Documento string `json:"documento"` // CPF ou CNPJ, só os dígitos
+	ISPB      string `json:"ispb"`      // instituição participante do Pix
+	Agencia   string `json:"agencia,omitempty"`
+	Conta     string `json:"conta,omitempty"`
+}
+
+// Dados são as informações da transação e da notificação de infração; vão para o histórico em JSON
+type Dados struct {
+	EndToEndID      string       `json:"end_to_end_id"`
+	IDNotificacao   string       `json:"id_notificacao"` // ID da notificação de infração no DICT
+	Valor           int64        `json:"valor"`          // em centavos
+	Pagador         Participante `json:"pagador"`
+	Recebedor       Participante `json:"recebedor"`
+	DataTransacao   time.Time    `json:"data_transacao"`
+	DataNotificacao time.Time    `json:"data_notificacao"`
+}
+
+// Decisao é o que o analista informa ao mudar a situação do caso
+type Decisao struct {
+	Valor         int64 // em centavos: bloqueado, ao bloquear os fundos, ou devolvido, ao devolver
+	Justificativa string
+}
+
+// Caso é o tratamento de uma notificação de infração do Mecanismo Especial de Devolução (MED)
+// do Pix, ligado a um ticket da categoria meds. Toda mudança fica no Historico.
+type Caso struct {
+	TicketID string
+	Dados
+
+	Situacao       Situacao
+	ValorBloqueado int64
+	ValorDevolvido int64
+	PrazoAnalise   time.Time
+	PrazoDevolucao time.Time
+	DataBloqueio   *time.Time
+	DataConclusao  *time.Time
+
+	Historico []Registro
+	Versao    int // registros do histórico já gravados
+}
+
+var (
+	formatoEndToEnd = regexp.MustCompile(`^E[0-9]{8}[0-9]{12}[a-zA-Z0-9]{11}$`)
+	formatoISPB     = regexp.MustCompile(`^[0-9]{8}$`)
+)
+
+// NovoCaso abre o caso MED do ticket, com os prazos contados da notificação
+func NovoCaso(t *ticket.Ticket, dados Dados, prazos Prazos, usuarioID string, agora time.Time) (*Caso, error) {
+	if t.Categoria != ticket.CategoriaMeds {
+		return nil, ErrTicketNaoMED
+	}
+	agora = instante(agora)
+	dados = normalizar(dados)
+	if err := validar(dados, prazos, agora); err != nil {
+		return nil, err
+	}
+
+	c := &Caso{TicketID: t.ID, Dados: dados, Situacao: SituacaoEmAnalise}
+	c.calcularPrazos(prazos)
+	return c, c.registrar(AcaoAbertura, SituacaoEmAnalise, 0, "", usuarioID, agora)
+}
+
+// Corrigir substitui os dados do caso enquanto ele está em análise; os prazos são recalculados
+// se a data da notificação mudar
+func (c *Caso) Corrigir(dados Dados, prazos Prazos, justificativa, usuarioID string, agora time.Time) error {
+	if c.Situacao != SituacaoEmAnalise {
+		return fmt.Errorf("%w: dados só podem ser corrigidos em análise", ErrSituacaoInvalida)
+	}
+	if strings.TrimSpace(justificativa) == "" {
+		return ErrJustificativa
+	}
+	agora = instante(agora)
+	dados = normalizar(dados)
+	if err := validar(dados, prazos, agora); err != nil {
+		return err
+	}
+
+	c.Dados = dados
+	c.calcularPrazos(prazos)
+	return c.registrar(AcaoCorrecao, c.Situacao, 0, justificativa, usuarioID, agora)
+}
+
+// Aplicar move o caso para a situação informada
+func (c *Caso) Aplicar(para Situacao, decisao Decisao, usuarioID string, agora time.Time) error {
+	// 1. confere a transição
+	if c.Situacao.Encerrada() {
+		return ErrCasoEncerrado
+	}
+	permitida := false
+	for _, s := range transicoes[c.Situacao] {
+		permitida = permitida || s == para
+	}
+	if !permitida {
+		return fmt.Errorf("%w: de %s para %s", ErrSituacaoInvalida, c.Situacao, para)
+	}
+	if strings.TrimSpace(decisao.Justificativa) == "" {
+		return ErrJustificativa
+	}
+	agora = instante(agora)
+
+	// 2. aplica os efeitos da nova situação
+	var acao Acao
+	switch para {
+	case SituacaoFundosBloqueados:
+		if decisao.Valor <= 0 || decisao.Valor > c.Valor {
+			return ErrValorBloqueio
+		}
+		acao = AcaoBloqueio
+		c.ValorBloqueado = decisao.Valor
+		c.DataBloqueio = &agora
+
+	case SituacaoDevolvido:
+		if decisao.Valor <= 0 || decisao.Valor > c.ValorBloqueado {
+			return ErrValorDevolucao
+		}
+		acao = AcaoDevolucao
+		c.ValorDevolvido = decisao.Valor
+		c.DataConclusao = &agora
+
+	case SituacaoRejeitado:
+		// rejeitar depois do bloqueio libera os fundos, sem valor a informar
+		acao = AcaoRejeicao
+		decisao.Valor = 0
+		c.DataConclusao = &agora
+	}
+
+	return c.registrar(acao, para, decisao.Valor, decisao.Justificativa, usuarioID, agora)
+}
+
+// PrazoVigente é o prazo da etapa atual; casos encerrados não têm prazo
+func (c *Caso) PrazoVigente() *time.Time {
+	switch c.Situacao {
+	case SituacaoEmAnalise:
+		return &c.PrazoAnalise
+	case SituacaoFundosBloqueados:
+		return &c.PrazoDevolucao
+	}
+	return nil
+}
+
+// Vencido indica se o prazo da etapa atual já passou
+func (c *Caso) Vencido(agora time.Time) bool {
+	prazo := c.PrazoVigente()
+	return prazo != nil && agora.After(*prazo)
+}
+
+func (c *Caso) calcularPrazos(prazos Prazos) {
+	c.PrazoAnalise = c.DataNotificacao.Add(prazos.Analise)
+	c.PrazoDevolucao = c.DataNotificacao.Add(prazos.Devolucao)
+}
+
+// normalizar remove espaços e a pontuação dos documentos
+func normalizar(d Dados) Dados {
+	d.EndToEndID = strings.TrimSpace(d.EndToEndID)
+	d.IDNotificacao = strings.ToLower(strings.TrimSpace(d.IDNotificacao))
+	d.DataTransacao = instante(d.DataTransacao)
+	d.DataNotificacao = instante(d.DataNotificacao)
+	for _, p := range []*Participante{&d.Pagador, &d.Recebedor} {
+		p.Nome = strings.TrimSpace(p.Nome)
+		p.Documento = somenteDigitos(p.Documento)
+		p.ISPB = strings.TrimSpace(p.ISPB)
+		p.Agencia = strings.TrimSpace(p.Agencia)
+		p.Conta = strings.TrimSpace(p.Conta)
+	}
+	return d
+}
+
+func validar(d Dados, prazos Prazos, agora time.Time) error {
+	if !formatoEndToEnd.MatchString(d.EndToEndID) {
+		return ErrEndToEndInvalido
+	}
+	if _, err := uuid.Parse(d.IDNotificacao); err != nil {
+		return ErrNotificacaoInvalida
+	}
+	if d.Valor <= 0 {
+		return ErrValorInvalido
+	}
+	if err := validarParticipante("pagador", d.Pagador); err != nil {
+		return err
+	}
+	if err := validarParticipante("recebedor", d.Recebedor); err != nil {
+		return err
+	}
+	if d.DataNotificacao.IsZero() || d.DataNotificacao.After(agora) {
+		return ErrDataNotificacaoFutura
+	}
+	if d.DataTransacao.IsZero() || d.DataTransacao.After(d.DataNotificacao) {
+		return ErrDataTransacaoInvalida
+	}
+	if d.DataNotificacao.Sub(d.DataTransacao) > prazos.Notificacao {
+		return ErrNotificacaoForaDoPrazo
+	}
+	return nil
+}
+
+// validarParticipante devolve o erro com o campo do participante, ex.: "pagador.documento"
+func validarParticipante(papel string, p Participante) error {
+	var err *ticket.Erro
+	switch {
+	case p.Nome == "":
+		err = ErrNomeObrigatorio
+	case !documentoValido(p.Documento):
+		err = ErrDocumentoInvalido
+	case !formatoISPB.MatchString(p.ISPB):
+		err = ErrISPBInvalido
+	default:
+		return nil
+	}
+	return ticket.NovoErroValidacao(papel+"."+err.Campo, err.Codigo, err.Mensagem)
+}
+
+func documentoValido(documento string) bool {
+	switch len(documento) {
+	case 11:
+		return formulario.CPFValido(documento)
+	case 14:
+		return cnpjValido(documento)
+	}
+	return false
+}
+
+// cnpjValido confere os dígitos verificadores de um CNPJ de 14 dígitos
+func cnpjValido(cnpj string) bool {
+	for _, r := range cnpj {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	if strings.Count(cnpj, cnpj[:1]) == len(cnpj) {
+		return false
+	}
+	pesos := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for n := 12; n <= 13; n++ {
+		soma := 0
+		for i := 0; i < n; i++ {
+			soma += int(cnpj[i]-'0') * pesos[i+13-n]
+		}
+		verificador := soma % 11
+		if verificador < 2 {
+			verificador = 0
+		} else {
+			verificador = 11 - verificador
+		}
+		if verificador != int(cnpj[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+func somenteDigitos(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '.' || r == '-' || r == '/' || r == ' ':
+		default:
+			// mantém o caractere para o documento ser recusado na validação
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
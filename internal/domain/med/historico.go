@@ -0,0 +1,138 @@
+package med
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+var (
+	ErrHistoricoAdulterado = ticket.NovoErroConflito("med_historico_adulterado", "o histórico do caso MED não confere com os hashes gravados")
+)
+
+// Acao é o tipo de registro do histórico do caso
+type Acao string
+
+const (
+	AcaoAbertura  Acao = "abertura"
+	AcaoCorrecao  Acao = "correcao"
+	AcaoBloqueio  Acao = "bloqueio"
+	AcaoDevolucao Acao = "devolucao"
+	AcaoRejeicao  Acao = "rejeicao"
+)
+
+// Registro é uma entrada do histórico do caso, para prestação de contas ao regulador. Os registros
+// não são alterados depois de gravados: cada um guarda os dados do caso após a ação e o hash do
+// registro anterior, então qualquer mudança posterior quebra a cadeia de hashes.
+type Registro struct {
+	Sequencia        int // começa em 1
+	Acao             Acao
+	SituacaoAnterior Situacao
+	SituacaoNova     Situacao
+	Valor            int64 // em centavos, nos bloqueios e devoluções
+	Justificativa    string
+	Dados            string // JSON dos dados do caso após a ação
+	ForaDoPrazo      bool   // a decisão foi tomada depois do prazo da etapa
+	UsuarioID        string
+	Data             time.Time
+	HashAnterior     string
+	Hash             string
+}
+
+// retrato é o estado do caso gravado em cada registro
+type retrato struct {
+	Dados
+	Situacao       Situacao   `json:"situacao"`
+	ValorBloqueado int64      `json:"valor_bloqueado"`
+	ValorDevolvido int64      `json:"valor_devolvido"`
+	PrazoAnalise   time.Time  `json:"prazo_analise"`
+	PrazoDevolucao time.Time  `json:"prazo_devolucao"`
+	DataBloqueio   *time.Time `json:"data_bloqueio,omitempty"`
+	DataConclusao  *time.Time `json:"data_conclusao,omitempty"`
+}
+
+// registrar acrescenta ao histórico a ação que levou o caso à situação nova
+func (c *Caso) registrar(acao Acao, para Situacao, valor int64, justificativa, usuarioID string, agora time.Time) error {
+	// o prazo é o da etapa em que a decisão foi tomada, antes de mudar a situação
+	foraDoPrazo := acao != AcaoAbertura && acao != AcaoCorrecao && c.Vencido(agora)
+	anterior := c.Situacao
+	c.Situacao = para
+
+	dados, err := json.Marshal(retrato{
+		Dados:          c.Dados,
+		Situacao:       c.Situacao,
+		ValorBloqueado: c.ValorBloqueado,
+		ValorDevolvido: c.ValorDevolvido,
+		PrazoAnalise:   c.PrazoAnalise,
+		PrazoDevolucao: c.PrazoDevolucao,
+		DataBloqueio:   c.DataBloqueio,
+		DataConclusao:  c.DataConclusao,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao registrar histórico do caso MED: %v", err)
+	}
+
+	r := Registro{
+		Sequencia:        len(c.Historico) + 1,
+		Acao:             acao,
+		SituacaoAnterior: anterior,
+		SituacaoNova:     para,
+		Valor:            valor,
+		Justificativa:    strings.TrimSpace(justificativa),
+		Dados:            string(dados),
+		ForaDoPrazo:      foraDoPrazo,
+		UsuarioID:        usuarioID,
+		Data:             agora,
+	}
+	if len(c.Historico) > 0 {
+		r.HashAnterior = c.Historico[len(c.Historico)-1].Hash
+	}
+	r.Hash = r.calcularHash(c.TicketID)
+	c.Historico = append(c.Historico, r)
+	return nil
+}
+
+// calcularHash resume o registro e o hash anterior; o ticket entra no cálculo para um registro
+// não poder ser movido para outro caso
+func (r Registro) calcularHash(ticketID string) string {
+	campos := []string{
+		ticketID,
+		strconv.Itoa(r.Sequencia),
+		string(r.Acao),
+		string(r.SituacaoAnterior),
+		string(r.SituacaoNova),
+		strconv.FormatInt(r.Valor, 10),
+		r.Justificativa,
+		r.Dados,
+		strconv.FormatBool(r.ForaDoPrazo),
+		r.UsuarioID,
+		r.Data.UTC().Format(time.RFC3339Nano),
+		r.HashAnterior,
+	}
+	soma := sha256.Sum256([]byte(strings.Join(campos, "\x1f")))
+	return hex.EncodeToString(soma[:])
+}
+
+// VerificarHistorico refaz a cadeia de hashes e falha com ErrHistoricoAdulterado no primeiro
+// registro que não confere
+func (c *Caso) VerificarHistorico() error {
+	hashAnterior := ""
+	for i, r := range c.Historico {
+		if r.Sequencia != i+1 || r.HashAnterior != hashAnterior || r.calcularHash(c.TicketID) != r.Hash {
+			return fmt.Errorf("%w: registro %d", ErrHistoricoAdulterado, i+1)
+		}
+		hashAnterior = r.Hash
+	}
+	return nil
+}
+
+// instante leva o horário para UTC com a precisão do banco, para o hash não mudar ao ser relido
+func instante(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
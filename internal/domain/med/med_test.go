@@ -0,0 +1,162 @@
+package med
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+var notificacao = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func dadosTeste() Dados {
+	return Dados{
+		EndToEndID:    "E12345678202609301230abcdefghijk",
+		IDNotificacao: "3F2504E0-4F89-41D3-9A0C-0305E82C3301",
+		Valor:         150000,
+		Pagador:       Participante{Nome: "Maria", Documento: "529.982.247-25", ISPB: "12345678"},
+		Recebedor:     Participante{Nome: "Loja X", Documento: "11.222.333/0001-81", ISPB: "87654321", Conta: "1234-5"},
+		DataTransacao: notificacao.Add(-24 * time.Hour),
+		// com fuso, para conferir que o horário é gravado em UTC
+		DataNotificacao: notificacao.In(time.FixedZone("BRT", -3*3600)),
+	}
+}
+
+func novoCasoTeste(t *testing.T) *Caso {
+	tk, err := ticket.NovoTicket("MED", "Golpe do Pix", ticket.CategoriaMeds, "", "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	c, err := NovoCaso(tk, dadosTeste(), PrazosPadrao(), "analista", notificacao.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Erro ao abrir caso: %v", err)
+	}
+	return c
+}
+
+func TestNovoCaso(t *testing.T) {
+	c := novoCasoTeste(t)
+	if c.Situacao != SituacaoEmAnalise || !c.PrazoAnalise.Equal(notificacao.Add(7*24*time.Hour)) {
+		t.Errorf("Caso aberto diferente: situação %s, prazo %v", c.Situacao, c.PrazoAnalise)
+	}
+	if c.Recebedor.Documento != "11222333000181" || c.IDNotificacao != "3f2504e0-4f89-41d3-9a0c-0305e82c3301" {
+		t.Errorf("Dados não normalizados: %+v", c.Dados)
+	}
+
+	tk, _ := ticket.NovoTicket("Bug", "Tela quebrada", ticket.CategoriaTI, ticket.SubcategoriaBug, "usuario_teste")
+	if _, err := NovoCaso(tk, dadosTeste(), PrazosPadrao(), "analista", notificacao); !errors.Is(err, ErrTicketNaoMED) {
+		t.Errorf("Esperava ErrTicketNaoMED, recebido %v", err)
+	}
+
+	med, _ := ticket.NovoTicket("MED", "Golpe do Pix", ticket.CategoriaMeds, "", "usuario_teste")
+	casos := []struct {
+		nome   string
+		mudar  func(d *Dados)
+		codigo string
+		campo  string
+	}{
+		{"end-to-end curto", func(d *Dados) { d.EndToEndID = "E123" }, "end_to_end_invalido", "end_to_end_id"},
+		{"notificação sem uuid", func(d *Dados) { d.IDNotificacao = "abc" }, "notificacao_invalida", "id_notificacao"},
+		{"valor zero", func(d *Dados) { d.Valor = 0 }, "valor_invalido", "valor"},
+		{"cpf inválido", func(d *Dados) { d.Pagador.Documento = "111.111.111-11" }, "documento_invalido", "pagador.documento"},
+		{"cnpj inválido", func(d *Dados) { d.Recebedor.Documento = "11.222.333/0001-80" }, "documento_invalido", "recebedor.documento"},
+		{"ispb", func(d *Dados) { d.Recebedor.ISPB = "123" }, "ispb_invalido", "recebedor.ispb"},
+		{"notificação futura", func(d *Dados) { d.DataNotificacao = notificacao.Add(48 * time.Hour) }, "data_notificacao_invalida", "data_notificacao"},
+		{"transação após notificação", func(d *Dados) { d.DataTransacao = notificacao.Add(time.Minute) }, "data_transacao_invalida", "data_transacao"},
+		{"notificação tardia", func(d *Dados) { d.DataTransacao = notificacao.Add(-81 * 24 * time.Hour) }, "notificacao_fora_do_prazo", "data_notificacao"},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			d := dadosTeste()
+			caso.mudar(&d)
+			_, err := NovoCaso(med, d, PrazosPadrao(), "analista", notificacao.Add(time.Hour))
+			e, ok := ticket.ComoErro(err)
+			if !ok || e.Codigo != caso.codigo || e.Campo != caso.campo {
+				t.Errorf("Esperava %s em %s, recebido %v", caso.codigo, caso.campo, err)
+			}
+		})
+	}
+}
+
+func TestCaso_Aplicar(t *testing.T) {
+	c := novoCasoTeste(t)
+	agora := notificacao.Add(2 * 24 * time.Hour)
+
+	// não é possível devolver sem bloquear, nem decidir sem justificativa ou acima do valor
+	if err := c.Aplicar(SituacaoDevolvido, Decisao{Valor: 100, Justificativa: "ok"}, "analista", agora); !errors.Is(err, ErrSituacaoInvalida) {
+		t.Errorf("Esperava ErrSituacaoInvalida, recebido %v", err)
+	}
+	if err := c.Aplicar(SituacaoFundosBloqueados, Decisao{Valor: 100}, "analista", agora); !errors.Is(err, ErrJustificativa) {
+		t.Errorf("Esperava ErrJustificativa, recebido %v", err)
+	}
+	if err := c.Aplicar(SituacaoFundosBloqueados, Decisao{Valor: 150001, Justificativa: "saldo"}, "analista", agora); !errors.Is(err, ErrValorBloqueio) {
+		t.Errorf("Esperava ErrValorBloqueio, recebido %v", err)
+	}
+
+	// bloqueio dentro do prazo e devolução depois do prazo
+	if err := c.Aplicar(SituacaoFundosBloqueados, Decisao{Valor: 90000, Justificativa: "saldo disponível"}, "analista", agora); err != nil {
+		t.Fatalf("Erro ao bloquear: %v", err)
+	}
+	if !c.PrazoVigente().Equal(c.PrazoDevolucao) {
+		t.Errorf("Prazo vigente deveria ser o da devolução")
+	}
+	if err := c.Aplicar(SituacaoDevolvido, Decisao{Valor: 90001, Justificativa: "devolução"}, "analista", agora); !errors.Is(err, ErrValorDevolucao) {
+		t.Errorf("Esperava ErrValorDevolucao, recebido %v", err)
+	}
+	tarde := notificacao.Add(12 * 24 * time.Hour)
+	if err := c.Aplicar(SituacaoDevolvido, Decisao{Valor: 90000, Justificativa: "devolução"}, "supervisor", tarde); err != nil {
+		t.Fatalf("Erro ao devolver: %v", err)
+	}
+	if c.PrazoVigente() != nil || c.Vencido(tarde.Add(time.Hour)) {
+		t.Errorf("Caso encerrado não deveria ter prazo")
+	}
+	if err := c.Aplicar(SituacaoRejeitado, Decisao{Justificativa: "engano"}, "analista", tarde); !errors.Is(err, ErrCasoEncerrado) {
+		t.Errorf("Esperava ErrCasoEncerrado, recebido %v", err)
+	}
+
+	esperados := []struct {
+		acao        Acao
+		situacao    Situacao
+		foraDoPrazo bool
+	}{
+		{AcaoAbertura, SituacaoEmAnalise, false},
+		{AcaoBloqueio, SituacaoFundosBloqueados, false},
+		{AcaoDevolucao, SituacaoDevolvido, true},
+	}
+	if len(c.Historico) != len(esperados) {
+		t.Fatalf("Esperava %d registros, recebido %+v", len(esperados), c.Historico)
+	}
+	for i, e := range esperados {
+		r := c.Historico[i]
+		if r.Acao != e.acao || r.SituacaoNova != e.situacao || r.ForaDoPrazo != e.foraDoPrazo {
+			t.Errorf("Registro %d = %+v, esperava %+v", i+1, r, e)
+		}
+	}
+}
+
+func TestCaso_VerificarHistorico(t *testing.T) {
+	c := novoCasoTeste(t)
+	if err := c.Corrigir(dadosTeste(), PrazosPadrao(), "", "analista", notificacao.Add(time.Hour)); !errors.Is(err, ErrJustificativa) {
+		t.Errorf("Esperava ErrJustificativa, recebido %v", err)
+	}
+	d := dadosTeste()
+	d.Valor = 120000
+	if err := c.Corrigir(d, PrazosPadrao(), "valor informado errado", "analista", notificacao.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Erro ao corrigir: %v", err)
+	}
+	c.Aplicar(SituacaoRejeitado, Decisao{Justificativa: "sem indícios de fraude"}, "analista", notificacao.Add(3*time.Hour))
+
+	if err := c.VerificarHistorico(); err != nil {
+		t.Fatalf("Histórico íntegro recusado: %v", err)
+	}
+	if c.Historico[1].HashAnterior != c.Historico[0].Hash {
+		t.Errorf("Registros não encadeados")
+	}
+
+	// alterar a justificativa de um registro antigo quebra a cadeia
+	c.Historico[1].Justificativa = "outra"
+	if err := c.VerificarHistorico(); !errors.Is(err, ErrHistoricoAdulterado) {
+		t.Errorf("Esperava ErrHistoricoAdulterado, recebido %v", err)
+	}
+}
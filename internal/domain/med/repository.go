@@ -0,0 +1,31 @@
+package med
+
+import (
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Filtro da listagem de casos
+type Filtro struct {
+	Situacoes []Situacao
+	// PrazoAte lista só os casos em aberto cujo prazo vigente vence até o instante informado
+	PrazoAte *time.Time
+	// Escopo limita a listagem aos tickets que o usuário pode ver; nil não restringe
+	Escopo *ticket.EscopoAcesso
+}
+
+type Repository interface {
+	// Gravar o caso novo com o histórico; falha com ErrCasoJaExiste se o ticket já tiver um caso
+	Criar(c *Caso) error
+
+	// Buscar o caso do ticket com o histórico; falha com ErrCasoNaoEncontrado
+	BuscarPorTicket(ticketID string) (*Caso, error)
+
+	// Gravar a situação e os registros novos do histórico; falha com ErrConflito se outro
+	// registro foi gravado desde a leitura do caso
+	Atualizar(c *Caso) error
+
+	// Listar os casos, sem o histórico, do prazo vigente mais próximo para o mais distante
+	Listar(filtro Filtro) ([]*Caso, error)
+}
@@ -0,0 +1,139 @@
+package notificacao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Tipo identifica o assunto da notificação, e é a chave das preferências do usuário
+type Tipo string
+
+const (
+	TipoTicketCriado         Tipo = "ticket_criado"
+	TipoTicketAtribuido      Tipo = "ticket_atribuido"
+	TipoStatusAlterado       Tipo = "status_alterado"
+	TipoObservacaoAdicionada Tipo = "observacao_adicionada"
+	TipoAlertaSLA            Tipo = "alerta_sla"
+	TipoEscalonamento        Tipo = "escalonamento"
+)
+
+// Tipos são todas as notificações enviadas
+var Tipos = []Tipo{
+	TipoTicketCriado,
+	TipoTicketAtribuido,
+	TipoStatusAlterado,
+	TipoObservacaoAdicionada,
+	TipoAlertaSLA,
+	TipoEscalonamento,
+}
+
+var (
+	ErrTipoInvalido = ticket.NovoErroValidacao("notificacoes", "tipo_notificacao_invalido", "tipo de notificação inválido")
+
+	// ErrEnvioRecusado indica que o servidor de e-mail recusou a mensagem de vez (ex.: destinatário inexistente),
+	// então não adianta tentar de novo
+	ErrEnvioRecusado = errors.New("envio recusado pelo servidor de e-mail")
+)
+
+// ValidarTipo verifica se o tipo de notificação existe
+func ValidarTipo(tipo Tipo) error {
+	for _, t := range Tipos {
+		if t == tipo {
+			return nil
+		}
+	}
+	return ErrTipoInvalido
+}
+
+// Preferencias guarda as notificações que o usuário não quer receber; as demais são enviadas
+type Preferencias struct {
+	UsuarioID   string
+	Desativadas []Tipo
+}
+
+// Permite indica se o usuário recebe as notificações do tipo
+func (p *Preferencias) Permite(tipo Tipo) bool {
+	for _, d := range p.Desativadas {
+		if d == tipo {
+			return false
+		}
+	}
+	return true
+}
+
+// Definir ativa ou desativa as notificações do tipo
+func (p *Preferencias) Definir(tipo Tipo, ativa bool) error {
+	if err := ValidarTipo(tipo); err != nil {
+		return err
+	}
+
+	desativadas := []Tipo{}
+	for _, d := range p.Desativadas {
+		if d != tipo {
+			desativadas = append(desativadas, d)
+		}
+	}
+	if !ativa {
+		desativadas = append(desativadas, tipo)
+	}
+	p.Desativadas = desativadas
+	return nil
+}
+
+// Mensagem é o e-mail montado para um destinatário
+type Mensagem struct {
+	Para     string // e-mail
+	Nome     string
+	Assunto  string
+	Corpo    string
+	TicketID string // ticket de que a mensagem trata, usado para ligar as respostas a ele
+}
+
+// Enviador entrega as mensagens; a implementação de produção usa SMTP
+type Enviador interface {
+	// Enviar entrega a mensagem; falhas definitivas devem ser embrulhadas em ErrEnvioRecusado
+	Enviar(ctx context.Context, m Mensagem) error
+}
+
+// Dados são as informações disponíveis para os templates das mensagens
+type Dados struct {
+	Destinatario   string // nome de quem recebe
+	Autor          string // nome de quem fez a alteração
+	Ticket         ticket.ResumoTicket
+	StatusAnterior ticket.Status
+	Observacao     string
+	Prazo          string     // prazo alertado: primeira_resposta ou resolucao
+	Vencimento     *time.Time // quando o prazo alertado vence
+	Regra          string     // regra de escalonamento aplicada
+	Mensagem       string     // mensagem da ação notificar da regra
+}
+
+// Renderizador monta o assunto e o corpo da mensagem de cada tipo de notificação
+type Renderizador interface {
+	Renderizar(tipo Tipo, dados Dados) (assunto, corpo string, err error)
+}
+
+// StatusEnvio é o resultado de um envio registrado no log
+type StatusEnvio string
+
+const (
+	EnvioRealizado StatusEnvio = "enviada"
+	EnvioFalhou    StatusEnvio = "falhou"
+)
+
+// Registro é uma linha do log de notificações
+type Registro struct {
+	ID        string
+	EventoID  string
+	TicketID  string
+	UsuarioID string
+	Email     string
+	Tipo      Tipo
+	Assunto   string
+	Status    StatusEnvio
+	Erro      string
+	Data      time.Time
+}
@@ -0,0 +1,237 @@
+package notificacao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+
+	"github.com/google/uuid"
+)
+
+// Usuarios consulta os destinatários no diretório de usuários
+type Usuarios interface {
+	BuscarUsuario(id string) (*usuario.Usuario, error)
+}
+
+// Notificador assina os eventos de domínio e avisa por e-mail o solicitante, o responsável
+// e os seguidores do ticket, respeitando as preferências de cada um
+type Notificador struct {
+	repo         Repository
+	usuarios     Usuarios
+	renderizador Renderizador
+	enviador     Enviador
+}
+
+// NovoNotificador cria o notificador de tickets
+func NovoNotificador(repo Repository, usuarios Usuarios, renderizador Renderizador, enviador Enviador) *Notificador {
+	return &Notificador{repo: repo, usuarios: usuarios, renderizador: renderizador, enviador: enviador}
+}
+
+// destinatario é um usuário a ser avisado e o assunto do aviso
+type destinatario struct {
+	usuarioID string
+	tipo      Tipo
+}
+
+// Receber envia as notificações do evento. Cada destinatário recebe no máximo uma mensagem por evento,
+// e quem já a recebeu não recebe de novo quando o relay repete o evento. Falhas temporárias de envio
+// são devolvidas para o relay tentar novamente; recusas definitivas ficam apenas no log.
+func (n *Notificador) Receber(ctx context.Context, e ticket.Evento) error {
+	// 1. quem deve ser avisado e de quê
+	destinatarios, err := n.destinatarios(e)
+	if err != nil {
+		return err
+	}
+	if len(destinatarios) == 0 {
+		return nil
+	}
+
+	dados := n.dados(e)
+	var erros []error
+	for _, d := range destinatarios {
+		if err := n.notificar(ctx, e, d, dados); err != nil {
+			erros = append(erros, fmt.Errorf("notificação de %s: %w", d.usuarioID, err))
+		}
+	}
+	return errors.Join(erros...)
+}
+
+// destinatarios escolhe quem recebe cada tipo de notificação. A atribuição tem prioridade sobre
+// os demais avisos do mesmo evento, e quem fez a alteração não é avisado dela, exceto na
+// confirmação de abertura enviada ao solicitante.
+func (n *Notificador) destinatarios(e ticket.Evento) ([]destinatario, error) {
+	destinatarios := []destinatario{}
+	vistos := map[string]bool{usuario.IDSistema: true}
+	adicionar := func(tipo Tipo, ids ...string) {
+		for _, id := range ids {
+			if id == "" || vistos[id] || id == e.UsuarioID {
+				continue
+			}
+			vistos[id] = true
+			destinatarios = append(destinatarios, destinatario{usuarioID: id, tipo: tipo})
+		}
+	}
+	seguidores := func() ([]string, error) {
+		return n.repo.ListarSeguidores(e.Ticket.ID)
+	}
+
+	switch e.Tipo {
+	case ticket.EventoTicketCriado:
+		if e.Ticket.AbertoPor != usuario.IDSistema {
+			vistos[e.Ticket.AbertoPor] = true
+			destinatarios = append(destinatarios, destinatario{usuarioID: e.Ticket.AbertoPor, tipo: TipoTicketCriado})
+		}
+		adicionar(TipoTicketAtribuido, e.Ticket.Responsavel)
+
+	case ticket.EventoStatusAlterado, ticket.EventoCampoModificado:
+		if mudanca(e, "responsavel") != nil {
+			adicionar(TipoTicketAtribuido, e.Ticket.Responsavel)
+		}
+		if e.Tipo == ticket.EventoStatusAlterado {
+			ids, err := seguidores()
+			if err != nil {
+				return nil, err
+			}
+			adicionar(TipoStatusAlterado, append([]string{e.Ticket.AbertoPor, e.Ticket.Responsavel}, ids...)...)
+		}
+
+	case ticket.EventoObservacaoAdicionada:
+		ids, err := seguidores()
+		if err != nil {
+			return nil, err
+		}
+		adicionar(TipoObservacaoAdicionada, append([]string{e.Ticket.AbertoPor, e.Ticket.Responsavel}, ids...)...)
+
+	case ticket.EventoSLAEmRisco:
+		ids, err := seguidores()
+		if err != nil {
+			return nil, err
+		}
+		adicionar(TipoAlertaSLA, append([]string{e.Ticket.Responsavel}, ids...)...)
+
+	case ticket.EventoTicketEscalonado:
+		// só as regras com a ação notificar avisam; as demais ações chegam pelos seus próprios avisos
+		if mudanca(e, campoNotificarEscalonamento) == nil {
+			break
+		}
+		ids, err := seguidores()
+		if err != nil {
+			return nil, err
+		}
+		adicionar(TipoEscalonamento, append([]string{e.Ticket.Responsavel}, ids...)...)
+	}
+	return destinatarios, nil
+}
+
+// dados reúne as informações do evento comuns a todos os destinatários
+func (n *Notificador) dados(e ticket.Evento) Dados {
+	dados := Dados{Autor: e.UsuarioID, Ticket: e.Ticket}
+	if u, err := n.usuarios.BuscarUsuario(e.UsuarioID); err == nil {
+		dados.Autor = u.Nome
+	}
+	if m := mudanca(e, "status"); m != nil {
+		dados.StatusAnterior = ticket.Status(m.ValorAnterior)
+	}
+	if e.Observacao != nil {
+		dados.Observacao = e.Observacao.Descricao
+	}
+	if e.Tipo == ticket.EventoSLAEmRisco && len(e.Mudancas) > 0 {
+		dados.Prazo = e.Mudancas[0].ValorNovo
+		dados.Vencimento = e.Ticket.PrazoResolucao
+		if dados.Prazo == ticket.PrazoPrimeiraResposta {
+			dados.Vencimento = e.Ticket.PrazoPrimeiraResposta
+		}
+	}
+	if e.Tipo == ticket.EventoTicketEscalonado {
+		if m := mudanca(e, "escalonamento"); m != nil {
+			dados.Regra = m.ValorNovo
+		}
+		if m := mudanca(e, campoNotificarEscalonamento); m != nil {
+			dados.Mensagem = m.ValorNovo
+		}
+	}
+	return dados
+}
+
+// notificar envia a notificação a um destinatário e registra o envio no log
+func (n *Notificador) notificar(ctx context.Context, e ticket.Evento, d destinatario, dados Dados) error {
+	// 2. apenas usuários ativos e com e-mail recebem notificações
+	u, err := n.usuarios.BuscarUsuario(d.usuarioID)
+	if errors.Is(err, usuario.ErrUsuarioNaoEncontrado) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !u.Ativo || u.Email == "" {
+		return nil
+	}
+
+	// 3. respeita as preferências do usuário
+	preferencias, err := n.repo.BuscarPreferencias(u.ID)
+	if err != nil {
+		return err
+	}
+	if !preferencias.Permite(d.tipo) {
+		return nil
+	}
+
+	// 4. o relay entrega pelo menos uma vez: quem já recebeu a notificação do evento não recebe de novo
+	enviada, err := n.repo.JaEnviada(e.ID, u.ID)
+	if err != nil {
+		return err
+	}
+	if enviada {
+		return nil
+	}
+
+	// 5. monta e envia a mensagem
+	dados.Destinatario = u.Nome
+	assunto, corpo, err := n.renderizador.Renderizar(d.tipo, dados)
+	if err != nil {
+		return err
+	}
+	errEnvio := n.enviador.Enviar(ctx, Mensagem{Para: u.Email, Nome: u.Nome, Assunto: assunto, Corpo: corpo, TicketID: e.Ticket.ID})
+
+	// 6. registra o envio, com sucesso ou falha, no log
+	registro := &Registro{
+		ID:        uuid.New().String(),
+		EventoID:  e.ID,
+		TicketID:  e.Ticket.ID,
+		UsuarioID: u.ID,
+		Email:     u.Email,
+		Tipo:      d.tipo,
+		Assunto:   assunto,
+		Status:    EnvioRealizado,
+		Data:      time.Now(),
+	}
+	if errEnvio != nil {
+		registro.Status = EnvioFalhou
+		registro.Erro = errEnvio.Error()
+	}
+	if err := n.repo.Registrar(registro); err != nil {
+		return err
+	}
+
+	if errEnvio != nil && !errors.Is(errEnvio, ErrEnvioRecusado) {
+		return errEnvio
+	}
+	return nil
+}
+
+// campoNotificarEscalonamento é a modificação da ação notificar de uma regra de escalonamento
+const campoNotificarEscalonamento = "escalonamento.notificar"
+
+// mudanca retorna a modificação do campo levada no evento, se houver
+func mudanca(e ticket.Evento, campo string) *ticket.Modificacao {
+	for i, m := range e.Mudancas {
+		if m.CampoModificado == campo {
+			return &e.Mudancas[i]
+		}
+	}
+	return nil
+}
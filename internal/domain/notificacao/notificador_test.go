@@ -0,0 +1,296 @@
+package notificacao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// repositorioMemoria guarda preferências, seguidores e o log em memória
+type repositorioMemoria struct {
+	preferencias map[string]*Preferencias
+	seguidores   map[string][]string
+	registros    []*Registro
+}
+
+func novoRepositorioMemoria() *repositorioMemoria {
+	return &repositorioMemoria{preferencias: map[string]*Preferencias{}, seguidores: map[string][]string{}}
+}
+
+func (r *repositorioMemoria) BuscarPreferencias(usuarioID string) (*Preferencias, error) {
+	if p, ok := r.preferencias[usuarioID]; ok {
+		return p, nil
+	}
+	return &Preferencias{UsuarioID: usuarioID}, nil
+}
+
+func (r *repositorioMemoria) SalvarPreferencias(p *Preferencias) error {
+	r.preferencias[p.UsuarioID] = p
+	return nil
+}
+
+func (r *repositorioMemoria) ListarSeguidores(ticketID string) ([]string, error) {
+	return r.seguidores[ticketID], nil
+}
+
+func (r *repositorioMemoria) AdicionarSeguidor(ticketID, usuarioID string) error {
+	r.seguidores[ticketID] = append(r.seguidores[ticketID], usuarioID)
+	return nil
+}
+
+func (r *repositorioMemoria) RemoverSeguidor(string, string) error { return nil }
+
+func (r *repositorioMemoria) JaEnviada(eventoID, usuarioID string) (bool, error) {
+	for _, reg := range r.registros {
+		if reg.EventoID == eventoID && reg.UsuarioID == usuarioID && reg.Status == EnvioRealizado {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *repositorioMemoria) Registrar(reg *Registro) error {
+	r.registros = append(r.registros, reg)
+	return nil
+}
+
+func (r *repositorioMemoria) ListarRegistros(FiltrosRegistro) ([]*Registro, error) {
+	return r.registros, nil
+}
+
+// usuariosMemoria é o diretório de usuários do teste
+type usuariosMemoria map[string]*usuario.Usuario
+
+func (u usuariosMemoria) BuscarUsuario(id string) (*usuario.Usuario, error) {
+	if usr, ok := u[id]; ok {
+		return usr, nil
+	}
+	return nil, usuario.ErrUsuarioNaoEncontrado
+}
+
+// renderizadorTeste usa o tipo como assunto
+type renderizadorTeste struct{}
+
+func (renderizadorTeste) Renderizar(tipo Tipo, dados Dados) (string, string, error) {
+	return string(tipo), fmt.Sprintf("Olá, %s. %s alterou o ticket %s.", dados.Destinatario, dados.Autor, dados.Ticket.Titulo), nil
+}
+
+// enviadorMemoria guarda as mensagens enviadas; falhas simula erros de envio por destinatário
+type enviadorMemoria struct {
+	enviadas []Mensagem
+	falhas   map[string]error
+}
+
+func (e *enviadorMemoria) Enviar(_ context.Context, m Mensagem) error {
+	if err := e.falhas[m.Para]; err != nil {
+		return err
+	}
+	e.enviadas = append(e.enviadas, m)
+	return nil
+}
+
+func (e *enviadorMemoria) para(email string) []Mensagem {
+	mensagens := []Mensagem{}
+	for _, m := range e.enviadas {
+		if m.Para == email {
+			mensagens = append(mensagens, m)
+		}
+	}
+	return mensagens
+}
+
+func novoUsuarioTeste(t *testing.T, id string) *usuario.Usuario {
+	u, err := usuario.NovoUsuario(id, "Nome "+id, id+"@nox.com")
+	if err != nil {
+		t.Fatalf("Erro ao criar usuário: %v", err)
+	}
+	return u
+}
+
+func novoCenario(t *testing.T) (*Notificador, *repositorioMemoria, *enviadorMemoria) {
+	usuarios := usuariosMemoria{}
+	for _, id := range []string{"solicitante", "analista", "supervisor", "sem_email"} {
+		usuarios[id] = novoUsuarioTeste(t, id)
+	}
+	usuarios["sem_email"].Email = ""
+
+	repo := novoRepositorioMemoria()
+	enviador := &enviadorMemoria{falhas: map[string]error{}}
+	return NovoNotificador(repo, usuarios, renderizadorTeste{}, enviador), repo, enviador
+}
+
+func resumoTeste() ticket.ResumoTicket {
+	return ticket.ResumoTicket{ID: "ticket-1", Titulo: "Saque não creditado", Status: ticket.StatusEmCurso, AbertoPor: "solicitante", Responsavel: "analista"}
+}
+
+func TestNotificador_DestinatariosPorEvento(t *testing.T) {
+	n, repo, enviador := novoCenario(t)
+	repo.AdicionarSeguidor("ticket-1", "supervisor")
+	repo.AdicionarSeguidor("ticket-1", "sem_email")
+	ctx := context.Background()
+
+	// abertura: confirmação ao solicitante e atribuição ao responsável
+	criado := ticket.Evento{ID: "e1", Tipo: ticket.EventoTicketCriado, UsuarioID: "solicitante", Ticket: resumoTeste()}
+	if err := n.Receber(ctx, criado); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if m := enviador.para("solicitante@nox.com"); len(m) != 1 || m[0].Assunto != string(TipoTicketCriado) {
+		t.Errorf("Esperava confirmação de abertura ao solicitante, recebido %+v", m)
+	}
+	if m := enviador.para("analista@nox.com"); len(m) != 1 || m[0].Assunto != string(TipoTicketAtribuido) {
+		t.Errorf("Esperava atribuição ao responsável, recebido %+v", m)
+	}
+	if len(enviador.para("supervisor@nox.com")) != 0 {
+		t.Error("Seguidor não deveria ser avisado da abertura")
+	}
+
+	// observação do responsável: avisa o solicitante e os seguidores, não o autor
+	enviador.enviadas = nil
+	observacao := ticket.Evento{
+		ID: "e2", Tipo: ticket.EventoObservacaoAdicionada, UsuarioID: "analista", Ticket: resumoTeste(),
+		Observacao: &ticket.Observacao{Descricao: "Estorno solicitado"},
+	}
+	if err := n.Receber(ctx, observacao); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if len(enviador.enviadas) != 2 || len(enviador.para("solicitante@nox.com")) != 1 || len(enviador.para("supervisor@nox.com")) != 1 {
+		t.Errorf("Esperava avisar solicitante e seguidor, recebido %+v", enviador.enviadas)
+	}
+	if corpo := enviador.para("solicitante@nox.com")[0].Corpo; corpo != "Olá, Nome solicitante. Nome analista alterou o ticket Saque não creditado." {
+		t.Errorf("Corpo diferente: %s", corpo)
+	}
+
+	// o mesmo evento repetido pelo relay não gera novos envios
+	enviador.enviadas = nil
+	if err := n.Receber(ctx, observacao); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if len(enviador.enviadas) != 0 {
+		t.Errorf("Evento repetido não deveria gerar envios, recebido %+v", enviador.enviadas)
+	}
+
+	// campo alterado sem troca de responsável não gera notificação
+	campo := ticket.Evento{
+		ID: "e3", Tipo: ticket.EventoCampoModificado, UsuarioID: "supervisor", Ticket: resumoTeste(),
+		Mudancas: []ticket.Modificacao{{CampoModificado: "urgencia", ValorAnterior: "2", ValorNovo: "4"}},
+	}
+	if err := n.Receber(ctx, campo); err != nil || len(enviador.enviadas) != 0 {
+		t.Errorf("Não esperava envios, recebido %+v (erro %v)", enviador.enviadas, err)
+	}
+
+	// todos os envios ficam no log
+	if len(repo.registros) != 4 {
+		t.Errorf("Esperava 4 envios no log, recebido %d", len(repo.registros))
+	}
+}
+
+func TestNotificador_AtribuicaoTemPrioridade(t *testing.T) {
+	n, _, enviador := novoCenario(t)
+
+	evento := ticket.Evento{
+		ID: "e1", Tipo: ticket.EventoStatusAlterado, UsuarioID: "supervisor", Ticket: resumoTeste(),
+		Mudancas: []ticket.Modificacao{
+			{CampoModificado: "status", ValorAnterior: "aberto", ValorNovo: "em_curso"},
+			{CampoModificado: "responsavel", ValorAnterior: "", ValorNovo: "analista"},
+		},
+	}
+	if err := n.Receber(context.Background(), evento); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if m := enviador.para("analista@nox.com"); len(m) != 1 || m[0].Assunto != string(TipoTicketAtribuido) {
+		t.Errorf("Esperava apenas a atribuição ao novo responsável, recebido %+v", m)
+	}
+	if m := enviador.para("solicitante@nox.com"); len(m) != 1 || m[0].Assunto != string(TipoStatusAlterado) {
+		t.Errorf("Esperava mudança de status ao solicitante, recebido %+v", m)
+	}
+}
+
+func TestNotificador_PreferenciasEFalhas(t *testing.T) {
+	n, repo, enviador := novoCenario(t)
+	repo.AdicionarSeguidor("ticket-1", "supervisor")
+
+	// o solicitante desativou os avisos de status e o servidor recusa o e-mail do supervisor
+	preferencias := &Preferencias{UsuarioID: "solicitante"}
+	preferencias.Definir(TipoStatusAlterado, false)
+	repo.SalvarPreferencias(preferencias)
+	enviador.falhas["supervisor@nox.com"] = fmt.Errorf("550 caixa inexistente: %w", ErrEnvioRecusado)
+
+	evento := ticket.Evento{
+		ID: "e1", Tipo: ticket.EventoStatusAlterado, UsuarioID: "analista", Ticket: resumoTeste(),
+		Mudancas: []ticket.Modificacao{{CampoModificado: "status", ValorAnterior: "em_curso", ValorNovo: "aguardando_cliente"}},
+	}
+	if err := n.Receber(context.Background(), evento); err != nil {
+		t.Fatalf("Recusa definitiva não deveria voltar ao relay: %v", err)
+	}
+	if len(enviador.enviadas) != 0 {
+		t.Errorf("Não esperava envios, recebido %+v", enviador.enviadas)
+	}
+	if len(repo.registros) != 1 || repo.registros[0].Status != EnvioFalhou || repo.registros[0].UsuarioID != "supervisor" {
+		t.Errorf("Esperava a falha registrada no log, recebido %+v", repo.registros)
+	}
+
+	// falha temporária volta ao relay, e o envio acontece na repetição
+	enviador.falhas["supervisor@nox.com"] = errors.New("conexão recusada")
+	evento.ID = "e2"
+	if err := n.Receber(context.Background(), evento); err == nil {
+		t.Fatal("Esperava erro de envio para o relay repetir o evento")
+	}
+	delete(enviador.falhas, "supervisor@nox.com")
+	if err := n.Receber(context.Background(), evento); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if len(enviador.para("supervisor@nox.com")) != 1 {
+		t.Errorf("Esperava o envio na repetição, recebido %+v", enviador.enviadas)
+	}
+
+	if err := preferencias.Definir("sms", false); !errors.Is(err, ErrTipoInvalido) {
+		t.Errorf("Esperava ErrTipoInvalido, recebido %v", err)
+	}
+}
+
+func TestNotificador_AlertaSLA(t *testing.T) {
+	n, _, enviador := novoCenario(t)
+
+	evento := ticket.Evento{
+		ID: "e1", Tipo: ticket.EventoSLAEmRisco, UsuarioID: usuario.IDSistema, Ticket: resumoTeste(),
+		Mudancas: []ticket.Modificacao{{CampoModificado: "alerta_sla", ValorNovo: ticket.PrazoResolucao}},
+	}
+	if err := n.Receber(context.Background(), evento); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if len(enviador.enviadas) != 1 || enviador.enviadas[0].Para != "analista@nox.com" || enviador.enviadas[0].Assunto != string(TipoAlertaSLA) {
+		t.Errorf("Esperava o alerta apenas ao responsável, recebido %+v", enviador.enviadas)
+	}
+}
+
+func TestNotificador_Escalonamento(t *testing.T) {
+	n, _, enviador := novoCenario(t)
+
+	// a regra sem a ação notificar não gera aviso
+	evento := ticket.Evento{
+		ID: "e1", Tipo: ticket.EventoTicketEscalonado, UsuarioID: usuario.IDSistema, Ticket: resumoTeste(),
+		Mudancas: []ticket.Modificacao{
+			{CampoModificado: "escalonamento.aumentar_urgencia", ValorAnterior: "3", ValorNovo: "4"},
+			{CampoModificado: "escalonamento", ValorNovo: "aberto_urgente"},
+		},
+	}
+	if err := n.Receber(context.Background(), evento); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if len(enviador.enviadas) != 0 {
+		t.Errorf("Não esperava aviso sem a ação notificar, recebido %+v", enviador.enviadas)
+	}
+
+	evento.ID = "e2"
+	evento.Mudancas = append([]ticket.Modificacao{{CampoModificado: "escalonamento.notificar", ValorNovo: "Ticket urgente sem atendimento"}}, evento.Mudancas...)
+	if err := n.Receber(context.Background(), evento); err != nil {
+		t.Fatalf("Erro ao notificar: %v", err)
+	}
+	if len(enviador.enviadas) != 1 || enviador.enviadas[0].Para != "analista@nox.com" || enviador.enviadas[0].Assunto != string(TipoEscalonamento) {
+		t.Errorf("Esperava o aviso apenas ao responsável, recebido %+v", enviador.enviadas)
+	}
+}
@@ -0,0 +1,34 @@
+package notificacao
+
+// FiltrosRegistro restringe a consulta ao log de notificações
+type FiltrosRegistro struct {
+	UsuarioID string
+	TicketID  string
+	Limite    int
+}
+
+type Repository interface {
+	// Buscar as preferências do usuário; sem preferências gravadas, todas as notificações ficam ativas
+	BuscarPreferencias(usuarioID string) (*Preferencias, error)
+
+	// Gravar as preferências do usuário
+	SalvarPreferencias(p *Preferencias) error
+
+	// Listar os IDs dos usuários que seguem o ticket
+	ListarSeguidores(ticketID string) ([]string, error)
+
+	// Passar a seguir o ticket; seguir de novo não tem efeito
+	AdicionarSeguidor(ticketID, usuarioID string) error
+
+	// Deixar de seguir o ticket
+	RemoverSeguidor(ticketID, usuarioID string) error
+
+	// Indica se a notificação do evento já foi enviada ao usuário
+	JaEnviada(eventoID, usuarioID string) (bool, error)
+
+	// Registrar um envio no log
+	Registrar(r *Registro) error
+
+	// Listar os envios mais recentes
+	ListarRegistros(filtros FiltrosRegistro) ([]*Registro, error)
+}
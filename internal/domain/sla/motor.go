@@ -0,0 +1,62 @@
+package sla
+
+import (
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Calendario conta os prazos em tempo útil
+type Calendario interface {
+	ticket.Calendario
+	Adicionar(inicio time.Time, d time.Duration) time.Time
+}
+
+// Motor escolhe a política de SLA de cada ticket e calcula seus prazos
+type Motor struct {
+	politicas  []Politica
+	calendario Calendario
+}
+
+// NovoMotor cria um motor de SLA com as políticas informadas.
+// Com calendario nil os prazos são contados em tempo corrido.
+func NovoMotor(politicas []Politica, calendario Calendario) *Motor {
+	if calendario == nil {
+		calendario = ticket.TempoCorrido{}
+	}
+	return &Motor{politicas: politicas, calendario: calendario}
+}
+
+// Politica retorna a política mais específica que se aplica ao ticket
+func (m *Motor) Politica(t *ticket.Ticket) (Politica, bool) {
+	var escolhida Politica
+	encontrou := false
+	for _, p := range m.politicas {
+		if !p.atende(t) {
+			continue
+		}
+		if !encontrou || p.especificidade() > escolhida.especificidade() {
+			escolhida = p
+			encontrou = true
+		}
+	}
+	return escolhida, encontrou
+}
+
+// Aplicar calcula os prazos do ticket, em tempo útil, a partir da data de abertura
+func (m *Motor) Aplicar(t *ticket.Ticket) {
+	politica, ok := m.Politica(t)
+	if !ok {
+		return
+	}
+
+	t.DefinirPrazosSLA(
+		m.calendario.Adicionar(t.DataAbertura, politica.PrimeiraResposta),
+		m.calendario.Adicionar(t.DataAbertura, politica.Resolucao),
+	)
+}
+
+// Estado calcula a situação do SLA do ticket usando o calendário do motor
+func (m *Motor) Estado(t *ticket.Ticket, agora time.Time) ticket.EstadoSLA {
+	return t.EstadoSLA(agora, m.calendario)
+}
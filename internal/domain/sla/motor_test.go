@@ -0,0 +1,71 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Função auxiliar para criar um ticket de teste com urgência e gravidade
+func novoTicketTeste(t *testing.T, urgencia, gravidade int) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Ticket de Teste", "Descrição", ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket de teste: %v", err)
+	}
+	tk.Urgencia = urgencia
+	tk.Gravidade = gravidade
+	return tk
+}
+
+func TestMotor_MatrizPadrao(t *testing.T) {
+	motor := NovoMotor(PoliticasPadrao(), nil)
+
+	critico := novoTicketTeste(t, 5, 5)
+	motor.Aplicar(critico)
+	if got := critico.SLA.PrazoResolucao.Sub(critico.DataAbertura); got != 4*time.Hour {
+		t.Errorf("Prazo de resolução diferente para 5x5: esperado 4h, recebido %s", got)
+	}
+
+	baixo := novoTicketTeste(t, 1, 1)
+	motor.Aplicar(baixo)
+	if got := baixo.SLA.PrazoPrimeiraResposta.Sub(baixo.DataAbertura); got != 24*time.Hour {
+		t.Errorf("Prazo de primeira resposta diferente para 1x1: esperado 24h, recebido %s", got)
+	}
+}
+
+func TestMotor_PoliticaMaisEspecifica(t *testing.T) {
+	politicas := append(PoliticasPadrao(), Politica{
+		Nome:             "saque",
+		Categoria:        ticket.CategoriaFinanceiro,
+		Subcategoria:     ticket.SubcategoriaSolicitacaoSaque,
+		PrimeiraResposta: 15 * time.Minute,
+		Resolucao:        2 * time.Hour,
+	})
+	motor := NovoMotor(politicas, nil)
+
+	tk := novoTicketTeste(t, 1, 1)
+	politica, ok := motor.Politica(tk)
+	if !ok || politica.Nome != "saque" {
+		t.Errorf("Esperava a política de saque, recebido %+v", politica)
+	}
+}
+
+func TestEstadoSLA_Violacao(t *testing.T) {
+	motor := NovoMotor(PoliticasPadrao(), nil)
+	tk := novoTicketTeste(t, 5, 5)
+	motor.Aplicar(tk)
+
+	dentro := motor.Estado(tk, tk.DataAbertura.Add(30*time.Minute))
+	if dentro.Violado {
+		t.Error("SLA não deveria estar violado dentro do prazo")
+	}
+	if dentro.TempoRestanteResolucao != 3*time.Hour+30*time.Minute {
+		t.Errorf("Tempo restante diferente: recebido %s", dentro.TempoRestanteResolucao)
+	}
+
+	fora := motor.Estado(tk, tk.DataAbertura.Add(2*time.Hour))
+	if !fora.Violado {
+		t.Error("SLA deveria estar violado após o prazo de primeira resposta")
+	}
+}
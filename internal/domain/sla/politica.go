@@ -0,0 +1,144 @@
+package sla
+
+import (
+	"fmt"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Politica define os prazos de atendimento para um recorte de tickets.
+// Campos vazios (ou zero) funcionam como curinga.
+type Politica struct {
+	Nome             string
+	Categoria        ticket.Categoria
+	Subcategoria     ticket.Subcategoria
+	Urgencia         int
+	Gravidade        int
+	PrimeiraResposta time.Duration
+	Resolucao        time.Duration
+}
+
+// Validar confere os recortes e os prazos da política
+func (p Politica) Validar() error {
+	if p.Nome == "" {
+		return fmt.Errorf("política de SLA sem nome")
+	}
+	if p.Urgencia < 0 || p.Urgencia > 5 || p.Gravidade < 0 || p.Gravidade > 5 {
+		return fmt.Errorf("política %s: urgência e gravidade devem ser de 1 a 5", p.Nome)
+	}
+	if p.Subcategoria != "" && p.Categoria == "" {
+		return fmt.Errorf("política %s: a subcategoria exige a categoria", p.Nome)
+	}
+	if p.PrimeiraResposta <= 0 || p.Resolucao <= 0 {
+		return fmt.Errorf("política %s: os prazos devem ser positivos", p.Nome)
+	}
+	if p.Resolucao < p.PrimeiraResposta {
+		return fmt.Errorf("política %s: a resolução não pode vencer antes da primeira resposta", p.Nome)
+	}
+	return nil
+}
+
+// ValidarClassificacoes confere na taxonomia as categorias e subcategorias das políticas
+func ValidarClassificacoes(politicas []Politica, taxonomia ticket.Taxonomia) error {
+	for _, p := range politicas {
+		var err error
+		switch {
+		case p.Subcategoria != "":
+			err = taxonomia.ValidarClassificacao(p.Categoria, p.Subcategoria)
+		case p.Categoria != "":
+			err = taxonomia.ValidarCategoria(p.Categoria)
+		}
+		if err != nil {
+			return fmt.Errorf("política de SLA %s: %w", p.Nome, err)
+		}
+	}
+	return nil
+}
+
+// atende verifica se a política se aplica ao ticket
+func (p Politica) atende(t *ticket.Ticket) bool {
+	if p.Categoria != "" && p.Categoria != t.Categoria {
+		return false
+	}
+	if p.Subcategoria != "" && p.Subcategoria != t.Subcategoria {
+		return false
+	}
+	if p.Urgencia != 0 && p.Urgencia != t.Urgencia {
+		return false
+	}
+	if p.Gravidade != 0 && p.Gravidade != t.Gravidade {
+		return false
+	}
+	return true
+}
+
+// especificidade dá mais peso à subcategoria, depois à categoria e por fim à célula da matriz
+func (p Politica) especificidade() int {
+	peso := 0
+	if p.Subcategoria != "" {
+		peso += 8
+	}
+	if p.Categoria != "" {
+		peso += 4
+	}
+	if p.Urgencia != 0 {
+		peso++
+	}
+	if p.Gravidade != 0 {
+		peso++
+	}
+	return peso
+}
+
+// Prioridade classifica a célula urgência × gravidade de 1 (mais crítica) a 4
+func Prioridade(urgencia, gravidade int) int {
+	switch impacto := urgencia * gravidade; {
+	case impacto >= 16:
+		return 1
+	case impacto >= 9:
+		return 2
+	case impacto >= 4:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// PrazosPorPrioridade são os alvos de primeira resposta e resolução de cada prioridade, de 1 a 4
+type PrazosPorPrioridade map[int][2]time.Duration
+
+// prazosPadrao são os alvos usados quando a configuração não informa outros
+var prazosPadrao = PrazosPorPrioridade{
+	1: {1 * time.Hour, 4 * time.Hour},
+	2: {4 * time.Hour, 24 * time.Hour},
+	3: {8 * time.Hour, 72 * time.Hour},
+	4: {24 * time.Hour, 120 * time.Hour},
+}
+
+// PoliticasPadrao monta uma política para cada célula da matriz urgência × gravidade com os prazos padrão
+func PoliticasPadrao() []Politica {
+	return Matriz(prazosPadrao)
+}
+
+// Matriz monta uma política para cada célula da matriz urgência × gravidade; as prioridades sem
+// prazos informados usam os prazos padrão
+func Matriz(prazosPorPrioridade PrazosPorPrioridade) []Politica {
+	politicas := make([]Politica, 0, 25)
+	for urgencia := 1; urgencia <= 5; urgencia++ {
+		for gravidade := 1; gravidade <= 5; gravidade++ {
+			prazos, ok := prazosPorPrioridade[Prioridade(urgencia, gravidade)]
+			if !ok {
+				prazos = prazosPadrao[Prioridade(urgencia, gravidade)]
+			}
+			politicas = append(politicas, Politica{
+				Nome:             "matriz",
+				Urgencia:         urgencia,
+				Gravidade:        gravidade,
+				PrimeiraResposta: prazos[0],
+				Resolucao:        prazos[1],
+			})
+		}
+	}
+	return politicas
+}
@@ -0,0 +1,17 @@
+package taxonomia
+
+import "nox_tickets/internal/domain/ticket"
+
+type Repository interface {
+	// Criar categoria com as subcategorias; falha com ErrCategoriaDuplicada se o código já existir
+	Criar(c *Categoria) error
+
+	// Buscar categoria pelo código, com as subcategorias; falha com ErrCategoriaNaoEncontrada
+	Buscar(codigo ticket.Categoria) (*Categoria, error)
+
+	// Listar categorias ordenadas por nome, com as subcategorias
+	Listar(apenasAtivas bool) ([]*Categoria, error)
+
+	// Atualizar o nome e a situação da categoria e gravar as subcategorias, incluindo as novas
+	Atualizar(c *Categoria) error
+}
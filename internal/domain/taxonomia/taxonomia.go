@@ -0,0 +1,128 @@
+package taxonomia
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+var (
+	ErrCategoriaNaoEncontrada    = ticket.NovoErroNaoEncontrado("categoria_nao_encontrada", "categoria não encontrada")
+	ErrSubcategoriaNaoEncontrada = ticket.NovoErroNaoEncontrado("subcategoria_nao_encontrada", "subcategoria não encontrada")
+	ErrCodigoInvalido            = ticket.NovoErroValidacao("codigo", "codigo_invalido", "o código deve ter até 50 letras minúsculas, números ou _")
+	ErrNomeObrigatorio           = ticket.NovoErroValidacao("nome", "nome_obrigatorio", "nome é obrigatório")
+	ErrCategoriaDuplicada        = ticket.NovoErroConflito("categoria_duplicada", "já existe uma categoria com este código")
+	ErrSubcategoriaDuplicada     = ticket.NovoErroConflito("subcategoria_duplicada", "a categoria já tem uma subcategoria com este código")
+)
+
+// os códigos são gravados nos tickets e usados nas URLs, então ficam restritos a um formato simples
+var formatoCodigo = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// Categoria é uma categoria de ticket, com as subcategorias aceitas nela. Categorias e
+// subcategorias não são apagadas, só desativadas, porque os tickets antigos continuam com elas.
+type Categoria struct {
+	Codigo        ticket.Categoria
+	Nome          string // nome de exibição
+	Ativa         bool
+	Subcategorias []Subcategoria
+	DataCriacao   time.Time
+}
+
+// Subcategoria é uma subcategoria aceita dentro de uma categoria
+type Subcategoria struct {
+	Codigo ticket.Subcategoria
+	Nome   string
+	Ativa  bool
+}
+
+// NovaCategoria cria uma categoria ativa, sem subcategorias
+func NovaCategoria(codigo ticket.Categoria, nome string) (*Categoria, error) {
+	c := &Categoria{
+		Codigo:      ticket.Categoria(normalizarCodigo(string(codigo))),
+		Ativa:       true,
+		DataCriacao: time.Now(),
+	}
+	if !formatoCodigo.MatchString(string(c.Codigo)) {
+		return nil, ErrCodigoInvalido
+	}
+	if err := c.SetNome(nome); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetNome altera o nome de exibição da categoria
+func (c *Categoria) SetNome(nome string) error {
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+	c.Nome = nome
+	return nil
+}
+
+// AdicionarSubcategoria inclui uma subcategoria ativa na categoria
+func (c *Categoria) AdicionarSubcategoria(codigo ticket.Subcategoria, nome string) (*Subcategoria, error) {
+	codigo = ticket.Subcategoria(normalizarCodigo(string(codigo)))
+	if !formatoCodigo.MatchString(string(codigo)) {
+		return nil, ErrCodigoInvalido
+	}
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return nil, ErrNomeObrigatorio
+	}
+	if _, err := c.Subcategoria(codigo); err == nil {
+		return nil, ErrSubcategoriaDuplicada
+	}
+	c.Subcategorias = append(c.Subcategorias, Subcategoria{Codigo: codigo, Nome: nome, Ativa: true})
+	return &c.Subcategorias[len(c.Subcategorias)-1], nil
+}
+
+// Subcategoria retorna a subcategoria da categoria pelo código
+func (c *Categoria) Subcategoria(codigo ticket.Subcategoria) (*Subcategoria, error) {
+	codigo = ticket.Subcategoria(normalizarCodigo(string(codigo)))
+	for i := range c.Subcategorias {
+		if c.Subcategorias[i].Codigo == codigo {
+			return &c.Subcategorias[i], nil
+		}
+	}
+	return nil, ErrSubcategoriaNaoEncontrada
+}
+
+// SetNome altera o nome de exibição da subcategoria
+func (s *Subcategoria) SetNome(nome string) error {
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+	s.Nome = nome
+	return nil
+}
+
+// Aceita indica se um ticket pode ser classificado na categoria com a subcategoria informada.
+// Uma categoria com subcategorias ativas exige uma delas; uma categoria sem nenhuma não aceita subcategoria.
+func (c *Categoria) Aceita(subcategoria ticket.Subcategoria) error {
+	if !c.Ativa {
+		return ticket.ErrCategoriaInvalida
+	}
+	subcategoria = ticket.Subcategoria(normalizarCodigo(string(subcategoria)))
+	if subcategoria == "" {
+		for _, s := range c.Subcategorias {
+			if s.Ativa {
+				return ticket.ErrSubcategoriaObrigatoria
+			}
+		}
+		return nil
+	}
+	s, err := c.Subcategoria(subcategoria)
+	if err != nil || !s.Ativa {
+		return ticket.ErrSubcategoriaInvalida
+	}
+	return nil
+}
+
+func normalizarCodigo(codigo string) string {
+	return strings.ToLower(strings.TrimSpace(codigo))
+}
@@ -0,0 +1,136 @@
+package taxonomia
+
+import (
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// repositorioEmMemoria guarda as categorias em um mapa
+type repositorioEmMemoria map[ticket.Categoria]*Categoria
+
+func (r repositorioEmMemoria) Criar(c *Categoria) error {
+	if _, ok := r[c.Codigo]; ok {
+		return ErrCategoriaDuplicada
+	}
+	r[c.Codigo] = c
+	return nil
+}
+
+func (r repositorioEmMemoria) Buscar(codigo ticket.Categoria) (*Categoria, error) {
+	c, ok := r[codigo]
+	if !ok {
+		return nil, ErrCategoriaNaoEncontrada
+	}
+	return c, nil
+}
+
+func (r repositorioEmMemoria) Listar(apenasAtivas bool) ([]*Categoria, error) {
+	var categorias []*Categoria
+	for _, c := range r {
+		if c.Ativa || !apenasAtivas {
+			categorias = append(categorias, c)
+		}
+	}
+	return categorias, nil
+}
+
+func (r repositorioEmMemoria) Atualizar(c *Categoria) error {
+	r[c.Codigo] = c
+	return nil
+}
+
+func novaTaxonomiaTeste(t *testing.T) (*Validador, repositorioEmMemoria) {
+	repo := repositorioEmMemoria{}
+
+	financeiro, err := NovaCategoria("Financeiro", "Financeiro")
+	if err != nil {
+		t.Fatalf("Erro ao criar categoria: %v", err)
+	}
+	financeiro.AdicionarSubcategoria(ticket.SubcategoriaSolicitacaoSaque, "Solicitação de saque")
+	financeiro.AdicionarSubcategoria(ticket.SubcategoriaDuvidas, "Dúvidas")
+	repo.Criar(financeiro)
+
+	ti, _ := NovaCategoria(ticket.CategoriaTI, "TI")
+	ti.AdicionarSubcategoria(ticket.SubcategoriaBug, "Bug")
+	repo.Criar(ti)
+
+	// categoria sem subcategorias
+	gestores, _ := NovaCategoria(ticket.CategoriaGestores, "Gestores")
+	repo.Criar(gestores)
+
+	return NovoValidador(repo), repo
+}
+
+func TestValidador_ValidarClassificacao(t *testing.T) {
+	validador, repo := novaTaxonomiaTeste(t)
+
+	casos := []struct {
+		nome         string
+		categoria    ticket.Categoria
+		subcategoria ticket.Subcategoria
+		esperado     error
+	}{
+		{"par válido", ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, nil},
+		{"maiúsculas", "FINANCEIRO", "Solicitacao_De_Saque", nil},
+		{"subcategoria de outra categoria", ticket.CategoriaTI, ticket.SubcategoriaSolicitacaoSaque, ticket.ErrSubcategoriaInvalida},
+		{"subcategoria inexistente", ticket.CategoriaTI, "qualquer_coisa", ticket.ErrSubcategoriaInvalida},
+		{"subcategoria obrigatória", ticket.CategoriaTI, "", ticket.ErrSubcategoriaObrigatoria},
+		{"categoria sem subcategorias", ticket.CategoriaGestores, "", nil},
+		{"categoria sem subcategorias não aceita subcategoria", ticket.CategoriaGestores, ticket.SubcategoriaBug, ticket.ErrSubcategoriaInvalida},
+		{"categoria inexistente", "marketing", "", ticket.ErrCategoriaInvalida},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if err := validador.ValidarClassificacao(c.categoria, c.subcategoria); !errors.Is(err, c.esperado) {
+				t.Errorf("Esperava %v, recebeu %v", c.esperado, err)
+			}
+		})
+	}
+
+	// desativar a subcategoria a tira da classificação; desativar a categoria tira a categoria inteira
+	saque, _ := repo[ticket.CategoriaFinanceiro].Subcategoria(ticket.SubcategoriaSolicitacaoSaque)
+	saque.Ativa = false
+	if err := validador.ValidarClassificacao(ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque); !errors.Is(err, ticket.ErrSubcategoriaInvalida) {
+		t.Errorf("Esperava subcategoria desativada inválida, recebeu %v", err)
+	}
+	repo[ticket.CategoriaFinanceiro].Ativa = false
+	if err := validador.ValidarCategoria(ticket.CategoriaFinanceiro); !errors.Is(err, ticket.ErrCategoriaInvalida) {
+		t.Errorf("Esperava categoria desativada inválida, recebeu %v", err)
+	}
+}
+
+func TestValidarRecorte(t *testing.T) {
+	validador, _ := novaTaxonomiaTeste(t)
+
+	if err := ValidarRecorte(validador, "", ticket.SubcategoriaBug); err != nil {
+		t.Errorf("Recorte sem categoria vale para qualquer uma: %v", err)
+	}
+	if err := ValidarRecorte(validador, ticket.CategoriaTI, ""); err != nil {
+		t.Errorf("Recorte só com a categoria deveria ser válido: %v", err)
+	}
+	if err := ValidarRecorte(validador, ticket.CategoriaTI, ticket.SubcategoriaSolicitacaoSaque); !errors.Is(err, ticket.ErrSubcategoriaInvalida) {
+		t.Errorf("Esperava ErrSubcategoriaInvalida, recebeu %v", err)
+	}
+}
+
+func TestCategoria_Validacoes(t *testing.T) {
+	if _, err := NovaCategoria("com espaço", "Nome"); !errors.Is(err, ErrCodigoInvalido) {
+		t.Errorf("Esperava ErrCodigoInvalido, recebeu %v", err)
+	}
+	if _, err := NovaCategoria("marketing", "  "); !errors.Is(err, ErrNomeObrigatorio) {
+		t.Errorf("Esperava ErrNomeObrigatorio, recebeu %v", err)
+	}
+
+	c, _ := NovaCategoria("marketing", "Marketing")
+	if _, err := c.AdicionarSubcategoria("campanhas", "Campanhas"); err != nil {
+		t.Fatalf("Erro ao adicionar subcategoria: %v", err)
+	}
+	if _, err := c.AdicionarSubcategoria("Campanhas", "De novo"); !errors.Is(err, ErrSubcategoriaDuplicada) {
+		t.Errorf("Esperava ErrSubcategoriaDuplicada, recebeu %v", err)
+	}
+	if _, err := c.Subcategoria("inexistente"); !errors.Is(err, ErrSubcategoriaNaoEncontrada) {
+		t.Errorf("Esperava ErrSubcategoriaNaoEncontrada, recebeu %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package taxonomia
+
+import (
+	"errors"
+	"strings"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Validador valida a classificação dos tickets contra as categorias gravadas no repositório;
+// implementa ticket.Taxonomia
+type Validador struct {
+	repo Repository
+}
+
+// NovoValidador cria o validador sobre o repositório da taxonomia
+func NovoValidador(repo Repository) *Validador {
+	return &Validador{repo: repo}
+}
+
+// ValidarCategoria falha com ticket.ErrCategoriaInvalida se a categoria não existir ou estiver inativa
+func (v *Validador) ValidarCategoria(categoria ticket.Categoria) error {
+	c, err := v.buscar(categoria)
+	if err != nil {
+		return err
+	}
+	if !c.Ativa {
+		return ticket.ErrCategoriaInvalida
+	}
+	return nil
+}
+
+// ValidarClassificacao valida a categoria e a subcategoria de um ticket
+func (v *Validador) ValidarClassificacao(categoria ticket.Categoria, subcategoria ticket.Subcategoria) error {
+	c, err := v.buscar(categoria)
+	if err != nil {
+		return err
+	}
+	return c.Aceita(subcategoria)
+}
+
+// buscar traduz a categoria inexistente em erro de validação do ticket
+func (v *Validador) buscar(categoria ticket.Categoria) (*Categoria, error) {
+	c, err := v.repo.Buscar(ticket.Categoria(normalizarCodigo(string(categoria))))
+	if errors.Is(err, ErrCategoriaNaoEncontrada) {
+		return nil, ticket.ErrCategoriaInvalida
+	}
+	return c, err
+}
+
+// ValidarRecorte valida um filtro de categoria e subcategoria em que os valores vazios valem
+// para qualquer um, como o das filas e dos webhooks
+func ValidarRecorte(taxonomia ticket.Taxonomia, categoria ticket.Categoria, subcategoria ticket.Subcategoria) error {
+	if strings.TrimSpace(string(categoria)) == "" {
+		return nil
+	}
+	if subcategoria == "" {
+		return taxonomia.ValidarCategoria(categoria)
+	}
+	return taxonomia.ValidarClassificacao(categoria, subcategoria)
+}
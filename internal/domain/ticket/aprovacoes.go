@@ -0,0 +1,77 @@
+package ticket
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DecisaoAprovacao é o voto de um aprovador
+type DecisaoAprovacao string
+
+const (
+	DecisaoAprovada  DecisaoAprovacao = "aprovada"
+	DecisaoRejeitada DecisaoAprovacao = "rejeitada"
+)
+
+// Aprovacao é a decisão de um aprovador em uma etapa da aprovação do ticket. As decisões valem
+// para o valor em que foram tomadas: se o valor mudar, a aprovação recomeça.
+type Aprovacao struct {
+	ID         string
+	TicketID   string
+	Etapa      string
+	Decisao    DecisaoAprovacao
+	Comentario string
+	Valor      int64 // valor do ticket no momento da decisão, em centavos
+	UsuarioID  string
+	Data       time.Time
+}
+
+// AprovacoesNovas retorna as decisões registradas desde a última gravação
+func (t *Ticket) AprovacoesNovas() []Aprovacao {
+	if t.aprovacoesPersistidas > len(t.Aprovacoes) {
+		return nil
+	}
+	return t.Aprovacoes[t.aprovacoesPersistidas:]
+}
+
+// RegistrarAprovacao acrescenta a decisão do aprovador e registra a modificação "aprovacao.<etapa>".
+// As regras de quem pode decidir ficam com quem chama, que conhece a política de aprovação
+func (t *Ticket) RegistrarAprovacao(etapa string, decisao DecisaoAprovacao, comentario string, valor int64, usuarioID string) error {
+	if t.Status == StatusFinalizado || t.Status == StatusCancelado {
+		return ErrTicketEncerrado
+	}
+
+	t.Aprovacoes = append(t.Aprovacoes, Aprovacao{
+		ID:         uuid.New().String(),
+		TicketID:   t.ID,
+		Etapa:      etapa,
+		Decisao:    decisao,
+		Comentario: comentario,
+		Valor:      valor,
+		UsuarioID:  usuarioID,
+		Data:       time.Now(),
+	})
+	return t.registrarModificacao("aprovacao."+etapa, "", string(decisao)+" "+strconv.FormatFloat(float64(valor)/100, 'f', 2, 64), usuarioID)
+}
+
+// Centavos converte um valor em reais para centavos, arredondando para o centavo mais próximo
+func Centavos(reais float64) int64 {
+	return int64(math.Round(reais * 100))
+}
+
+// ValorEmCentavos retorna o campo personalizado numérico, em reais, convertido para centavos. É o valor do
+// ticket nas aprovações: a conversão acontece só aqui, e daí em diante os valores são comparados como inteiros
+func (t *Ticket) ValorEmCentavos(campo string) (int64, bool) {
+	switch v := t.Campos[campo].(type) {
+	case float64:
+		return Centavos(v), true
+	case int:
+		return int64(v) * 100, true
+	case int64:
+		return v * 100, true
+	}
+	return 0, false
+}
@@ -0,0 +1,94 @@
+package ticket
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// prefixoCampo é o prefixo das modificações dos campos personalizados (campos.<nome>)
+const prefixoCampo = "campos."
+
+// ErrCampoReservado indica um campo personalizado com o nome de uma informação adicional do ticket
+var ErrCampoReservado = NovoErroValidacao("campos", "campo_reservado", "o nome é de uma informação adicional do ticket e não pode ser um campo personalizado")
+
+// Formularios valida os campos do ticket contra o formulário da categoria e subcategoria dele
+type Formularios interface {
+	// ValidarCampos confere os tipos e os formatos dos campos e, com obrigatorios, a presença dos
+	// campos exigidos pelo formulário. Os erros de todos os campos vêm juntos em Erro.Detalhes
+	ValidarCampos(t *Ticket, obrigatorios bool) error
+}
+
+// CamposPadrao são as informações adicionais gravadas em colunas do ticket; o formulário pode
+// referenciá-las pelo nome, mas elas não podem ser usadas como campos personalizados
+var CamposPadrao = []string{"merchant", "nox_id", "cpf", "plataforma", "contato"}
+
+// SetCampos altera os campos personalizados do ticket. Um valor nulo remove o campo. Cada campo
+// alterado fica nas modificações como campos.<nome>, com os valores em JSON
+func (t *Ticket) SetCampos(campos map[string]interface{}, usuarioID string) error {
+	nomes := make([]string, 0, len(campos))
+	for nome := range campos {
+		for _, padrao := range CamposPadrao {
+			if nome == padrao {
+				return ErrCampoReservado
+			}
+		}
+		nomes = append(nomes, nome)
+	}
+	// ordem estável no histórico
+	sort.Strings(nomes)
+
+	for _, nome := range nomes {
+		valor := campos[nome]
+		anterior, existia := t.Campos[nome]
+		if existia && reflect.DeepEqual(anterior, valor) || !existia && valor == nil {
+			continue
+		}
+
+		if valor == nil {
+			delete(t.Campos, nome)
+		} else {
+			if t.Campos == nil {
+				t.Campos = map[string]interface{}{}
+			}
+			t.Campos[nome] = valor
+		}
+		if err := t.registrarModificacao(prefixoCampo+nome, valorJSON(anterior, existia), valorJSON(valor, valor != nil), usuarioID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValoresFormulario retorna os valores que o formulário confere: as informações adicionais
+// preenchidas e os campos personalizados
+func (t *Ticket) ValoresFormulario() map[string]interface{} {
+	valores := map[string]interface{}{}
+	for nome, valor := range t.Campos {
+		valores[nome] = valor
+	}
+	padrao := map[string]*string{
+		"merchant":   t.Merchant,
+		"nox_id":     t.NoxID,
+		"cpf":        t.CPF,
+		"plataforma": t.Plataforma,
+		"contato":    &t.Contato,
+	}
+	for nome, valor := range padrao {
+		if valor != nil && *valor != "" {
+			valores[nome] = *valor
+		}
+	}
+	return valores
+}
+
+func valorJSON(valor interface{}, presente bool) string {
+	if !presente {
+		return ""
+	}
+	conteudo, err := json.Marshal(valor)
+	if err != nil {
+		return ""
+	}
+	return string(conteudo)
+}
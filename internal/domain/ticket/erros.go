@@ -0,0 +1,92 @@
+package ticket
+
+import "errors"
+
+// TipoErro classifica os erros do domínio, para quem os recebe decidir como tratá-los
+type TipoErro string
+
+const (
+	TipoNaoEncontrado     TipoErro = "nao_encontrado"
+	TipoValidacao         TipoErro = "validacao"
+	TipoTransicaoInvalida TipoErro = "transicao_invalida"
+	TipoConflito          TipoErro = "conflito"
+	TipoProibido          TipoErro = "proibido"
+	TipoNaoAutenticado    TipoErro = "nao_autenticado"
+)
+
+// Erro é um erro do domínio com tipo, código estável e, nas validações, o campo envolvido.
+// Os erros exportados do pacote são valores *Erro e podem ser comparados com errors.Is
+// mesmo quando embrulhados com fmt.Errorf("%w: ...").
+type Erro struct {
+	Tipo     TipoErro
+	Codigo   string // identificador estável, para o cliente tratar sem depender da mensagem
+	Mensagem string
+	Campo    string // campo inválido, apenas para erros de validação
+
+	// Detalhes traz um erro por campo quando a validação confere vários campos de uma vez
+	Detalhes []*Erro
+}
+
+func (e *Erro) Error() string {
+	return e.Mensagem
+}
+
+// NovoErroNaoEncontrado cria um erro de recurso inexistente
+func NovoErroNaoEncontrado(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: TipoNaoEncontrado, Codigo: codigo, Mensagem: mensagem}
+}
+
+// NovoErroValidacao cria um erro de validação de um campo
+func NovoErroValidacao(campo, codigo, mensagem string) *Erro {
+	return &Erro{Tipo: TipoValidacao, Codigo: codigo, Mensagem: mensagem, Campo: campo}
+}
+
+// NovoErroValidacaoCampos cria um erro de validação que reúne os erros de vários campos
+func NovoErroValidacaoCampos(codigo, mensagem string, detalhes []*Erro) *Erro {
+	return &Erro{Tipo: TipoValidacao, Codigo: codigo, Mensagem: mensagem, Detalhes: detalhes}
+}
+
+// NovoErroTransicao cria um erro de operação não permitida no status atual do ticket
+func NovoErroTransicao(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: TipoTransicaoInvalida, Codigo: codigo, Mensagem: mensagem}
+}
+
+// NovoErroConflito cria um erro de conflito com o estado gravado
+func NovoErroConflito(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: TipoConflito, Codigo: codigo, Mensagem: mensagem}
+}
+
+// NovoErroProibido cria um erro de operação que o usuário não tem permissão para fazer
+func NovoErroProibido(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: TipoProibido, Codigo: codigo, Mensagem: mensagem}
+}
+
+// NovoErroNaoAutenticado cria um erro de operação feita sem usuário autenticado
+func NovoErroNaoAutenticado(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: TipoNaoAutenticado, Codigo: codigo, Mensagem: mensagem}
+}
+
+// ComoErro extrai o erro do domínio de uma cadeia de erros
+func ComoErro(err error) (*Erro, bool) {
+	var e *Erro
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+var (
+	ErrNaoEncontrado = NovoErroNaoEncontrado("ticket_nao_encontrado", "ticket não encontrado")
+
+	ErrTituloObrigatorio      = NovoErroValidacao("titulo", "titulo_obrigatorio", "título é obrigatório")
+	ErrDescricaoObrigatoria   = NovoErroValidacao("descricao", "descricao_obrigatoria", "descrição é obrigatória")
+	ErrCategoriaObrigatoria   = NovoErroValidacao("categoria", "categoria_obrigatoria", "categoria é obrigatória")
+	ErrAbertoPorObrigatorio   = NovoErroValidacao("aberto_por", "aberto_por_obrigatorio", "aberto por é obrigatório")
+	ErrResponsavelObrigatorio = NovoErroValidacao("responsavel", "responsavel_obrigatorio", "responsável é obrigatório para iniciar o atendimento")
+	ErrResponsavelInvalido    = NovoErroValidacao("responsavel", "responsavel_invalido", "responsável não existe ou está desativado")
+
+	ErrTicketEncerrado = NovoErroTransicao("ticket_encerrado", "não é possível modificar um ticket finalizado ou cancelado")
+
+	ErrProibido       = NovoErroProibido("acesso_negado", "usuário não tem permissão para esta operação")
+	ErrNaoAutenticado = NovoErroNaoAutenticado("nao_autenticado", "usuário não autenticado")
+)
@@ -0,0 +1,147 @@
+package ticket
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TipoEvento identifica o que aconteceu com o ticket
+type TipoEvento string
+
+const (
+	EventoTicketCriado         TipoEvento = "ticket.criado"
+	EventoStatusAlterado       TipoEvento = "ticket.status_alterado"
+	EventoObservacaoAdicionada TipoEvento = "ticket.observacao_adicionada"
+	EventoCampoModificado      TipoEvento = "ticket.campo_modificado"
+	EventoSLAEmRisco           TipoEvento = "ticket.sla_em_risco"
+	EventoTicketEscalonado     TipoEvento = "ticket.escalonado"
+)
+
+// TiposEvento são todos os eventos de domínio do ticket
+var TiposEvento = []TipoEvento{
+	EventoTicketCriado,
+	EventoStatusAlterado,
+	EventoObservacaoAdicionada,
+	EventoCampoModificado,
+	EventoSLAEmRisco,
+	EventoTicketEscalonado,
+}
+
+// Evento é um evento de domínio do ticket, gravado no outbox junto com a alteração que o gerou
+type Evento struct {
+	ID        string
+	Tipo      TipoEvento
+	Data      time.Time
+	UsuarioID string // quem fez a alteração
+
+	// Ticket é o estado do ticket depois da alteração
+	Ticket ResumoTicket
+
+	// Mudancas traz as modificações dos eventos ticket.status_alterado e ticket.campo_modificado,
+	// o alerta do evento ticket.sla_em_risco, ou as ações e a regra do evento ticket.escalonado
+	Mudancas []Modificacao
+
+	// Observacao traz a observação do evento ticket.observacao_adicionada
+	Observacao *Observacao
+}
+
+// ResumoTicket é o estado do ticket levado nos eventos
+type ResumoTicket struct {
+	ID           string
+	Titulo       string
+	Status       Status
+	Categoria    Categoria
+	Subcategoria Subcategoria
+	Urgencia     int
+	Gravidade    int
+	AbertoPor    string
+	Responsavel  string
+	FilaID       string
+
+	PrazoPrimeiraResposta *time.Time
+	PrazoResolucao        *time.Time
+}
+
+// EventosPendentes retorna os eventos das alterações ainda não gravadas, para o repositório
+// gravá-los no outbox na mesma transação. Um ticket novo gera apenas ticket.criado, com o estado
+// final da criação; nos demais, as modificações geram um ticket.status_alterado se o status mudou
+// (ou um ticket.campo_modificado se não), cada alerta de SLA gera um ticket.sla_em_risco,
+// cada regra de escalonamento aplicada gera um ticket.escalonado com as suas ações
+// e cada observação nova gera seu próprio evento.
+func (t *Ticket) EventosPendentes() []Evento {
+	agora := time.Now()
+	resumo := t.resumo()
+	novoEvento := func(tipo TipoEvento, usuarioID string) Evento {
+		return Evento{ID: uuid.New().String(), Tipo: tipo, Data: agora, UsuarioID: usuarioID, Ticket: resumo}
+	}
+
+	if !t.persistido {
+		return []Evento{novoEvento(EventoTicketCriado, t.AbertoPor)}
+	}
+
+	eventos := []Evento{}
+	var modificacoes, alertas, acoes []Modificacao
+	var escalonamentos [][]Modificacao
+	for _, m := range t.ModificacoesNovas() {
+		switch {
+		case m.CampoModificado == campoAlertaSLA:
+			alertas = append(alertas, m)
+		case strings.HasPrefix(m.CampoModificado, prefixoAcaoEscalonamento):
+			acoes = append(acoes, m)
+		case m.CampoModificado == campoEscalonamento:
+			// a regra fecha o grupo das ações registradas antes dela
+			escalonamentos = append(escalonamentos, append(acoes, m))
+			acoes = nil
+		default:
+			modificacoes = append(modificacoes, m)
+		}
+	}
+	if len(modificacoes) > 0 {
+		tipo := EventoCampoModificado
+		for _, m := range modificacoes {
+			if m.CampoModificado == "status" {
+				tipo = EventoStatusAlterado
+			}
+		}
+		evento := novoEvento(tipo, modificacoes[0].UsuarioID)
+		evento.Mudancas = append([]Modificacao(nil), modificacoes...)
+		eventos = append(eventos, evento)
+	}
+	for _, a := range alertas {
+		evento := novoEvento(EventoSLAEmRisco, a.UsuarioID)
+		evento.Mudancas = []Modificacao{a}
+		eventos = append(eventos, evento)
+	}
+	for _, e := range escalonamentos {
+		evento := novoEvento(EventoTicketEscalonado, e[len(e)-1].UsuarioID)
+		evento.Mudancas = e
+		eventos = append(eventos, evento)
+	}
+	for _, o := range t.ObservacoesNovas() {
+		evento := novoEvento(EventoObservacaoAdicionada, o.UsuarioID)
+		observacao := o
+		evento.Observacao = &observacao
+		eventos = append(eventos, evento)
+	}
+	return eventos
+}
+
+func (t *Ticket) resumo() ResumoTicket {
+	return ResumoTicket{
+		ID:           t.ID,
+		Titulo:       t.Titulo,
+		Status:       t.Status,
+		Categoria:    t.Categoria,
+		Subcategoria: t.Subcategoria,
+		Urgencia:     t.Urgencia,
+		Gravidade:    t.Gravidade,
+		AbertoPor:    t.AbertoPor,
+		Responsavel:  t.Responsavel,
+		FilaID:       t.FilaID,
+
+		PrazoPrimeiraResposta: t.SLA.PrazoPrimeiraResposta,
+		PrazoResolucao:        t.SLA.PrazoResolucao,
+	}
+}
@@ -0,0 +1,104 @@
+package ticket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicket_EventosPendentes(t *testing.T) {
+	tk := novoTicketTeste(t)
+
+	// um ticket novo gera apenas ticket.criado, com o estado final da criação
+	tk.SetUrgencia(3, "usuario_teste")
+	if eventos := tk.EventosPendentes(); len(eventos) != 1 || eventos[0].Tipo != EventoTicketCriado || eventos[0].Ticket.Urgencia != 3 {
+		t.Errorf("Esperava ticket.criado, recebido %+v", eventos)
+	}
+	tk.MarcarComoPersistido()
+	if eventos := tk.EventosPendentes(); len(eventos) != 0 {
+		t.Errorf("Não esperava eventos depois de gravar, recebido %+v", eventos)
+	}
+
+	tk.SetUrgencia(4, "analista")
+	tk.AdicionarObservacao("Cliente retornou", "usuario_teste")
+	eventos := tk.EventosPendentes()
+	if len(eventos) != 2 || eventos[0].Tipo != EventoCampoModificado || eventos[1].Tipo != EventoObservacaoAdicionada {
+		t.Fatalf("Eventos incorretos: %+v", eventos)
+	}
+	if m := eventos[0].Mudancas; len(m) != 1 || m[0].CampoModificado != "urgencia" || eventos[0].UsuarioID != "analista" {
+		t.Errorf("Mudanças incorretas: %+v", eventos[0])
+	}
+	if eventos[1].Observacao == nil || eventos[1].Observacao.Descricao != "Cliente retornou" {
+		t.Errorf("Observação incorreta: %+v", eventos[1].Observacao)
+	}
+	tk.MarcarComoPersistido()
+
+	// mudança de status leva as demais mudanças junto, em um único evento
+	if err := MaquinaDeEstadosPadrao().Aplicar(tk, StatusEmCurso, ContextoTransicao{UsuarioID: "analista", Responsavel: "analista"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	eventos = tk.EventosPendentes()
+	if len(eventos) != 1 || eventos[0].Tipo != EventoStatusAlterado || eventos[0].Ticket.Status != StatusEmCurso {
+		t.Errorf("Esperava ticket.status_alterado, recebido %+v", eventos)
+	}
+}
+
+func TestTicket_AlertaSLA(t *testing.T) {
+	tk := novoTicketTeste(t)
+	agora := tk.DataAbertura
+	tk.DefinirPrazosSLA(agora.Add(30*time.Minute), agora.Add(8*time.Hour))
+	tk.MarcarComoPersistido()
+
+	// só a primeira resposta vence dentro da antecedência
+	prazos := tk.PrazosEmRisco(tk.EstadoSLA(agora, nil), time.Hour)
+	if len(prazos) != 1 || prazos[0] != PrazoPrimeiraResposta {
+		t.Fatalf("Esperava alerta da primeira resposta, recebido %v", prazos)
+	}
+	tk.RegistrarAlertaSLA(prazos[0], "sistema")
+
+	eventos := tk.EventosPendentes()
+	if len(eventos) != 1 || eventos[0].Tipo != EventoSLAEmRisco || eventos[0].Mudancas[0].ValorNovo != PrazoPrimeiraResposta {
+		t.Fatalf("Esperava ticket.sla_em_risco, recebido %+v", eventos)
+	}
+	if eventos[0].Ticket.PrazoResolucao == nil {
+		t.Error("Evento deveria levar os prazos de SLA")
+	}
+	tk.MarcarComoPersistido()
+
+	// o prazo já alertado não gera novo alerta, e prazos estourados não são alertados
+	if prazos := tk.PrazosEmRisco(tk.EstadoSLA(agora.Add(10*time.Minute), nil), time.Hour); len(prazos) != 0 {
+		t.Errorf("Não esperava novo alerta, recebido %v", prazos)
+	}
+	if prazos := tk.PrazosEmRisco(tk.EstadoSLA(agora.Add(9*time.Hour), nil), time.Hour); len(prazos) != 0 {
+		t.Errorf("Não esperava alerta de prazo estourado, recebido %v", prazos)
+	}
+	if prazos := tk.PrazosEmRisco(tk.EstadoSLA(agora.Add(7*time.Hour+30*time.Minute), nil), time.Hour); len(prazos) != 1 || prazos[0] != PrazoResolucao {
+		t.Errorf("Esperava alerta da resolução, recebido %v", prazos)
+	}
+}
+
+func TestTicket_EventosEscalonamento(t *testing.T) {
+	tk := novoTicketTeste(t)
+	tk.MarcarComoPersistido()
+
+	// cada regra aplicada gera um ticket.escalonado com as suas ações; a urgência segue no ticket.campo_modificado
+	tk.SetUrgencia(4, "sistema")
+	tk.RegistrarAcaoEscalonamento("aumentar_urgencia", "3", "4", "sistema")
+	tk.RegistrarAcaoEscalonamento("notificar", "", "Ticket parado", "sistema")
+	tk.RegistrarEscalonamento("aberto_urgente", "sistema")
+	tk.RegistrarAcaoEscalonamento("notificar", "", "Avise a supervisão", "sistema")
+	tk.RegistrarEscalonamento("aberto_parado", "sistema")
+
+	eventos := tk.EventosPendentes()
+	if len(eventos) != 3 || eventos[0].Tipo != EventoCampoModificado || eventos[1].Tipo != EventoTicketEscalonado || eventos[2].Tipo != EventoTicketEscalonado {
+		t.Fatalf("Eventos incorretos: %+v", eventos)
+	}
+	if m := eventos[0].Mudancas; len(m) != 1 || m[0].CampoModificado != "urgencia" {
+		t.Errorf("Mudanças incorretas: %+v", m)
+	}
+	if m := eventos[1].Mudancas; len(m) != 3 || m[1].ValorNovo != "Ticket parado" || m[2].ValorNovo != "aberto_urgente" {
+		t.Errorf("Escalonamento incorreto: %+v", m)
+	}
+	if m := eventos[2].Mudancas; len(m) != 2 || m[0].ValorNovo != "Avise a supervisão" || m[1].ValorNovo != "aberto_parado" {
+		t.Errorf("Escalonamento incorreto: %+v", m)
+	}
+}
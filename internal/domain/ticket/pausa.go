@@ -0,0 +1,138 @@
+package ticket
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMotivoPausaObrigatorio = NovoErroValidacao("motivo", "motivo_obrigatorio", "motivo é obrigatório para pausar o atendimento")
+)
+
+// Pausa é um intervalo em que o atendimento ficou parado esperando alguém
+type Pausa struct {
+	ID        string
+	TicketID  string
+	Status    Status
+	Motivo    string
+	UsuarioID string
+	Inicio    time.Time
+	Fim       *time.Time
+}
+
+// ResumoExecucao separa o tempo de execução em tempo ativo e tempo em espera
+type ResumoExecucao struct {
+	TempoAtivo  time.Duration
+	TempoEspera time.Duration
+}
+
+// EhStatusDePausa indica se o status suspende o atendimento
+func EhStatusDePausa(status Status) bool {
+	switch status {
+	case StatusAguardandoCliente, StatusAguardandoTerceiro, StatusPausado:
+		return true
+	default:
+		return false
+	}
+}
+
+// PausaAberta retorna a pausa em andamento, se houver
+func (t *Ticket) PausaAberta() *Pausa {
+	for i := range t.Pausas {
+		if t.Pausas[i].Fim == nil {
+			return &t.Pausas[i]
+		}
+	}
+	return nil
+}
+
+// ResumoExecucao calcula o tempo ativo e o tempo em espera desde o início do atendimento.
+// Se calendario for nil, os tempos são medidos em tempo corrido.
+func (t *Ticket) ResumoExecucao(agora time.Time, calendario Calendario) ResumoExecucao {
+	if calendario == nil {
+		calendario = TempoCorrido{}
+	}
+	if t.DataInicio == nil {
+		return ResumoExecucao{}
+	}
+
+	fim := agora
+	if t.DataConclusao != nil {
+		fim = *t.DataConclusao
+	}
+
+	total := calendario.DuracaoUtil(*t.DataInicio, fim)
+	espera := t.tempoEmPausa(fim, calendario)
+
+	return ResumoExecucao{
+		TempoAtivo:  total - espera,
+		TempoEspera: espera,
+	}
+}
+
+// PausasNovas retorna as pausas abertas desde a última gravação
+func (t *Ticket) PausasNovas() []Pausa {
+	if t.pausasPersistidas > len(t.Pausas) {
+		return nil
+	}
+	return t.Pausas[t.pausasPersistidas:]
+}
+
+// PausasEncerradas retorna as pausas já gravadas em aberto que foram encerradas desde a última gravação
+func (t *Ticket) PausasEncerradas() []Pausa {
+	if t.pausaAbertaPersistida == "" {
+		return nil
+	}
+	for _, p := range t.Pausas[:min(t.pausasPersistidas, len(t.Pausas))] {
+		if p.ID == t.pausaAbertaPersistida && p.Fim != nil {
+			return []Pausa{p}
+		}
+	}
+	return nil
+}
+
+// motivoReabertura é o motivo da pausa entre a conclusão e a reabertura
+func motivoReabertura(motivo string) string {
+	if motivo == "" {
+		return "ticket concluído até a reabertura"
+	}
+	return motivo
+}
+
+// iniciarPausa abre uma nova pausa ao entrar em um status de espera
+func (t *Ticket) iniciarPausa(status Status, ctx ContextoTransicao) error {
+	if ctx.Motivo == "" {
+		return ErrMotivoPausaObrigatorio
+	}
+
+	t.Pausas = append(t.Pausas, Pausa{
+		ID:        uuid.New().String(),
+		TicketID:  t.ID,
+		Status:    status,
+		Motivo:    ctx.Motivo,
+		UsuarioID: ctx.UsuarioID,
+		Inicio:    ctx.Agora,
+	})
+	return nil
+}
+
+// encerrarPausa fecha a pausa em andamento ao sair de um status de espera
+func (t *Ticket) encerrarPausa(agora time.Time) {
+	if pausa := t.PausaAberta(); pausa != nil {
+		pausa.Fim = &agora
+	}
+}
+
+// tempoEmPausa soma a duração das pausas até o instante fim
+func (t *Ticket) tempoEmPausa(fim time.Time, calendario Calendario) time.Duration {
+	var total time.Duration
+	for _, p := range t.Pausas {
+		termino := fim
+		if p.Fim != nil && p.Fim.Before(fim) {
+			termino = *p.Fim
+		}
+		total += calendario.DuracaoUtil(p.Inicio, termino)
+	}
+	return total
+}
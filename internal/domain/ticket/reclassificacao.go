@@ -0,0 +1,76 @@
+package ticket
+
+import (
+	"sort"
+	"time"
+)
+
+// Reclassificacao é uma mudança de categoria e/ou subcategoria do histórico de modificações
+type Reclassificacao struct {
+	TicketID  string
+	Titulo    string
+	UsuarioID string
+	Data      time.Time
+
+	CategoriaAnterior    Categoria
+	SubcategoriaAnterior Subcategoria
+	CategoriaNova        Categoria
+	SubcategoriaNova     Subcategoria
+}
+
+// ReclassificacoesDoHistorico reconstrói as reclassificações de um ticket a partir das modificações de categoria e
+// subcategoria, da mais recente para a mais antiga. As modificações do mesmo usuário no mesmo instante formam uma
+// única reclassificação, e o campo que não mudou nela é o vigente naquele momento, obtido voltando da classificação atual
+func ReclassificacoesDoHistorico(t *Ticket, modificacoes []Modificacao) []*Reclassificacao {
+	historico := make([]Modificacao, 0, len(modificacoes))
+	for _, m := range modificacoes {
+		if m.CampoModificado == "categoria" || m.CampoModificado == "subcategoria" {
+			historico = append(historico, m)
+		}
+	}
+	sort.SliceStable(historico, func(a, b int) bool { return historico[a].DataModificacao.Before(historico[b].DataModificacao) })
+
+	// percorre do fim para o início, desfazendo cada reclassificação sobre a classificação atual
+	categoria, subcategoria := t.Categoria, t.Subcategoria
+	reclassificacoes := []*Reclassificacao{}
+	for fim := len(historico); fim > 0; {
+		ultima := historico[fim-1]
+		inicio := fim - 1
+		for inicio > 0 && historico[inicio-1].DataModificacao.Equal(ultima.DataModificacao) && historico[inicio-1].UsuarioID == ultima.UsuarioID {
+			inicio--
+		}
+
+		rc := &Reclassificacao{
+			TicketID:             t.ID,
+			Titulo:               t.Titulo,
+			UsuarioID:            ultima.UsuarioID,
+			Data:                 ultima.DataModificacao,
+			CategoriaAnterior:    categoria,
+			SubcategoriaAnterior: subcategoria,
+			CategoriaNova:        categoria,
+			SubcategoriaNova:     subcategoria,
+		}
+		for _, m := range historico[inicio:fim] {
+			if m.CampoModificado == "categoria" {
+				rc.CategoriaAnterior, rc.CategoriaNova = Categoria(m.ValorAnterior), Categoria(m.ValorNovo)
+			} else {
+				rc.SubcategoriaAnterior, rc.SubcategoriaNova = Subcategoria(m.ValorAnterior), Subcategoria(m.ValorNovo)
+			}
+		}
+		reclassificacoes = append(reclassificacoes, rc)
+
+		categoria, subcategoria = rc.CategoriaAnterior, rc.SubcategoriaAnterior
+		fim = inicio
+	}
+	return reclassificacoes
+}
+
+// Envolve indica se a categoria é a de origem ou a de destino da reclassificação
+func (rc *Reclassificacao) Envolve(categoria Categoria) bool {
+	return rc.CategoriaAnterior == categoria || rc.CategoriaNova == categoria
+}
+
+// NoPeriodo indica se a reclassificação ocorreu entre inicio e fim (limites inclusivos; zero não limita)
+func (rc *Reclassificacao) NoPeriodo(inicio, fim time.Time) bool {
+	return (inicio.IsZero() || !rc.Data.Before(inicio)) && (fim.IsZero() || !rc.Data.After(fim))
+}
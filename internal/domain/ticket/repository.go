@@ -1,6 +1,18 @@
 package ticket
 
-import "time"
+import (
+	"time"
+)
+
+var (
+	ErrOrdenacaoInvalida = NovoErroValidacao("ordenar_por", "ordenacao_invalida", "campo de ordenação inválido")
+	ErrCursorInvalido    = NovoErroValidacao("cursor", "cursor_invalido", "cursor de paginação inválido")
+
+	// ErrConflito indica que o ticket foi alterado por outra requisição desde que foi lido
+	ErrConflito = NovoErroConflito("conflito_de_versao", "ticket foi modificado por outra requisição")
+	// ErrVersaoDesatualizada indica que a versão informada pelo cliente não é a versão atual
+	ErrVersaoDesatualizada = NovoErroConflito("versao_desatualizada", "versão do ticket informada não é a atual")
+)
 
 type Repository interface {
 	// Criar novo ticket
@@ -9,42 +21,156 @@ type Repository interface {
 	// Buscar ticket por ID
 	GetByID(id string) (*Ticket, error)
 
-	// Listar tickets com filtros
-	List(filtros TicketFiltros) ([]*Ticket, error)
+	// Listar uma página de tickets com filtros, junto com o total de tickets encontrados
+	List(filtros TicketFiltros) (*ResultadoLista, error)
+
+	// Listar uma página de tickets completos, com observações, modificações, pausas e aprovações, para as
+	// rotinas que avaliam o histórico sem buscar ticket a ticket; não calcula o Total
+	ListarCompletos(filtros TicketFiltros) (*ResultadoLista, error)
+
+	// Pesquisar tickets por texto em título, descrição e observações, combinando com os filtros
+	Pesquisar(consulta string, filtros TicketFiltros) (*ResultadoPesquisa, error)
 
-	// Atualizar ticket
+	// Atualizar ticket, gravando os filhos novos e os eventos de domínio na mesma transação; é o único
+	// caminho de escrita depois da criação. Falha com ErrConflito se a versão gravada não for ticket.Versao.
+	// Em caso de sucesso, ticket.Versao passa a ser a nova versão
 	Update(ticket *Ticket) error
 
 	// Deletar ticket (se necessário)
 	Delete(id string) error
 
-	// Adicionar observação
-	AdicionarObservacao(ticketID string, observacao *Observacao) error
-
 	// Listar observações
 	ListarObservacoes(ticketID string) ([]*Observacao, error)
 
-	// Para rastrear modificacoes
-	AdicionarModificacao(ticketID string, modificacao *Modificacao) error
-
 	// Listar modificacoes
 	ListarModificacoes(ticketID string) ([]*Modificacao, error)
 
+	// Listar as reclassificações que envolvem a categoria, da mais recente para a mais antiga
+	ListarReclassificacoes(filtro FiltroReclassificacoes) ([]*Reclassificacao, error)
+
 	// Buscar por status específicos
 	ListarPorStatus(status Status) ([]*Ticket, error)
+}
 
-	// Para atualizar status específicos
-	AtualizarStatus(ticketID string, novoStatus Status, usuarioID string) error
+// FiltroReclassificacoes seleciona as reclassificações em que a categoria é a de origem ou a de destino,
+// no período entre DataInicio e DataFim (limites inclusivos; zero não limita)
+type FiltroReclassificacoes struct {
+	Categoria  Categoria
+	DataInicio time.Time
+	DataFim    time.Time
+
+	// Escopo limita o relatório aos tickets que o usuário pode ver; nil não restringe
+	Escopo *EscopoAcesso
 }
 
-// TicketFiltros define os filtros possíveis para busca
+// TicketFiltros define os filtros possíveis para busca.
+// Campos vazios (ou nil) não filtram.
 type TicketFiltros struct {
-	Status      []Status
-	Categoria   []Categoria
-	Responsavel string
-	AbertoPor   string
-	DataInicio  time.Time
-	DataFim     time.Time
-	Urgencia    *int
-	Gravidade   *int
+	Status       []Status
+	Categoria    []Categoria
+	Subcategoria []Subcategoria
+	Responsavel  string
+	AbertoPor    string
+	FilaID       string
+	Merchant     string
+	NoxID        string
+	Plataforma   string
+
+	// período de abertura (DataInicio/DataFim) e de conclusão, limites inclusivos
+	DataInicio          time.Time
+	DataFim             time.Time
+	DataConclusaoInicio time.Time
+	DataConclusaoFim    time.Time
+
+	// Urgencia e Gravidade filtram pelo valor exato; Min/Max por faixa
+	Urgencia     *int
+	UrgenciaMin  *int
+	UrgenciaMax  *int
+	Gravidade    *int
+	GravidadeMin *int
+	GravidadeMax *int
+
+	SLAViolado *bool
+
+	// Escopo limita a consulta aos tickets que o usuário pode ver; nil não restringe
+	Escopo *EscopoAcesso
+
+	// ParadosAntesDe deixa os tickets que entraram no status atual antes da data e, com ParadosSemObservacao,
+	// sem observações depois dela. SemEscalonamento descarta os tickets em que a regra de escalonamento
+	// já foi aplicada desde esse início da contagem
+	ParadosAntesDe       time.Time
+	ParadosSemObservacao bool
+	SemEscalonamento     string
+
+	// PrazoSemAlerta deixa os tickets com algum prazo de SLA em aberto ainda não alertado
+	PrazoSemAlerta bool
+
+	// paginação: Limite 0 traz todos os tickets. Com Cursor preenchido
+	// a página começa logo após o último ticket da página anterior e Deslocamento é ignorado
+	Limite       int
+	Deslocamento int
+	Cursor       string
+	Ordenacao    Ordenacao
+}
+
+// CampoOrdenacao define por qual campo a listagem é ordenada
+type CampoOrdenacao string
+
+const (
+	OrdenarPorDataAbertura CampoOrdenacao = "data_abertura"
+	OrdenarPorUrgencia     CampoOrdenacao = "urgencia"
+	OrdenarPorGravidade    CampoOrdenacao = "gravidade"
+	OrdenarPorPrazoSLA     CampoOrdenacao = "prazo_sla"
+)
+
+// Ordenacao define o campo e a direção da listagem. O padrão é data de abertura decrescente
+type Ordenacao struct {
+	Campo       CampoOrdenacao
+	Descendente bool
+}
+
+// ValidarCampoOrdenacao verifica se o campo de ordenação é suportado
+func ValidarCampoOrdenacao(campo CampoOrdenacao) error {
+	switch campo {
+	case "", OrdenarPorDataAbertura, OrdenarPorUrgencia, OrdenarPorGravidade, OrdenarPorPrazoSLA:
+		return nil
+	default:
+		return ErrOrdenacaoInvalida
+	}
+}
+
+// ResultadoLista é uma página de tickets
+type ResultadoLista struct {
+	Tickets       []*Ticket
+	Total         int    // total de tickets que atendem aos filtros, sem paginação
+	ProximoCursor string // vazio quando não há próxima página
+}
+
+// ItemPesquisa é um ticket encontrado pela busca textual
+type ItemPesquisa struct {
+	Ticket          *Ticket
+	Relevancia      float64
+	TituloDestacado string // título com os termos encontrados entre <mark> e </mark>
+	Trecho          string // trechos da descrição e das observações com os termos destacados
+}
+
+// ResultadoPesquisa é uma página da busca textual, ordenada por relevância
+type ResultadoPesquisa struct {
+	Itens []ItemPesquisa
+	Total int
+}
+
+// EscopoAcesso descreve quais tickets um usuário pode ver, para o repositório filtrar na própria consulta.
+// Um ticket é visível se atender a qualquer uma das condições.
+type EscopoAcesso struct {
+	// UsuarioID libera os tickets abertos pelo usuário ou atribuídos a ele
+	UsuarioID string
+
+	// Categorias liberadas por completo, inclusive as restritas
+	Categorias []Categoria
+
+	// NaoRestritos libera os tickets fora das categorias e subcategorias restritas
+	NaoRestritos           bool
+	CategoriasRestritas    []Categoria
+	SubcategoriasRestritas []Subcategoria
 }
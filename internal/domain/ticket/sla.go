@@ -0,0 +1,147 @@
+package ticket
+
+import "time"
+
+// SLA guarda os prazos de atendimento calculados para o ticket
+type SLA struct {
+	PrazoPrimeiraResposta *time.Time
+	PrazoResolucao        *time.Time
+	DataPrimeiraResposta  *time.Time
+	Violado               bool
+}
+
+// EstadoSLA é a situação do SLA do ticket em um determinado instante.
+// Os tempos restantes são negativos quando o prazo já passou.
+type EstadoSLA struct {
+	PrazoPrimeiraResposta         *time.Time
+	PrazoResolucao                *time.Time
+	DataPrimeiraResposta          *time.Time
+	Violado                       bool
+	TempoRestantePrimeiraResposta time.Duration // em tempo útil
+	TempoRestanteResolucao        time.Duration // em tempo útil
+
+	// tempos restantes em tempo corrido
+	TempoRestantePrimeiraRespostaCorrido time.Duration
+	TempoRestanteResolucaoCorrido        time.Duration
+}
+
+// DefinirPrazosSLA define os prazos de primeira resposta e de resolução do ticket
+func (t *Ticket) DefinirPrazosSLA(primeiraResposta, resolucao time.Time) {
+	t.SLA.PrazoPrimeiraResposta = &primeiraResposta
+	t.SLA.PrazoResolucao = &resolucao
+	t.recalcularViolacaoSLA()
+}
+
+// EstadoSLA calcula o tempo restante e se algum prazo já foi estourado.
+// Se calendario for nil, os tempos úteis são iguais aos corridos.
+func (t *Ticket) EstadoSLA(agora time.Time, calendario Calendario) EstadoSLA {
+	if calendario == nil {
+		calendario = TempoCorrido{}
+	}
+
+	estado := EstadoSLA{
+		PrazoPrimeiraResposta: t.SLA.PrazoPrimeiraResposta,
+		PrazoResolucao:        t.SLA.PrazoResolucao,
+		DataPrimeiraResposta:  t.SLA.DataPrimeiraResposta,
+		Violado:               t.SLA.Violado,
+	}
+
+	if t.SLA.PrazoPrimeiraResposta != nil {
+		referencia := agora
+		if t.SLA.DataPrimeiraResposta != nil {
+			referencia = *t.SLA.DataPrimeiraResposta
+		}
+		estado.TempoRestantePrimeiraRespostaCorrido = t.SLA.PrazoPrimeiraResposta.Sub(referencia)
+		estado.TempoRestantePrimeiraResposta = tempoRestante(calendario, referencia, *t.SLA.PrazoPrimeiraResposta)
+		if estado.TempoRestantePrimeiraRespostaCorrido < 0 {
+			estado.Violado = true
+		}
+	}
+
+	if t.SLA.PrazoResolucao != nil && t.Status != StatusCancelado {
+		referencia := agora
+		if t.DataConclusao != nil {
+			referencia = *t.DataConclusao
+		}
+		estado.TempoRestanteResolucaoCorrido = t.SLA.PrazoResolucao.Sub(referencia)
+		estado.TempoRestanteResolucao = tempoRestante(calendario, referencia, *t.SLA.PrazoResolucao)
+		if estado.TempoRestanteResolucaoCorrido < 0 {
+			estado.Violado = true
+		}
+	}
+
+	return estado
+}
+
+// Prazos de SLA que geram alerta antes de vencer
+const (
+	PrazoPrimeiraResposta = "primeira_resposta"
+	PrazoResolucao        = "resolucao"
+)
+
+// campoAlertaSLA é a modificação que registra o alerta de um prazo prestes a vencer
+const campoAlertaSLA = "alerta_sla"
+
+// PrazosEmRisco retorna os prazos ainda em aberto que vencem dentro da antecedência, em tempo útil,
+// e que ainda não foram alertados. Prazos já estourados não geram alerta.
+func (t *Ticket) PrazosEmRisco(estado EstadoSLA, antecedencia time.Duration) []string {
+	emRisco := func(restante time.Duration) bool {
+		return restante > 0 && restante <= antecedencia
+	}
+
+	prazos := []string{}
+	if estado.PrazoPrimeiraResposta != nil && estado.DataPrimeiraResposta == nil &&
+		emRisco(estado.TempoRestantePrimeiraResposta) && !t.alertaRegistrado(PrazoPrimeiraResposta) {
+		prazos = append(prazos, PrazoPrimeiraResposta)
+	}
+	if estado.PrazoResolucao != nil && t.DataConclusao == nil && t.Status != StatusCancelado &&
+		emRisco(estado.TempoRestanteResolucao) && !t.alertaRegistrado(PrazoResolucao) {
+		prazos = append(prazos, PrazoResolucao)
+	}
+	return prazos
+}
+
+// RegistrarAlertaSLA registra que o prazo está prestes a vencer; cada prazo é alertado uma única vez
+func (t *Ticket) RegistrarAlertaSLA(prazo, usuarioID string) error {
+	return t.registrarModificacao(campoAlertaSLA, "", prazo, usuarioID)
+}
+
+func (t *Ticket) alertaRegistrado(prazo string) bool {
+	for _, m := range t.Modificacoes {
+		if m.CampoModificado == campoAlertaSLA && m.ValorNovo == prazo {
+			return true
+		}
+	}
+	return false
+}
+
+// registrarPrimeiraResposta marca o momento da primeira resposta ao solicitante
+func (t *Ticket) registrarPrimeiraResposta(agora time.Time) {
+	if t.SLA.DataPrimeiraResposta != nil {
+		return
+	}
+	t.SLA.DataPrimeiraResposta = &agora
+	t.recalcularViolacaoSLA()
+}
+
+// recalcularViolacaoSLA fixa a violação quando a resposta ou a conclusão aconteceram fora do prazo
+func (t *Ticket) recalcularViolacaoSLA() {
+	violado := false
+	if t.SLA.PrazoPrimeiraResposta != nil && t.SLA.DataPrimeiraResposta != nil &&
+		t.SLA.DataPrimeiraResposta.After(*t.SLA.PrazoPrimeiraResposta) {
+		violado = true
+	}
+	if t.SLA.PrazoResolucao != nil && t.DataConclusao != nil &&
+		t.DataConclusao.After(*t.SLA.PrazoResolucao) {
+		violado = true
+	}
+	t.SLA.Violado = violado
+}
+
+// tempoRestante mede o tempo útil até o prazo, negativo se o prazo já passou
+func tempoRestante(calendario Calendario, referencia, prazo time.Time) time.Duration {
+	if prazo.Before(referencia) {
+		return -calendario.DuracaoUtil(prazo, referencia)
+	}
+	return calendario.DuracaoUtil(referencia, prazo)
+}
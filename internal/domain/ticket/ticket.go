@@ -1,7 +1,6 @@
 package ticket
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,16 +11,23 @@ import (
 type Status string
 
 var (
-	ErrUrgenciaInvalida  = errors.New("urgência inválida")
-	ErrGravidadeInvalida = errors.New("gravidade inválida")
-	ErrCategoriaInvalida = errors.New("categoria inválida")
+	ErrUrgenciaInvalida  = NovoErroValidacao("urgencia", "urgencia_invalida", "urgência inválida, deve ser de 1 a 5")
+	ErrGravidadeInvalida = NovoErroValidacao("gravidade", "gravidade_invalida", "gravidade inválida, deve ser de 1 a 5")
+	ErrCategoriaInvalida = NovoErroValidacao("categoria", "categoria_invalida", "categoria inválida")
+
+	ErrSubcategoriaInvalida    = NovoErroValidacao("subcategoria", "subcategoria_invalida", "subcategoria inválida para a categoria")
+	ErrSubcategoriaObrigatoria = NovoErroValidacao("subcategoria", "subcategoria_obrigatoria", "subcategoria é obrigatória para a categoria")
 )
 
 const (
-	StatusAberto     Status = "aberto"
-	StatusEmCurso    Status = "em_curso"
-	StatusFinalizado Status = "finalizado"
-	StatusCancelado  Status = "cancelado"
+	StatusAberto             Status = "aberto"
+	StatusEmCurso            Status = "em_curso"
+	StatusAguardandoCliente  Status = "aguardando_cliente"
+	StatusAguardandoTerceiro Status = "aguardando_terceiro"
+	StatusPausado            Status = "pausado"
+	StatusReaberto           Status = "reaberto"
+	StatusFinalizado         Status = "finalizado"
+	StatusCancelado          Status = "cancelado"
 )
 
 type Categoria string
@@ -58,6 +64,32 @@ const (
 	SubcategoriaSolicitacaoSaque     Subcategoria = "solicitacao_de_saque"
 )
 
+// Calendario mede o tempo útil entre dois instantes, descontando noites, fins de semana e feriados
+type Calendario interface {
+	DuracaoUtil(inicio, fim time.Time) time.Duration
+}
+
+// Taxonomia define as categorias e os pares de categoria e subcategoria aceitos; é mantida em
+// tempo de execução pelos administradores, então as constantes acima são apenas as categorias iniciais
+type Taxonomia interface {
+	// ValidarCategoria falha com ErrCategoriaInvalida se a categoria não existir ou estiver inativa
+	ValidarCategoria(categoria Categoria) error
+
+	// ValidarClassificacao também exige que a subcategoria seja uma subcategoria ativa da categoria
+	ValidarClassificacao(categoria Categoria, subcategoria Subcategoria) error
+}
+
+// TempoCorrido mede e conta as durações no relógio de parede; é usado quando nenhum calendário é configurado
+type TempoCorrido struct{}
+
+func (TempoCorrido) DuracaoUtil(inicio, fim time.Time) time.Duration {
+	return fim.Sub(inicio)
+}
+
+func (TempoCorrido) Adicionar(inicio time.Time, d time.Duration) time.Time {
+	return inicio.Add(d)
+}
+
 type Observacao struct {
 	ID          string
 	TicketID    string
@@ -90,65 +122,104 @@ type Ticket struct {
 	Gravidade       int
 	AbertoPor       string
 	Responsavel     string
+	FilaID          string // fila de atendimento em que o ticket foi roteado
 	Contato         string
 	Plataforma      *string
+	Campos          map[string]interface{} // campos personalizados do formulário da categoria/subcategoria
 	DataAbertura    time.Time
 	DataInicio      *time.Time
 	DataConclusao   *time.Time
-	DuracaoTotal    time.Duration
-	DuracaoExecucao time.Duration
-	Observacoes     []Observacao
-	Modificacoes    []Modificacao
+	DuracaoTotal    time.Duration // em tempo útil
+	DuracaoExecucao time.Duration // em tempo útil
+	SLA             SLA
+
+	// Versao é incrementada a cada gravação, para detectar edições concorrentes
+	Versao int
+
+	// durações em tempo corrido (relógio de parede)
+	DuracaoTotalCorrida    time.Duration
+	DuracaoExecucaoCorrida time.Duration
+
+	Observacoes  []Observacao
+	Modificacoes []Modificacao
+	Pausas       []Pausa
+	Aprovacoes   []Aprovacao
+
+	// quantas observações, modificações, pausas e aprovações já estão gravadas no repositório;
+	// as que vêm depois delas nas listas são novas e precisam ser inseridas
+	observacoesPersistidas  int
+	modificacoesPersistidas int
+	pausasPersistidas       int
+	aprovacoesPersistidas   int
+
+	// pausa que estava em aberto na última gravação; se foi encerrada, o fim precisa ser gravado
+	pausaAbertaPersistida string
+
+	// indica se o ticket já foi gravado alguma vez; um ticket novo gera o evento ticket.criado
+	persistido bool
+}
+
+// ObservacoesNovas retorna as observações adicionadas desde a última gravação
+func (t *Ticket) ObservacoesNovas() []Observacao {
+	if t.observacoesPersistidas > len(t.Observacoes) {
+		return nil
+	}
+	return t.Observacoes[t.observacoesPersistidas:]
 }
 
-// ValidateCategoria verifica se a categoria é válida
-func ValidateCategoria(categoria Categoria) error {
-	categoriaLower := Categoria(strings.ToLower(string(categoria)))
-	switch categoriaLower {
-	case CategoriaFinanceiro,
-		CategoriaComercial,
-		CategoriaCompliance,
-		CategoriaContratos,
-		CategoriaGestores,
-		CategoriaMeds,
-		CategoriaOnboarding,
-		CategoriaOperacional,
-		CategoriaReclamacoes,
-		CategoriaTI,
-		CategoriaTrading:
+// ModificacoesNovas retorna as modificações registradas desde a última gravação
+func (t *Ticket) ModificacoesNovas() []Modificacao {
+	if t.modificacoesPersistidas > len(t.Modificacoes) {
 		return nil
-	default:
-		return ErrCategoriaInvalida
 	}
+	return t.Modificacoes[t.modificacoesPersistidas:]
+}
+
+// VerificarVersao confere se a versão esperada pelo cliente é a versão atual do ticket
+func (t *Ticket) VerificarVersao(esperada int) error {
+	if t.Versao != esperada {
+		return fmt.Errorf("%w: esperada %d, atual %d", ErrVersaoDesatualizada, esperada, t.Versao)
+	}
+	return nil
+}
+
+// MarcarComoPersistido indica que todas as observações, modificações, pausas e aprovações atuais, e os eventos
+// delas, estão gravados. Deve ser chamado pelo repositório depois de carregar ou salvar o ticket.
+func (t *Ticket) MarcarComoPersistido() {
+	t.observacoesPersistidas = len(t.Observacoes)
+	t.modificacoesPersistidas = len(t.Modificacoes)
+	t.pausasPersistidas = len(t.Pausas)
+	t.pausaAbertaPersistida = ""
+	if pausa := t.PausaAberta(); pausa != nil {
+		t.pausaAbertaPersistida = pausa.ID
+	}
+	t.aprovacoesPersistidas = len(t.Aprovacoes)
+	t.persistido = true
 }
 
 func NovoTicket(titulo, descricao string, categoria Categoria, subcategoria Subcategoria, abertoPor string) (*Ticket, error) {
 	if titulo == "" {
-		return nil, errors.New("titulo é obrigatório")
+		return nil, ErrTituloObrigatorio
 	}
 	if descricao == "" {
-		return nil, errors.New("descrição é obrigatória")
+		return nil, ErrDescricaoObrigatoria
 	}
 	if categoria == "" {
-		return nil, errors.New("categoria é obrigatória")
+		return nil, ErrCategoriaObrigatoria
 	}
 	if abertoPor == "" {
-		return nil, errors.New("abertura por é obrigatório")
+		return nil, ErrAbertoPorObrigatorio
 	}
 
-	// Validar e converter categoria para minúsculas
-	if err := ValidateCategoria(categoria); err != nil {
-		return nil, err
-	}
-	categoriaLower := Categoria(strings.ToLower(string(categoria)))
-
+	// categoria e subcategoria são gravadas em minúsculas; o par é validado contra a Taxonomia pelo caso de uso
 	return &Ticket{
 		ID:           uuid.New().String(),
 		Titulo:       titulo,
 		Descricao:    descricao,
-		Categoria:    categoriaLower,
-		Subcategoria: subcategoria,
+		Categoria:    Categoria(strings.ToLower(string(categoria))),
+		Subcategoria: Subcategoria(strings.ToLower(string(subcategoria))),
 		Status:       StatusAberto,
+		Versao:       1,
 		AbertoPor:    abertoPor,
 		DataAbertura: time.Now(),
 		Urgencia:     1, // valor padrão, pode ser alterado depois
@@ -175,70 +246,93 @@ func (t *Ticket) SetInformacaoAdicional(merchant, noxID, cpf, plataforma, contat
 	}
 }
 
-// IniciarAtendimento inicia o atendimento do ticket
-func (t *Ticket) IniciarAtendimento(responsavel string) error {
-	if t.Status != StatusAberto {
-		return errors.New("ticket não pode ser iniciado pois não está aberto")
+// AdicionarObservacao - adiciona uma nova observacao no ticket
+func (t *Ticket) AdicionarObservacao(descricao, usuarioID string) error {
+	observacao := Observacao{
+		ID:          uuid.New().String(),
+		TicketID:    t.ID,
+		UsuarioID:   usuarioID,
+		Descricao:   descricao,
+		DataCriacao: time.Now(),
 	}
 
-	agora := time.Now()
-	t.Status = StatusEmCurso
-	t.Responsavel = responsavel
-	t.DataInicio = &agora
-
-	return t.registrarModificacao("status", string(StatusAberto), string(StatusEmCurso), responsavel)
-}
+	t.Observacoes = append(t.Observacoes, observacao)
 
-// Concluir finaliza o ticket
-func (t *Ticket) Concluir(usuarioID string) error {
-	if t.Status != StatusEmCurso {
-		return errors.New("ticket não pode ser concluído pois não está em atendimento")
+	// uma observação de alguém que não é o solicitante conta como primeira resposta
+	if usuarioID != t.AbertoPor {
+		t.registrarPrimeiraResposta(observacao.DataCriacao)
 	}
 
-	agora := time.Now()
-	statusAnterior := t.Status
-	t.Status = StatusFinalizado
-	t.DataConclusao = &agora
-
-	// Calcula a duracao do Ticket
-	t.DuracaoTotal = agora.Sub(t.DataAbertura)
-	if t.DataInicio != nil {
-		t.DuracaoExecucao = agora.Sub(*t.DataInicio)
+	return nil
+}
+
+// DefinirFila coloca o ticket em uma fila de atendimento e registra a modificação
+func (t *Ticket) DefinirFila(filaID, usuarioID string) error {
+	if t.FilaID == filaID {
+		return nil
 	}
+	valorAnterior := t.FilaID
+	t.FilaID = filaID
+	return t.registrarModificacao("fila", valorAnterior, filaID, usuarioID)
+}
 
-	return t.registrarModificacao("status", string(statusAnterior), string(StatusFinalizado), usuarioID)
+// RegistrarAtribuicao registra a troca de responsável feita por uma regra de atribuição
+func (t *Ticket) RegistrarAtribuicao(responsavelAnterior, usuarioID string) error {
+	return t.registrarModificacao("responsavel", responsavelAnterior, t.Responsavel, usuarioID)
 }
 
-// Cancelar cancela o ticket
-func (t *Ticket) Cancelar(usuarioID string) error {
+// Reatribuir troca o responsável de um ticket em andamento e registra a modificação
+func (t *Ticket) Reatribuir(responsavel, usuarioID string) error {
 	if t.Status == StatusFinalizado || t.Status == StatusCancelado {
-		return errors.New("ticket não pode ser cancelado pois já foi finalizado ou cancelado")
+		return ErrTicketEncerrado
+	}
+	if responsavel == "" {
+		return ErrResponsavelObrigatorio
+	}
+	if responsavel == t.Responsavel {
+		return nil
 	}
+	anterior := t.Responsavel
+	t.Responsavel = responsavel
+	return t.RegistrarAtribuicao(anterior, usuarioID)
+}
 
-	statusAnterior := t.Status
-	t.Status = StatusCancelado
+// campoEscalonamento é a modificação que registra a regra de escalonamento aplicada; as ações da regra
+// vêm antes dela, no campo prefixoAcaoEscalonamento seguido do tipo da ação (ex.: escalonamento.notificar)
+const (
+	campoEscalonamento       = "escalonamento"
+	prefixoAcaoEscalonamento = "escalonamento."
+)
 
-	return t.registrarModificacao("status", string(statusAnterior), string(StatusCancelado), usuarioID)
+// RegistrarAcaoEscalonamento registra uma ação executada por uma regra de escalonamento
+func (t *Ticket) RegistrarAcaoEscalonamento(acao, valorAnterior, valorNovo, usuarioID string) error {
+	return t.registrarModificacao(prefixoAcaoEscalonamento+acao, valorAnterior, valorNovo, usuarioID)
 }
 
-// AdicionarObservacao - adiciona uma nova observacao no ticket
-func (t *Ticket) AdicionarObservacao(descricao, usuarioID string) error {
-	observacao := Observacao{
-		ID:          uuid.New().String(),
-		TicketID:    t.ID,
-		UsuarioID:   usuarioID,
-		Descricao:   descricao,
-		DataCriacao: time.Now(),
-	}
+// RegistrarEscalonamento registra que uma regra de escalonamento foi aplicada ao ticket, depois das suas ações
+func (t *Ticket) RegistrarEscalonamento(regra, usuarioID string) error {
+	return t.registrarModificacao(campoEscalonamento, "", regra, usuarioID)
+}
 
-	t.Observacoes = append(t.Observacoes, observacao)
+// RegistrarAnexo registra o envio de um arquivo ao ticket; tickets encerrados não recebem anexos
+func (t *Ticket) RegistrarAnexo(nome, usuarioID string) error {
+	if t.Status == StatusFinalizado || t.Status == StatusCancelado {
+		return ErrTicketEncerrado
+	}
+	return t.registrarModificacao("anexo", "", nome, usuarioID)
+}
 
-	return nil
+// RegistrarRemocaoAnexo registra que um arquivo foi removido do ticket
+func (t *Ticket) RegistrarRemocaoAnexo(nome, usuarioID string) error {
+	if t.Status == StatusFinalizado || t.Status == StatusCancelado {
+		return ErrTicketEncerrado
+	}
+	return t.registrarModificacao("anexo", nome, "", usuarioID)
 }
 
 func (t *Ticket) SetUrgencia(urgencia int, usuarioID string) error {
 	if urgencia < 1 || urgencia > 5 {
-		return errors.New("urgência inválida")
+		return ErrUrgenciaInvalida
 	}
 
 	valorAnterior := t.Urgencia
@@ -248,7 +342,7 @@ func (t *Ticket) SetUrgencia(urgencia int, usuarioID string) error {
 
 func (t *Ticket) SetGravidade(gravidade int, usuarioID string) error {
 	if gravidade < 1 || gravidade > 5 {
-		return errors.New("gravidade inválida")
+		return ErrGravidadeInvalida
 	}
 
 	valorAnterior := t.Gravidade
@@ -259,7 +353,7 @@ func (t *Ticket) SetGravidade(gravidade int, usuarioID string) error {
 // SetTitulo define o título do ticket e registra a modificação
 func (t *Ticket) SetTitulo(titulo string, usuarioID string) error {
 	if titulo == "" {
-		return errors.New("título é obrigatório")
+		return ErrTituloObrigatorio
 	}
 
 	valorAnterior := t.Titulo
@@ -270,7 +364,7 @@ func (t *Ticket) SetTitulo(titulo string, usuarioID string) error {
 // SetDescricao define a descrição do ticket e registra a modificação
 func (t *Ticket) SetDescricao(descricao string, usuarioID string) error {
 	if descricao == "" {
-		return errors.New("descrição é obrigatória")
+		return ErrDescricaoObrigatoria
 	}
 
 	valorAnterior := t.Descricao
@@ -278,25 +372,52 @@ func (t *Ticket) SetDescricao(descricao string, usuarioID string) error {
 	return t.registrarModificacao("descricao", valorAnterior, descricao, usuarioID)
 }
 
-// SetCategoria define a categoria do ticket e registra a modificação
-func (t *Ticket) SetCategoria(categoria Categoria, usuarioID string) error {
+// SetCategoria define a categoria do ticket; a subcategoria atual precisa pertencer à nova categoria
+func (t *Ticket) SetCategoria(categoria Categoria, taxonomia Taxonomia, usuarioID string) error {
+	return t.Reclassificar(categoria, t.Subcategoria, taxonomia, usuarioID)
+}
+
+// SetSubcategoria define a subcategoria do ticket dentro da categoria atual
+func (t *Ticket) SetSubcategoria(subcategoria Subcategoria, taxonomia Taxonomia, usuarioID string) error {
+	return t.Reclassificar(t.Categoria, subcategoria, taxonomia, usuarioID)
+}
+
+// Reclassificar muda a categoria e a subcategoria juntas; o par precisa estar ativo na taxonomia.
+// Cada campo alterado fica em uma modificação própria (categoria e subcategoria), no mesmo instante
+func (t *Ticket) Reclassificar(categoria Categoria, subcategoria Subcategoria, taxonomia Taxonomia, usuarioID string) error {
 	if categoria == "" {
-		return errors.New("categoria é obrigatória")
+		return ErrCategoriaObrigatoria
 	}
 
-	// Validar e converter categoria para minúsculas
-	if err := ValidateCategoria(categoria); err != nil {
+	// converter para minúsculas e validar o par
+	categoria = Categoria(strings.ToLower(string(categoria)))
+	subcategoria = Subcategoria(strings.ToLower(string(subcategoria)))
+	if categoria == t.Categoria && subcategoria == t.Subcategoria {
+		return nil
+	}
+	if err := taxonomia.ValidarClassificacao(categoria, subcategoria); err != nil {
 		return err
 	}
-	categoriaLower := Categoria(strings.ToLower(string(categoria)))
 
-	valorAnterior := t.Categoria
-	t.Categoria = categoriaLower
-	return t.registrarModificacao("categoria", string(valorAnterior), string(categoriaLower), usuarioID)
+	agora := time.Now()
+	if categoria != t.Categoria {
+		t.registrarModificacaoEm("categoria", string(t.Categoria), string(categoria), usuarioID, agora)
+		t.Categoria = categoria
+	}
+	if subcategoria != t.Subcategoria {
+		t.registrarModificacaoEm("subcategoria", string(t.Subcategoria), string(subcategoria), usuarioID, agora)
+		t.Subcategoria = subcategoria
+	}
+	return nil
 }
 
 // registrarModificacao - registra uma nova modificacao no ticket
 func (t *Ticket) registrarModificacao(campo, valorAnterior, valorNovo, usuarioID string) error {
+	return t.registrarModificacaoEm(campo, valorAnterior, valorNovo, usuarioID, time.Now())
+}
+
+// registrarModificacaoEm registra a modificação com a data informada, para agrupar as que ocorrem juntas
+func (t *Ticket) registrarModificacaoEm(campo, valorAnterior, valorNovo, usuarioID string, data time.Time) error {
 	modificacao := Modificacao{
 		ID:              uuid.New().String(),
 		TicketID:        t.ID,
@@ -304,7 +425,7 @@ func (t *Ticket) registrarModificacao(campo, valorAnterior, valorNovo, usuarioID
 		CampoModificado: campo,
 		ValorAnterior:   valorAnterior,
 		ValorNovo:       valorNovo,
-		DataModificacao: time.Now(),
+		DataModificacao: data,
 	}
 
 	t.Modificacoes = append(t.Modificacoes, modificacao)
@@ -0,0 +1,141 @@
+package ticket
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// taxonomiaTeste aceita apenas os pares cadastrados
+type taxonomiaTeste map[Categoria][]Subcategoria
+
+func (tx taxonomiaTeste) ValidarCategoria(categoria Categoria) error {
+	if _, ok := tx[categoria]; !ok {
+		return ErrCategoriaInvalida
+	}
+	return nil
+}
+
+func (tx taxonomiaTeste) ValidarClassificacao(categoria Categoria, subcategoria Subcategoria) error {
+	if err := tx.ValidarCategoria(categoria); err != nil {
+		return err
+	}
+	for _, s := range tx[categoria] {
+		if s == subcategoria {
+			return nil
+		}
+	}
+	return ErrSubcategoriaInvalida
+}
+
+func TestTicket_Reclassificar(t *testing.T) {
+	taxonomia := taxonomiaTeste{
+		CategoriaTI:         {SubcategoriaBug, SubcategoriaMelhoria},
+		CategoriaFinanceiro: {SubcategoriaSolicitacaoSaque},
+	}
+	tk := novoTicketTeste(t)
+
+	// a subcategoria muda dentro da categoria atual
+	if err := tk.SetSubcategoria("MELHORIA", taxonomia, "analista"); err != nil {
+		t.Fatalf("Erro ao mudar a subcategoria: %v", err)
+	}
+	if tk.Subcategoria != SubcategoriaMelhoria {
+		t.Errorf("Subcategoria = %s, esperava melhoria", tk.Subcategoria)
+	}
+
+	// trocar só a categoria falha quando a subcategoria atual não pertence a ela; as duas juntas passam
+	if err := tk.SetCategoria(CategoriaFinanceiro, taxonomia, "analista"); !errors.Is(err, ErrSubcategoriaInvalida) {
+		t.Errorf("Esperava ErrSubcategoriaInvalida, recebido %v", err)
+	}
+	if tk.Categoria != CategoriaTI {
+		t.Errorf("Classificação não deveria mudar após erro, categoria %s", tk.Categoria)
+	}
+	if err := tk.Reclassificar(CategoriaFinanceiro, SubcategoriaSolicitacaoSaque, taxonomia, "analista"); err != nil {
+		t.Fatalf("Erro ao reclassificar: %v", err)
+	}
+
+	// repetir a classificação atual não registra nada
+	if err := tk.Reclassificar(CategoriaFinanceiro, SubcategoriaSolicitacaoSaque, taxonomia, "analista"); err != nil {
+		t.Fatalf("Erro ao repetir a classificação: %v", err)
+	}
+
+	// cada campo alterado tem a própria modificação, com o valor sem a outra parte da classificação
+	esperadas := []Modificacao{
+		{CampoModificado: "subcategoria", ValorAnterior: "bug", ValorNovo: "melhoria"},
+		{CampoModificado: "categoria", ValorAnterior: "ti", ValorNovo: "financeiro"},
+		{CampoModificado: "subcategoria", ValorAnterior: "melhoria", ValorNovo: "solicitacao_de_saque"},
+	}
+	if len(tk.Modificacoes) != len(esperadas) {
+		t.Fatalf("Esperava %d modificações, recebido %+v", len(esperadas), tk.Modificacoes)
+	}
+	for i, e := range esperadas {
+		m := tk.Modificacoes[i]
+		if m.CampoModificado != e.CampoModificado || m.ValorAnterior != e.ValorAnterior || m.ValorNovo != e.ValorNovo {
+			t.Errorf("Modificação %d = %+v, esperava %+v", i, m, e)
+		}
+	}
+	if !tk.Modificacoes[1].DataModificacao.Equal(tk.Modificacoes[2].DataModificacao) {
+		t.Error("Categoria e subcategoria alteradas juntas deveriam ter a mesma data")
+	}
+}
+
+// Teste do relatório: as modificações de categoria e subcategoria voltam a formar as reclassificações
+func TestReclassificacoesDoHistorico(t *testing.T) {
+	base := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	tk := &Ticket{ID: "t1", Categoria: CategoriaFinanceiro, Subcategoria: SubcategoriaSolicitacaoSaque}
+	historico := []Modificacao{
+		{CampoModificado: "titulo", ValorAnterior: "a", ValorNovo: "b", DataModificacao: base},
+		{CampoModificado: "subcategoria", ValorAnterior: "bug", ValorNovo: "melhoria", UsuarioID: "analista", DataModificacao: base.Add(time.Hour)},
+		{CampoModificado: "categoria", ValorAnterior: "ti", ValorNovo: "financeiro", UsuarioID: "supervisor", DataModificacao: base.Add(2 * time.Hour)},
+		{CampoModificado: "subcategoria", ValorAnterior: "melhoria", ValorNovo: "solicitacao_de_saque", UsuarioID: "supervisor", DataModificacao: base.Add(2 * time.Hour)},
+	}
+
+	rc := ReclassificacoesDoHistorico(tk, historico)
+	if len(rc) != 2 {
+		t.Fatalf("Esperava 2 reclassificações, recebido %d", len(rc))
+	}
+	// a mais recente primeiro, com as duas partes mudando juntas
+	if rc[0].CategoriaAnterior != CategoriaTI || rc[0].SubcategoriaAnterior != SubcategoriaMelhoria ||
+		rc[0].CategoriaNova != CategoriaFinanceiro || rc[0].SubcategoriaNova != SubcategoriaSolicitacaoSaque || rc[0].UsuarioID != "supervisor" {
+		t.Errorf("Reclassificação mais recente diferente: %+v", rc[0])
+	}
+	// a mudança só de subcategoria mantém a categoria vigente naquele momento
+	if rc[1].CategoriaAnterior != CategoriaTI || rc[1].CategoriaNova != CategoriaTI ||
+		rc[1].SubcategoriaAnterior != SubcategoriaBug || rc[1].SubcategoriaNova != SubcategoriaMelhoria {
+		t.Errorf("Reclassificação mais antiga diferente: %+v", rc[1])
+	}
+	if !rc[1].Envolve(CategoriaTI) || rc[1].Envolve(CategoriaFinanceiro) || !rc[0].Envolve(CategoriaFinanceiro) {
+		t.Error("Envolve deveria considerar a categoria de origem e a de destino")
+	}
+}
+
+func TestTicket_SetCampos(t *testing.T) {
+	tk := novoTicketTeste(t)
+
+	if err := tk.SetCampos(map[string]interface{}{"cpf": "52998224725"}, "analista"); !errors.Is(err, ErrCampoReservado) {
+		t.Errorf("Esperava ErrCampoReservado, recebido %v", err)
+	}
+
+	tk.SetCampos(map[string]interface{}{"valor": 10.5, "ids": []interface{}{"tx_1"}}, "analista")
+	// repetir o mesmo valor e remover um campo inexistente não registram nada
+	tk.SetCampos(map[string]interface{}{"valor": 10.5, "inexistente": nil}, "analista")
+	tk.SetCampos(map[string]interface{}{"valor": nil}, "analista")
+
+	if _, ok := tk.Campos["valor"]; ok || len(tk.Campos) != 1 {
+		t.Errorf("Campos = %v, esperava só ids", tk.Campos)
+	}
+	esperadas := []Modificacao{
+		{CampoModificado: "campos.ids", ValorAnterior: "", ValorNovo: `["tx_1"]`},
+		{CampoModificado: "campos.valor", ValorAnterior: "", ValorNovo: "10.5"},
+		{CampoModificado: "campos.valor", ValorAnterior: "10.5", ValorNovo: ""},
+	}
+	if len(tk.Modificacoes) != len(esperadas) {
+		t.Fatalf("Esperava %d modificações, recebido %+v", len(esperadas), tk.Modificacoes)
+	}
+	for i, e := range esperadas {
+		m := tk.Modificacoes[i]
+		if m.CampoModificado != e.CampoModificado || m.ValorAnterior != e.ValorAnterior || m.ValorNovo != e.ValorNovo {
+			t.Errorf("Modificação %d = %+v, esperava %+v", i, m, e)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package ticket
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTransicaoInvalida = NovoErroTransicao("transicao_invalida", "transição de status inválida")
+	ErrPrazoReabertura   = NovoErroTransicao("prazo_reabertura_expirado", "prazo para reabertura expirado")
+)
+
+// PrazoReaberturaPadrao é o tempo, a partir da conclusão, em que um ticket ainda pode ser reaberto
+const PrazoReaberturaPadrao = 7 * 24 * time.Hour
+
+// Guarda é uma condição sobre o ticket que precisa ser satisfeita para a transição acontecer
+type Guarda func(t *Ticket, agora time.Time) error
+
+// Transicao define uma mudança de status permitida e as guardas que a protegem
+type Transicao struct {
+	De      Status
+	Para    Status
+	Guardas []Guarda
+}
+
+// ContextoTransicao carrega os dados informados por quem está pedindo a transição
+type ContextoTransicao struct {
+	UsuarioID   string
+	Responsavel string
+	Motivo      string // obrigatório ao entrar em um status de espera
+	Agora       time.Time
+}
+
+// Responsaveis confirma que um usuário pode assumir o atendimento de tickets
+type Responsaveis interface {
+	ValidarResponsavel(id string) error
+}
+
+// MaquinaDeEstados aplica as mudanças de status a partir de uma tabela declarativa de transições
+type MaquinaDeEstados struct {
+	transicoes   map[Status][]Transicao
+	status       map[Status]bool
+	calendario   Calendario
+	responsaveis Responsaveis
+}
+
+// NovaMaquinaDeEstados cria uma máquina de estados com a tabela de transições informada
+func NovaMaquinaDeEstados(transicoes []Transicao) *MaquinaDeEstados {
+	m := &MaquinaDeEstados{
+		transicoes: make(map[Status][]Transicao),
+		status:     make(map[Status]bool),
+		calendario: TempoCorrido{},
+	}
+	for _, tr := range transicoes {
+		m.transicoes[tr.De] = append(m.transicoes[tr.De], tr)
+		m.status[tr.De] = true
+		m.status[tr.Para] = true
+	}
+	return m
+}
+
+// Calendario retorna o calendário usado para medir as durações
+func (m *MaquinaDeEstados) Calendario() Calendario {
+	return m.calendario
+}
+
+// ComCalendario faz a máquina calcular as durações do ticket em tempo útil
+func (m *MaquinaDeEstados) ComCalendario(calendario Calendario) *MaquinaDeEstados {
+	m.calendario = calendario
+	return m
+}
+
+// ComResponsaveis faz a máquina recusar responsáveis desconhecidos ou desativados ao iniciar o atendimento
+func (m *MaquinaDeEstados) ComResponsaveis(responsaveis Responsaveis) *MaquinaDeEstados {
+	m.responsaveis = responsaveis
+	return m
+}
+
+// ComGuarda acrescenta a guarda a todas as transições que levam ao status informado
+func (m *MaquinaDeEstados) ComGuarda(para Status, guarda Guarda) *MaquinaDeEstados {
+	for de, transicoes := range m.transicoes {
+		for i := range transicoes {
+			if transicoes[i].Para == para {
+				m.transicoes[de][i].Guardas = append(m.transicoes[de][i].Guardas, guarda)
+			}
+		}
+	}
+	return m
+}
+
+// MaquinaDeEstadosPadrao retorna a máquina de estados com as transições padrão da aplicação
+func MaquinaDeEstadosPadrao() *MaquinaDeEstados {
+	return NovaMaquinaDeEstados(TransicoesPadrao())
+}
+
+// TransicoesPadrao é a tabela de transições usada pela aplicação
+func TransicoesPadrao() []Transicao {
+	reabertura := GuardaPrazoReabertura(PrazoReaberturaPadrao)
+
+	return []Transicao{
+		{De: StatusAberto, Para: StatusEmCurso},
+		{De: StatusAberto, Para: StatusCancelado},
+
+		{De: StatusEmCurso, Para: StatusAguardandoCliente},
+		{De: StatusEmCurso, Para: StatusAguardandoTerceiro},
+		{De: StatusEmCurso, Para: StatusPausado},
+		{De: StatusEmCurso, Para: StatusFinalizado},
+		{De: StatusEmCurso, Para: StatusCancelado},
+
+		{De: StatusAguardandoCliente, Para: StatusEmCurso},
+		{De: StatusAguardandoCliente, Para: StatusCancelado},
+
+		{De: StatusAguardandoTerceiro, Para: StatusEmCurso},
+		{De: StatusAguardandoTerceiro, Para: StatusCancelado},
+
+		{De: StatusPausado, Para: StatusEmCurso},
+		{De: StatusPausado, Para: StatusCancelado},
+
+		{De: StatusFinalizado, Para: StatusReaberto, Guardas: []Guarda{reabertura}},
+
+		{De: StatusReaberto, Para: StatusEmCurso},
+		{De: StatusReaberto, Para: StatusCancelado},
+	}
+}
+
+// GuardaPrazoReabertura só permite a transição enquanto o ticket foi concluído há menos de prazo
+func GuardaPrazoReabertura(prazo time.Duration) Guarda {
+	return func(t *Ticket, agora time.Time) error {
+		if t.DataConclusao == nil {
+			return nil
+		}
+		if agora.Sub(*t.DataConclusao) > prazo {
+			return ErrPrazoReabertura
+		}
+		return nil
+	}
+}
+
+// Conhece indica se o status faz parte da tabela de transições
+func (m *MaquinaDeEstados) Conhece(status Status) bool {
+	return m.status[status]
+}
+
+// Permitidas retorna os próximos status possíveis para o ticket, já avaliando as guardas
+func (m *MaquinaDeEstados) Permitidas(t *Ticket, agora time.Time) []Status {
+	permitidas := []Status{}
+	for _, tr := range m.transicoes[t.Status] {
+		if avaliarGuardas(tr, t, agora) == nil {
+			permitidas = append(permitidas, tr.Para)
+		}
+	}
+	return permitidas
+}
+
+// Aplicar move o ticket para o novo status, respeitando a tabela de transições
+func (m *MaquinaDeEstados) Aplicar(t *Ticket, para Status, ctx ContextoTransicao) error {
+	if ctx.Agora.IsZero() {
+		ctx.Agora = time.Now()
+	}
+
+	// 1. procura a transição na tabela
+	var transicao *Transicao
+	for i, tr := range m.transicoes[t.Status] {
+		if tr.Para == para {
+			transicao = &m.transicoes[t.Status][i]
+			break
+		}
+	}
+	if transicao == nil {
+		return fmt.Errorf("%w: de %s para %s", ErrTransicaoInvalida, t.Status, para)
+	}
+
+	// 2. avalia as guardas
+	if err := avaliarGuardas(*transicao, t, ctx.Agora); err != nil {
+		return err
+	}
+
+	// 3. aplica os efeitos de entrar no novo status
+	statusAnterior := t.Status
+	if err := t.entrarEm(para, ctx, m); err != nil {
+		return err
+	}
+	if EhStatusDePausa(statusAnterior) || statusAnterior == StatusReaberto {
+		t.encerrarPausa(ctx.Agora)
+	}
+	t.Status = para
+
+	return t.registrarModificacao("status", string(statusAnterior), string(para), ctx.UsuarioID)
+}
+
+func avaliarGuardas(tr Transicao, t *Ticket, agora time.Time) error {
+	for _, guarda := range tr.Guardas {
+		if err := guarda(t, agora); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entrarEm aplica os efeitos colaterais de cada status de destino
+func (t *Ticket) entrarEm(para Status, ctx ContextoTransicao, m *MaquinaDeEstados) error {
+	calendario := m.calendario
+
+	if EhStatusDePausa(para) {
+		return t.iniciarPausa(para, ctx)
+	}
+
+	switch para {
+	case StatusEmCurso:
+		responsavel := ctx.Responsavel
+		if responsavel == "" {
+			responsavel = t.Responsavel
+		}
+		if responsavel == "" {
+			return ErrResponsavelObrigatorio
+		}
+		if m.responsaveis != nil {
+			if err := m.responsaveis.ValidarResponsavel(responsavel); err != nil {
+				return err
+			}
+		}
+		t.Responsavel = responsavel
+		if t.DataInicio == nil {
+			agora := ctx.Agora
+			t.DataInicio = &agora
+		}
+		t.registrarPrimeiraResposta(ctx.Agora)
+
+	case StatusFinalizado:
+		agora := ctx.Agora
+		t.DataConclusao = &agora
+
+		// Calcula a duracao do Ticket, em tempo útil e em tempo corrido,
+		// descontando da execução o tempo em que o ticket ficou pausado
+		t.DuracaoTotal = calendario.DuracaoUtil(t.DataAbertura, agora)
+		t.DuracaoTotalCorrida = agora.Sub(t.DataAbertura)
+		if t.DataInicio != nil {
+			t.DuracaoExecucao = calendario.DuracaoUtil(*t.DataInicio, agora) - t.tempoEmPausa(agora, calendario)
+			t.DuracaoExecucaoCorrida = agora.Sub(*t.DataInicio) - t.tempoEmPausa(agora, TempoCorrido{})
+		}
+		t.recalcularViolacaoSLA()
+
+	case StatusReaberto:
+		// o tempo entre a conclusão e a retomada do atendimento não é execução: fica
+		// registrado como uma pausa, encerrada quando o ticket sai de reaberto
+		inicio := ctx.Agora
+		if t.DataConclusao != nil {
+			inicio = *t.DataConclusao
+		}
+		t.Pausas = append(t.Pausas, Pausa{
+			ID:        uuid.New().String(),
+			TicketID:  t.ID,
+			Status:    StatusReaberto,
+			Motivo:    motivoReabertura(ctx.Motivo),
+			UsuarioID: ctx.UsuarioID,
+			Inicio:    inicio,
+		})
+		t.DataConclusao = nil
+	}
+
+	return nil
+}
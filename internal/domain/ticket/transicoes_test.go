@@ -0,0 +1,281 @@
+package ticket
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Função auxiliar para criar um ticket de teste
+func novoTicketTeste(t *testing.T) *Ticket {
+	tk, err := NovoTicket("Ticket de Teste", "Descrição do ticket de teste", CategoriaTI, SubcategoriaBug, "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket de teste: %v", err)
+	}
+	return tk
+}
+
+func TestMaquinaDeEstados_FluxoCompleto(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+
+	passos := []struct {
+		para        Status
+		responsavel string
+		motivo      string
+	}{
+		{StatusEmCurso, "analista", ""},
+		{StatusAguardandoCliente, "", "aguardando comprovante"},
+		{StatusEmCurso, "", ""},
+		{StatusFinalizado, "", ""},
+		{StatusReaberto, "", ""},
+		{StatusEmCurso, "", ""},
+	}
+
+	for _, p := range passos {
+		ctx := ContextoTransicao{UsuarioID: "analista", Responsavel: p.responsavel, Motivo: p.motivo}
+		if err := m.Aplicar(tk, p.para, ctx); err != nil {
+			t.Fatalf("Erro ao mudar para %s: %v", p.para, err)
+		}
+	}
+
+	if tk.Responsavel != "analista" {
+		t.Errorf("Responsável diferente: esperado analista, recebido %s", tk.Responsavel)
+	}
+	if tk.DataConclusao != nil {
+		t.Error("Esperava data de conclusão limpa após reabertura")
+	}
+	if len(tk.Modificacoes) != len(passos) {
+		t.Errorf("Esperava %d modificações, recebido %d", len(passos), len(tk.Modificacoes))
+	}
+}
+
+func TestMaquinaDeEstados_TransicaoInvalida(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+
+	err := m.Aplicar(tk, StatusFinalizado, ContextoTransicao{UsuarioID: "analista"})
+	if !errors.Is(err, ErrTransicaoInvalida) {
+		t.Errorf("Esperava ErrTransicaoInvalida, recebido %v", err)
+	}
+	if tk.Status != StatusAberto {
+		t.Errorf("Status não deveria mudar: recebido %s", tk.Status)
+	}
+}
+
+func TestMaquinaDeEstados_ResponsavelObrigatorio(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+
+	if err := m.Aplicar(tk, StatusEmCurso, ContextoTransicao{UsuarioID: "analista"}); err == nil {
+		t.Error("Esperava erro ao iniciar atendimento sem responsável")
+	}
+}
+
+func TestMaquinaDeEstados_PrazoReabertura(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+
+	if err := m.Aplicar(tk, StatusEmCurso, ContextoTransicao{UsuarioID: "analista", Responsavel: "analista"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	if err := m.Aplicar(tk, StatusFinalizado, ContextoTransicao{UsuarioID: "analista"}); err != nil {
+		t.Fatalf("Erro ao concluir: %v", err)
+	}
+
+	depois := tk.DataConclusao.Add(PrazoReaberturaPadrao + time.Hour)
+	for _, s := range m.Permitidas(tk, depois) {
+		if s == StatusReaberto {
+			t.Error("Reabertura não deveria ser permitida após o prazo")
+		}
+	}
+
+	err := m.Aplicar(tk, StatusReaberto, ContextoTransicao{UsuarioID: "analista", Agora: depois})
+	if !errors.Is(err, ErrPrazoReabertura) {
+		t.Errorf("Esperava ErrPrazoReabertura, recebido %v", err)
+	}
+
+	dentro := tk.DataConclusao.Add(time.Hour)
+	permitidas := m.Permitidas(tk, dentro)
+	if len(permitidas) != 1 || permitidas[0] != StatusReaberto {
+		t.Errorf("Esperava apenas reaberto como permitido, recebido %v", permitidas)
+	}
+}
+
+func TestMaquinaDeEstados_PausasDescontadasDaExecucao(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+	inicio := tk.DataAbertura
+
+	passos := []struct {
+		para   Status
+		motivo string
+		em     time.Duration
+	}{
+		{StatusEmCurso, "", 0},
+		{StatusAguardandoCliente, "aguardando comprovante", time.Hour},
+		{StatusEmCurso, "", 3 * time.Hour},
+		{StatusFinalizado, "", 4 * time.Hour},
+	}
+	for _, p := range passos {
+		ctx := ContextoTransicao{UsuarioID: "analista", Responsavel: "analista", Motivo: p.motivo, Agora: inicio.Add(p.em)}
+		if err := m.Aplicar(tk, p.para, ctx); err != nil {
+			t.Fatalf("Erro ao mudar para %s: %v", p.para, err)
+		}
+	}
+
+	if tk.DuracaoExecucao != 2*time.Hour {
+		t.Errorf("Duração de execução diferente: esperado 2h, recebido %s", tk.DuracaoExecucao)
+	}
+	if tk.DuracaoTotal != 4*time.Hour {
+		t.Errorf("Duração total diferente: esperado 4h, recebido %s", tk.DuracaoTotal)
+	}
+
+	resumo := tk.ResumoExecucao(inicio.Add(5*time.Hour), nil)
+	if resumo.TempoAtivo != 2*time.Hour || resumo.TempoEspera != 2*time.Hour {
+		t.Errorf("Resumo diferente: recebido %+v", resumo)
+	}
+	if len(tk.Pausas) != 1 || tk.Pausas[0].Fim == nil {
+		t.Errorf("Esperava uma pausa encerrada, recebido %+v", tk.Pausas)
+	}
+}
+
+func TestMaquinaDeEstados_ReaberturaNaoContaComoExecucao(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+	inicio := tk.DataAbertura
+
+	// 2h de execução, 24h concluído, 2h reaberto sem atendimento e mais 1h de execução
+	passos := []struct {
+		para Status
+		em   time.Duration
+	}{
+		{StatusEmCurso, 0},
+		{StatusFinalizado, 2 * time.Hour},
+		{StatusReaberto, 26 * time.Hour},
+		{StatusEmCurso, 28 * time.Hour},
+		{StatusFinalizado, 29 * time.Hour},
+	}
+	for _, p := range passos {
+		ctx := ContextoTransicao{UsuarioID: "analista", Responsavel: "analista", Agora: inicio.Add(p.em)}
+		if err := m.Aplicar(tk, p.para, ctx); err != nil {
+			t.Fatalf("Erro ao mudar para %s: %v", p.para, err)
+		}
+	}
+
+	if tk.DuracaoExecucao != 3*time.Hour || tk.DuracaoExecucaoCorrida != 3*time.Hour {
+		t.Errorf("Duração de execução diferente: esperado 3h, recebido %s (corrida %s)", tk.DuracaoExecucao, tk.DuracaoExecucaoCorrida)
+	}
+	if tk.DuracaoTotal != 29*time.Hour {
+		t.Errorf("Duração total diferente: esperado 29h, recebido %s", tk.DuracaoTotal)
+	}
+	if len(tk.Pausas) != 1 || tk.Pausas[0].Status != StatusReaberto || tk.Pausas[0].Fim == nil ||
+		!tk.Pausas[0].Inicio.Equal(inicio.Add(2*time.Hour)) || !tk.Pausas[0].Fim.Equal(inicio.Add(28*time.Hour)) {
+		t.Errorf("Esperava a pausa da conclusão até a retomada, recebido %+v", tk.Pausas)
+	}
+	resumo := tk.ResumoExecucao(inicio.Add(30*time.Hour), nil)
+	if resumo.TempoAtivo != 3*time.Hour || resumo.TempoEspera != 26*time.Hour {
+		t.Errorf("Resumo diferente: recebido %+v", resumo)
+	}
+}
+
+func TestMaquinaDeEstados_PausaExigeMotivo(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+
+	if err := m.Aplicar(tk, StatusEmCurso, ContextoTransicao{UsuarioID: "analista", Responsavel: "analista"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	err := m.Aplicar(tk, StatusPausado, ContextoTransicao{UsuarioID: "analista"})
+	if !errors.Is(err, ErrMotivoPausaObrigatorio) {
+		t.Errorf("Esperava ErrMotivoPausaObrigatorio, recebido %v", err)
+	}
+}
+
+// responsaveisTeste aceita apenas os usuários ativos informados
+type responsaveisTeste map[string]bool
+
+func (r responsaveisTeste) ValidarResponsavel(id string) error {
+	if !r[id] {
+		return ErrResponsavelInvalido
+	}
+	return nil
+}
+
+func TestMaquinaDeEstados_ResponsavelDesconhecido(t *testing.T) {
+	m := MaquinaDeEstadosPadrao().ComResponsaveis(responsaveisTeste{"analista": true, "desligado": false})
+
+	for _, responsavel := range []string{"desconhecido", "desligado"} {
+		tk := novoTicketTeste(t)
+		err := m.Aplicar(tk, StatusEmCurso, ContextoTransicao{UsuarioID: "supervisor", Responsavel: responsavel})
+		if !errors.Is(err, ErrResponsavelInvalido) {
+			t.Errorf("Esperava ErrResponsavelInvalido para %s, recebido %v", responsavel, err)
+		}
+		if tk.Status != StatusAberto || tk.Responsavel != "" {
+			t.Errorf("Ticket não deveria mudar, recebido status %s e responsável %q", tk.Status, tk.Responsavel)
+		}
+	}
+
+	tk := novoTicketTeste(t)
+	if err := m.Aplicar(tk, StatusEmCurso, ContextoTransicao{UsuarioID: "supervisor", Responsavel: "analista"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+}
+
+func TestMaquinaDeEstados_ComGuarda(t *testing.T) {
+	errBloqueio := NovoErroTransicao("bloqueio_teste", "bloqueado pelo teste")
+	m := MaquinaDeEstadosPadrao().ComGuarda(StatusFinalizado, func(t *Ticket, agora time.Time) error {
+		return errBloqueio
+	})
+
+	tk := novoTicketTeste(t)
+	if err := m.Aplicar(tk, StatusEmCurso, ContextoTransicao{UsuarioID: "supervisor", Responsavel: "analista"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	if err := m.Aplicar(tk, StatusFinalizado, ContextoTransicao{UsuarioID: "analista"}); !errors.Is(err, errBloqueio) {
+		t.Errorf("Esperava a guarda bloquear a conclusão, recebido %v", err)
+	}
+	if tk.Status != StatusEmCurso {
+		t.Errorf("Ticket não deveria mudar, recebido status %s", tk.Status)
+	}
+
+	// as demais transições não são afetadas
+	if err := m.Aplicar(tk, StatusCancelado, ContextoTransicao{UsuarioID: "analista"}); err != nil {
+		t.Errorf("Erro ao cancelar: %v", err)
+	}
+}
+
+func TestTicket_PausasNovasEEncerradas(t *testing.T) {
+	m := MaquinaDeEstadosPadrao()
+	tk := novoTicketTeste(t)
+	inicio := tk.DataAbertura
+	aplicar := func(para Status, motivo string, em time.Duration) {
+		ctx := ContextoTransicao{UsuarioID: "analista", Responsavel: "analista", Motivo: motivo, Agora: inicio.Add(em)}
+		if err := m.Aplicar(tk, para, ctx); err != nil {
+			t.Fatalf("Erro ao mudar para %s: %v", para, err)
+		}
+	}
+
+	aplicar(StatusEmCurso, "", 0)
+	aplicar(StatusAguardandoCliente, "aguardando comprovante", time.Hour)
+	if len(tk.PausasNovas()) != 1 || len(tk.PausasEncerradas()) != 0 {
+		t.Fatalf("Esperava uma pausa nova, recebido %+v e %+v", tk.PausasNovas(), tk.PausasEncerradas())
+	}
+	tk.MarcarComoPersistido()
+
+	// a pausa gravada em aberto é encerrada e outra é aberta: só essas duas são gravadas
+	aplicar(StatusEmCurso, "", 2*time.Hour)
+	aplicar(StatusPausado, "aguardando o time de produto", 3*time.Hour)
+	encerradas, novas := tk.PausasEncerradas(), tk.PausasNovas()
+	if len(encerradas) != 1 || encerradas[0].ID != tk.Pausas[0].ID || encerradas[0].Fim == nil {
+		t.Errorf("Esperava a primeira pausa encerrada, recebido %+v", encerradas)
+	}
+	if len(novas) != 1 || novas[0].ID != tk.Pausas[1].ID {
+		t.Errorf("Esperava apenas a segunda pausa como nova, recebido %+v", novas)
+	}
+
+	tk.MarcarComoPersistido()
+	if len(tk.PausasNovas()) != 0 || len(tk.PausasEncerradas()) != 0 {
+		t.Error("Depois de gravado, o ticket não deveria ter pausas a gravar")
+	}
+}
@@ -0,0 +1,123 @@
+package usuario
+
+import (
+	"errors"
+	"strings"
+
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Diretorio consulta os usuários cadastrados para os outros domínios
+type Diretorio struct {
+	repo Repository
+}
+
+// NovoDiretorio cria o diretório sobre o repositório de usuários
+func NovoDiretorio(repo Repository) *Diretorio {
+	return &Diretorio{repo: repo}
+}
+
+// ValidarResponsavel aceita apenas usuários cadastrados e ativos como responsáveis por tickets
+func (d *Diretorio) ValidarResponsavel(id string) error {
+	u, err := d.repo.BuscarUsuario(id)
+	if errors.Is(err, ErrUsuarioNaoEncontrado) {
+		return ticket.ErrResponsavelInvalido
+	}
+	if err != nil {
+		return err
+	}
+	if !u.Ativo {
+		return ticket.ErrResponsavelInvalido
+	}
+	return nil
+}
+
+// GarantirUsuario retorna o usuário autenticado, cadastrando-o no primeiro acesso
+// com o nome e o e-mail do token. Usuários desativados são recusados.
+func (d *Diretorio) GarantirUsuario(p auth.Principal) (*Usuario, error) {
+	// 1. usuário já cadastrado
+	u, err := d.repo.BuscarUsuario(p.ID)
+	if err == nil {
+		if !u.Ativo {
+			return nil, ErrUsuarioDesativado
+		}
+		return u, nil
+	}
+	if !errors.Is(err, ErrUsuarioNaoEncontrado) {
+		return nil, err
+	}
+
+	// 2. primeiro acesso: cadastra com os dados do token
+	nome := p.Nome
+	if nome == "" {
+		nome = p.ID
+	}
+	u, err = NovoUsuario(p.ID, nome, "")
+	if err != nil {
+		return nil, err
+	}
+	// e-mail inválido no token não impede o cadastro
+	_ = u.SetEmail(p.Email)
+
+	err = d.repo.CriarUsuario(u)
+	if errors.Is(err, ErrUsuarioDuplicado) {
+		// outra requisição cadastrou o usuário ao mesmo tempo, ou o e-mail já está em uso
+		if existente, errBusca := d.repo.BuscarUsuario(p.ID); errBusca == nil {
+			return existente, nil
+		}
+		u.Email = ""
+		err = d.repo.CriarUsuario(u)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// BuscarAtivo retorna o usuário cadastrado, recusando os desativados
+func (d *Diretorio) BuscarAtivo(id string) (*Usuario, error) {
+	u, err := d.repo.BuscarUsuario(id)
+	if err != nil {
+		return nil, err
+	}
+	if !u.Ativo {
+		return nil, ErrUsuarioDesativado
+	}
+	return u, nil
+}
+
+// UsuarioDoEmail retorna o usuário dono do e-mail, cadastrando-o com um novo ID se o
+// endereço ainda não for conhecido. Usado para quem abre tickets por e-mail sem nunca
+// ter acessado a aplicação. Usuários desativados são recusados.
+func (d *Diretorio) UsuarioDoEmail(email, nome string) (*Usuario, error) {
+	// 1. e-mail já cadastrado
+	u, err := d.repo.BuscarUsuarioPorEmail(email)
+	if err == nil {
+		if !u.Ativo {
+			return nil, ErrUsuarioDesativado
+		}
+		return u, nil
+	}
+	if !errors.Is(err, ErrUsuarioNaoEncontrado) {
+		return nil, err
+	}
+
+	// 2. endereço novo: cadastra com o nome do remetente, ou o próprio endereço
+	if strings.TrimSpace(nome) == "" {
+		nome = email
+	}
+	u, err = NovoUsuario("", nome, email)
+	if err != nil {
+		return nil, err
+	}
+	err = d.repo.CriarUsuario(u)
+	if errors.Is(err, ErrUsuarioDuplicado) {
+		// outro e-mail do mesmo remetente cadastrou o usuário ao mesmo tempo
+		return d.repo.BuscarUsuarioPorEmail(email)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
@@ -0,0 +1,36 @@
+package usuario
+
+// FiltrosUsuario restringe a listagem de usuários
+type FiltrosUsuario struct {
+	EquipeID     string
+	ApenasAtivos bool
+}
+
+type Repository interface {
+	// Criar usuário; falha com ErrUsuarioDuplicado se o ID ou o e-mail já existirem
+	CriarUsuario(u *Usuario) error
+
+	// Buscar usuário por ID, com as equipes
+	BuscarUsuario(id string) (*Usuario, error)
+
+	// Buscar usuário pelo e-mail, sem diferenciar maiúsculas; falha com ErrUsuarioNaoEncontrado
+	BuscarUsuarioPorEmail(email string) (*Usuario, error)
+
+	// Listar usuários ordenados por nome
+	ListarUsuarios(filtros FiltrosUsuario) ([]*Usuario, error)
+
+	// Atualizar nome, e-mail, situação e equipes do usuário
+	AtualizarUsuario(u *Usuario) error
+
+	// Criar equipe; falha com ErrEquipeDuplicada se o nome já existir
+	CriarEquipe(e *Equipe) error
+
+	// Buscar equipe por ID
+	BuscarEquipe(id string) (*Equipe, error)
+
+	// Listar equipes ordenadas por nome
+	ListarEquipes(apenasAtivas bool) ([]*Equipe, error)
+
+	// Atualizar nome, descrição e situação da equipe
+	AtualizarEquipe(e *Equipe) error
+}
@@ -0,0 +1,139 @@
+package usuario
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+// IDSistema identifica o usuário usado nas ações automáticas da aplicação
+const IDSistema = "sistema"
+
+var (
+	ErrUsuarioNaoEncontrado = ticket.NovoErroNaoEncontrado("usuario_nao_encontrado", "usuário não encontrado")
+	ErrEquipeNaoEncontrada  = ticket.NovoErroNaoEncontrado("equipe_nao_encontrada", "equipe não encontrada")
+
+	ErrNomeObrigatorio = ticket.NovoErroValidacao("nome", "nome_obrigatorio", "nome é obrigatório")
+	ErrEmailInvalido   = ticket.NovoErroValidacao("email", "email_invalido", "e-mail inválido")
+	ErrEquipeInvalida  = ticket.NovoErroValidacao("equipes", "equipe_invalida", "equipe não existe ou está desativada")
+
+	ErrUsuarioDuplicado = ticket.NovoErroConflito("usuario_duplicado", "já existe um usuário com este id ou e-mail")
+	ErrEquipeDuplicada  = ticket.NovoErroConflito("equipe_duplicada", "já existe uma equipe com este nome")
+
+	ErrUsuarioDesativado = ticket.NovoErroProibido("usuario_desativado", "usuário desativado")
+)
+
+// Usuario é uma pessoa que abre ou atende tickets. O ID é o mesmo da claim sub do token.
+type Usuario struct {
+	ID          string
+	Nome        string
+	Email       string
+	Ativo       bool
+	Equipes     []string // IDs das equipes de que o usuário faz parte
+	Habilidades []string // categorias e subcategorias que o usuário sabe atender
+	DataCriacao time.Time
+}
+
+// Equipe agrupa os usuários que atendem os mesmos tickets
+type Equipe struct {
+	ID          string
+	Nome        string
+	Descricao   string
+	Ativa       bool
+	DataCriacao time.Time
+}
+
+// NovoUsuario cria um usuário ativo; sem ID informado, um novo é gerado
+func NovoUsuario(id, nome, email string) (*Usuario, error) {
+	u := &Usuario{
+		ID:          strings.TrimSpace(id),
+		Ativo:       true,
+		Equipes:     []string{},
+		Habilidades: []string{},
+		DataCriacao: time.Now(),
+	}
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	if err := u.SetNome(nome); err != nil {
+		return nil, err
+	}
+	if err := u.SetEmail(email); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// SetNome altera o nome do usuário
+func (u *Usuario) SetNome(nome string) error {
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+	u.Nome = nome
+	return nil
+}
+
+// SetEmail altera o e-mail do usuário; o e-mail é opcional
+func (u *Usuario) SetEmail(email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email != "" {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return ErrEmailInvalido
+		}
+	}
+	u.Email = email
+	return nil
+}
+
+// SetHabilidades define as habilidades do usuário, em minúsculas e sem repetição
+func (u *Usuario) SetHabilidades(habilidades []string) {
+	u.Habilidades = []string{}
+	vistas := make(map[string]bool)
+	for _, h := range habilidades {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" || vistas[h] {
+			continue
+		}
+		vistas[h] = true
+		u.Habilidades = append(u.Habilidades, h)
+	}
+}
+
+// PertenceA indica se o usuário faz parte da equipe
+func (u *Usuario) PertenceA(equipeID string) bool {
+	for _, e := range u.Equipes {
+		if e == equipeID {
+			return true
+		}
+	}
+	return false
+}
+
+// NovaEquipe cria uma equipe ativa
+func NovaEquipe(nome, descricao string) (*Equipe, error) {
+	e := &Equipe{
+		ID:          uuid.New().String(),
+		Descricao:   strings.TrimSpace(descricao),
+		Ativa:       true,
+		DataCriacao: time.Now(),
+	}
+	if err := e.SetNome(nome); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// SetNome altera o nome da equipe
+func (e *Equipe) SetNome(nome string) error {
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+	e.Nome = nome
+	return nil
+}
@@ -0,0 +1,137 @@
+package usuario
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// repositorioMemoria guarda os usuários em memória para os testes do diretório
+type repositorioMemoria struct {
+	usuarios map[string]*Usuario
+}
+
+func novoRepositorioMemoria(usuarios ...*Usuario) *repositorioMemoria {
+	r := &repositorioMemoria{usuarios: make(map[string]*Usuario)}
+	for _, u := range usuarios {
+		r.usuarios[u.ID] = u
+	}
+	return r
+}
+
+func (r *repositorioMemoria) CriarUsuario(u *Usuario) error {
+	if _, ok := r.usuarios[u.ID]; ok {
+		return ErrUsuarioDuplicado
+	}
+	r.usuarios[u.ID] = u
+	return nil
+}
+
+func (r *repositorioMemoria) BuscarUsuario(id string) (*Usuario, error) {
+	u, ok := r.usuarios[id]
+	if !ok {
+		return nil, ErrUsuarioNaoEncontrado
+	}
+	return u, nil
+}
+
+func (r *repositorioMemoria) BuscarUsuarioPorEmail(email string) (*Usuario, error) {
+	for _, u := range r.usuarios {
+		if u.Email != "" && u.Email == strings.ToLower(email) {
+			return u, nil
+		}
+	}
+	return nil, ErrUsuarioNaoEncontrado
+}
+
+func (r *repositorioMemoria) ListarUsuarios(FiltrosUsuario) ([]*Usuario, error) { return nil, nil }
+func (r *repositorioMemoria) AtualizarUsuario(*Usuario) error                   { return nil }
+func (r *repositorioMemoria) CriarEquipe(*Equipe) error                         { return nil }
+func (r *repositorioMemoria) BuscarEquipe(string) (*Equipe, error) {
+	return nil, ErrEquipeNaoEncontrada
+}
+func (r *repositorioMemoria) ListarEquipes(bool) ([]*Equipe, error) { return nil, nil }
+func (r *repositorioMemoria) AtualizarEquipe(*Equipe) error         { return nil }
+
+func TestNovoUsuario_Validacoes(t *testing.T) {
+	if _, err := NovoUsuario("", " ", ""); !errors.Is(err, ErrNomeObrigatorio) {
+		t.Errorf("Esperava ErrNomeObrigatorio, recebido %v", err)
+	}
+	if _, err := NovoUsuario("", "Ana", "ana@"); !errors.Is(err, ErrEmailInvalido) {
+		t.Errorf("Esperava ErrEmailInvalido, recebido %v", err)
+	}
+
+	u, err := NovoUsuario("", "Ana", " Ana@Nox.com ")
+	if err != nil {
+		t.Fatalf("Erro ao criar usuário: %v", err)
+	}
+	if u.ID == "" || !u.Ativo || u.Email != "ana@nox.com" {
+		t.Errorf("Usuário diferente do esperado: %+v", u)
+	}
+}
+
+func TestDiretorio_ValidarResponsavel(t *testing.T) {
+	ativo, _ := NovoUsuario("analista", "Analista", "")
+	desativado, _ := NovoUsuario("desligado", "Desligado", "")
+	desativado.Ativo = false
+	d := NovoDiretorio(novoRepositorioMemoria(ativo, desativado))
+
+	if err := d.ValidarResponsavel("analista"); err != nil {
+		t.Errorf("Esperava responsável válido, recebido %v", err)
+	}
+	for _, id := range []string{"desligado", "desconhecido"} {
+		if err := d.ValidarResponsavel(id); !errors.Is(err, ticket.ErrResponsavelInvalido) {
+			t.Errorf("Esperava ErrResponsavelInvalido para %s, recebido %v", id, err)
+		}
+	}
+}
+
+func TestDiretorio_GarantirUsuario(t *testing.T) {
+	repo := novoRepositorioMemoria()
+	d := NovoDiretorio(repo)
+
+	// primeiro acesso cadastra o usuário com os dados do token
+	u, err := d.GarantirUsuario(auth.Principal{ID: "u-1", Nome: "Bia", Email: "bia@nox.com"})
+	if err != nil {
+		t.Fatalf("Erro ao garantir usuário: %v", err)
+	}
+	if repo.usuarios["u-1"] != u || u.Nome != "Bia" || u.Email != "bia@nox.com" {
+		t.Errorf("Usuário não cadastrado como esperado: %+v", u)
+	}
+
+	// usuário desativado é recusado
+	u.Ativo = false
+	if _, err := d.GarantirUsuario(auth.Principal{ID: "u-1"}); !errors.Is(err, ErrUsuarioDesativado) {
+		t.Errorf("Esperava ErrUsuarioDesativado, recebido %v", err)
+	}
+}
+
+func TestDiretorio_UsuarioDoEmail(t *testing.T) {
+	existente, _ := NovoUsuario("u-1", "Bia", "bia@nox.com")
+	repo := novoRepositorioMemoria(existente)
+	d := NovoDiretorio(repo)
+
+	// e-mail cadastrado devolve o usuário, sem diferenciar maiúsculas
+	u, err := d.UsuarioDoEmail("Bia@Nox.com", "Outro Nome")
+	if err != nil || u != existente {
+		t.Fatalf("Esperava o usuário existente, recebido %+v, %v", u, err)
+	}
+
+	// endereço novo cadastra um usuário com ID gerado; sem nome, usa o endereço
+	novo, err := d.UsuarioDoEmail("cliente@loja.com", "")
+	if err != nil {
+		t.Fatalf("Erro ao cadastrar usuário do e-mail: %v", err)
+	}
+	if novo.ID == "" || novo.Nome != "cliente@loja.com" || novo.Email != "cliente@loja.com" || repo.usuarios[novo.ID] != novo {
+		t.Errorf("Usuário não cadastrado como esperado: %+v", novo)
+	}
+
+	// usuário desativado é recusado
+	existente.Ativo = false
+	if _, err := d.UsuarioDoEmail("bia@nox.com", ""); !errors.Is(err, ErrUsuarioDesativado) {
+		t.Errorf("Esperava ErrUsuarioDesativado, recebido %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+// Cabeçalhos enviados em cada entrega
+const (
+	CabecalhoEvento     = "X-Nox-Evento"
+	CabecalhoEntrega    = "X-Nox-Entrega"
+	CabecalhoTimestamp  = "X-Nox-Timestamp"
+	CabecalhoAssinatura = "X-Nox-Assinatura"
+)
+
+var (
+	ErrAssinaturaNaoEncontrada = ticket.NovoErroNaoEncontrado("webhook_nao_encontrado", "webhook não encontrado")
+	ErrURLInvalida             = ticket.NovoErroValidacao("url", "url_invalida", "URL deve ser absoluta, com esquema http ou https")
+	ErrEventoInvalido          = ticket.NovoErroValidacao("eventos", "evento_invalido", "tipo de evento inválido")
+)
+
+// Assinatura é a inscrição de um sistema externo para receber eventos de tickets.
+// Eventos e categorias vazios recebem todos os eventos de todas as categorias.
+type Assinatura struct {
+	ID          string
+	URL         string
+	Segredo     string // chave do HMAC-SHA256 das entregas
+	Eventos     []ticket.TipoEvento
+	Categorias  []ticket.Categoria
+	Ativa       bool
+	CriadoPor   string
+	DataCriacao time.Time
+}
+
+// NovaAssinatura cria uma assinatura ativa com um segredo aleatório
+func NovaAssinatura(endereco string, eventos []ticket.TipoEvento, categorias []ticket.Categoria, criadoPor string) (*Assinatura, error) {
+	a := &Assinatura{
+		ID:          uuid.New().String(),
+		Segredo:     GerarSegredo(),
+		Ativa:       true,
+		CriadoPor:   criadoPor,
+		DataCriacao: time.Now(),
+	}
+	if err := a.SetURL(endereco); err != nil {
+		return nil, err
+	}
+	if err := a.SetEventos(eventos); err != nil {
+		return nil, err
+	}
+	if err := a.SetCategorias(categorias); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// GerarSegredo cria um segredo aleatório de 32 bytes em hexadecimal
+func GerarSegredo() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// SetURL altera o endereço que recebe as entregas
+func (a *Assinatura) SetURL(endereco string) error {
+	u, err := url.Parse(strings.TrimSpace(endereco))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrURLInvalida
+	}
+	a.URL = u.String()
+	return nil
+}
+
+// SetEventos altera os tipos de evento assinados
+func (a *Assinatura) SetEventos(eventos []ticket.TipoEvento) error {
+	for _, e := range eventos {
+		if !contem(ticket.TiposEvento, e) {
+			return ErrEventoInvalido
+		}
+	}
+	a.Eventos = eventos
+	return nil
+}
+
+// SetCategorias altera as categorias de ticket assinadas; elas são validadas contra a taxonomia pelos casos de uso
+func (a *Assinatura) SetCategorias(categorias []ticket.Categoria) error {
+	normalizadas := make([]ticket.Categoria, len(categorias))
+	for i, c := range categorias {
+		c = ticket.Categoria(strings.ToLower(strings.TrimSpace(string(c))))
+		if c == "" {
+			return ticket.ErrCategoriaInvalida
+		}
+		normalizadas[i] = c
+	}
+	a.Categorias = normalizadas
+	return nil
+}
+
+// Atende indica se o evento deve ser entregue a esta assinatura
+func (a *Assinatura) Atende(e Evento) bool {
+	if !a.Ativa {
+		return false
+	}
+	if len(a.Eventos) > 0 && !contem(a.Eventos, e.Tipo) {
+		return false
+	}
+	return len(a.Categorias) == 0 || contem(a.Categorias, e.Ticket.Categoria)
+}
+
+// Assinar calcula a assinatura enviada no cabeçalho X-Nox-Assinatura: o HMAC-SHA256, com o segredo
+// da assinatura, de "<timestamp>.<corpo>", em hexadecimal e com o prefixo "sha256=".
+// O timestamp (segundos Unix) vai no cabeçalho X-Nox-Timestamp para o destino recusar reenvios antigos.
+func Assinar(segredo string, timestamp int64, corpo []byte) string {
+	mac := hmac.New(sha256.New, []byte(segredo))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(corpo)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func contem[T comparable](lista []T, valor T) bool {
+	for _, v := range lista {
+		if v == valor {
+			return true
+		}
+	}
+	return false
+}
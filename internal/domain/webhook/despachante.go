@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+// Despachante assina os eventos de domínio e coloca cada um na fila de entrega das assinaturas interessadas
+type Despachante struct {
+	repo Repository
+}
+
+// NovoDespachante cria o despachante de eventos
+func NovoDespachante(repo Repository) *Despachante {
+	return &Despachante{repo: repo}
+}
+
+// Receber enfileira uma entrega do evento para cada assinatura ativa que o atende.
+// O repositório ignora entregas repetidas do mesmo evento, então receber o evento de novo não duplica envios.
+func (d *Despachante) Receber(_ context.Context, e ticket.Evento) error {
+	assinaturas, err := d.repo.ListarAssinaturas(true)
+	if err != nil {
+		return err
+	}
+
+	evento := NovoEvento(e)
+	agora := time.Now()
+	var payload []byte
+	entregas := []*Entrega{}
+	for _, a := range assinaturas {
+		if !a.Atende(evento) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(evento); err != nil {
+				return err
+			}
+		}
+		entregas = append(entregas, &Entrega{
+			ID:               uuid.New().String(),
+			AssinaturaID:     a.ID,
+			EventoID:         evento.ID,
+			Tipo:             evento.Tipo,
+			TicketID:         evento.Ticket.ID,
+			Payload:          payload,
+			Status:           EntregaPendente,
+			ProximaTentativa: agora,
+			DataCriacao:      agora,
+		})
+	}
+	if len(entregas) == 0 {
+		return nil
+	}
+	return d.repo.Enfileirar(entregas)
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// StatusEntrega é a situação de uma entrega na fila de envio
+type StatusEntrega string
+
+const (
+	EntregaPendente   StatusEntrega = "pendente"   // aguardando a próxima tentativa
+	EntregaConcluida  StatusEntrega = "entregue"   // o destino respondeu 2xx
+	EntregaDescartada StatusEntrega = "descartada" // esgotou as tentativas e foi para a dead-letter
+)
+
+const (
+	// MaxTentativas é o número de tentativas antes de a entrega ir para a dead-letter
+	MaxTentativas = 8
+
+	atrasoInicial = 30 * time.Second
+	atrasoMaximo  = time.Hour
+)
+
+// Entrega é o envio de um evento para uma assinatura
+type Entrega struct {
+	ID               string
+	AssinaturaID     string
+	EventoID         string
+	Tipo             ticket.TipoEvento
+	TicketID         string
+	Payload          []byte
+	Status           StatusEntrega
+	Tentativas       int
+	ProximaTentativa time.Time
+	UltimoErro       string
+	DataCriacao      time.Time
+	DataConclusao    *time.Time
+
+	// Historico traz as tentativas já feitas, quando carregado pelo repositório
+	Historico []Tentativa
+}
+
+// Tentativa é o registro de um envio, para o log de entregas
+type Tentativa struct {
+	Numero     int
+	StatusHTTP int // zero quando não houve resposta
+	Erro       string
+	Duracao    time.Duration
+	Data       time.Time
+}
+
+// Sucesso indica se o destino aceitou a entrega
+func (t Tentativa) Sucesso() bool {
+	return t.Erro == "" && t.StatusHTTP >= 200 && t.StatusHTTP < 300
+}
+
+// Cliente envia o corpo assinado ao endereço da assinatura e retorna o status HTTP da resposta
+type Cliente interface {
+	Enviar(ctx context.Context, url string, cabecalhos map[string]string, corpo []byte) (int, error)
+}
+
+// Atraso é o tempo de espera depois da tentativa de número n: 30s, 1min, 2min... até 1h
+func Atraso(n int) time.Duration {
+	atraso := atrasoInicial
+	for i := 1; i < n && atraso < atrasoMaximo; i++ {
+		atraso *= 2
+	}
+	return min(atraso, atrasoMaximo)
+}
+
+// RegistrarTentativa atualiza a entrega com o resultado de um envio e retorna a tentativa numerada.
+// Em caso de falha a entrega é reagendada com espera exponencial ou, esgotadas as tentativas, descartada.
+func (e *Entrega) RegistrarTentativa(t Tentativa) Tentativa {
+	e.Tentativas++
+	t.Numero = e.Tentativas
+	e.Historico = append(e.Historico, t)
+
+	if t.Sucesso() {
+		e.Status = EntregaConcluida
+		e.UltimoErro = ""
+		e.DataConclusao = &t.Data
+		return t
+	}
+
+	e.UltimoErro = t.Erro
+	if e.UltimoErro == "" {
+		e.UltimoErro = fmt.Sprintf("resposta HTTP %d", t.StatusHTTP)
+	}
+	if e.Tentativas >= MaxTentativas {
+		e.Status = EntregaDescartada
+		e.DataConclusao = &t.Data
+		return t
+	}
+	e.ProximaTentativa = t.Data.Add(Atraso(e.Tentativas))
+	return t
+}
+
+// Descartar registra uma tentativa que não foi feita e manda a entrega direto para a dead-letter,
+// para os casos em que tentar de novo não adianta (ex.: assinatura desativada)
+func (e *Entrega) Descartar(motivo string, agora time.Time) Tentativa {
+	t := e.RegistrarTentativa(Tentativa{Erro: motivo, Data: agora})
+	e.Status = EntregaDescartada
+	e.DataConclusao = &t.Data
+	return t
+}
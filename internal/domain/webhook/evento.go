@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Evento é o corpo JSON enviado aos webhooks, montado a partir do evento de domínio do ticket
+type Evento struct {
+	ID         string            `json:"id"`
+	Tipo       ticket.TipoEvento `json:"tipo"`
+	Data       time.Time         `json:"data"`
+	UsuarioID  string            `json:"usuario_id"`
+	Ticket     TicketEvento      `json:"ticket"`
+	Mudancas   []MudancaEvento   `json:"mudancas,omitempty"`
+	Observacao *ObservacaoEvento `json:"observacao,omitempty"`
+}
+
+// TicketEvento é o estado do ticket depois do evento
+type TicketEvento struct {
+	ID           string              `json:"id"`
+	Titulo       string              `json:"titulo"`
+	Status       ticket.Status       `json:"status"`
+	Categoria    ticket.Categoria    `json:"categoria"`
+	Subcategoria ticket.Subcategoria `json:"subcategoria"`
+	Urgencia     int                 `json:"urgencia"`
+	Gravidade    int                 `json:"gravidade"`
+	AbertoPor    string              `json:"aberto_por"`
+	Responsavel  string              `json:"responsavel,omitempty"`
+	FilaID       string              `json:"fila_id,omitempty"`
+
+	PrazoPrimeiraResposta *time.Time `json:"prazo_primeira_resposta,omitempty"`
+	PrazoResolucao        *time.Time `json:"prazo_resolucao,omitempty"`
+}
+
+// MudancaEvento é uma modificação registrada no ticket
+type MudancaEvento struct {
+	Campo         string    `json:"campo"`
+	ValorAnterior string    `json:"valor_anterior"`
+	ValorNovo     string    `json:"valor_novo"`
+	UsuarioID     string    `json:"usuario_id"`
+	Data          time.Time `json:"data"`
+}
+
+// ObservacaoEvento é a observação adicionada ao ticket
+type ObservacaoEvento struct {
+	ID        string    `json:"id"`
+	UsuarioID string    `json:"usuario_id"`
+	Descricao string    `json:"descricao"`
+	Data      time.Time `json:"data"`
+}
+
+// NovoEvento monta o corpo do webhook a partir do evento de domínio
+func NovoEvento(e ticket.Evento) Evento {
+	evento := Evento{
+		ID:        e.ID,
+		Tipo:      e.Tipo,
+		Data:      e.Data,
+		UsuarioID: e.UsuarioID,
+		Ticket:    TicketEvento(e.Ticket),
+	}
+	for _, m := range e.Mudancas {
+		evento.Mudancas = append(evento.Mudancas, MudancaEvento{
+			Campo:         m.CampoModificado,
+			ValorAnterior: m.ValorAnterior,
+			ValorNovo:     m.ValorNovo,
+			UsuarioID:     m.UsuarioID,
+			Data:          m.DataModificacao,
+		})
+	}
+	if o := e.Observacao; o != nil {
+		evento.Observacao = &ObservacaoEvento{ID: o.ID, UsuarioID: o.UsuarioID, Descricao: o.Descricao, Data: o.DataCriacao}
+	}
+	return evento
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import "time"
+
+// FiltrosEntrega limita a consulta ao log de entregas
+type FiltrosEntrega struct {
+	AssinaturaID string
+	Status       StatusEntrega // vazio traz todas
+	Limite       int
+}
+
+// Repository é a interface para as assinaturas de webhook e a fila de entregas
+type Repository interface {
+	CriarAssinatura(a *Assinatura) error
+	BuscarAssinatura(id string) (*Assinatura, error)
+	ListarAssinaturas(apenasAtivas bool) ([]*Assinatura, error)
+	AtualizarAssinatura(a *Assinatura) error
+
+	// Enfileirar grava novas entregas pendentes
+	Enfileirar(entregas []*Entrega) error
+
+	// Pendentes retorna as entregas pendentes cuja próxima tentativa já venceu, das mais antigas às mais novas
+	Pendentes(agora time.Time, limite int) ([]*Entrega, error)
+
+	// SalvarTentativa grava a tentativa no log e a nova situação da entrega;
+	// entregas descartadas também são copiadas para a dead-letter
+	SalvarTentativa(e *Entrega, t Tentativa) error
+
+	// ListarEntregas retorna as entregas mais recentes com o histórico de tentativas
+	ListarEntregas(filtros FiltrosEntrega) ([]*Entrega, error)
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// repositorioMemoria guarda assinaturas e entregas em memória para os testes do despachante
+type repositorioMemoria struct {
+	assinaturas []*Assinatura
+	entregas    []*Entrega
+}
+
+func (r *repositorioMemoria) CriarAssinatura(a *Assinatura) error {
+	r.assinaturas = append(r.assinaturas, a)
+	return nil
+}
+func (r *repositorioMemoria) BuscarAssinatura(string) (*Assinatura, error) {
+	return nil, ErrAssinaturaNaoEncontrada
+}
+func (r *repositorioMemoria) ListarAssinaturas(bool) ([]*Assinatura, error) {
+	return r.assinaturas, nil
+}
+func (r *repositorioMemoria) AtualizarAssinatura(*Assinatura) error { return nil }
+func (r *repositorioMemoria) Enfileirar(e []*Entrega) error {
+	r.entregas = append(r.entregas, e...)
+	return nil
+}
+func (r *repositorioMemoria) Pendentes(time.Time, int) ([]*Entrega, error) { return r.entregas, nil }
+func (r *repositorioMemoria) SalvarTentativa(*Entrega, Tentativa) error    { return nil }
+func (r *repositorioMemoria) ListarEntregas(FiltrosEntrega) ([]*Entrega, error) {
+	return r.entregas, nil
+}
+
+func novoTicketTeste(t *testing.T) *ticket.Ticket {
+	tk, err := ticket.NovoTicket("Ticket de Teste", "Descrição do ticket de teste", ticket.CategoriaTI, ticket.SubcategoriaBug, "usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao criar ticket de teste: %v", err)
+	}
+	tk.MarcarComoPersistido()
+	return tk
+}
+
+func TestDespachante_FiltraPorEventoECategoria(t *testing.T) {
+	todos, _ := NovaAssinatura("https://exemplo.com/todos", nil, nil, "admin")
+	financeiro, _ := NovaAssinatura("https://exemplo.com/financeiro", nil, []ticket.Categoria{ticket.CategoriaFinanceiro}, "admin")
+	criados, _ := NovaAssinatura("https://exemplo.com/criados", []ticket.TipoEvento{ticket.EventoTicketCriado}, nil, "admin")
+	inativa, _ := NovaAssinatura("https://exemplo.com/inativa", nil, nil, "admin")
+	inativa.Ativa = false
+	repo := &repositorioMemoria{assinaturas: []*Assinatura{todos, financeiro, criados, inativa}}
+
+	tk := novoTicketTeste(t)
+	tk.AdicionarObservacao("Cliente retornou", "usuario_teste")
+	eventos := tk.EventosPendentes()
+	if len(eventos) != 1 {
+		t.Fatalf("Esperava um evento, recebido %+v", eventos)
+	}
+	if err := NovoDespachante(repo).Receber(context.Background(), eventos[0]); err != nil {
+		t.Fatalf("Erro ao receber evento: %v", err)
+	}
+	if len(repo.entregas) != 1 || repo.entregas[0].AssinaturaID != todos.ID {
+		t.Fatalf("Esperava uma entrega para a assinatura geral, recebido %+v", repo.entregas)
+	}
+
+	var evento Evento
+	if err := json.Unmarshal(repo.entregas[0].Payload, &evento); err != nil {
+		t.Fatalf("Payload inválido: %v", err)
+	}
+	if evento.Tipo != ticket.EventoObservacaoAdicionada || evento.Observacao == nil || evento.Ticket.ID != tk.ID {
+		t.Errorf("Payload incorreto: %+v", evento)
+	}
+}
+
+func TestAssinar(t *testing.T) {
+	corpo := []byte(`{"tipo":"ticket.criado"}`)
+	assinatura := Assinar("segredo", 1700000000, corpo)
+	if !hmac.Equal([]byte(assinatura), []byte(Assinar("segredo", 1700000000, corpo))) {
+		t.Error("Esperava a mesma assinatura para o mesmo conteúdo")
+	}
+	if assinatura == Assinar("outro", 1700000000, corpo) || assinatura == Assinar("segredo", 1700000001, corpo) {
+		t.Error("Esperava assinaturas diferentes para segredo ou timestamp diferentes")
+	}
+	if len(assinatura) != len("sha256=")+64 {
+		t.Errorf("Formato inesperado: %s", assinatura)
+	}
+}
+
+func TestEntrega_RetentativasEDeadLetter(t *testing.T) {
+	e := &Entrega{Status: EntregaPendente}
+	agora := time.Now()
+
+	e.RegistrarTentativa(Tentativa{StatusHTTP: 500, Data: agora})
+	if e.Status != EntregaPendente || !e.ProximaTentativa.Equal(agora.Add(30*time.Second)) {
+		t.Errorf("Esperava nova tentativa em 30s, recebido %s em %v", e.Status, e.ProximaTentativa.Sub(agora))
+	}
+	e.RegistrarTentativa(Tentativa{Erro: "conexão recusada", Data: agora})
+	if !e.ProximaTentativa.Equal(agora.Add(time.Minute)) || e.UltimoErro != "conexão recusada" {
+		t.Errorf("Esperava nova tentativa em 1min, recebido %v (%s)", e.ProximaTentativa.Sub(agora), e.UltimoErro)
+	}
+	if Atraso(20) != time.Hour {
+		t.Errorf("Esperava espera máxima de 1h, recebido %v", Atraso(20))
+	}
+
+	for e.Status == EntregaPendente {
+		e.RegistrarTentativa(Tentativa{StatusHTTP: 503, Data: agora})
+	}
+	if e.Status != EntregaDescartada || e.Tentativas != MaxTentativas || len(e.Historico) != MaxTentativas {
+		t.Errorf("Esperava descarte após %d tentativas, recebido %s após %d", MaxTentativas, e.Status, e.Tentativas)
+	}
+
+	ok := &Entrega{Status: EntregaPendente}
+	if ok.RegistrarTentativa(Tentativa{StatusHTTP: 204, Data: agora}); ok.Status != EntregaConcluida || ok.DataConclusao == nil {
+		t.Errorf("Esperava entrega concluída, recebido %s", ok.Status)
+	}
+}
+
+func TestNovaAssinatura_Validacoes(t *testing.T) {
+	if _, err := NovaAssinatura("ftp://exemplo.com", nil, nil, "admin"); !errors.Is(err, ErrURLInvalida) {
+		t.Errorf("Esperava ErrURLInvalida, recebido %v", err)
+	}
+	if _, err := NovaAssinatura("https://exemplo.com", []ticket.TipoEvento{"ticket.apagado"}, nil, "admin"); !errors.Is(err, ErrEventoInvalido) {
+		t.Errorf("Esperava ErrEventoInvalido, recebido %v", err)
+	}
+}
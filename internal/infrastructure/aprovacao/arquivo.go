@@ -0,0 +1,69 @@
+package aprovacao
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"nox_tickets/internal/domain/aprovacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// arquivoAprovacao é o formato do arquivo de configuração das regras de aprovação
+type arquivoAprovacao struct {
+	Regras []arquivoRegra `json:"regras"`
+}
+
+// arquivoRegra descreve a aprovação de uma categoria e subcategoria; "campo_valor" é o campo
+// personalizado com o valor do ticket (por padrão, "valor")
+type arquivoRegra struct {
+	Categoria    string         `json:"categoria"`
+	Subcategoria string         `json:"subcategoria,omitempty"`
+	CampoValor   string         `json:"campo_valor,omitempty"`
+	Faixas       []arquivoFaixa `json:"faixas"`
+}
+
+// arquivoFaixa tem o valor mínimo em reais, como o campo personalizado do ticket
+type arquivoFaixa struct {
+	ValorMinimo float64        `json:"valor_minimo"`
+	Etapas      []arquivoEtapa `json:"etapas"`
+}
+
+type arquivoEtapa struct {
+	Nome       string   `json:"nome"`
+	Papeis     []string `json:"papeis"`
+	Aprovacoes int      `json:"aprovacoes"`
+}
+
+// CarregarArquivo lê a política de aprovação do arquivo JSON
+func CarregarArquivo(caminho string) (*aprovacao.Politica, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de aprovação: %v", err)
+	}
+
+	var arquivo arquivoAprovacao
+	if err := json.Unmarshal(conteudo, &arquivo); err != nil {
+		return nil, fmt.Errorf("erro ao interpretar arquivo de aprovação: %v", err)
+	}
+
+	regras := make([]aprovacao.Regra, 0, len(arquivo.Regras))
+	for _, r := range arquivo.Regras {
+		faixas := make([]aprovacao.Faixa, 0, len(r.Faixas))
+		for _, f := range r.Faixas {
+			etapas := make([]aprovacao.Etapa, 0, len(f.Etapas))
+			for _, e := range f.Etapas {
+				etapas = append(etapas, aprovacao.Etapa{Nome: e.Nome, Papeis: e.Papeis, Aprovacoes: e.Aprovacoes})
+			}
+			faixas = append(faixas, aprovacao.Faixa{ValorMinimo: ticket.Centavos(f.ValorMinimo), Etapas: etapas})
+		}
+		regras = append(regras, aprovacao.Regra{
+			Categoria:    ticket.Categoria(r.Categoria),
+			Subcategoria: ticket.Subcategoria(r.Subcategoria),
+			CampoValor:   r.CampoValor,
+			Faixas:       faixas,
+		})
+	}
+
+	return aprovacao.NovaPolitica(regras)
+}
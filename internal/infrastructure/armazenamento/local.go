@@ -0,0 +1,101 @@
+package armazenamento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"nox_tickets/internal/domain/anexo"
+)
+
+// Local guarda os anexos em um diretório do sistema de arquivos, um arquivo por chave
+type Local struct {
+	raiz string
+}
+
+// NovoLocal cria o armazenamento local, criando o diretório raiz se preciso
+func NovoLocal(raiz string) (*Local, error) {
+	if err := os.MkdirAll(raiz, 0o750); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de anexos: %v", err)
+	}
+	return &Local{raiz: raiz}, nil
+}
+
+// caminho do arquivo da chave; chaves que saiam da raiz são recusadas
+func (l *Local) caminho(chave string) (string, error) {
+	limpa := filepath.Clean("/" + chave)
+	if limpa == "/" || strings.Contains(chave, "..") {
+		return "", fmt.Errorf("chave de anexo inválida: %q", chave)
+	}
+	return filepath.Join(l.raiz, filepath.FromSlash(limpa)), nil
+}
+
+// Salvar grava o conteúdo em um arquivo temporário e o renomeia, para que um envio
+// interrompido não deixe um arquivo pela metade
+func (l *Local) Salvar(ctx context.Context, a *anexo.Anexo, conteudo io.Reader) error {
+	destino, err := l.caminho(a.Chave)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(destino), 0o750); err != nil {
+		return err
+	}
+
+	temporario, err := os.CreateTemp(filepath.Dir(destino), ".envio-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporario.Name())
+
+	if _, err := io.Copy(temporario, leitorComContexto{ctx: ctx, r: conteudo}); err != nil {
+		temporario.Close()
+		return err
+	}
+	if err := temporario.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporario.Name(), destino)
+}
+
+// Abrir abre o arquivo da chave para leitura
+func (l *Local) Abrir(_ context.Context, chave string) (io.ReadCloser, error) {
+	caminho, err := l.caminho(chave)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(caminho)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, anexo.ErrAnexoNaoEncontrado
+	}
+	return f, err
+}
+
+// Remover apaga o arquivo da chave
+func (l *Local) Remover(_ context.Context, chave string) error {
+	caminho, err := l.caminho(chave)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(caminho); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// leitorComContexto interrompe a cópia quando o contexto é cancelado
+type leitorComContexto struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (l leitorComContexto) Read(p []byte) (int, error) {
+	if err := l.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return l.r.Read(p)
+}
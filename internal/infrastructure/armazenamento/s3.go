@@ -0,0 +1,247 @@
+package armazenamento
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/anexo"
+)
+
+// hash SHA-256 do corpo vazio, usado nas requisições sem corpo
+const hashVazio = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// ConfigS3 contém os dados de acesso a um serviço compatível com S3 (AWS, MinIO, Ceph)
+type ConfigS3 struct {
+	Endpoint     string // ex.: "https://s3.sa-east-1.amazonaws.com" ou "http://localhost:9000"
+	Regiao       string // padrão us-east-1, que o MinIO aceita por padrão
+	Bucket       string
+	ChaveAcesso  string
+	ChaveSecreta string
+	Timeout      time.Duration // tempo limite de cada requisição, padrão 30s
+}
+
+// S3 guarda os anexos em um bucket de um serviço compatível com S3. As requisições usam
+// endereçamento por caminho (endpoint/bucket/chave), que funciona em qualquer servidor
+// compatível, e são assinadas com AWS Signature Version 4.
+type S3 struct {
+	config   ConfigS3
+	endpoint *url.URL
+	http     *http.Client
+}
+
+// NovoS3 valida a configuração e cria o armazenamento; o bucket já deve existir
+func NovoS3(config ConfigS3) (*S3, error) {
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, errors.New("endpoint e bucket do S3 são obrigatórios")
+	}
+	if config.ChaveAcesso == "" || config.ChaveSecreta == "" {
+		return nil, errors.New("chaves de acesso do S3 não informadas")
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("endpoint do S3 inválido: %q", config.Endpoint)
+	}
+	if config.Regiao == "" {
+		config.Regiao = "us-east-1"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &S3{config: config, endpoint: endpoint, http: &http.Client{Timeout: config.Timeout}}, nil
+}
+
+// Salvar envia o conteúdo com PUT; o checksum do anexo é enviado como hash do corpo,
+// então o servidor recusa o envio se o conteúdo chegar diferente
+func (s *S3) Salvar(ctx context.Context, a *anexo.Anexo, conteudo io.Reader) error {
+	req, err := s.requisicao(ctx, http.MethodPut, a.Chave, conteudo)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = a.Tamanho
+	req.Header.Set("Content-Type", a.TipoMIME)
+
+	resp, err := s.enviar(req, a.SHA256)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return erroS3(resp)
+	}
+	return nil
+}
+
+// Abrir baixa o conteúdo da chave com GET
+func (s *S3) Abrir(ctx context.Context, chave string) (io.ReadCloser, error) {
+	req, err := s.requisicao(ctx, http.MethodGet, chave, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.enviar(req, hashVazio)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, anexo.ErrAnexoNaoEncontrado
+	default:
+		defer resp.Body.Close()
+		return nil, erroS3(resp)
+	}
+}
+
+// Remover apaga a chave com DELETE; o S3 responde 204 mesmo para chaves inexistentes
+func (s *S3) Remover(ctx context.Context, chave string) error {
+	req, err := s.requisicao(ctx, http.MethodDelete, chave, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.enviar(req, hashVazio)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return erroS3(resp)
+	}
+	return nil
+}
+
+// requisicao monta a requisição para a chave no bucket
+func (s *S3) requisicao(ctx context.Context, metodo, chave string, corpo io.Reader) (*http.Request, error) {
+	if chave == "" || strings.Contains(chave, "..") {
+		return nil, fmt.Errorf("chave de anexo inválida: %q", chave)
+	}
+	caminho := strings.TrimSuffix(s.endpoint.Path, "/") + "/" + s.config.Bucket + "/" + chave
+	u := &url.URL{
+		Scheme:  s.endpoint.Scheme,
+		Host:    s.endpoint.Host,
+		Path:    caminho,
+		RawPath: codificarCaminho(caminho),
+	}
+	return http.NewRequestWithContext(ctx, metodo, u.String(), corpo)
+}
+
+// enviar assina e envia a requisição; sem checksum, o corpo vai sem assinatura de conteúdo
+func (s *S3) enviar(req *http.Request, hashCorpo string) (*http.Response, error) {
+	if hashCorpo == "" {
+		hashCorpo = "UNSIGNED-PAYLOAD"
+	}
+	s.assinar(req, hashCorpo, time.Now())
+	return s.http.Do(req)
+}
+
+// assinar adiciona os cabeçalhos da AWS Signature Version 4. São assinados o host, os
+// cabeçalhos x-amz-* e os de conteúdo e faixa, quando presentes.
+func (s *S3) assinar(req *http.Request, hashCorpo string, agora time.Time) {
+	data := agora.UTC().Format("20060102T150405Z")
+	dia := data[:8]
+	req.Header.Set("X-Amz-Date", data)
+	req.Header.Set("X-Amz-Content-Sha256", hashCorpo)
+
+	// 1. requisição canônica
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	cabecalhos := map[string]string{"host": host}
+	for nome, valores := range req.Header {
+		nome = strings.ToLower(nome)
+		if strings.HasPrefix(nome, "x-amz-") || nome == "content-type" || nome == "content-md5" || nome == "range" {
+			cabecalhos[nome] = strings.TrimSpace(strings.Join(valores, ","))
+		}
+	}
+	nomes := make([]string, 0, len(cabecalhos))
+	for nome := range cabecalhos {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	var canonicos strings.Builder
+	for _, nome := range nomes {
+		canonicos.WriteString(nome + ":" + cabecalhos[nome] + "\n")
+	}
+	assinados := strings.Join(nomes, ";")
+
+	canonica := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		consultaCanonica(req.URL.Query()),
+		canonicos.String(),
+		assinados,
+		hashCorpo,
+	}, "\n")
+
+	// 2. texto a assinar, com o escopo da credencial
+	escopo := dia + "/" + s.config.Regiao + "/s3/aws4_request"
+	somaCanonica := sha256.Sum256([]byte(canonica))
+	texto := "AWS4-HMAC-SHA256\n" + data + "\n" + escopo + "\n" + hex.EncodeToString(somaCanonica[:])
+
+	// 3. chave derivada do segredo, do dia, da região e do serviço
+	chave := hmacSHA256([]byte("AWS4"+s.config.ChaveSecreta), dia)
+	chave = hmacSHA256(chave, s.config.Regiao)
+	chave = hmacSHA256(chave, "s3")
+	chave = hmacSHA256(chave, "aws4_request")
+	assinatura := hex.EncodeToString(hmacSHA256(chave, texto))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.ChaveAcesso, escopo, assinados, assinatura))
+}
+
+func hmacSHA256(chave []byte, texto string) []byte {
+	h := hmac.New(sha256.New, chave)
+	h.Write([]byte(texto))
+	return h.Sum(nil)
+}
+
+// codificarCaminho codifica cada byte fora dos caracteres não reservados, mantendo as barras,
+// como a AWS exige no caminho canônico
+func codificarCaminho(caminho string) string {
+	var b strings.Builder
+	for i := 0; i < len(caminho); i++ {
+		c := caminho[i]
+		if c == '/' || naoReservado(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func consultaCanonica(valores url.Values) string {
+	pares := make([]string, 0, len(valores))
+	for nome, lista := range valores {
+		for _, valor := range lista {
+			pares = append(pares, codificarComponente(nome)+"="+codificarComponente(valor))
+		}
+	}
+	sort.Strings(pares)
+	return strings.Join(pares, "&")
+}
+
+func codificarComponente(s string) string {
+	return strings.ReplaceAll(codificarCaminho(s), "/", "%2F")
+}
+
+func naoReservado(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~'
+}
+
+// erroS3 descreve a resposta de erro do servidor, com o início do XML de erro
+func erroS3(resp *http.Response) error {
+	corpo, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("erro do S3: %s: %s", resp.Status, strings.TrimSpace(string(corpo)))
+}
@@ -0,0 +1,123 @@
+package calendario
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/calendario"
+)
+
+// arquivoCalendario é o formato do arquivo de configuração do calendário
+type arquivoCalendario struct {
+	Fuso       string                      `json:"fuso"`
+	Expediente map[string][2]string        `json:"expediente"`
+	Nacionais  []arquivoFeriado            `json:"feriados_nacionais"`
+	Regionais  map[string][]arquivoFeriado `json:"feriados_regionais"`
+}
+
+// arquivoFeriado aceita "MM-DD" (todo ano), "AAAA-MM-DD" (data única) ou deslocamento da Páscoa
+type arquivoFeriado struct {
+	Nome   string `json:"nome"`
+	Data   string `json:"data,omitempty"`
+	Pascoa *int   `json:"pascoa,omitempty"`
+}
+
+var diasDaSemana = map[string]time.Weekday{
+	"domingo": time.Sunday,
+	"segunda": time.Monday,
+	"terca":   time.Tuesday,
+	"quarta":  time.Wednesday,
+	"quinta":  time.Thursday,
+	"sexta":   time.Friday,
+	"sabado":  time.Saturday,
+}
+
+// CarregarArquivo lê o calendário do arquivo JSON, incluindo os feriados nacionais
+// e os feriados regionais das regiões informadas (ex.: "SP", "SP/sao_paulo")
+func CarregarArquivo(caminho string, regioes ...string) (*calendario.Calendario, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de calendário: %v", err)
+	}
+
+	var arquivo arquivoCalendario
+	if err := json.Unmarshal(conteudo, &arquivo); err != nil {
+		return nil, fmt.Errorf("erro ao interpretar arquivo de calendário: %v", err)
+	}
+
+	// 1. fuso horário
+	fuso := time.UTC
+	if arquivo.Fuso != "" {
+		fuso, err = time.LoadLocation(arquivo.Fuso)
+		if err != nil {
+			return nil, fmt.Errorf("fuso horário inválido %q: %v", arquivo.Fuso, err)
+		}
+	}
+
+	// 2. expediente de cada dia da semana
+	jornadas := make(map[time.Weekday]calendario.Jornada)
+	for nome, horario := range arquivo.Expediente {
+		dia, ok := diasDaSemana[strings.ToLower(nome)]
+		if !ok {
+			return nil, fmt.Errorf("dia da semana inválido: %s", nome)
+		}
+		inicio, err := parseHorario(horario[0])
+		if err != nil {
+			return nil, err
+		}
+		fim, err := parseHorario(horario[1])
+		if err != nil {
+			return nil, err
+		}
+		jornadas[dia] = calendario.Jornada{Inicio: inicio, Fim: fim}
+	}
+
+	// 3. feriados nacionais e das regiões configuradas
+	feriados := []calendario.Feriado{}
+	for _, f := range arquivo.Nacionais {
+		feriado, err := converterFeriado(f, "")
+		if err != nil {
+			return nil, err
+		}
+		feriados = append(feriados, feriado)
+	}
+	for _, regiao := range regioes {
+		for _, f := range arquivo.Regionais[regiao] {
+			feriado, err := converterFeriado(f, regiao)
+			if err != nil {
+				return nil, err
+			}
+			feriados = append(feriados, feriado)
+		}
+	}
+
+	return calendario.NovoCalendario(fuso, jornadas, feriados)
+}
+
+func parseHorario(horario string) (time.Duration, error) {
+	var horas, minutos int
+	if _, err := fmt.Sscanf(horario, "%d:%d", &horas, &minutos); err != nil {
+		return 0, fmt.Errorf("horário inválido %q: %v", horario, err)
+	}
+	return time.Duration(horas)*time.Hour + time.Duration(minutos)*time.Minute, nil
+}
+
+func converterFeriado(f arquivoFeriado, regiao string) (calendario.Feriado, error) {
+	feriado := calendario.Feriado{Nome: f.Nome, Regiao: regiao, Pascoa: f.Pascoa}
+	if f.Pascoa != nil {
+		return feriado, nil
+	}
+
+	if data, err := time.Parse("2006-01-02", f.Data); err == nil {
+		feriado.Ano, feriado.Mes, feriado.Dia = data.Date()
+		return feriado, nil
+	}
+	if data, err := time.Parse("01-02", f.Data); err == nil {
+		feriado.Mes, feriado.Dia = data.Month(), data.Day()
+		return feriado, nil
+	}
+	return feriado, fmt.Errorf("data inválida para o feriado %q: %s", f.Nome, f.Data)
+}
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tickets_sla_violado;
+DROP INDEX IF EXISTS idx_tickets_sla_prazo_primeira_resposta;
+DROP INDEX IF EXISTS idx_tickets_sla_prazo_resolucao;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS sla_violado;
+ALTER TABLE tickets DROP COLUMN IF EXISTS data_primeira_resposta;
+ALTER TABLE tickets DROP COLUMN IF EXISTS sla_prazo_resolucao;
+ALTER TABLE tickets DROP COLUMN IF EXISTS sla_prazo_primeira_resposta;
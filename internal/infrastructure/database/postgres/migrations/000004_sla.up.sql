@@ -0,0 +1,10 @@
+-- Prazos de SLA calculados para cada ticket
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_prazo_primeira_resposta TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_prazo_resolucao TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS data_primeira_resposta TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_violado BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Índices para encontrar rapidamente os tickets com prazo estourado
+CREATE INDEX IF NOT EXISTS idx_tickets_sla_prazo_resolucao ON tickets (sla_prazo_resolucao) WHERE data_conclusao IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tickets_sla_prazo_primeira_resposta ON tickets (sla_prazo_primeira_resposta) WHERE data_primeira_resposta IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tickets_sla_violado ON tickets (sla_violado) WHERE sla_violado;
@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP COLUMN IF EXISTS duracao_execucao_corrida;
+ALTER TABLE tickets DROP COLUMN IF EXISTS duracao_total_corrida;
@@ -0,0 +1,10 @@
+-- duracao_total e duracao_execucao passam a ser medidas em tempo útil (expediente, sem feriados);
+-- as colunas abaixo guardam as mesmas durações em tempo corrido
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS duracao_total_corrida INTERVAL NOT NULL DEFAULT '0';
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS duracao_execucao_corrida INTERVAL NOT NULL DEFAULT '0';
+
+-- os tickets já concluídos foram medidos em tempo corrido
+UPDATE tickets
+SET duracao_total_corrida = duracao_total,
+    duracao_execucao_corrida = duracao_execucao
+WHERE data_conclusao IS NOT NULL;
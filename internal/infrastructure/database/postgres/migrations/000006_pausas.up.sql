@@ -0,0 +1,16 @@
+-- Intervalos em que o atendimento ficou parado aguardando o cliente, um terceiro ou pausado
+CREATE TABLE IF NOT EXISTS pausas (
+    id UUID PRIMARY KEY,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    motivo TEXT NOT NULL,
+    usuario_id VARCHAR(255) NOT NULL,
+    inicio TIMESTAMP WITH TIME ZONE NOT NULL,
+    fim TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_pausa_periodo CHECK (fim IS NULL OR fim >= inicio)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pausas_ticket_id ON pausas (ticket_id, inicio);
+
+-- no máximo uma pausa em aberto por ticket
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pausas_aberta ON pausas (ticket_id) WHERE fim IS NULL;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_tickets_busca;
+
+DROP TRIGGER IF EXISTS trg_observacoes_busca ON observacoes;
+DROP FUNCTION IF EXISTS observacoes_busca_trigger();
+
+DROP TRIGGER IF EXISTS trg_tickets_busca ON tickets;
+DROP FUNCTION IF EXISTS tickets_busca_trigger();
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS busca;
+
+DROP FUNCTION IF EXISTS tickets_documento_busca(UUID, TEXT, TEXT);
+DROP TEXT SEARCH CONFIGURATION IF EXISTS portuguese_unaccent;
@@ -0,0 +1,67 @@
+-- Busca textual em português, sem diferenciar acentos
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'portuguese_unaccent') THEN
+        CREATE TEXT SEARCH CONFIGURATION portuguese_unaccent (COPY = portuguese);
+        ALTER TEXT SEARCH CONFIGURATION portuguese_unaccent
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+    END IF;
+END
+$$;
+
+-- Documento de busca: título (peso A), descrição (peso B) e observações (peso C)
+CREATE OR REPLACE FUNCTION tickets_documento_busca(p_id UUID, p_titulo TEXT, p_descricao TEXT)
+RETURNS tsvector AS $$
+    SELECT
+        setweight(to_tsvector('portuguese_unaccent', COALESCE(p_titulo, '')), 'A') ||
+        setweight(to_tsvector('portuguese_unaccent', COALESCE(p_descricao, '')), 'B') ||
+        setweight(to_tsvector('portuguese_unaccent', COALESCE(
+            (SELECT string_agg(o.descricao, ' ') FROM observacoes o WHERE o.ticket_id = p_id), ''
+        )), 'C')
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS busca tsvector;
+
+-- Mantém o documento atualizado quando o título ou a descrição mudam
+CREATE OR REPLACE FUNCTION tickets_busca_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.busca := tickets_documento_busca(NEW.id, NEW.titulo, NEW.descricao);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_tickets_busca ON tickets;
+CREATE TRIGGER trg_tickets_busca
+    BEFORE INSERT OR UPDATE OF titulo, descricao ON tickets
+    FOR EACH ROW EXECUTE FUNCTION tickets_busca_trigger();
+
+-- Mantém o documento atualizado quando as observações mudam
+CREATE OR REPLACE FUNCTION observacoes_busca_trigger() RETURNS trigger AS $$
+DECLARE
+    v_ticket_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_ticket_id := OLD.ticket_id;
+    ELSE
+        v_ticket_id := NEW.ticket_id;
+    END IF;
+
+    UPDATE tickets
+    SET busca = tickets_documento_busca(id, titulo, descricao)
+    WHERE id = v_ticket_id;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_observacoes_busca ON observacoes;
+CREATE TRIGGER trg_observacoes_busca
+    AFTER INSERT OR UPDATE OF descricao OR DELETE ON observacoes
+    FOR EACH ROW EXECUTE FUNCTION observacoes_busca_trigger();
+
+-- Preenche o documento dos tickets existentes
+UPDATE tickets SET busca = tickets_documento_busca(id, titulo, descricao);
+
+CREATE INDEX IF NOT EXISTS idx_tickets_busca ON tickets USING GIN (busca);
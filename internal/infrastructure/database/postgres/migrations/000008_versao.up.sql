@@ -0,0 +1,2 @@
+-- Versão do ticket para controle de concorrência otimista
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS versao INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_tickets_responsavel;
+
+ALTER TABLE tickets
+    DROP CONSTRAINT IF EXISTS fk_tickets_responsavel,
+    DROP CONSTRAINT IF EXISTS fk_tickets_aberto_por;
+
+UPDATE tickets SET responsavel = '' WHERE responsavel IS NULL;
+
+DROP TABLE IF EXISTS usuarios_equipes;
+DROP TABLE IF EXISTS usuarios;
+DROP TABLE IF EXISTS equipes;
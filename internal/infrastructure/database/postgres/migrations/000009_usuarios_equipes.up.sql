@@ -0,0 +1,54 @@
+-- Diretório de usuários e equipes; tickets passam a referenciar usuários pelo ID
+CREATE TABLE IF NOT EXISTS equipes (
+    id UUID PRIMARY KEY,
+    nome VARCHAR(255) NOT NULL,
+    descricao TEXT NOT NULL DEFAULT '',
+    ativa BOOLEAN NOT NULL DEFAULT TRUE,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_equipes_nome ON equipes (LOWER(nome));
+
+-- o id é o mesmo da claim sub do token de acesso
+CREATE TABLE IF NOT EXISTS usuarios (
+    id VARCHAR(255) PRIMARY KEY,
+    nome VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    ativo BOOLEAN NOT NULL DEFAULT TRUE,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_usuarios_email ON usuarios (email) WHERE email IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS usuarios_equipes (
+    usuario_id VARCHAR(255) NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    equipe_id UUID NOT NULL REFERENCES equipes(id) ON DELETE CASCADE,
+    PRIMARY KEY (usuario_id, equipe_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_usuarios_equipes_equipe ON usuarios_equipes (equipe_id);
+
+-- usuário das ações automáticas
+INSERT INTO usuarios (id, nome) VALUES ('sistema', 'Sistema') ON CONFLICT (id) DO NOTHING;
+
+-- ticket sem responsável passa a ter responsavel nulo, em vez de texto vazio
+UPDATE tickets SET responsavel = NULL WHERE TRIM(responsavel) = '';
+
+-- cada texto já usado como autor ou responsável vira um usuário com esse id
+INSERT INTO usuarios (id, nome)
+SELECT DISTINCT existentes.id, existentes.id
+FROM (
+    SELECT aberto_por AS id FROM tickets
+    UNION SELECT responsavel FROM tickets
+    UNION SELECT usuario_id FROM observacoes
+    UNION SELECT usuario_id FROM modificacoes
+    UNION SELECT usuario_id FROM pausas
+) existentes
+WHERE existentes.id IS NOT NULL AND TRIM(existentes.id) <> ''
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE tickets
+    ADD CONSTRAINT fk_tickets_aberto_por FOREIGN KEY (aberto_por) REFERENCES usuarios(id),
+    ADD CONSTRAINT fk_tickets_responsavel FOREIGN KEY (responsavel) REFERENCES usuarios(id);
+
+CREATE INDEX IF NOT EXISTS idx_tickets_responsavel ON tickets (responsavel);
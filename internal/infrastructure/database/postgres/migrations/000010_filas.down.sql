@@ -0,0 +1,6 @@
+ALTER TABLE usuarios DROP COLUMN IF EXISTS habilidades;
+
+DROP INDEX IF EXISTS idx_tickets_fila;
+ALTER TABLE tickets DROP COLUMN IF EXISTS fila_id;
+
+DROP TABLE IF EXISTS filas;
@@ -0,0 +1,22 @@
+-- Filas de atendimento por categoria e subcategoria, atendidas por uma equipe
+CREATE TABLE IF NOT EXISTS filas (
+    id UUID PRIMARY KEY,
+    nome VARCHAR(255) NOT NULL,
+    categoria VARCHAR(50) NOT NULL DEFAULT '',     -- vazio atende qualquer categoria
+    subcategoria VARCHAR(50) NOT NULL DEFAULT '',  -- vazio atende qualquer subcategoria
+    equipe_id UUID NOT NULL REFERENCES equipes(id),
+    estrategia VARCHAR(50) NOT NULL,
+    atribuicao_automatica BOOLEAN NOT NULL DEFAULT FALSE,
+    ativa BOOLEAN NOT NULL DEFAULT TRUE,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_fila_estrategia CHECK (estrategia IN ('round_robin', 'menos_tickets', 'habilidade'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_filas_nome ON filas (LOWER(nome));
+
+-- fila em que cada ticket foi roteado
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS fila_id UUID REFERENCES filas(id);
+CREATE INDEX IF NOT EXISTS idx_tickets_fila ON tickets (fila_id, responsavel);
+
+-- categorias e subcategorias que cada usuário sabe atender, para a estratégia por habilidade
+ALTER TABLE usuarios ADD COLUMN IF NOT EXISTS habilidades TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS webhook_dead_letter;
+DROP TABLE IF EXISTS webhook_tentativas;
+DROP TABLE IF EXISTS webhook_entregas;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,54 @@
+-- Assinaturas de webhook: eventos e categorias vazios recebem tudo
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    segredo VARCHAR(128) NOT NULL,
+    eventos TEXT[] NOT NULL DEFAULT '{}',
+    categorias TEXT[] NOT NULL DEFAULT '{}',
+    ativo BOOLEAN NOT NULL DEFAULT TRUE,
+    criado_por VARCHAR(255) NOT NULL REFERENCES usuarios(id),
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Fila de entregas: cada evento gera uma entrega por assinatura interessada
+CREATE TABLE IF NOT EXISTS webhook_entregas (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    evento_id UUID NOT NULL,
+    tipo VARCHAR(50) NOT NULL,
+    ticket_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pendente',
+    tentativas INTEGER NOT NULL DEFAULT 0,
+    proxima_tentativa TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ultimo_erro TEXT NOT NULL DEFAULT '',
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    data_conclusao TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_webhook_entrega_status CHECK (status IN ('pendente', 'entregue', 'descartada'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_entregas_pendentes ON webhook_entregas (proxima_tentativa) WHERE status = 'pendente';
+CREATE INDEX IF NOT EXISTS idx_webhook_entregas_webhook ON webhook_entregas (webhook_id, data_criacao DESC);
+
+-- Log de entregas: uma linha por tentativa de envio
+CREATE TABLE IF NOT EXISTS webhook_tentativas (
+    id BIGSERIAL PRIMARY KEY,
+    entrega_id UUID NOT NULL REFERENCES webhook_entregas(id) ON DELETE CASCADE,
+    numero INTEGER NOT NULL,
+    status_http INTEGER NOT NULL DEFAULT 0,
+    erro TEXT NOT NULL DEFAULT '',
+    duracao_ms INTEGER NOT NULL DEFAULT 0,
+    data TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (entrega_id, numero)
+);
+
+-- Dead-letter: entregas que esgotaram as tentativas, guardadas para análise e reenvio manual
+CREATE TABLE IF NOT EXISTS webhook_dead_letter (
+    entrega_id UUID PRIMARY KEY REFERENCES webhook_entregas(id) ON DELETE CASCADE,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    tipo VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    tentativas INTEGER NOT NULL,
+    ultimo_erro TEXT NOT NULL,
+    data_descarte TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_webhook_entregas_evento;
+
+DROP TABLE IF EXISTS outbox;
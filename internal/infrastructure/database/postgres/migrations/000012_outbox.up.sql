@@ -0,0 +1,19 @@
+-- Outbox: eventos de domínio gravados na mesma transação da alteração do ticket e publicados pelo relay
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    sequencia BIGSERIAL NOT NULL UNIQUE,  -- ordem de gravação, que o relay respeita por ticket
+    agregado_id UUID NOT NULL,            -- ticket que gerou o evento
+    tipo VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    data_publicacao TIMESTAMP WITH TIME ZONE,
+    tentativas INTEGER NOT NULL DEFAULT 0,
+    proxima_tentativa TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ultimo_erro TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pendentes ON outbox (sequencia) WHERE data_publicacao IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_agregado ON outbox (agregado_id, sequencia) WHERE data_publicacao IS NULL;
+
+-- o relay entrega pelo menos uma vez: o mesmo evento não gera duas entregas para o mesmo webhook
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_entregas_evento ON webhook_entregas (webhook_id, evento_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notificacoes_log;
+DROP TABLE IF EXISTS ticket_seguidores;
+DROP TABLE IF EXISTS preferencias_notificacao;
@@ -0,0 +1,32 @@
+-- Preferências de notificação: sem linha para o usuário, todas as notificações ficam ativas
+CREATE TABLE IF NOT EXISTS preferencias_notificacao (
+    usuario_id VARCHAR(255) PRIMARY KEY REFERENCES usuarios(id) ON DELETE CASCADE,
+    desativadas TEXT[] NOT NULL DEFAULT '{}',
+    data_atualizacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Usuários que acompanham um ticket sem ser o solicitante ou o responsável
+CREATE TABLE IF NOT EXISTS ticket_seguidores (
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    usuario_id VARCHAR(255) NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (ticket_id, usuario_id)
+);
+
+-- Log de todos os e-mails enviados, com sucesso ou falha
+CREATE TABLE IF NOT EXISTS notificacoes_log (
+    id UUID PRIMARY KEY,
+    evento_id UUID NOT NULL,
+    ticket_id UUID NOT NULL,
+    usuario_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    tipo VARCHAR(50) NOT NULL,
+    assunto TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('enviada', 'falhou')),
+    erro TEXT NOT NULL DEFAULT '',
+    data_envio TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notificacoes_log_evento ON notificacoes_log (evento_id, usuario_id);
+CREATE INDEX IF NOT EXISTS idx_notificacoes_log_usuario ON notificacoes_log (usuario_id, data_envio DESC);
+CREATE INDEX IF NOT EXISTS idx_notificacoes_log_ticket ON notificacoes_log (ticket_id, data_envio DESC);
@@ -0,0 +1,16 @@
+-- E-mails recebidos já processados: evitam processar a mesma mensagem duas vezes e
+-- ligam as respostas ao ticket pelo In-Reply-To e References. O Message-ID é reservado
+-- (em_processamento) antes de o ticket ou a observação serem criados
+CREATE TABLE IF NOT EXISTS emails_recebidos (
+    message_id TEXT PRIMARY KEY,
+    remetente VARCHAR(255) NOT NULL,
+    assunto TEXT NOT NULL DEFAULT '',
+    resultado VARCHAR(30) NOT NULL CHECK (resultado IN ('em_processamento', 'ticket_criado', 'observacao_adicionada', 'rejeitado')),
+    ticket_id UUID REFERENCES tickets(id) ON DELETE SET NULL,
+    observacao_id UUID,
+    motivo TEXT NOT NULL DEFAULT '',
+    anexos INTEGER NOT NULL DEFAULT 0,
+    data_recebimento TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_emails_recebidos_ticket ON emails_recebidos (ticket_id) WHERE ticket_id IS NOT NULL;
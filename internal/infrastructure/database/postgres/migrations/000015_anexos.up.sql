@@ -0,0 +1,16 @@
+-- Metadados dos anexos dos tickets; o conteúdo fica no armazenamento configurado.
+-- O sha256 é o checksum do conteúdo, em hexadecimal, devolvido no download
+CREATE TABLE IF NOT EXISTS anexos (
+    id UUID PRIMARY KEY,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    observacao_id UUID,
+    nome VARCHAR(255) NOT NULL,
+    tipo_mime VARCHAR(255) NOT NULL,
+    tamanho BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    chave TEXT NOT NULL,
+    enviado_por VARCHAR(255) NOT NULL,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_anexos_ticket ON anexos (ticket_id, data_criacao);
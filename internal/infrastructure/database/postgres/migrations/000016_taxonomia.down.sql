@@ -0,0 +1,19 @@
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS fk_tickets_categoria;
+ALTER TABLE tickets ADD CONSTRAINT check_categoria_valid CHECK (
+    categoria IN (
+        'financeiro',
+        'comercial',
+        'compliance',
+        'contratos',
+        'gestores',
+        'meds',
+        'onboarding',
+        'operacional',
+        'reclamacoes',
+        'ti',
+        'trading'
+    )
+);
+
+DROP TABLE IF EXISTS subcategorias;
+DROP TABLE IF EXISTS categorias;
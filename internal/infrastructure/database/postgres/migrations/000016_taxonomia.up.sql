@@ -0,0 +1,80 @@
+-- Taxonomia de categorias e subcategorias, mantida pelos administradores em tempo de execução
+CREATE TABLE IF NOT EXISTS categorias (
+    codigo VARCHAR(50) PRIMARY KEY,
+    nome VARCHAR(255) NOT NULL,
+    ativa BOOLEAN NOT NULL DEFAULT TRUE,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_categoria_codigo CHECK (codigo ~ '^[a-z0-9_]{1,50}$')
+);
+
+CREATE TABLE IF NOT EXISTS subcategorias (
+    categoria VARCHAR(50) NOT NULL REFERENCES categorias(codigo),
+    codigo VARCHAR(50) NOT NULL,
+    nome VARCHAR(255) NOT NULL,
+    ativa BOOLEAN NOT NULL DEFAULT TRUE,
+    data_criacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (categoria, codigo),
+    CONSTRAINT check_subcategoria_codigo CHECK (codigo ~ '^[a-z0-9_]{1,50}$')
+);
+
+INSERT INTO categorias (codigo, nome) VALUES
+    ('financeiro', 'Financeiro'),
+    ('comercial', 'Comercial'),
+    ('compliance', 'Compliance'),
+    ('contratos', 'Contratos'),
+    ('gestores', 'Gestores'),
+    ('meds', 'MEDs'),
+    ('onboarding', 'Onboarding'),
+    ('operacional', 'Operacional'),
+    ('reclamacoes', 'Reclamações'),
+    ('ti', 'TI'),
+    ('trading', 'Trading')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO subcategorias (categoria, codigo, nome) VALUES
+    ('financeiro', 'solicitacao_de_saque', 'Solicitação de saque'),
+    ('financeiro', 'verificacao_de_transacao', 'Verificação de transação'),
+    ('financeiro', 'solicitacoes', 'Solicitações'),
+    ('financeiro', 'duvidas', 'Dúvidas'),
+    ('financeiro', 'outros', 'Outros'),
+    ('comercial', 'solicitacoes', 'Solicitações'),
+    ('comercial', 'duvidas', 'Dúvidas'),
+    ('comercial', 'outros', 'Outros'),
+    ('compliance', 'fraude', 'Fraude'),
+    ('compliance', 'kyc', 'KYC'),
+    ('compliance', 'uncompliant', 'Uncompliant'),
+    ('compliance', 'verificacao_de_transacao', 'Verificação de transação'),
+    ('compliance', 'outros', 'Outros'),
+    ('contratos', 'solicitacoes', 'Solicitações'),
+    ('contratos', 'duvidas', 'Dúvidas'),
+    ('contratos', 'outros', 'Outros'),
+    ('gestores', 'solicitacoes', 'Solicitações'),
+    ('gestores', 'duvidas', 'Dúvidas'),
+    ('gestores', 'outros', 'Outros'),
+    ('meds', 'solicitacao_enviada', 'Solicitação enviada'),
+    ('meds', 'verificacao_de_transacao', 'Verificação de transação'),
+    ('meds', 'fraude', 'Fraude'),
+    ('meds', 'outros', 'Outros'),
+    ('onboarding', 'cadastro_documentacao', 'Cadastro e documentação'),
+    ('onboarding', 'kyc', 'KYC'),
+    ('onboarding', 'duvidas', 'Dúvidas'),
+    ('onboarding', 'outros', 'Outros'),
+    ('operacional', 'solicitacoes', 'Solicitações'),
+    ('operacional', 'duvidas', 'Dúvidas'),
+    ('operacional', 'outros', 'Outros'),
+    ('reclamacoes', 'solicitacoes', 'Solicitações'),
+    ('reclamacoes', 'outros', 'Outros'),
+    ('ti', 'bug', 'Bug'),
+    ('ti', 'feature', 'Nova funcionalidade'),
+    ('ti', 'melhoria', 'Melhoria'),
+    ('ti', 'duvidas', 'Dúvidas'),
+    ('ti', 'outros', 'Outros'),
+    ('trading', 'solicitacoes', 'Solicitações'),
+    ('trading', 'duvidas', 'Dúvidas'),
+    ('trading', 'outros', 'Outros')
+ON CONFLICT (categoria, codigo) DO NOTHING;
+
+-- a lista fixa de categorias dá lugar à tabela; tickets antigos com subcategorias fora da
+-- taxonomia continuam válidos, a taxonomia é conferida nas gravações pela aplicação
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS check_categoria_valid;
+ALTER TABLE tickets ADD CONSTRAINT fk_tickets_categoria FOREIGN KEY (categoria) REFERENCES categorias(codigo);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS formularios;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS campos;
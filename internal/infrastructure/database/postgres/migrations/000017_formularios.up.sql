@@ -0,0 +1,47 @@
+-- Campos personalizados dos tickets, definidos pelo formulário da categoria/subcategoria
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS campos JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Formulários por categoria e subcategoria (vazia nas categorias sem subcategorias), em um subconjunto do JSON Schema
+CREATE TABLE IF NOT EXISTS formularios (
+    categoria VARCHAR(50) NOT NULL REFERENCES categorias(codigo),
+    subcategoria VARCHAR(50) NOT NULL DEFAULT '',
+    esquema JSONB NOT NULL,
+    data_atualizacao TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (categoria, subcategoria)
+);
+
+INSERT INTO formularios (categoria, subcategoria, esquema) VALUES
+    ('financeiro', 'solicitacao_de_saque', '{
+        "type": "object",
+        "title": "Solicitação de saque",
+        "required": ["cpf", "nox_id"],
+        "properties": {
+            "cpf": {"type": "string", "title": "CPF do titular", "format": "cpf"},
+            "nox_id": {"type": "string", "title": "NOX ID", "minLength": 1},
+            "valor": {"type": "number", "title": "Valor do saque (R$)", "minimum": 0.01}
+        },
+        "x-ordem": ["cpf", "nox_id", "valor"]
+    }'),
+    ('compliance', 'fraude', '{
+        "type": "object",
+        "title": "Fraude",
+        "required": ["ids_transacao", "valor"],
+        "properties": {
+            "ids_transacao": {"type": "array", "title": "IDs das transações", "items": {"type": "string", "minLength": 1}, "minItems": 1},
+            "valor": {"type": "number", "title": "Valor total envolvido (R$)", "minimum": 0.01},
+            "nox_id": {"type": "string", "title": "NOX ID"}
+        },
+        "x-ordem": ["ids_transacao", "valor", "nox_id"]
+    }'),
+    ('meds', 'fraude', '{
+        "type": "object",
+        "title": "Fraude",
+        "required": ["ids_transacao", "valor"],
+        "properties": {
+            "ids_transacao": {"type": "array", "title": "IDs das transações", "items": {"type": "string", "minLength": 1}, "minItems": 1},
+            "valor": {"type": "number", "title": "Valor total envolvido (R$)", "minimum": 0.01},
+            "nox_id": {"type": "string", "title": "NOX ID"}
+        },
+        "x-ordem": ["ids_transacao", "valor", "nox_id"]
+    }')
+ON CONFLICT (categoria, subcategoria) DO NOTHING;
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_med_historico_somente_insercao ON med_historico;
+DROP FUNCTION IF EXISTS med_historico_somente_insercao();
+
+DROP TABLE IF EXISTS med_historico;
+DROP TABLE IF EXISTS med_casos;
@@ -0,0 +1,54 @@
+-- Casos do Mecanismo Especial de Devolução (MED) do Pix, um por ticket da categoria meds.
+-- Os tickets com caso MED não podem ser apagados, para o histórico ficar disponível ao regulador
+CREATE TABLE IF NOT EXISTS med_casos (
+    ticket_id UUID PRIMARY KEY REFERENCES tickets(id) ON DELETE RESTRICT,
+    end_to_end_id VARCHAR(32) NOT NULL,
+    id_notificacao UUID NOT NULL UNIQUE,
+    valor BIGINT NOT NULL CHECK (valor > 0),
+    pagador JSONB NOT NULL,
+    recebedor JSONB NOT NULL,
+    data_transacao TIMESTAMP WITH TIME ZONE NOT NULL,
+    data_notificacao TIMESTAMP WITH TIME ZONE NOT NULL,
+    situacao VARCHAR(20) NOT NULL CHECK (situacao IN ('em_analise', 'fundos_bloqueados', 'devolvido', 'rejeitado')),
+    valor_bloqueado BIGINT NOT NULL DEFAULT 0,
+    valor_devolvido BIGINT NOT NULL DEFAULT 0,
+    prazo_analise TIMESTAMP WITH TIME ZONE NOT NULL,
+    prazo_devolucao TIMESTAMP WITH TIME ZONE NOT NULL,
+    data_bloqueio TIMESTAMP WITH TIME ZONE,
+    data_conclusao TIMESTAMP WITH TIME ZONE,
+    versao INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_med_casos_end_to_end ON med_casos (end_to_end_id);
+CREATE INDEX IF NOT EXISTS idx_med_casos_situacao ON med_casos (situacao);
+
+-- Histórico dos casos: cada registro guarda os dados após a ação e o hash do anterior. Os dados
+-- ficam em TEXT, e não em JSONB, para serem relidos exatamente como entraram no hash
+CREATE TABLE IF NOT EXISTS med_historico (
+    ticket_id UUID NOT NULL REFERENCES med_casos(ticket_id) ON DELETE RESTRICT,
+    sequencia INTEGER NOT NULL,
+    acao VARCHAR(20) NOT NULL,
+    situacao_anterior VARCHAR(20) NOT NULL DEFAULT '',
+    situacao_nova VARCHAR(20) NOT NULL,
+    valor BIGINT NOT NULL DEFAULT 0,
+    justificativa TEXT NOT NULL DEFAULT '',
+    dados TEXT NOT NULL,
+    fora_do_prazo BOOLEAN NOT NULL DEFAULT FALSE,
+    usuario_id VARCHAR(255) NOT NULL,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    hash_anterior VARCHAR(64) NOT NULL DEFAULT '',
+    hash VARCHAR(64) NOT NULL,
+    PRIMARY KEY (ticket_id, sequencia)
+);
+
+-- O histórico só recebe inserções
+CREATE OR REPLACE FUNCTION med_historico_somente_insercao() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'o histórico dos casos MED não pode ser alterado nem apagado';
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_med_historico_somente_insercao ON med_historico;
+CREATE TRIGGER trg_med_historico_somente_insercao
+    BEFORE UPDATE OR DELETE ON med_historico
+    FOR EACH ROW EXECUTE FUNCTION med_historico_somente_insercao();
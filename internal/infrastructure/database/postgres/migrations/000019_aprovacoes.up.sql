@@ -0,0 +1,19 @@
+-- Decisões dos aprovadores nas etapas de aprovação dos tickets (ex.: solicitações de saque).
+-- O valor é o do ticket no momento da decisão, em centavos como nos casos MED: se o valor mudar, as decisões
+-- anteriores deixam de contar. Por ser inteiro, a comparação não depende de arredondamento
+CREATE TABLE IF NOT EXISTS aprovacoes (
+    id UUID PRIMARY KEY,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    etapa VARCHAR(100) NOT NULL,
+    decisao VARCHAR(20) NOT NULL,
+    comentario TEXT NOT NULL DEFAULT '',
+    valor BIGINT NOT NULL,
+    usuario_id VARCHAR(255) NOT NULL,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT check_aprovacao_decisao CHECK (decisao IN ('aprovada', 'rejeitada'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_aprovacoes_ticket_id ON aprovacoes (ticket_id, data);
+
+-- cada aprovador decide uma única vez sobre o mesmo valor do ticket
+CREATE UNIQUE INDEX IF NOT EXISTS idx_aprovacoes_aprovador ON aprovacoes (ticket_id, usuario_id, valor);
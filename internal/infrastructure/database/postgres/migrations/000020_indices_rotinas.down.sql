@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_observacoes_ticket_data;
+DROP INDEX IF EXISTS idx_modificacoes_ticket_campo;
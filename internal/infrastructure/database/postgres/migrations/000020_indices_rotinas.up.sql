@@ -0,0 +1,4 @@
+-- Índices das consultas das rotinas de escalonamento e de alertas de SLA, que procuram por ticket a última
+-- mudança de status, a última observação, as regras já aplicadas e os prazos já alertados
+CREATE INDEX IF NOT EXISTS idx_modificacoes_ticket_campo ON modificacoes (ticket_id, campo_modificado, data_modificacao);
+CREATE INDEX IF NOT EXISTS idx_observacoes_ticket_data ON observacoes (ticket_id, data_criacao);
@@ -0,0 +1,89 @@
+package email
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"nox_tickets/internal/domain/email"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// arquivoCaixas é o formato do arquivo de configuração das caixas de entrada
+type arquivoCaixas struct {
+	Caixas []arquivoCaixa `json:"caixas"`
+	Padrao *arquivoCaixa  `json:"padrao,omitempty"`
+}
+
+// arquivoCaixa descreve uma caixa; urgência e gravidade são 3 quando omitidas
+type arquivoCaixa struct {
+	Endereco     string `json:"endereco"`
+	Categoria    string `json:"categoria"`
+	Subcategoria string `json:"subcategoria,omitempty"`
+	Urgencia     int    `json:"urgencia,omitempty"`
+	Gravidade    int    `json:"gravidade,omitempty"`
+}
+
+// CarregarArquivo lê as caixas de entrada do arquivo JSON
+func CarregarArquivo(caminho string) (*email.Roteamento, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de caixas de e-mail: %v", err)
+	}
+
+	var arquivo arquivoCaixas
+	if err := json.Unmarshal(conteudo, &arquivo); err != nil {
+		return nil, fmt.Errorf("erro ao interpretar arquivo de caixas de e-mail: %v", err)
+	}
+
+	roteamento := &email.Roteamento{Caixas: make([]email.Caixa, 0, len(arquivo.Caixas))}
+	enderecos := make(map[string]bool)
+	for _, c := range arquivo.Caixas {
+		caixa, err := lerCaixa(c)
+		if err != nil {
+			return nil, err
+		}
+		if caixa.Endereco == "" {
+			return nil, fmt.Errorf("caixa da categoria %s sem endereço", caixa.Categoria)
+		}
+		if enderecos[caixa.Endereco] {
+			return nil, fmt.Errorf("caixa %s duplicada", caixa.Endereco)
+		}
+		enderecos[caixa.Endereco] = true
+		roteamento.Caixas = append(roteamento.Caixas, caixa)
+	}
+
+	if arquivo.Padrao != nil {
+		padrao, err := lerCaixa(*arquivo.Padrao)
+		if err != nil {
+			return nil, err
+		}
+		roteamento.Padrao = &padrao
+	}
+
+	return roteamento, nil
+}
+
+func lerCaixa(c arquivoCaixa) (email.Caixa, error) {
+	caixa := email.Caixa{
+		Endereco:     strings.ToLower(strings.TrimSpace(c.Endereco)),
+		Categoria:    ticket.Categoria(strings.ToLower(c.Categoria)),
+		Subcategoria: ticket.Subcategoria(strings.ToLower(c.Subcategoria)),
+		Urgencia:     c.Urgencia,
+		Gravidade:    c.Gravidade,
+	}
+	if caixa.Categoria == "" {
+		return caixa, fmt.Errorf("caixa %s: categoria não informada", c.Endereco)
+	}
+	if caixa.Urgencia == 0 {
+		caixa.Urgencia = 3
+	}
+	if caixa.Gravidade == 0 {
+		caixa.Gravidade = 3
+	}
+	if caixa.Urgencia < 1 || caixa.Urgencia > 5 || caixa.Gravidade < 1 || caixa.Gravidade > 5 {
+		return caixa, fmt.Errorf("caixa %s: urgência e gravidade devem estar entre 1 e 5", c.Endereco)
+	}
+	return caixa, nil
+}
@@ -0,0 +1,59 @@
+package email
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Maildir lê as mensagens de uma caixa no formato maildir, entregue por um MTA
+// (Postfix, fetchmail, getmail). As mensagens chegam em new/ e, depois de processadas,
+// vão para cur/ marcadas como lidas; as rejeitadas também são sinalizadas.
+type Maildir struct {
+	dir string
+}
+
+// NovoMaildir abre a caixa, criando os diretórios new, cur e tmp se preciso
+func NovoMaildir(dir string) (*Maildir, error) {
+	for _, sub := range []string{"new", "cur", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			return nil, fmt.Errorf("erro ao abrir maildir: %v", err)
+		}
+	}
+	return &Maildir{dir: dir}, nil
+}
+
+// Novas lista as mensagens ainda não processadas, das mais antigas para as mais recentes
+func (m *Maildir) Novas() ([]string, error) {
+	entradas, err := os.ReadDir(filepath.Join(m.dir, "new"))
+	if err != nil {
+		return nil, err
+	}
+
+	// os nomes de arquivo do maildir começam com o horário da entrega
+	nomes := make([]string, 0, len(entradas))
+	for _, e := range entradas {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") {
+			nomes = append(nomes, e.Name())
+		}
+	}
+	sort.Strings(nomes)
+	return nomes, nil
+}
+
+// Ler retorna o conteúdo bruto de uma mensagem nova
+func (m *Maildir) Ler(nome string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(m.dir, "new", filepath.Base(nome)))
+}
+
+// Concluir move a mensagem para cur/, marcada como lida (S) e, se rejeitada, sinalizada (F)
+func (m *Maildir) Concluir(nome string, rejeitada bool) error {
+	nome = filepath.Base(nome)
+	flags := ":2,S"
+	if rejeitada {
+		flags = ":2,FS"
+	}
+	return os.Rename(filepath.Join(m.dir, "new", nome), filepath.Join(m.dir, "cur", nome+flags))
+}
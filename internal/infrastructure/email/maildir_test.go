@@ -0,0 +1,47 @@
+package email
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMaildir_NovasEConcluir(t *testing.T) {
+	dir := t.TempDir()
+	caixa, err := NovoMaildir(dir)
+	if err != nil {
+		t.Fatalf("Erro ao abrir maildir: %v", err)
+	}
+
+	// arquivos ocultos são ignorados e as mensagens saem em ordem de entrega
+	for _, nome := range []string{"1700000002.M2.host", "1700000001.M1.host", ".tmp"} {
+		os.WriteFile(filepath.Join(dir, "new", nome), []byte("Subject: "+nome+"\r\n\r\n"), 0o600)
+	}
+	novas, err := caixa.Novas()
+	if err != nil {
+		t.Fatalf("Erro ao listar: %v", err)
+	}
+	if !reflect.DeepEqual(novas, []string{"1700000001.M1.host", "1700000002.M2.host"}) {
+		t.Fatalf("Mensagens novas diferentes: %v", novas)
+	}
+	if conteudo, _ := caixa.Ler(novas[0]); string(conteudo) != "Subject: 1700000001.M1.host\r\n\r\n" {
+		t.Errorf("Conteúdo diferente: %q", conteudo)
+	}
+
+	// processadas vão para cur/ como lidas; as rejeitadas também ficam sinalizadas
+	if err := caixa.Concluir(novas[0], false); err != nil {
+		t.Fatalf("Erro ao concluir: %v", err)
+	}
+	if err := caixa.Concluir(novas[1], true); err != nil {
+		t.Fatalf("Erro ao concluir: %v", err)
+	}
+	for _, nome := range []string{"1700000001.M1.host:2,S", "1700000002.M2.host:2,FS"} {
+		if _, err := os.Stat(filepath.Join(dir, "cur", nome)); err != nil {
+			t.Errorf("Esperava %s em cur/: %v", nome, err)
+		}
+	}
+	if novas, _ := caixa.Novas(); len(novas) != 0 {
+		t.Errorf("Não esperava mensagens novas: %v", novas)
+	}
+}
@@ -0,0 +1,235 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// máximo de destinatários por mensagem, como sugere a RFC 5321
+	maxDestinatarios = 100
+
+	// tempo máximo de espera por um comando do cliente
+	timeoutComando = 5 * time.Minute
+)
+
+// Receptor processa cada mensagem aceita pelo servidor. O erro indica falha temporária:
+// o cliente recebe 451 e tenta entregar de novo mais tarde.
+type Receptor func(ctx context.Context, bruto []byte) error
+
+// ServidorSMTP é um servidor SMTP mínimo, só para receber as mensagens que o MTA da
+// organização encaminha às caixas de entrada. Não faz relay nem autenticação: deve
+// ficar atrás do MTA, sem exposição direta à internet.
+type ServidorSMTP struct {
+	endereco      string
+	dominio       string
+	tamanhoMaximo int64
+	receptor      Receptor
+	conexoes      sync.WaitGroup
+}
+
+// NovoServidorSMTP cria o servidor; tamanhoMaximo é o tamanho máximo de uma mensagem, em bytes
+func NovoServidorSMTP(endereco, dominio string, tamanhoMaximo int64, receptor Receptor) *ServidorSMTP {
+	return &ServidorSMTP{
+		endereco:      endereco,
+		dominio:       dominio,
+		tamanhoMaximo: tamanhoMaximo,
+		receptor:      receptor,
+	}
+}
+
+// Iniciar escuta no endereço configurado e atende até o contexto ser cancelado
+func (s *ServidorSMTP) Iniciar(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.endereco)
+	if err != nil {
+		return fmt.Errorf("erro ao escutar em %s: %v", s.endereco, err)
+	}
+	return s.Servir(ctx, listener)
+}
+
+// Servir atende as conexões do listener até o contexto ser cancelado, esperando as
+// sessões abertas terminarem
+func (s *ServidorSMTP) Servir(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	defer s.conexoes.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		s.conexoes.Add(1)
+		go func() {
+			defer s.conexoes.Done()
+			s.atender(ctx, conn)
+		}()
+	}
+}
+
+// sessao é o estado de uma transação SMTP
+type sessao struct {
+	identificado  bool // EHLO ou HELO recebido
+	emTransacao   bool // MAIL aceito; o remetente vazio (<>) é válido em avisos de entrega
+	remetente     string
+	destinatarios []string
+}
+
+func (s *ServidorSMTP) atender(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	leitor := textproto.NewReader(bufio.NewReader(conn))
+	escritor := textproto.NewWriter(bufio.NewWriter(conn))
+	responder := func(linhas ...string) {
+		conn.SetWriteDeadline(time.Now().Add(timeoutComando))
+		for _, l := range linhas {
+			if escritor.PrintfLine("%s", l) != nil {
+				return
+			}
+		}
+	}
+
+	responder("220 " + s.dominio + " ESMTP NOX Tickets")
+	var sess sessao
+	for {
+		conn.SetReadDeadline(time.Now().Add(timeoutComando))
+		linha, err := leitor.ReadLine()
+		if err != nil {
+			return
+		}
+		comando, argumento, _ := strings.Cut(linha, " ")
+		argumento = strings.TrimSpace(argumento)
+
+		switch strings.ToUpper(comando) {
+		case "EHLO":
+			sess = sessao{identificado: true}
+			responder("250-"+s.dominio, "250-SIZE "+strconv.FormatInt(s.tamanhoMaximo, 10), "250-8BITMIME", "250 PIPELINING")
+		case "HELO":
+			sess = sessao{identificado: true}
+			responder("250 " + s.dominio)
+		case "MAIL":
+			if !sess.identificado {
+				responder("503 5.5.1 envie EHLO ou HELO antes")
+				continue
+			}
+			remetente, parametros, ok := lerEnderecoComando(argumento, "FROM:")
+			if !ok {
+				responder("501 5.5.4 sintaxe: MAIL FROM:<endereco>")
+				continue
+			}
+			if tamanho, ok := parametros["SIZE"]; ok {
+				if n, err := strconv.ParseInt(tamanho, 10, 64); err == nil && n > s.tamanhoMaximo {
+					responder("552 5.3.4 mensagem maior que o permitido")
+					continue
+				}
+			}
+			sess = sessao{identificado: true, emTransacao: true, remetente: remetente}
+			responder("250 2.1.0 ok")
+		case "RCPT":
+			if !sess.emTransacao {
+				responder("503 5.5.1 envie MAIL antes")
+				continue
+			}
+			destinatario, _, ok := lerEnderecoComando(argumento, "TO:")
+			if !ok || destinatario == "" {
+				responder("501 5.5.4 sintaxe: RCPT TO:<endereco>")
+				continue
+			}
+			if len(sess.destinatarios) >= maxDestinatarios {
+				responder("452 4.5.3 destinatários demais")
+				continue
+			}
+			sess.destinatarios = append(sess.destinatarios, destinatario)
+			responder("250 2.1.5 ok")
+		case "DATA":
+			if len(sess.destinatarios) == 0 {
+				responder("503 5.5.1 envie RCPT antes")
+				continue
+			}
+			responder("354 envie a mensagem terminando com <CRLF>.<CRLF>")
+			resposta, ok := s.receber(ctx, leitor, sess)
+			if !ok {
+				return
+			}
+			sess = sessao{identificado: true}
+			responder(resposta)
+		case "RSET":
+			sess = sessao{identificado: sess.identificado}
+			responder("250 2.0.0 ok")
+		case "NOOP":
+			responder("250 2.0.0 ok")
+		case "VRFY":
+			responder("252 2.5.0 endereço não verificado")
+		case "QUIT":
+			responder("221 2.0.0 até logo")
+			return
+		default:
+			responder("502 5.5.2 comando não suportado")
+		}
+	}
+}
+
+// receber lê o conteúdo do DATA e o entrega ao receptor, retornando a resposta ao cliente.
+// ok falso indica que a conexão caiu no meio da mensagem.
+func (s *ServidorSMTP) receber(ctx context.Context, leitor *textproto.Reader, sess sessao) (string, bool) {
+	// os destinatários do envelope vão como Delivered-To, para que cópias ocultas
+	// também cheguem à caixa certa; o DotReader já converte as quebras de linha para \n
+	var bruto strings.Builder
+	for _, d := range sess.destinatarios {
+		bruto.WriteString("Delivered-To: " + d + "\n")
+	}
+
+	dados := leitor.DotReader()
+	conteudo, err := io.ReadAll(io.LimitReader(dados, s.tamanhoMaximo+1))
+	if err != nil {
+		return "", false
+	}
+	if int64(len(conteudo)) > s.tamanhoMaximo {
+		// descarta o resto da mensagem antes de responder
+		if _, err := io.Copy(io.Discard, dados); err != nil {
+			return "", false
+		}
+		return "552 5.3.4 mensagem maior que o permitido", true
+	}
+	bruto.Write(conteudo)
+
+	if err := s.receptor(ctx, []byte(bruto.String())); err != nil {
+		return "451 4.3.0 falha temporária ao processar a mensagem, tente mais tarde", true
+	}
+	return "250 2.0.0 mensagem recebida", true
+}
+
+// lerEnderecoComando interpreta "FROM:<endereco> PARAM=valor" e "TO:<endereco>"
+func lerEnderecoComando(argumento, prefixo string) (string, map[string]string, bool) {
+	if len(argumento) < len(prefixo) || !strings.EqualFold(argumento[:len(prefixo)], prefixo) {
+		return "", nil, false
+	}
+	resto := strings.TrimSpace(argumento[len(prefixo):])
+	if !strings.HasPrefix(resto, "<") {
+		return "", nil, false
+	}
+	fim := strings.Index(resto, ">")
+	if fim < 0 {
+		return "", nil, false
+	}
+	endereco := resto[1:fim]
+
+	parametros := map[string]string{}
+	for _, p := range strings.Fields(resto[fim+1:]) {
+		chave, valor, _ := strings.Cut(p, "=")
+		parametros[strings.ToUpper(chave)] = valor
+	}
+	return endereco, parametros, true
+}
@@ -0,0 +1,98 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// iniciarServidor sobe o servidor em uma porta livre; o receptor guarda as mensagens
+// ou falha quando falhar é verdadeiro
+func iniciarServidor(t *testing.T, tamanhoMaximo int64, falhar bool) (string, func() []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Erro ao escutar: %v", err)
+	}
+
+	var mu sync.Mutex
+	var recebidas []string
+	receptor := func(_ context.Context, bruto []byte) error {
+		if falhar {
+			return errors.New("banco indisponível")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		recebidas = append(recebidas, string(bruto))
+		return nil
+	}
+
+	ctx, cancelar := context.WithCancel(context.Background())
+	servidor := NovoServidorSMTP("", "tickets.nox.com", tamanhoMaximo, receptor)
+	fim := make(chan error, 1)
+	go func() { fim <- servidor.Servir(ctx, listener) }()
+	t.Cleanup(func() {
+		cancelar()
+		if err := <-fim; err != nil {
+			t.Errorf("Servidor terminou com erro: %v", err)
+		}
+	})
+
+	return listener.Addr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, recebidas...)
+	}
+}
+
+const mensagemTeste = "From: Cliente <cliente@loja.com>\r\n" +
+	"To: suporte@nox.com\r\n" +
+	"Subject: Saque pendente\r\n" +
+	"Message-ID: <abc@loja.com>\r\n" +
+	"\r\n" +
+	"Meu saque não caiu.\r\n" +
+	".linha que começa com ponto\r\n"
+
+func TestServidorSMTP_RecebeMensagem(t *testing.T) {
+	endereco, recebidas := iniciarServidor(t, 1<<20, false)
+
+	// a caixa só aparece no envelope (cópia oculta)
+	err := smtp.SendMail(endereco, nil, "cliente@loja.com", []string{"financeiro@nox.com"}, []byte(mensagemTeste))
+	if err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	mensagens := recebidas()
+	if len(mensagens) != 1 {
+		t.Fatalf("Esperava uma mensagem, recebidas %d", len(mensagens))
+	}
+	if !strings.HasPrefix(mensagens[0], "Delivered-To: financeiro@nox.com\n") {
+		t.Errorf("Destinatário do envelope ausente:\n%s", mensagens[0])
+	}
+	if !strings.Contains(mensagens[0], "Meu saque não caiu.\n.linha que começa com ponto\n") {
+		t.Errorf("Corpo diferente:\n%s", mensagens[0])
+	}
+}
+
+func TestServidorSMTP_RecusaMensagens(t *testing.T) {
+	// mensagem maior que o limite é recusada de forma permanente
+	endereco, recebidas := iniciarServidor(t, 64, false)
+	err := smtp.SendMail(endereco, nil, "cliente@loja.com", []string{"suporte@nox.com"}, []byte(mensagemTeste))
+	if err == nil || !strings.HasPrefix(err.Error(), "552") {
+		t.Errorf("Esperava 552, recebido %v", err)
+	}
+	if len(recebidas()) != 0 {
+		t.Errorf("Mensagem grande não deveria chegar ao receptor")
+	}
+
+	// falha do receptor é temporária, para o MTA tentar de novo
+	endereco, _ = iniciarServidor(t, 1<<20, true)
+	err = smtp.SendMail(endereco, nil, "cliente@loja.com", []string{"suporte@nox.com"}, []byte(mensagemTeste))
+	if err == nil || !strings.HasPrefix(err.Error(), "451") {
+		t.Errorf("Esperava 451, recebido %v", err)
+	}
+}
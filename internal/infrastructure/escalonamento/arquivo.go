@@ -0,0 +1,100 @@
+package escalonamento
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"nox_tickets/internal/domain/escalonamento"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// arquivoEscalonamento é o formato do arquivo de configuração das regras de escalonamento
+type arquivoEscalonamento struct {
+	Regras []arquivoRegra `json:"regras"`
+}
+
+// arquivoRegra descreve uma regra; "apos" usa a notação de duração do Go (ex.: "30m", "24h")
+type arquivoRegra struct {
+	Nome        string        `json:"nome"`
+	Status      []string      `json:"status"`
+	UrgenciaMin int           `json:"urgencia_min,omitempty"`
+	Apos        string        `json:"apos"`
+	Desde       string        `json:"desde,omitempty"`
+	Acoes       []arquivoAcao `json:"acoes"`
+}
+
+type arquivoAcao struct {
+	Tipo     string `json:"tipo"`
+	Urgencia int    `json:"urgencia,omitempty"`
+	Fila     string `json:"fila,omitempty"`
+	Mensagem string `json:"mensagem,omitempty"`
+}
+
+// CarregarArquivo lê as regras de escalonamento do arquivo JSON
+func CarregarArquivo(caminho string) ([]escalonamento.Regra, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de escalonamento: %v", err)
+	}
+
+	var arquivo arquivoEscalonamento
+	if err := json.Unmarshal(conteudo, &arquivo); err != nil {
+		return nil, fmt.Errorf("erro ao interpretar arquivo de escalonamento: %v", err)
+	}
+
+	maquina := ticket.MaquinaDeEstadosPadrao()
+	regras := make([]escalonamento.Regra, 0, len(arquivo.Regras))
+	nomes := make(map[string]bool)
+	for _, r := range arquivo.Regras {
+		// 1. tempo limite e referência (por padrão, a entrada no status atual)
+		apos, err := time.ParseDuration(r.Apos)
+		if err != nil {
+			return nil, fmt.Errorf("regra %s: tempo limite inválido %q", r.Nome, r.Apos)
+		}
+		desde := escalonamento.Referencia(r.Desde)
+		if desde == "" {
+			desde = escalonamento.DesdeStatus
+		}
+
+		// 2. status observados
+		status := make([]ticket.Status, 0, len(r.Status))
+		for _, s := range r.Status {
+			if !maquina.Conhece(ticket.Status(s)) {
+				return nil, fmt.Errorf("regra %s: status inválido %q", r.Nome, s)
+			}
+			status = append(status, ticket.Status(s))
+		}
+
+		// 3. ações
+		acoes := make([]escalonamento.Acao, 0, len(r.Acoes))
+		for _, a := range r.Acoes {
+			acoes = append(acoes, escalonamento.Acao{
+				Tipo:     escalonamento.TipoAcao(a.Tipo),
+				Urgencia: a.Urgencia,
+				Fila:     a.Fila,
+				Mensagem: a.Mensagem,
+			})
+		}
+
+		regra := escalonamento.Regra{
+			Nome:        r.Nome,
+			Status:      status,
+			UrgenciaMin: r.UrgenciaMin,
+			Apos:        apos,
+			Desde:       desde,
+			Acoes:       acoes,
+		}
+		if err := regra.Validar(); err != nil {
+			return nil, err
+		}
+		if nomes[regra.Nome] {
+			return nil, fmt.Errorf("regra %s duplicada", regra.Nome)
+		}
+		nomes[regra.Nome] = true
+		regras = append(regras, regra)
+	}
+
+	return regras, nil
+}
@@ -0,0 +1,247 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/auth"
+)
+
+var (
+	ErrTokenInvalido = errors.New("token inválido")
+	ErrTokenExpirado = errors.New("token expirado")
+)
+
+// tolerância de relógio entre quem emite o token e a aplicação
+const toleranciaRelogio = 30 * time.Second
+
+// Config define como os tokens são verificados: com um segredo compartilhado (HS256)
+// ou com as chaves públicas de um arquivo JWKS (RS256). Emissor e Audiencia são opcionais.
+type Config struct {
+	Segredo     string
+	ArquivoJWKS string
+	Emissor     string
+	Audiencia   string
+}
+
+// Validador verifica a assinatura e as claims dos tokens JWT
+type Validador struct {
+	segredo   []byte
+	chavesRSA map[string]*rsa.PublicKey // por kid
+	emissor   string
+	audiencia string
+	agora     func() time.Time
+}
+
+// NovoValidador cria o validador a partir da configuração
+func NovoValidador(cfg Config) (*Validador, error) {
+	v := &Validador{
+		emissor:   cfg.Emissor,
+		audiencia: cfg.Audiencia,
+		agora:     time.Now,
+	}
+
+	if cfg.Segredo != "" {
+		v.segredo = []byte(cfg.Segredo)
+	}
+	if cfg.ArquivoJWKS != "" {
+		chaves, err := carregarJWKS(cfg.ArquivoJWKS)
+		if err != nil {
+			return nil, err
+		}
+		v.chavesRSA = chaves
+	}
+	if v.segredo == nil && v.chavesRSA == nil {
+		return nil, errors.New("informe o segredo ou o arquivo JWKS para validar os tokens")
+	}
+
+	return v, nil
+}
+
+type cabecalho struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type claims struct {
+	Sub    string      `json:"sub"`
+	Iss    string      `json:"iss"`
+	Aud    interface{} `json:"aud"` // string ou lista de strings
+	Exp    *int64      `json:"exp"`
+	Nbf    *int64      `json:"nbf"`
+	Nome   string      `json:"name"`
+	Email  string      `json:"email"`
+	Papeis []string    `json:"papeis"`
+}
+
+// Validar verifica o token e retorna o usuário autenticado
+func (v *Validador) Validar(token string) (auth.Principal, error) {
+	// 1. separa as três partes do token
+	partes := strings.Split(token, ".")
+	if len(partes) != 3 {
+		return auth.Principal{}, ErrTokenInvalido
+	}
+
+	var cab cabecalho
+	if err := decodificarParte(partes[0], &cab); err != nil {
+		return auth.Principal{}, ErrTokenInvalido
+	}
+
+	// 2. confere a assinatura com o algoritmo configurado (nunca aceita "none")
+	assinatura, err := base64.RawURLEncoding.DecodeString(partes[2])
+	if err != nil {
+		return auth.Principal{}, ErrTokenInvalido
+	}
+	if err := v.verificarAssinatura(cab, partes[0]+"."+partes[1], assinatura); err != nil {
+		return auth.Principal{}, err
+	}
+
+	// 3. confere as claims
+	var c claims
+	if err := decodificarParte(partes[1], &c); err != nil {
+		return auth.Principal{}, ErrTokenInvalido
+	}
+	if err := v.verificarClaims(c); err != nil {
+		return auth.Principal{}, err
+	}
+
+	return auth.Principal{
+		ID:     c.Sub,
+		Nome:   c.Nome,
+		Email:  c.Email,
+		Papeis: c.Papeis,
+	}, nil
+}
+
+func (v *Validador) verificarAssinatura(cab cabecalho, conteudo string, assinatura []byte) error {
+	switch cab.Alg {
+	case "HS256":
+		if v.segredo == nil {
+			return fmt.Errorf("%w: algoritmo HS256 não configurado", ErrTokenInvalido)
+		}
+		mac := hmac.New(sha256.New, v.segredo)
+		mac.Write([]byte(conteudo))
+		if !hmac.Equal(mac.Sum(nil), assinatura) {
+			return fmt.Errorf("%w: assinatura não confere", ErrTokenInvalido)
+		}
+		return nil
+
+	case "RS256":
+		chave, ok := v.chavesRSA[cab.Kid]
+		if !ok {
+			return fmt.Errorf("%w: chave %q desconhecida", ErrTokenInvalido, cab.Kid)
+		}
+		hash := sha256.Sum256([]byte(conteudo))
+		if err := rsa.VerifyPKCS1v15(chave, crypto.SHA256, hash[:], assinatura); err != nil {
+			return fmt.Errorf("%w: assinatura não confere", ErrTokenInvalido)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: algoritmo %q não suportado", ErrTokenInvalido, cab.Alg)
+	}
+}
+
+func (v *Validador) verificarClaims(c claims) error {
+	agora := v.agora()
+
+	if c.Sub == "" {
+		return fmt.Errorf("%w: token sem usuário (sub)", ErrTokenInvalido)
+	}
+	if c.Exp == nil {
+		return fmt.Errorf("%w: token sem expiração (exp)", ErrTokenInvalido)
+	}
+	if agora.After(time.Unix(*c.Exp, 0).Add(toleranciaRelogio)) {
+		return ErrTokenExpirado
+	}
+	if c.Nbf != nil && agora.Add(toleranciaRelogio).Before(time.Unix(*c.Nbf, 0)) {
+		return fmt.Errorf("%w: token ainda não é válido", ErrTokenInvalido)
+	}
+	if v.emissor != "" && c.Iss != v.emissor {
+		return fmt.Errorf("%w: emissor inválido", ErrTokenInvalido)
+	}
+	if v.audiencia != "" && !contemAudiencia(c.Aud, v.audiencia) {
+		return fmt.Errorf("%w: audiência inválida", ErrTokenInvalido)
+	}
+	return nil
+}
+
+func contemAudiencia(aud interface{}, esperada string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == esperada
+	case []interface{}:
+		for _, item := range a {
+			if s, ok := item.(string); ok && s == esperada {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodificarParte(parte string, destino interface{}) error {
+	dados, err := base64.RawURLEncoding.DecodeString(parte)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(dados, destino)
+}
+
+// formato do arquivo JWKS (RFC 7517), apenas com os campos das chaves RSA
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// carregarJWKS lê as chaves públicas RSA de um arquivo JWKS
+func carregarJWKS(caminho string) (map[string]*rsa.PublicKey, error) {
+	dados, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo JWKS: %v", err)
+	}
+
+	var conjunto jwks
+	if err := json.Unmarshal(dados, &conjunto); err != nil {
+		return nil, fmt.Errorf("erro ao interpretar arquivo JWKS: %v", err)
+	}
+
+	chaves := make(map[string]*rsa.PublicKey)
+	for _, k := range conjunto.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("módulo inválido na chave %q: %v", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("expoente inválido na chave %q: %v", k.Kid, err)
+		}
+		chaves[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(chaves) == 0 {
+		return nil, errors.New("arquivo JWKS sem chaves RSA de assinatura")
+	}
+
+	return chaves, nil
+}
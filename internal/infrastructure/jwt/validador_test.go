@@ -0,0 +1,132 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// montarToken gera um token com o cabeçalho e as claims informados, assinado por assinar
+func montarToken(t *testing.T, cab, c map[string]interface{}, assinar func(conteudo string) []byte) string {
+	t.Helper()
+	codificar := func(v interface{}) string {
+		dados, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Erro ao codificar token: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(dados)
+	}
+	conteudo := codificar(cab) + "." + codificar(c)
+	return conteudo + "." + base64.RawURLEncoding.EncodeToString(assinar(conteudo))
+}
+
+func assinarHS256(segredo string) func(string) []byte {
+	return func(conteudo string) []byte {
+		mac := hmac.New(sha256.New, []byte(segredo))
+		mac.Write([]byte(conteudo))
+		return mac.Sum(nil)
+	}
+}
+
+func TestValidador_HS256(t *testing.T) {
+	v, err := NovoValidador(Config{Segredo: "segredo", Emissor: "nox", Audiencia: "tickets"})
+	if err != nil {
+		t.Fatalf("Erro ao criar validador: %v", err)
+	}
+
+	claims := map[string]interface{}{
+		"sub": "analista_1", "name": "Analista", "iss": "nox", "aud": []string{"tickets"},
+		"exp": time.Now().Add(time.Hour).Unix(), "papeis": []string{"analista"},
+	}
+	token := montarToken(t, map[string]interface{}{"alg": "HS256"}, claims, assinarHS256("segredo"))
+
+	principal, err := v.Validar(token)
+	if err != nil {
+		t.Fatalf("Erro ao validar token: %v", err)
+	}
+	if principal.ID != "analista_1" || len(principal.Papeis) != 1 {
+		t.Errorf("Principal diferente do esperado: %+v", principal)
+	}
+
+	// assinatura com outro segredo
+	falso := montarToken(t, map[string]interface{}{"alg": "HS256"}, claims, assinarHS256("outro"))
+	if _, err := v.Validar(falso); !errors.Is(err, ErrTokenInvalido) {
+		t.Errorf("Esperava ErrTokenInvalido, recebido %v", err)
+	}
+
+	// algoritmo none
+	semAssinatura := montarToken(t, map[string]interface{}{"alg": "none"}, claims, func(string) []byte { return nil })
+	if _, err := v.Validar(semAssinatura); !errors.Is(err, ErrTokenInvalido) {
+		t.Errorf("Esperava ErrTokenInvalido para alg none, recebido %v", err)
+	}
+
+	// token expirado
+	claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	expirado := montarToken(t, map[string]interface{}{"alg": "HS256"}, claims, assinarHS256("segredo"))
+	if _, err := v.Validar(expirado); !errors.Is(err, ErrTokenExpirado) {
+		t.Errorf("Esperava ErrTokenExpirado, recebido %v", err)
+	}
+}
+
+func TestValidador_RS256ComJWKS(t *testing.T) {
+	chave, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Erro ao gerar chave: %v", err)
+	}
+
+	// grava o JWKS com a chave pública
+	conjunto := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA", "kid": "chave-1", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(chave.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(chave.E)).Bytes()),
+		}},
+	}
+	dados, _ := json.Marshal(conjunto)
+	caminho := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(caminho, dados, 0o600); err != nil {
+		t.Fatalf("Erro ao gravar JWKS: %v", err)
+	}
+
+	v, err := NovoValidador(Config{ArquivoJWKS: caminho})
+	if err != nil {
+		t.Fatalf("Erro ao criar validador: %v", err)
+	}
+
+	assinar := func(conteudo string) []byte {
+		hash := sha256.Sum256([]byte(conteudo))
+		assinatura, err := rsa.SignPKCS1v15(rand.Reader, chave, crypto.SHA256, hash[:])
+		if err != nil {
+			t.Fatalf("Erro ao assinar token: %v", err)
+		}
+		return assinatura
+	}
+	claims := map[string]interface{}{"sub": "supervisor_1", "exp": time.Now().Add(time.Hour).Unix()}
+
+	token := montarToken(t, map[string]interface{}{"alg": "RS256", "kid": "chave-1"}, claims, assinar)
+	if principal, err := v.Validar(token); err != nil || principal.ID != "supervisor_1" {
+		t.Errorf("Esperava token válido, recebido %+v, %v", principal, err)
+	}
+
+	// kid desconhecido
+	token = montarToken(t, map[string]interface{}{"alg": "RS256", "kid": "chave-2"}, claims, assinar)
+	if _, err := v.Validar(token); !errors.Is(err, ErrTokenInvalido) {
+		t.Errorf("Esperava ErrTokenInvalido, recebido %v", err)
+	}
+
+	// HS256 não foi configurado: não pode ser aceito com a chave pública como segredo
+	token = montarToken(t, map[string]interface{}{"alg": "HS256"}, claims, assinarHS256(""))
+	if _, err := v.Validar(token); !errors.Is(err, ErrTokenInvalido) {
+		t.Errorf("Esperava ErrTokenInvalido, recebido %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package notificacao
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"nox_tickets/internal/domain/email"
+	"nox_tickets/internal/domain/notificacao"
+)
+
+// ConfigSMTP contém os dados de acesso ao servidor de e-mail
+type ConfigSMTP struct {
+	Host      string
+	Porta     string // padrão 25
+	Usuario   string // vazio desativa a autenticação
+	Senha     string
+	Remetente string        // ex.: "NOX Tickets <nox@empresa.com>"
+	Timeout   time.Duration // tempo limite de cada envio, padrão 10s
+}
+
+// EnviadorSMTP entrega as notificações por SMTP, com STARTTLS quando o servidor oferece
+type EnviadorSMTP struct {
+	config    ConfigSMTP
+	remetente *mail.Address
+}
+
+// NovoEnviadorSMTP valida a configuração e cria o enviador
+func NovoEnviadorSMTP(config ConfigSMTP) (*EnviadorSMTP, error) {
+	if config.Host == "" {
+		return nil, errors.New("host SMTP não informado")
+	}
+	if config.Porta == "" {
+		config.Porta = "25"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	remetente, err := mail.ParseAddress(config.Remetente)
+	if err != nil {
+		return nil, fmt.Errorf("remetente inválido %q: %v", config.Remetente, err)
+	}
+	return &EnviadorSMTP{config: config, remetente: remetente}, nil
+}
+
+// Enviar entrega a mensagem em uma conexão própria. Destinatários recusados pelo servidor
+// (respostas 5xx ao RCPT) são devolvidos como notificacao.ErrEnvioRecusado.
+func (e *EnviadorSMTP) Enviar(ctx context.Context, m notificacao.Mensagem) error {
+	ctx, cancelar := context.WithTimeout(ctx, e.config.Timeout)
+	defer cancelar()
+
+	conexao, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort(e.config.Host, e.config.Porta))
+	if err != nil {
+		return err
+	}
+	if prazo, ok := ctx.Deadline(); ok {
+		conexao.SetDeadline(prazo)
+	}
+	cliente, err := smtp.NewClient(conexao, e.config.Host)
+	if err != nil {
+		conexao.Close()
+		return err
+	}
+	defer cliente.Close()
+
+	// 1. protege a conexão quando o servidor oferece STARTTLS
+	if ok, _ := cliente.Extension("STARTTLS"); ok {
+		if err := cliente.StartTLS(&tls.Config{ServerName: e.config.Host}); err != nil {
+			return err
+		}
+	}
+
+	// 2. autentica, se configurado
+	if e.config.Usuario != "" {
+		if err := cliente.Auth(smtp.PlainAuth("", e.config.Usuario, e.config.Senha, e.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	// 3. envelope: a recusa definitiva do destinatário não adianta ser repetida
+	if err := cliente.Mail(e.remetente.Address); err != nil {
+		return err
+	}
+	if err := cliente.Rcpt(m.Para); err != nil {
+		var erroSMTP *textproto.Error
+		if errors.As(err, &erroSMTP) && erroSMTP.Code >= 500 {
+			return fmt.Errorf("%w: %v", notificacao.ErrEnvioRecusado, err)
+		}
+		return err
+	}
+
+	// 4. conteúdo
+	escritor, err := cliente.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := escritor.Write(e.montar(m)); err != nil {
+		return err
+	}
+	if err := escritor.Close(); err != nil {
+		return err
+	}
+	return cliente.Quit()
+}
+
+// montar gera a mensagem MIME em texto puro UTF-8, com o corpo em quoted-printable
+func (e *EnviadorSMTP) montar(m notificacao.Mensagem) []byte {
+	var buf bytes.Buffer
+	cabecalhos := [][2]string{
+		{"From", e.remetente.String()},
+		{"To", (&mail.Address{Name: m.Nome, Address: m.Para}).String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Assunto)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", "<" + email.IDMensagem(m.TicketID, e.config.Host) + ">"},
+		{"Auto-Submitted", "auto-generated"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, c := range cabecalhos {
+		fmt.Fprintf(&buf, "%s: %s\r\n", c[0], c[1])
+	}
+	buf.WriteString("\r\n")
+
+	corpo := quotedprintable.NewWriter(&buf)
+	corpo.Write([]byte(m.Corpo))
+	corpo.Close()
+	return buf.Bytes()
+}
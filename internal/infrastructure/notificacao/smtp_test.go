@@ -0,0 +1,162 @@
+package notificacao
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"nox_tickets/internal/domain/email"
+	"nox_tickets/internal/domain/notificacao"
+)
+
+// servidorSMTP é um servidor SMTP mínimo, em memória, que aceita qualquer remetente
+// e recusa os destinatários da lista de recusados
+type servidorSMTP struct {
+	listener  net.Listener
+	recusados map[string]bool
+
+	mu        sync.Mutex
+	mensagens []string
+	envelopes []string
+}
+
+func novoServidorSMTP(t *testing.T, recusados ...string) *servidorSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Erro ao abrir servidor SMTP: %v", err)
+	}
+	s := &servidorSMTP{listener: listener, recusados: map[string]bool{}}
+	for _, r := range recusados {
+		s.recusados[r] = true
+	}
+	go s.aceitar()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *servidorSMTP) porta() string {
+	_, porta, _ := net.SplitHostPort(s.listener.Addr().String())
+	return porta
+}
+
+func (s *servidorSMTP) aceitar() {
+	for {
+		conexao, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.atender(conexao)
+	}
+}
+
+func (s *servidorSMTP) atender(conexao net.Conn) {
+	defer conexao.Close()
+	tp := textproto.NewConn(conexao)
+	tp.PrintfLine("220 localhost ESMTP teste")
+
+	for {
+		linha, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		comando := strings.ToUpper(strings.SplitN(linha, " ", 2)[0])
+		switch comando {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 localhost")
+		case "MAIL":
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			destinatario := strings.Trim(strings.SplitN(linha, ":", 2)[1], "<> ")
+			if s.recusados[destinatario] {
+				tp.PrintfLine("550 caixa postal inexistente")
+				continue
+			}
+			s.mu.Lock()
+			s.envelopes = append(s.envelopes, destinatario)
+			s.mu.Unlock()
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 envie a mensagem")
+			dados, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.mensagens = append(s.mensagens, string(dados))
+			s.mu.Unlock()
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 até logo")
+			return
+		default:
+			tp.PrintfLine("502 comando não implementado")
+		}
+	}
+}
+
+func TestEnviadorSMTP_EntregaMensagem(t *testing.T) {
+	servidor := novoServidorSMTP(t)
+	enviador, err := NovoEnviadorSMTP(ConfigSMTP{Host: "127.0.0.1", Porta: servidor.porta(), Remetente: "NOX Tickets <nox@nox.com>"})
+	if err != nil {
+		t.Fatalf("Erro ao criar enviador: %v", err)
+	}
+
+	err = enviador.Enviar(context.Background(), notificacao.Mensagem{
+		Para:     "ana@nox.com",
+		Nome:     "Ana Souza",
+		Assunto:  "[NOX Tickets] Nova observação: Saque não creditado",
+		Corpo:    "Olá, Ana.\n\nJoão adicionou uma observação ao ticket.\n",
+		TicketID: "0f8fad5b-d9cb-469f-a165-70867728950e",
+	})
+	if err != nil {
+		t.Fatalf("Erro ao enviar: %v", err)
+	}
+
+	if len(servidor.envelopes) != 1 || servidor.envelopes[0] != "ana@nox.com" {
+		t.Fatalf("Destinatário do envelope incorreto: %v", servidor.envelopes)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(servidor.mensagens[0]))
+	if err != nil {
+		t.Fatalf("Mensagem inválida: %v", err)
+	}
+	assunto, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if assunto != "[NOX Tickets] Nova observação: Saque não creditado" {
+		t.Errorf("Assunto diferente: %q", assunto)
+	}
+	// o Message-ID carrega o ticket, para que as respostas sejam associadas a ele
+	if id, ok := email.TicketDoIDMensagem(msg.Header.Get("Message-ID")); !ok || id != "0f8fad5b-d9cb-469f-a165-70867728950e" {
+		t.Errorf("Message-ID sem o ticket: %q", msg.Header.Get("Message-ID"))
+	}
+	if para, _ := msg.Header.AddressList("To"); len(para) != 1 || para[0].Name != "Ana Souza" {
+		t.Errorf("Destinatário diferente: %v", para)
+	}
+	corpo, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if !strings.Contains(string(corpo), "João adicionou uma observação ao ticket.") {
+		t.Errorf("Corpo diferente: %q", corpo)
+	}
+}
+
+func TestEnviadorSMTP_DestinatarioRecusado(t *testing.T) {
+	servidor := novoServidorSMTP(t, "inexistente@nox.com")
+	enviador, _ := NovoEnviadorSMTP(ConfigSMTP{Host: "127.0.0.1", Porta: servidor.porta(), Remetente: "nox@nox.com"})
+
+	err := enviador.Enviar(context.Background(), notificacao.Mensagem{Para: "inexistente@nox.com", Assunto: "Teste", Corpo: "Teste"})
+	if !errors.Is(err, notificacao.ErrEnvioRecusado) {
+		t.Errorf("Esperava ErrEnvioRecusado, recebido %v", err)
+	}
+
+	// servidor fora do ar é falha temporária
+	servidor.listener.Close()
+	err = enviador.Enviar(context.Background(), notificacao.Mensagem{Para: "ana@nox.com", Assunto: "Teste", Corpo: "Teste"})
+	if err == nil || errors.Is(err, notificacao.ErrEnvioRecusado) {
+		t.Errorf("Esperava erro temporário, recebido %v", err)
+	}
+}
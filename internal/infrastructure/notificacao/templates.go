@@ -0,0 +1,94 @@
+package notificacao
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+//go:embed templates/*.tmpl
+var arquivosTemplates embed.FS
+
+// rótulos dos status e dos prazos usados nas mensagens
+var (
+	rotulosStatus = map[ticket.Status]string{
+		ticket.StatusAberto:             "Aberto",
+		ticket.StatusEmCurso:            "Em curso",
+		ticket.StatusAguardandoCliente:  "Aguardando cliente",
+		ticket.StatusAguardandoTerceiro: "Aguardando terceiro",
+		ticket.StatusPausado:            "Pausado",
+		ticket.StatusReaberto:           "Reaberto",
+		ticket.StatusFinalizado:         "Finalizado",
+		ticket.StatusCancelado:          "Cancelado",
+	}
+	rotulosPrazo = map[string]string{
+		ticket.PrazoPrimeiraResposta: "primeira resposta",
+		ticket.PrazoResolucao:        "resolução",
+	}
+)
+
+// Templates monta as mensagens em pt-BR a partir dos templates embutidos no binário.
+// Cada tipo de notificação tem um arquivo com os blocos "assunto" e "corpo"; o assunto
+// termina com a referência [#<id>] do ticket, que liga as respostas por e-mail a ele.
+type Templates struct {
+	porTipo map[notificacao.Tipo]*template.Template
+}
+
+// CarregarTemplates lê os templates de todos os tipos de notificação; as datas são exibidas no fuso informado
+func CarregarTemplates(fuso *time.Location) (*Templates, error) {
+	if fuso == nil {
+		fuso = time.Local
+	}
+	funcoes := template.FuncMap{
+		"status": func(s ticket.Status) string {
+			if rotulo, ok := rotulosStatus[s]; ok {
+				return rotulo
+			}
+			return string(s)
+		},
+		"prazo": func(p string) string {
+			if rotulo, ok := rotulosPrazo[p]; ok {
+				return rotulo
+			}
+			return p
+		},
+		"data": func(t time.Time) string {
+			return t.In(fuso).Format("02/01/2006 às 15:04")
+		},
+		"minusculas": strings.ToLower,
+	}
+
+	templates := &Templates{porTipo: map[notificacao.Tipo]*template.Template{}}
+	for _, tipo := range notificacao.Tipos {
+		t, err := template.New(string(tipo)).Funcs(funcoes).ParseFS(arquivosTemplates,
+			"templates/base.tmpl", fmt.Sprintf("templates/%s.tmpl", tipo))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar template de %s: %v", tipo, err)
+		}
+		templates.porTipo[tipo] = t
+	}
+	return templates, nil
+}
+
+// Renderizar executa os blocos de assunto e corpo do template do tipo
+func (t *Templates) Renderizar(tipo notificacao.Tipo, dados notificacao.Dados) (string, string, error) {
+	tmpl, ok := t.porTipo[tipo]
+	if !ok {
+		return "", "", notificacao.ErrTipoInvalido
+	}
+
+	var assunto, corpo bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&assunto, "assunto", dados); err != nil {
+		return "", "", fmt.Errorf("erro ao montar assunto de %s: %v", tipo, err)
+	}
+	if err := tmpl.ExecuteTemplate(&corpo, "corpo", dados); err != nil {
+		return "", "", fmt.Errorf("erro ao montar corpo de %s: %v", tipo, err)
+	}
+	return strings.TrimSpace(assunto.String()), corpo.String(), nil
+}
@@ -0,0 +1,59 @@
+package notificacao
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/ticket"
+)
+
+func TestTemplates_RenderizaTodosOsTipos(t *testing.T) {
+	templates, err := CarregarTemplates(time.UTC)
+	if err != nil {
+		t.Fatalf("Erro ao carregar templates: %v", err)
+	}
+
+	vencimento := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+	dados := notificacao.Dados{
+		Destinatario:   "Ana",
+		Autor:          "João",
+		StatusAnterior: ticket.StatusEmCurso,
+		Observacao:     "Comprovante enviado",
+		Prazo:          ticket.PrazoResolucao,
+		Vencimento:     &vencimento,
+		Regra:          "aberto_urgente",
+		Mensagem:       "Ticket urgente sem atendimento",
+		Ticket: ticket.ResumoTicket{
+			ID: "ticket-1", Titulo: "Saque não creditado", Status: ticket.StatusAguardandoCliente,
+			Categoria: ticket.CategoriaFinanceiro, Urgencia: 3, Gravidade: 2, PrazoResolucao: &vencimento,
+		},
+	}
+
+	esperados := map[notificacao.Tipo][]string{
+		notificacao.TipoTicketCriado:         {"Ticket aberto: Saque não creditado", "Recebemos o seu ticket"},
+		notificacao.TipoTicketAtribuido:      {"Ticket atribuído a você", "João atribuiu um ticket a você", "Resolução até: 10/03/2026 às 14:30"},
+		notificacao.TipoStatusAlterado:       {"Ticket aguardando cliente", `de "Em curso" para "Aguardando cliente"`},
+		notificacao.TipoObservacaoAdicionada: {"Nova observação", "Comprovante enviado"},
+		notificacao.TipoAlertaSLA:            {"Prazo de resolução prestes a vencer", "vence em 10/03/2026 às 14:30"},
+		notificacao.TipoEscalonamento:        {"Ticket escalonado", "pela regra aberto_urgente", "Ticket urgente sem atendimento"},
+	}
+	for _, tipo := range notificacao.Tipos {
+		assunto, corpo, err := templates.Renderizar(tipo, dados)
+		if err != nil {
+			t.Fatalf("Erro ao renderizar %s: %v", tipo, err)
+		}
+		if !strings.HasSuffix(assunto, "[#ticket-1]") {
+			t.Errorf("Assunto de %s sem a referência do ticket: %q", tipo, assunto)
+		}
+		if !strings.HasPrefix(corpo, "Olá, Ana.") || !strings.Contains(corpo, "Identificador: ticket-1") {
+			t.Errorf("Corpo de %s incompleto:\n%s", tipo, corpo)
+		}
+		for _, trecho := range esperados[tipo] {
+			if !strings.Contains(assunto+"\n"+corpo, trecho) {
+				t.Errorf("%s: esperava %q em\n%s\n%s", tipo, trecho, assunto, corpo)
+			}
+		}
+	}
+}
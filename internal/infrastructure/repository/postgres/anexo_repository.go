@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"nox_tickets/internal/domain/anexo"
+)
+
+type AnexoRepository struct {
+	db *sql.DB
+}
+
+func NewAnexoRepository(db *sql.DB) *AnexoRepository {
+	return &AnexoRepository{db: db}
+}
+
+// colunasAnexo são as colunas lidas por scanAnexo, na mesma ordem
+const colunasAnexo = `id, ticket_id, COALESCE(observacao_id::text, ''), nome, tipo_mime, tamanho, sha256, chave, enviado_por, data_criacao`
+
+func scanAnexo(row interface{ Scan(...interface{}) error }) (*anexo.Anexo, error) {
+	a := &anexo.Anexo{}
+	err := row.Scan(&a.ID, &a.TicketID, &a.ObservacaoID, &a.Nome, &a.TipoMIME, &a.Tamanho, &a.SHA256, &a.Chave, &a.EnviadoPor, &a.DataCriacao)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// registrar os metadados do anexo
+func (r *AnexoRepository) Criar(a *anexo.Anexo) error {
+	_, err := r.db.Exec(
+		`INSERT INTO anexos (id, ticket_id, observacao_id, nome, tipo_mime, tamanho, sha256, chave, enviado_por, data_criacao)
+		 VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, $9, $10)`,
+		a.ID, a.TicketID, a.ObservacaoID, a.Nome, a.TipoMIME, a.Tamanho, a.SHA256, a.Chave, a.EnviadoPor, a.DataCriacao,
+	)
+	return err
+}
+
+// buscar anexo por ID
+func (r *AnexoRepository) BuscarPorID(id string) (*anexo.Anexo, error) {
+	a, err := scanAnexo(r.db.QueryRow("SELECT "+colunasAnexo+" FROM anexos WHERE id::text = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, anexo.ErrAnexoNaoEncontrado
+	}
+	return a, err
+}
+
+// listar os anexos do ticket, do mais antigo para o mais recente
+func (r *AnexoRepository) ListarPorTicket(ticketID string) ([]*anexo.Anexo, error) {
+	rows, err := r.db.Query(
+		"SELECT "+colunasAnexo+" FROM anexos WHERE ticket_id::text = $1 ORDER BY data_criacao, id",
+		ticketID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	anexos := []*anexo.Anexo{}
+	for rows.Next() {
+		a, err := scanAnexo(rows)
+		if err != nil {
+			return nil, err
+		}
+		anexos = append(anexos, a)
+	}
+	return anexos, rows.Err()
+}
+
+// remover os metadados do anexo
+func (r *AnexoRepository) Remover(id string) error {
+	_, err := r.db.Exec("DELETE FROM anexos WHERE id::text = $1", id)
+	return err
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"nox_tickets/internal/domain/email"
+
+	"github.com/lib/pq"
+)
+
+type EmailRepository struct {
+	db *sql.DB
+}
+
+func NewEmailRepository(db *sql.DB) *EmailRepository {
+	return &EmailRepository{db: db}
+}
+
+// buscar um e-mail já processado
+func (r *EmailRepository) BuscarRecebido(messageID string) (*email.Recebido, error) {
+	rec := &email.Recebido{}
+	var ticketID, observacaoID sql.NullString
+	err := r.db.QueryRow(
+		`SELECT message_id, remetente, assunto, resultado, ticket_id, observacao_id, motivo, anexos, data_recebimento
+		 FROM emails_recebidos WHERE message_id = $1`,
+		messageID,
+	).Scan(&rec.MessageID, &rec.Remetente, &rec.Assunto, &rec.Resultado, &ticketID, &observacaoID,
+		&rec.Motivo, &rec.Anexos, &rec.DataRecebimento)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, email.ErrRecebidoNaoEncontrado
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec.TicketID = ticketID.String
+	rec.ObservacaoID = observacaoID.String
+	return rec, nil
+}
+
+// ticket do primeiro e-mail da conversa que gerou ticket
+func (r *EmailRepository) TicketDasMensagens(messageIDs []string) (string, error) {
+	if len(messageIDs) == 0 {
+		return "", nil
+	}
+	var ticketID string
+	err := r.db.QueryRow(
+		`SELECT ticket_id::text FROM emails_recebidos
+		 WHERE message_id = ANY($1) AND ticket_id IS NOT NULL
+		 ORDER BY data_recebimento LIMIT 1`,
+		pq.Array(messageIDs),
+	).Scan(&ticketID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return ticketID, err
+}
+
+// reservar o Message-ID; só uma das entregas da mesma mensagem consegue inserir a linha
+func (r *EmailRepository) Reservar(rec *email.Recebido) (bool, error) {
+	result, err := r.db.Exec(
+		`INSERT INTO emails_recebidos (message_id, remetente, assunto, resultado, data_recebimento)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (message_id) DO NOTHING`,
+		rec.MessageID, rec.Remetente, rec.Assunto, email.ResultadoEmProcessamento, rec.DataRecebimento,
+	)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+// liberar a reserva; e-mails já concluídos não são apagados
+func (r *EmailRepository) Liberar(messageID string) error {
+	_, err := r.db.Exec(
+		`DELETE FROM emails_recebidos WHERE message_id = $1 AND resultado = $2`,
+		messageID, email.ResultadoEmProcessamento,
+	)
+	return err
+}
+
+// registrar o resultado; conclui a reserva, e o mesmo Message-ID só é concluído uma vez
+func (r *EmailRepository) Registrar(rec *email.Recebido) error {
+	_, err := r.db.Exec(
+		`INSERT INTO emails_recebidos (message_id, remetente, assunto, resultado, ticket_id, observacao_id, motivo, anexos, data_recebimento)
+		 VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, '')::uuid, $7, $8, $9)
+		 ON CONFLICT (message_id) DO UPDATE SET
+		    resultado = EXCLUDED.resultado, ticket_id = EXCLUDED.ticket_id, observacao_id = EXCLUDED.observacao_id,
+		    motivo = EXCLUDED.motivo, anexos = EXCLUDED.anexos
+		 WHERE emails_recebidos.resultado = $10`,
+		rec.MessageID, rec.Remetente, rec.Assunto, rec.Resultado, rec.TicketID, rec.ObservacaoID,
+		rec.Motivo, rec.Anexos, rec.DataRecebimento, email.ResultadoEmProcessamento,
+	)
+	return err
+}
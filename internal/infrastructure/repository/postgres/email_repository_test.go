@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/anexo"
+	"nox_tickets/internal/domain/email"
+
+	"github.com/google/uuid"
+)
+
+// Teste do registro de e-mails recebidos e dos anexos gerados por eles
+func TestEmailRepository_RecebidosEAnexos(t *testing.T) {
+	ticketRepo := setupTestDB(t)
+	repo := NewEmailRepository(ticketRepo.db)
+	anexoRepo := NewAnexoRepository(ticketRepo.db)
+
+	tk := createTestTicket()
+	if err := ticketRepo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+
+	// e-mail ainda não processado
+	messageID := uuid.New().String() + "@cliente.com"
+	if _, err := repo.BuscarRecebido(messageID); err != email.ErrRecebidoNaoEncontrado {
+		t.Fatalf("Esperava ErrRecebidoNaoEncontrado, recebido %v", err)
+	}
+
+	// só a primeira entrega reserva a mensagem; liberar a reserva permite reservar de novo
+	rec := &email.Recebido{
+		MessageID: messageID, Remetente: "cliente@cliente.com", Assunto: "Saque não caiu", DataRecebimento: time.Now(),
+	}
+	for i, esperado := range []bool{true, false} {
+		if reservado, err := repo.Reservar(rec); err != nil || reservado != esperado {
+			t.Fatalf("Reserva %d: esperava %v, recebido %v, %v", i, esperado, reservado, err)
+		}
+	}
+	if salvo, err := repo.BuscarRecebido(messageID); err != nil || salvo.Resultado != email.ResultadoEmProcessamento {
+		t.Errorf("Esperava a mensagem em processamento, recebido %+v, %v", salvo, err)
+	}
+	if err := repo.Liberar(messageID); err != nil {
+		t.Fatalf("Erro ao liberar reserva: %v", err)
+	}
+	if reservado, err := repo.Reservar(rec); err != nil || !reservado {
+		t.Fatalf("Esperava reservar de novo depois de liberar, recebido %v, %v", reservado, err)
+	}
+
+	// registrar conclui a reserva; registrar de novo e liberar não alteram o e-mail concluído
+	rec.Resultado, rec.TicketID, rec.Anexos = email.ResultadoTicketCriado, tk.ID, 1
+	for i := 0; i < 2; i++ {
+		if err := repo.Registrar(rec); err != nil {
+			t.Fatalf("Erro ao registrar e-mail: %v", err)
+		}
+	}
+	repo.Liberar(messageID)
+	salvo, err := repo.BuscarRecebido(messageID)
+	if err != nil || salvo.TicketID != tk.ID || salvo.Resultado != email.ResultadoTicketCriado || salvo.ObservacaoID != "" {
+		t.Errorf("E-mail registrado diferente: %+v, %v", salvo, err)
+	}
+
+	// rejeitados não têm ticket e não servem para encontrar a conversa
+	rejeitado := uuid.New().String() + "@cliente.com"
+	repo.Registrar(&email.Recebido{
+		MessageID: rejeitado, Remetente: "x@y.com", Resultado: email.ResultadoRejeitado, Motivo: "sem texto", DataRecebimento: time.Now(),
+	})
+	if id, err := repo.TicketDasMensagens([]string{rejeitado, "desconhecido@z"}); err != nil || id != "" {
+		t.Errorf("Não esperava ticket, recebido %q, %v", id, err)
+	}
+	if id, _ := repo.TicketDasMensagens([]string{rejeitado, messageID}); id != tk.ID {
+		t.Errorf("Esperava o ticket %s, recebido %q", tk.ID, id)
+	}
+
+	// anexos do ticket
+	a, _ := anexo.NovoAnexo(tk.ID, "comprovante.pdf", "usuario_teste")
+	a.TipoMIME, a.Tamanho = "application/pdf", 4
+	a.SHA256 = "88d4266fd4e6338d13b845fcf289579d209c897823b9217da3e161936f031589"
+	if err := anexoRepo.Criar(a); err != nil {
+		t.Fatalf("Erro ao registrar anexo: %v", err)
+	}
+	anexos, err := anexoRepo.ListarPorTicket(tk.ID)
+	if err != nil || len(anexos) != 1 || anexos[0].Nome != "comprovante.pdf" || anexos[0].Chave != a.Chave {
+		t.Errorf("Anexos diferentes: %+v, %v", anexos, err)
+	}
+	buscado, err := anexoRepo.BuscarPorID(a.ID)
+	if err != nil || buscado.SHA256 != a.SHA256 || buscado.Tamanho != 4 {
+		t.Errorf("Anexo buscado diferente: %+v, %v", buscado, err)
+	}
+
+	if err := anexoRepo.Remover(a.ID); err != nil {
+		t.Fatalf("Erro ao remover anexo: %v", err)
+	}
+	if _, err := anexoRepo.BuscarPorID(a.ID); !errors.Is(err, anexo.ErrAnexoNaoEncontrado) {
+		t.Errorf("Esperava ErrAnexoNaoEncontrado, recebido %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/lib/pq"
+)
+
+type FilaRepository struct {
+	db *sql.DB
+}
+
+func NewFilaRepository(db *sql.DB) *FilaRepository {
+	return &FilaRepository{db: db}
+}
+
+// colunasFila são as colunas lidas por scanFila, na mesma ordem
+const colunasFila = `id, nome, categoria, subcategoria, equipe_id, estrategia, atribuicao_automatica, ativa, data_criacao`
+
+func scanFila(row interface{ Scan(...interface{}) error }) (*fila.Fila, error) {
+	f := &fila.Fila{}
+	err := row.Scan(&f.ID, &f.Nome, &f.Categoria, &f.Subcategoria, &f.EquipeID, &f.Estrategia,
+		&f.AtribuicaoAutomatica, &f.Ativa, &f.DataCriacao)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// criar uma nova fila
+func (r *FilaRepository) Criar(f *fila.Fila) error {
+	_, err := r.db.Exec(
+		`INSERT INTO filas (`+colunasFila+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		f.ID, f.Nome, f.Categoria, f.Subcategoria, f.EquipeID, f.Estrategia,
+		f.AtribuicaoAutomatica, f.Ativa, f.DataCriacao,
+	)
+	return erroFila(err)
+}
+
+// buscar fila por id
+func (r *FilaRepository) Buscar(id string) (*fila.Fila, error) {
+	f, err := scanFila(r.db.QueryRow("SELECT "+colunasFila+" FROM filas WHERE id::text = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fila.ErrFilaNaoEncontrada
+	}
+	return f, err
+}
+
+// listar filas, por nome
+func (r *FilaRepository) Listar(apenasAtivas bool) ([]*fila.Fila, error) {
+	rows, err := r.db.Query(
+		"SELECT "+colunasFila+" FROM filas WHERE ativa OR NOT $1 ORDER BY nome",
+		apenasAtivas,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filas := []*fila.Fila{}
+	for rows.Next() {
+		f, err := scanFila(rows)
+		if err != nil {
+			return nil, err
+		}
+		filas = append(filas, f)
+	}
+	return filas, rows.Err()
+}
+
+// atualizar a configuração da fila
+func (r *FilaRepository) Atualizar(f *fila.Fila) error {
+	result, err := r.db.Exec(
+		`UPDATE filas SET nome = $1, categoria = $2, subcategoria = $3, equipe_id = $4,
+		 estrategia = $5, atribuicao_automatica = $6, ativa = $7
+		 WHERE id::text = $8`,
+		f.Nome, f.Categoria, f.Subcategoria, f.EquipeID, f.Estrategia, f.AtribuicaoAutomatica, f.Ativa, f.ID,
+	)
+	if err != nil {
+		return erroFila(err)
+	}
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas == 0 {
+		return fila.ErrFilaNaoEncontrada
+	}
+	return nil
+}
+
+// Candidatos lista os membros ativos da equipe da fila com a quantidade de tickets em aberto
+// e o início do último atendimento recebido na fila, em uma única consulta
+func (r *FilaRepository) Candidatos(filaID string) ([]fila.Candidato, error) {
+	rows, err := r.db.Query(
+		`SELECT u.id, u.habilidades,
+			(SELECT COUNT(*) FROM tickets t
+			 WHERE t.responsavel = u.id AND t.status <> ALL($2)),
+			(SELECT MAX(t.data_inicio) FROM tickets t
+			 WHERE t.responsavel = u.id AND t.fila_id = f.id)
+		FROM filas f
+		JOIN usuarios_equipes ue ON ue.equipe_id = f.equipe_id
+		JOIN usuarios u ON u.id = ue.usuario_id AND u.ativo
+		WHERE f.id::text = $1
+		ORDER BY u.id`,
+		filaID, textos([]ticket.Status{ticket.StatusFinalizado, ticket.StatusCancelado}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidatos := []fila.Candidato{}
+	for rows.Next() {
+		var c fila.Candidato
+		var habilidades pq.StringArray
+		if err := rows.Scan(&c.UsuarioID, &habilidades, &c.TicketsAbertos, &c.UltimaAtribuicao); err != nil {
+			return nil, err
+		}
+		c.Habilidades = []string(habilidades)
+		candidatos = append(candidatos, c)
+	}
+	return candidatos, rows.Err()
+}
+
+// erroFila converte as violações de restrição da tabela de filas em erros do domínio
+func erroFila(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case codigoViolacaoUnica:
+		return fila.ErrFilaDuplicada
+	case "23503", "22P02": // equipe inexistente ou id de equipe mal formado
+		return fila.ErrEquipeInvalida
+	}
+	return err
+}
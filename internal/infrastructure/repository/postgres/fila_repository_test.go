@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"testing"
+
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+)
+
+// Teste dos candidatos: membros ativos da equipe, com a carga de cada um
+func TestFilaRepository_Candidatos(t *testing.T) {
+	usuarioRepo, ticketRepo := setupUsuarioRepository(t)
+	filaRepo := NewFilaRepository(ticketRepo.db)
+
+	equipe, _ := usuario.NovaEquipe("Saques", "")
+	equipe.Nome += " " + equipe.ID[:8] // nome único entre execuções
+	if err := usuarioRepo.CriarEquipe(equipe); err != nil {
+		t.Fatalf("Erro ao criar equipe: %v", err)
+	}
+
+	ocupado, _ := usuario.NovoUsuario("", "Ocupado", "")
+	livre, _ := usuario.NovoUsuario("", "Livre", "")
+	desligado, _ := usuario.NovoUsuario("", "Desligado", "")
+	desligado.Ativo = false
+	for _, u := range []*usuario.Usuario{ocupado, livre, desligado} {
+		u.Equipes = []string{equipe.ID}
+		if err := usuarioRepo.CriarUsuario(u); err != nil {
+			t.Fatalf("Erro ao criar usuário: %v", err)
+		}
+	}
+
+	f, _ := fila.NovaFila("Fila "+equipe.Nome, ticket.CategoriaFinanceiro, "", equipe.ID, fila.EstrategiaMenosTickets)
+	if err := filaRepo.Criar(f); err != nil {
+		t.Fatalf("Erro ao criar fila: %v", err)
+	}
+
+	tk := createTestTicket()
+	tk.DefinirFila(f.ID, usuario.IDSistema)
+	if err := ticket.MaquinaDeEstadosPadrao().Aplicar(tk, ticket.StatusEmCurso, ticket.ContextoTransicao{UsuarioID: ocupado.ID, Responsavel: ocupado.ID}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	if err := ticketRepo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+
+	candidatos, err := filaRepo.Candidatos(f.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar candidatos: %v", err)
+	}
+	if len(candidatos) != 2 {
+		t.Fatalf("Esperava 2 candidatos ativos, recebido %d", len(candidatos))
+	}
+	for _, c := range candidatos {
+		switch c.UsuarioID {
+		case ocupado.ID:
+			if c.TicketsAbertos != 1 || c.UltimaAtribuicao == nil {
+				t.Errorf("Carga diferente para o ocupado: %+v", c)
+			}
+		case livre.ID:
+			if c.TicketsAbertos != 0 || c.UltimaAtribuicao != nil {
+				t.Errorf("Carga diferente para o livre: %+v", c)
+			}
+		default:
+			t.Errorf("Candidato inesperado: %s", c.UsuarioID)
+		}
+	}
+
+	salvo, err := ticketRepo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+	if salvo.FilaID != f.ID {
+		t.Errorf("Fila diferente: esperado %s, recebido %s", f.ID, salvo.FilaID)
+	}
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"nox_tickets/internal/domain/formulario"
+	"nox_tickets/internal/domain/ticket"
+)
+
+type FormularioRepository struct {
+	db *sql.DB
+}
+
+func NewFormularioRepository(db *sql.DB) *FormularioRepository {
+	return &FormularioRepository{db: db}
+}
+
+// buscar o formulário da categoria e subcategoria
+func (r *FormularioRepository) Buscar(categoria ticket.Categoria, subcategoria ticket.Subcategoria) (*formulario.Formulario, error) {
+	f := &formulario.Formulario{}
+	var esquemaJSON []byte
+	err := r.db.QueryRow(
+		`SELECT categoria, subcategoria, esquema, data_atualizacao FROM formularios WHERE categoria = $1 AND subcategoria = $2`,
+		categoria, subcategoria,
+	).Scan(&f.Categoria, &f.Subcategoria, &esquemaJSON, &f.DataAtualizacao)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, formulario.ErrFormularioNaoEncontrado
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(esquemaJSON, &f.Esquema); err != nil {
+		return nil, fmt.Errorf("erro ao converter esquema: %v", err)
+	}
+	if err := f.Esquema.Validar(); err != nil {
+		return nil, fmt.Errorf("esquema gravado do formulário %s/%s: %v", categoria, subcategoria, err)
+	}
+	return f, nil
+}
+
+// salvar cria ou substitui o formulário da categoria e subcategoria
+func (r *FormularioRepository) Salvar(f *formulario.Formulario) error {
+	esquemaJSON, err := json.Marshal(f.Esquema)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(
+		`INSERT INTO formularios (categoria, subcategoria, esquema, data_atualizacao) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (categoria, subcategoria) DO UPDATE SET esquema = EXCLUDED.esquema, data_atualizacao = EXCLUDED.data_atualizacao`,
+		f.Categoria, f.Subcategoria, esquemaJSON, f.DataAtualizacao,
+	)
+	return err
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/formulario"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Teste dos formulários: o de saque vem da migração e os campos personalizados vão para o jsonb do ticket
+func TestFormularioRepository_SalvarECamposDoTicket(t *testing.T) {
+	ticketRepo := setupTestDB(t)
+	repo := NewFormularioRepository(ticketRepo.db)
+
+	saque, err := repo.Buscar(ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque)
+	if err != nil {
+		t.Fatalf("Erro ao buscar formulário de saque: %v", err)
+	}
+	if len(saque.Esquema.Obrigatorios) != 2 || saque.Esquema.Propriedades["cpf"].Formato != formulario.FormatoCPF {
+		t.Errorf("Formulário de saque diferente: %+v", saque.Esquema)
+	}
+
+	// substituir o formulário de uma classificação
+	esquema := formulario.EsquemaVazio()
+	esquema.Propriedades["sistema"] = &formulario.Propriedade{Tipo: formulario.TipoTexto, Enum: []string{"web", "app"}}
+	f, _ := formulario.NovoFormulario(ticket.CategoriaTI, ticket.SubcategoriaBug, esquema)
+	if err := repo.Salvar(f); err != nil {
+		t.Fatalf("Erro ao salvar formulário: %v", err)
+	}
+	salvo, err := repo.Buscar(ticket.CategoriaTI, ticket.SubcategoriaBug)
+	if err != nil {
+		t.Fatalf("Erro ao buscar formulário salvo: %v", err)
+	}
+	if len(salvo.Esquema.Propriedades["sistema"].Enum) != 2 {
+		t.Errorf("Esquema salvo diferente: %+v", salvo.Esquema)
+	}
+	if _, err := repo.Buscar(ticket.CategoriaTI, "inexistente"); !errors.Is(err, formulario.ErrFormularioNaoEncontrado) {
+		t.Errorf("Esperava ErrFormularioNaoEncontrado, recebido %v", err)
+	}
+
+	// os campos personalizados são gravados e lidos com o ticket
+	testTicket := createTestTicket()
+	testTicket.SetCampos(map[string]interface{}{"valor": 99.9, "ids_transacao": []interface{}{"tx_1"}}, "usuario_teste")
+	if err := ticketRepo.Create(testTicket); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	lido, err := ticketRepo.GetByID(testTicket.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+	if lido.Campos["valor"] != 99.9 || len(lido.Campos["ids_transacao"].([]interface{})) != 1 {
+		t.Errorf("Campos diferentes: %v", lido.Campos)
+	}
+
+	lido.SetCampos(map[string]interface{}{"valor": nil}, "usuario_teste")
+	if err := ticketRepo.Update(lido); err != nil {
+		t.Fatalf("Erro ao atualizar ticket: %v", err)
+	}
+	atualizado, _ := ticketRepo.GetByID(testTicket.ID)
+	if _, ok := atualizado.Campos["valor"]; ok {
+		t.Errorf("Campo removido continua gravado: %v", atualizado.Campos)
+	}
+}
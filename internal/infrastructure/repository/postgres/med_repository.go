@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"nox_tickets/internal/domain/med"
+
+	"github.com/lib/pq"
+)
+
+type MEDRepository struct {
+	db *sql.DB
+}
+
+func NewMEDRepository(db *sql.DB) *MEDRepository {
+	return &MEDRepository{db: db}
+}
+
+// colunasCasoMED são as colunas lidas por scanCasoMED, na mesma ordem
+const colunasCasoMED = `
+	c.ticket_id::text, c.end_to_end_id, c.id_notificacao::text, c.valor, c.pagador, c.recebedor,
+	c.data_transacao, c.data_notificacao, c.situacao, c.valor_bloqueado, c.valor_devolvido,
+	c.prazo_analise, c.prazo_devolucao, c.data_bloqueio, c.data_conclusao, c.versao`
+
+// prazoVigenteMED é o prazo da etapa atual do caso; nulo nos casos encerrados
+const prazoVigenteMED = `CASE c.situacao
+	WHEN 'em_analise' THEN c.prazo_analise
+	WHEN 'fundos_bloqueados' THEN c.prazo_devolucao
+END`
+
+func scanCasoMED(row interface{ Scan(...interface{}) error }) (*med.Caso, error) {
+	c := &med.Caso{}
+	var pagador, recebedor []byte
+	err := row.Scan(
+		&c.TicketID, &c.EndToEndID, &c.IDNotificacao, &c.Valor, &pagador, &recebedor,
+		&c.DataTransacao, &c.DataNotificacao, &c.Situacao, &c.ValorBloqueado, &c.ValorDevolvido,
+		&c.PrazoAnalise, &c.PrazoDevolucao, &c.DataBloqueio, &c.DataConclusao, &c.Versao,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(pagador, &c.Pagador); err != nil {
+		return nil, fmt.Errorf("erro ao converter pagador: %v", err)
+	}
+	if err := json.Unmarshal(recebedor, &c.Recebedor); err != nil {
+		return nil, fmt.Errorf("erro ao converter recebedor: %v", err)
+	}
+	return c, nil
+}
+
+// criar o caso com os registros do histórico
+func (r *MEDRepository) Criar(c *med.Caso) error {
+	pagador, recebedor, err := participantesJSON(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`INSERT INTO med_casos (
+			ticket_id, end_to_end_id, id_notificacao, valor, pagador, recebedor,
+			data_transacao, data_notificacao, situacao, valor_bloqueado, valor_devolvido,
+			prazo_analise, prazo_devolucao, data_bloqueio, data_conclusao, versao
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		c.TicketID, c.EndToEndID, c.IDNotificacao, c.Valor, pagador, recebedor,
+		c.DataTransacao, c.DataNotificacao, c.Situacao, c.ValorBloqueado, c.ValorDevolvido,
+		c.PrazoAnalise, c.PrazoDevolucao, c.DataBloqueio, c.DataConclusao, len(c.Historico),
+	)
+	if violacaoUnica(err) {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == "med_casos_id_notificacao_key" {
+			return med.ErrNotificacaoRepetida
+		}
+		return med.ErrCasoJaExiste
+	}
+	if err != nil {
+		return err
+	}
+	if err := inserirHistoricoMED(tx, c, 0); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	c.Versao = len(c.Historico)
+	return nil
+}
+
+// buscar o caso do ticket com o histórico
+func (r *MEDRepository) BuscarPorTicket(ticketID string) (*med.Caso, error) {
+	c, err := scanCasoMED(r.db.QueryRow("SELECT "+colunasCasoMED+" FROM med_casos c WHERE c.ticket_id::text = $1", ticketID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, med.ErrCasoNaoEncontrado
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`SELECT sequencia, acao, situacao_anterior, situacao_nova, valor, justificativa, dados,
+		        fora_do_prazo, usuario_id, data, hash_anterior, hash
+		 FROM med_historico WHERE ticket_id = $1 ORDER BY sequencia`,
+		c.TicketID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reg med.Registro
+		err := rows.Scan(
+			&reg.Sequencia, &reg.Acao, &reg.SituacaoAnterior, &reg.SituacaoNova, &reg.Valor, &reg.Justificativa, &reg.Dados,
+			&reg.ForaDoPrazo, &reg.UsuarioID, &reg.Data, &reg.HashAnterior, &reg.Hash,
+		)
+		if err != nil {
+			return nil, err
+		}
+		c.Historico = append(c.Historico, reg)
+	}
+	return c, rows.Err()
+}
+
+// atualizar a situação do caso e gravar os registros novos do histórico
+func (r *MEDRepository) Atualizar(c *med.Caso) error {
+	pagador, recebedor, err := participantesJSON(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// a versão é o número de registros já gravados: se mudou, outra operação gravou antes
+	res, err := tx.Exec(
+		`UPDATE med_casos SET
+			end_to_end_id = $2, id_notificacao = $3, valor = $4, pagador = $5, recebedor = $6,
+			data_transacao = $7, data_notificacao = $8, situacao = $9, valor_bloqueado = $10, valor_devolvido = $11,
+			prazo_analise = $12, prazo_devolucao = $13, data_bloqueio = $14, data_conclusao = $15, versao = $16
+		 WHERE ticket_id = $1 AND versao = $17`,
+		c.TicketID, c.EndToEndID, c.IDNotificacao, c.Valor, pagador, recebedor,
+		c.DataTransacao, c.DataNotificacao, c.Situacao, c.ValorBloqueado, c.ValorDevolvido,
+		c.PrazoAnalise, c.PrazoDevolucao, c.DataBloqueio, c.DataConclusao, len(c.Historico), c.Versao,
+	)
+	if violacaoUnica(err) {
+		return med.ErrNotificacaoRepetida
+	}
+	if err != nil {
+		return err
+	}
+	linhas, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas == 0 {
+		return med.ErrConflito
+	}
+
+	if err := inserirHistoricoMED(tx, c, c.Versao); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	c.Versao = len(c.Historico)
+	return nil
+}
+
+// listar os casos, do prazo vigente mais próximo para o mais distante
+func (r *MEDRepository) Listar(filtro med.Filtro) ([]*med.Caso, error) {
+	q := &consulta{}
+	if len(filtro.Situacoes) > 0 {
+		q.adicionar("c.situacao = ANY(" + q.arg(textos(filtro.Situacoes)) + "::text[])")
+	}
+	if filtro.PrazoAte != nil {
+		q.adicionar(prazoVigenteMED + " <= " + q.arg(*filtro.PrazoAte))
+	}
+	if filtro.Escopo != nil {
+		q.adicionar(condicaoEscopo(q, filtro.Escopo))
+	}
+
+	rows, err := r.db.Query(
+		`SELECT `+colunasCasoMED+`
+		 FROM med_casos c
+		 JOIN tickets t ON t.id = c.ticket_id`+q.clausulaWhere()+`
+		 ORDER BY `+prazoVigenteMED+` NULLS LAST, c.data_notificacao`,
+		q.args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	casos := []*med.Caso{}
+	for rows.Next() {
+		c, err := scanCasoMED(rows)
+		if err != nil {
+			return nil, err
+		}
+		casos = append(casos, c)
+	}
+	return casos, rows.Err()
+}
+
+// inserirHistoricoMED grava os registros do histórico a partir do índice informado
+func inserirHistoricoMED(tx *sql.Tx, c *med.Caso, desde int) error {
+	for _, reg := range c.Historico[desde:] {
+		_, err := tx.Exec(
+			`INSERT INTO med_historico (
+				ticket_id, sequencia, acao, situacao_anterior, situacao_nova, valor, justificativa, dados,
+				fora_do_prazo, usuario_id, data, hash_anterior, hash
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+			c.TicketID, reg.Sequencia, reg.Acao, reg.SituacaoAnterior, reg.SituacaoNova, reg.Valor, reg.Justificativa, reg.Dados,
+			reg.ForaDoPrazo, reg.UsuarioID, reg.Data, reg.HashAnterior, reg.Hash,
+		)
+		if violacaoUnica(err) {
+			return med.ErrConflito
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func participantesJSON(c *med.Caso) ([]byte, []byte, error) {
+	pagador, err := json.Marshal(c.Pagador)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao converter pagador: %v", err)
+	}
+	recebedor, err := json.Marshal(c.Recebedor)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao converter recebedor: %v", err)
+	}
+	return pagador, recebedor, nil
+}
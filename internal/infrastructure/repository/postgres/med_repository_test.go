@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/med"
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+func dadosMEDTeste() med.Dados {
+	agora := time.Now()
+	return med.Dados{
+		EndToEndID:      "E12345678202610011230abcdefghijk",
+		IDNotificacao:   uuid.New().String(),
+		Valor:           50000,
+		Pagador:         med.Participante{Nome: "Maria", Documento: "52998224725", ISPB: "12345678"},
+		Recebedor:       med.Participante{Nome: "Loja X", Documento: "11222333000181", ISPB: "87654321"},
+		DataTransacao:   agora.Add(-48 * time.Hour),
+		DataNotificacao: agora.Add(-time.Hour),
+	}
+}
+
+// Teste do caso MED: histórico gravado e relido com a cadeia de hashes íntegra e conflito de versão
+func TestMEDRepository_CasoEHistorico(t *testing.T) {
+	ticketRepo := setupTestDB(t)
+	repo := NewMEDRepository(ticketRepo.db)
+
+	testTicket, _ := ticket.NovoTicket("MED", "Golpe do Pix", ticket.CategoriaMeds, "", "usuario_teste")
+	if err := ticketRepo.Create(testTicket); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	dados := dadosMEDTeste()
+	caso, err := med.NovoCaso(testTicket, dados, med.PrazosPadrao(), "analista", time.Now())
+	if err != nil {
+		t.Fatalf("Erro ao abrir caso: %v", err)
+	}
+	if err := repo.Criar(caso); err != nil {
+		t.Fatalf("Erro ao gravar caso: %v", err)
+	}
+	if err := repo.Criar(caso); !errors.Is(err, med.ErrCasoJaExiste) {
+		t.Errorf("Esperava ErrCasoJaExiste, recebido %v", err)
+	}
+
+	// duas decisões a partir da mesma leitura: a segunda conflita
+	lido, err := repo.BuscarPorTicket(testTicket.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar caso: %v", err)
+	}
+	concorrente, _ := repo.BuscarPorTicket(testTicket.ID)
+	lido.Aplicar(med.SituacaoFundosBloqueados, med.Decisao{Valor: 30000, Justificativa: "saldo disponível"}, "analista", time.Now())
+	if err := repo.Atualizar(lido); err != nil {
+		t.Fatalf("Erro ao atualizar caso: %v", err)
+	}
+	concorrente.Aplicar(med.SituacaoRejeitado, med.Decisao{Justificativa: "sem indícios"}, "analista", time.Now())
+	if err := repo.Atualizar(concorrente); !errors.Is(err, med.ErrConflito) {
+		t.Errorf("Esperava ErrConflito, recebido %v", err)
+	}
+
+	relido, err := repo.BuscarPorTicket(testTicket.ID)
+	if err != nil {
+		t.Fatalf("Erro ao reler caso: %v", err)
+	}
+	if relido.Situacao != med.SituacaoFundosBloqueados || relido.ValorBloqueado != 30000 || len(relido.Historico) != 2 {
+		t.Errorf("Caso relido diferente: %+v", relido)
+	}
+	if relido.Recebedor.Documento != dados.Recebedor.Documento {
+		t.Errorf("Recebedor relido diferente: %+v", relido.Recebedor)
+	}
+	if err := relido.VerificarHistorico(); err != nil {
+		t.Errorf("Histórico relido não confere: %v", err)
+	}
+
+	// a listagem por prazo encontra o caso em aberto
+	prazo := relido.PrazoDevolucao.Add(time.Minute)
+	casos, err := repo.Listar(med.Filtro{Situacoes: []med.Situacao{med.SituacaoFundosBloqueados}, PrazoAte: &prazo})
+	if err != nil {
+		t.Fatalf("Erro ao listar casos: %v", err)
+	}
+	encontrado := false
+	for _, c := range casos {
+		encontrado = encontrado || c.TicketID == testTicket.ID
+	}
+	if !encontrado {
+		t.Errorf("Caso não encontrado na listagem")
+	}
+}
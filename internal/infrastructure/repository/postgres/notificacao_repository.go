@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"nox_tickets/internal/domain/notificacao"
+
+	"github.com/lib/pq"
+)
+
+type NotificacaoRepository struct {
+	db *sql.DB
+}
+
+func NewNotificacaoRepository(db *sql.DB) *NotificacaoRepository {
+	return &NotificacaoRepository{db: db}
+}
+
+// buscar as preferências do usuário; sem linha gravada, nenhuma notificação está desativada
+func (r *NotificacaoRepository) BuscarPreferencias(usuarioID string) (*notificacao.Preferencias, error) {
+	p := &notificacao.Preferencias{UsuarioID: usuarioID, Desativadas: []notificacao.Tipo{}}
+	var desativadas pq.StringArray
+	err := r.db.QueryRow(
+		"SELECT desativadas FROM preferencias_notificacao WHERE usuario_id = $1", usuarioID,
+	).Scan(&desativadas)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range desativadas {
+		p.Desativadas = append(p.Desativadas, notificacao.Tipo(d))
+	}
+	return p, nil
+}
+
+// gravar as preferências do usuário
+func (r *NotificacaoRepository) SalvarPreferencias(p *notificacao.Preferencias) error {
+	_, err := r.db.Exec(
+		`INSERT INTO preferencias_notificacao (usuario_id, desativadas, data_atualizacao) VALUES ($1, $2, NOW())
+		 ON CONFLICT (usuario_id) DO UPDATE SET desativadas = EXCLUDED.desativadas, data_atualizacao = NOW()`,
+		p.UsuarioID, textos(p.Desativadas),
+	)
+	return err
+}
+
+// listar os seguidores do ticket, na ordem em que passaram a seguir
+func (r *NotificacaoRepository) ListarSeguidores(ticketID string) ([]string, error) {
+	rows, err := r.db.Query(
+		"SELECT usuario_id FROM ticket_seguidores WHERE ticket_id::text = $1 ORDER BY data_criacao, usuario_id",
+		ticketID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seguidores := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		seguidores = append(seguidores, id)
+	}
+	return seguidores, rows.Err()
+}
+
+// passar a seguir o ticket
+func (r *NotificacaoRepository) AdicionarSeguidor(ticketID, usuarioID string) error {
+	_, err := r.db.Exec(
+		`INSERT INTO ticket_seguidores (ticket_id, usuario_id) VALUES ($1, $2)
+		 ON CONFLICT (ticket_id, usuario_id) DO NOTHING`,
+		ticketID, usuarioID,
+	)
+	return err
+}
+
+// deixar de seguir o ticket
+func (r *NotificacaoRepository) RemoverSeguidor(ticketID, usuarioID string) error {
+	_, err := r.db.Exec(
+		"DELETE FROM ticket_seguidores WHERE ticket_id::text = $1 AND usuario_id = $2",
+		ticketID, usuarioID,
+	)
+	return err
+}
+
+// JaEnviada indica se o e-mail do evento já foi entregue ao usuário
+func (r *NotificacaoRepository) JaEnviada(eventoID, usuarioID string) (bool, error) {
+	var enviada bool
+	err := r.db.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM notificacoes_log WHERE evento_id::text = $1 AND usuario_id = $2 AND status = $3)`,
+		eventoID, usuarioID, notificacao.EnvioRealizado,
+	).Scan(&enviada)
+	return enviada, err
+}
+
+// registrar um envio no log
+func (r *NotificacaoRepository) Registrar(reg *notificacao.Registro) error {
+	_, err := r.db.Exec(
+		`INSERT INTO notificacoes_log (`+colunasRegistro+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		reg.ID, reg.EventoID, reg.TicketID, reg.UsuarioID, reg.Email, reg.Tipo, reg.Assunto, reg.Status, reg.Erro, reg.Data,
+	)
+	return err
+}
+
+// colunasRegistro são as colunas lidas por ListarRegistros, na mesma ordem
+const colunasRegistro = `id, evento_id, ticket_id, usuario_id, email, tipo, assunto, status, erro, data_envio`
+
+// ListarRegistros retorna os envios mais recentes, filtrando por usuário e ticket
+func (r *NotificacaoRepository) ListarRegistros(filtros notificacao.FiltrosRegistro) ([]*notificacao.Registro, error) {
+	condicoes := []string{"TRUE"}
+	args := []interface{}{}
+	if filtros.UsuarioID != "" {
+		args = append(args, filtros.UsuarioID)
+		condicoes = append(condicoes, fmt.Sprintf("usuario_id = $%d", len(args)))
+	}
+	if filtros.TicketID != "" {
+		args = append(args, filtros.TicketID)
+		condicoes = append(condicoes, fmt.Sprintf("ticket_id::text = $%d", len(args)))
+	}
+	query := "SELECT " + colunasRegistro + " FROM notificacoes_log WHERE " + strings.Join(condicoes, " AND ") +
+		" ORDER BY data_envio DESC"
+	if filtros.Limite > 0 {
+		args = append(args, filtros.Limite)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	registros := []*notificacao.Registro{}
+	for rows.Next() {
+		reg := &notificacao.Registro{}
+		err := rows.Scan(&reg.ID, &reg.EventoID, &reg.TicketID, &reg.UsuarioID, &reg.Email,
+			&reg.Tipo, &reg.Assunto, &reg.Status, &reg.Erro, &reg.Data)
+		if err != nil {
+			return nil, err
+		}
+		registros = append(registros, reg)
+	}
+	return registros, rows.Err()
+}
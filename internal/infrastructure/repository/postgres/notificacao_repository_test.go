@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/notificacao"
+
+	"github.com/google/uuid"
+)
+
+// Teste das preferências, dos seguidores e do log de notificações
+func TestNotificacaoRepository_PreferenciasSeguidoresELog(t *testing.T) {
+	ticketRepo := setupTestDB(t)
+	repo := NewNotificacaoRepository(ticketRepo.db)
+
+	// sem preferências gravadas, tudo fica ativo
+	preferencias, err := repo.BuscarPreferencias("usuario_teste")
+	if err != nil {
+		t.Fatalf("Erro ao buscar preferências: %v", err)
+	}
+	preferencias.Definir(notificacao.TipoStatusAlterado, false)
+	if err := repo.SalvarPreferencias(preferencias); err != nil {
+		t.Fatalf("Erro ao salvar preferências: %v", err)
+	}
+	salvas, _ := repo.BuscarPreferencias("usuario_teste")
+	if salvas.Permite(notificacao.TipoStatusAlterado) || !salvas.Permite(notificacao.TipoObservacaoAdicionada) {
+		t.Errorf("Preferências diferentes: %+v", salvas)
+	}
+	salvas.Definir(notificacao.TipoStatusAlterado, true)
+	repo.SalvarPreferencias(salvas)
+
+	// seguir duas vezes não duplica
+	tk := createTestTicket()
+	if err := ticketRepo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := repo.AdicionarSeguidor(tk.ID, "usuario_teste"); err != nil {
+			t.Fatalf("Erro ao seguir ticket: %v", err)
+		}
+	}
+	if seguidores, _ := repo.ListarSeguidores(tk.ID); len(seguidores) != 1 || seguidores[0] != "usuario_teste" {
+		t.Errorf("Seguidores diferentes: %v", seguidores)
+	}
+	repo.RemoverSeguidor(tk.ID, "usuario_teste")
+	if seguidores, _ := repo.ListarSeguidores(tk.ID); len(seguidores) != 0 {
+		t.Errorf("Esperava nenhum seguidor, recebido %v", seguidores)
+	}
+
+	// só o envio com sucesso conta como já enviado
+	eventoID := uuid.New().String()
+	for _, status := range []notificacao.StatusEnvio{notificacao.EnvioFalhou, notificacao.EnvioRealizado} {
+		enviada, _ := repo.JaEnviada(eventoID, "usuario_teste")
+		if enviada {
+			t.Errorf("Não esperava envio antes de registrar %s", status)
+		}
+		err := repo.Registrar(&notificacao.Registro{
+			ID: uuid.New().String(), EventoID: eventoID, TicketID: tk.ID, UsuarioID: "usuario_teste",
+			Email: "teste@nox.com", Tipo: notificacao.TipoObservacaoAdicionada, Assunto: "Nova observação",
+			Status: status, Data: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Erro ao registrar envio: %v", err)
+		}
+	}
+	if enviada, _ := repo.JaEnviada(eventoID, "usuario_teste"); !enviada {
+		t.Error("Esperava envio registrado")
+	}
+	registros, err := repo.ListarRegistros(notificacao.FiltrosRegistro{TicketID: tk.ID, Limite: 10})
+	if err != nil {
+		t.Fatalf("Erro ao listar registros: %v", err)
+	}
+	if len(registros) != 2 || registros[0].Status != notificacao.EnvioRealizado {
+		t.Errorf("Registros diferentes: %+v", registros)
+	}
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"nox_tickets/internal/domain/evento"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// reservaOutbox é por quanto tempo os eventos retornados por Pendentes ficam reservados
+// para o relay que os buscou, para que outra instância não os publique ao mesmo tempo
+const reservaOutbox = time.Minute
+
+// gravarEventos grava os eventos de domínio no outbox, dentro da transação da alteração do ticket.
+// O payload é o próprio ticket.Evento em JSON: ele só é lido de volta pelo relay.
+func gravarEventos(tx *sql.Tx, eventos []ticket.Evento) error {
+	if len(eventos) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(
+		`INSERT INTO outbox (id, agregado_id, tipo, payload, data_criacao, proxima_tentativa) VALUES ($1, $2, $3, $4, $5, $5)`,
+	)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range eventos {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("erro ao converter evento: %v", err)
+		}
+		if _, err := stmt.Exec(e.ID, e.Ticket.ID, e.Tipo, payload, e.Data); err != nil {
+			return fmt.Errorf("erro ao gravar evento no outbox: %v", err)
+		}
+	}
+	return nil
+}
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Pendentes reserva e retorna os eventos vencidos na ordem de gravação. Um evento só é retornado
+// se nenhum evento anterior do mesmo ticket estiver esperando nova tentativa ou reservado por outro relay.
+func (r *OutboxRepository) Pendentes(agora time.Time, limite int) ([]evento.Pendente, error) {
+	rows, err := r.db.Query(
+		`WITH reservados AS (
+			SELECT o.id FROM outbox o
+			WHERE o.data_publicacao IS NULL AND o.proxima_tentativa <= $1
+			  AND NOT EXISTS (
+				SELECT 1 FROM outbox anterior
+				WHERE anterior.agregado_id = o.agregado_id AND anterior.data_publicacao IS NULL
+				  AND anterior.sequencia < o.sequencia AND anterior.proxima_tentativa > $1
+			  )
+			ORDER BY o.sequencia
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE outbox SET proxima_tentativa = $3
+		FROM reservados WHERE outbox.id = reservados.id
+		RETURNING outbox.sequencia, outbox.payload, outbox.tentativas`,
+		agora, limite, agora.Add(reservaOutbox),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type linha struct {
+		sequencia int64
+		pendente  evento.Pendente
+	}
+	linhas := []linha{}
+	for rows.Next() {
+		var l linha
+		var payload []byte
+		if err := rows.Scan(&l.sequencia, &payload, &l.pendente.Tentativas); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &l.pendente.Evento); err != nil {
+			return nil, fmt.Errorf("erro ao converter evento do outbox: %v", err)
+		}
+		linhas = append(linhas, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// o RETURNING não garante a ordem
+	sort.Slice(linhas, func(i, j int) bool { return linhas[i].sequencia < linhas[j].sequencia })
+	pendentes := make([]evento.Pendente, len(linhas))
+	for i, l := range linhas {
+		pendentes[i] = l.pendente
+	}
+	return pendentes, nil
+}
+
+// MarcarPublicado registra a publicação do evento
+func (r *OutboxRepository) MarcarPublicado(id string, agora time.Time) error {
+	_, err := r.db.Exec(
+		`UPDATE outbox SET data_publicacao = $1, tentativas = tentativas + 1, ultimo_erro = '' WHERE id = $2`,
+		agora, id,
+	)
+	return err
+}
+
+// RegistrarFalha conta a tentativa e reagenda o evento
+func (r *OutboxRepository) RegistrarFalha(id string, erro string, proximaTentativa time.Time) error {
+	_, err := r.db.Exec(
+		`UPDATE outbox SET tentativas = tentativas + 1, ultimo_erro = $1, proxima_tentativa = $2 WHERE id = $3`,
+		erro, proximaTentativa, id,
+	)
+	return err
+}
+
+// Adiar libera a reserva do evento para a data informada
+func (r *OutboxRepository) Adiar(id string, proximaTentativa time.Time) error {
+	_, err := r.db.Exec(`UPDATE outbox SET proxima_tentativa = $1 WHERE id = $2`, proximaTentativa, id)
+	return err
+}
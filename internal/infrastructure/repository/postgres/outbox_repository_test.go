@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+)
+
+// Teste do outbox: os eventos são gravados com a alteração e somem junto com ela no conflito
+func TestOutboxRepository_EventosNaTransacao(t *testing.T) {
+	repo := setupTestDB(t)
+	outbox := NewOutboxRepository(repo.db)
+
+	tk := createTestTicket()
+	if err := repo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	tk.AdicionarObservacao("Primeira observação", "usuario_teste")
+	if err := repo.Update(tk); err != nil {
+		t.Fatalf("Erro ao atualizar ticket: %v", err)
+	}
+
+	// uma gravação com versão desatualizada não deixa eventos no outbox
+	desatualizado, _ := repo.GetByID(tk.ID)
+	desatualizado.Versao--
+	desatualizado.SetUrgencia(5, "usuario_teste")
+	if err := repo.Update(desatualizado); err == nil {
+		t.Fatal("Esperava conflito de versão")
+	}
+
+	pendentes, err := outbox.Pendentes(time.Now().Add(time.Second), 10000)
+	if err != nil {
+		t.Fatalf("Erro ao buscar pendentes: %v", err)
+	}
+	tipos := []ticket.TipoEvento{}
+	for _, p := range pendentes {
+		if p.Evento.Ticket.ID == tk.ID {
+			tipos = append(tipos, p.Evento.Tipo)
+			if err := outbox.MarcarPublicado(p.Evento.ID, time.Now()); err != nil {
+				t.Fatalf("Erro ao marcar evento publicado: %v", err)
+			}
+		}
+	}
+	if len(tipos) != 2 || tipos[0] != ticket.EventoTicketCriado || tipos[1] != ticket.EventoObservacaoAdicionada {
+		t.Errorf("Esperava ticket.criado e ticket.observacao_adicionada, recebido %v", tipos)
+	}
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/lib/pq"
+)
+
+type TaxonomiaRepository struct {
+	db *sql.DB
+}
+
+func NewTaxonomiaRepository(db *sql.DB) *TaxonomiaRepository {
+	return &TaxonomiaRepository{db: db}
+}
+
+// criar a categoria com as subcategorias
+func (r *TaxonomiaRepository) Criar(c *taxonomia.Categoria) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`INSERT INTO categorias (codigo, nome, ativa, data_criacao) VALUES ($1, $2, $3, $4)`,
+		c.Codigo, c.Nome, c.Ativa, c.DataCriacao,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == codigoViolacaoUnica {
+			return taxonomia.ErrCategoriaDuplicada
+		}
+		return err
+	}
+	if err := salvarSubcategorias(tx, c); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// buscar categoria pelo código, com as subcategorias
+func (r *TaxonomiaRepository) Buscar(codigo ticket.Categoria) (*taxonomia.Categoria, error) {
+	c := &taxonomia.Categoria{}
+	err := r.db.QueryRow(
+		`SELECT codigo, nome, ativa, data_criacao FROM categorias WHERE codigo = $1`, codigo,
+	).Scan(&c.Codigo, &c.Nome, &c.Ativa, &c.DataCriacao)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, taxonomia.ErrCategoriaNaoEncontrada
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	porCodigo := map[ticket.Categoria]*taxonomia.Categoria{c.Codigo: c}
+	if err := r.carregarSubcategorias(porCodigo); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// listar categorias por nome, com as subcategorias
+func (r *TaxonomiaRepository) Listar(apenasAtivas bool) ([]*taxonomia.Categoria, error) {
+	rows, err := r.db.Query(
+		`SELECT codigo, nome, ativa, data_criacao FROM categorias WHERE ativa OR NOT $1 ORDER BY nome`,
+		apenasAtivas,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categorias := []*taxonomia.Categoria{}
+	porCodigo := map[ticket.Categoria]*taxonomia.Categoria{}
+	for rows.Next() {
+		c := &taxonomia.Categoria{}
+		if err := rows.Scan(&c.Codigo, &c.Nome, &c.Ativa, &c.DataCriacao); err != nil {
+			return nil, err
+		}
+		categorias = append(categorias, c)
+		porCodigo[c.Codigo] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.carregarSubcategorias(porCodigo); err != nil {
+		return nil, err
+	}
+	return categorias, nil
+}
+
+// atualizar nome e situação da categoria e gravar as subcategorias
+func (r *TaxonomiaRepository) Atualizar(c *taxonomia.Categoria) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`UPDATE categorias SET nome = $1, ativa = $2 WHERE codigo = $3`,
+		c.Nome, c.Ativa, c.Codigo,
+	)
+	if err != nil {
+		return err
+	}
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas == 0 {
+		return taxonomia.ErrCategoriaNaoEncontrada
+	}
+	if err := salvarSubcategorias(tx, c); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// carregarSubcategorias lê as subcategorias das categorias informadas em uma única consulta
+func (r *TaxonomiaRepository) carregarSubcategorias(porCodigo map[ticket.Categoria]*taxonomia.Categoria) error {
+	if len(porCodigo) == 0 {
+		return nil
+	}
+	codigos := make([]string, 0, len(porCodigo))
+	for codigo := range porCodigo {
+		codigos = append(codigos, string(codigo))
+	}
+
+	rows, err := r.db.Query(
+		`SELECT categoria, codigo, nome, ativa FROM subcategorias WHERE categoria = ANY($1) ORDER BY nome`,
+		pq.Array(codigos),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var categoria ticket.Categoria
+		var s taxonomia.Subcategoria
+		if err := rows.Scan(&categoria, &s.Codigo, &s.Nome, &s.Ativa); err != nil {
+			return err
+		}
+		c := porCodigo[categoria]
+		c.Subcategorias = append(c.Subcategorias, s)
+	}
+	return rows.Err()
+}
+
+// salvarSubcategorias insere as subcategorias novas e atualiza as existentes
+func salvarSubcategorias(tx *sql.Tx, c *taxonomia.Categoria) error {
+	for _, s := range c.Subcategorias {
+		_, err := tx.Exec(
+			`INSERT INTO subcategorias (categoria, codigo, nome, ativa) VALUES ($1, $2, $3, $4)
+			 ON CONFLICT (categoria, codigo) DO UPDATE SET nome = EXCLUDED.nome, ativa = EXCLUDED.ativa`,
+			c.Codigo, s.Codigo, s.Nome, s.Ativa,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"nox_tickets/internal/domain/taxonomia"
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+// Teste da taxonomia: categorias iniciais da migração, criação e atualização de subcategorias
+func TestTaxonomiaRepository_CriarEAtualizar(t *testing.T) {
+	ticketRepo := setupTestDB(t)
+	repo := NewTaxonomiaRepository(ticketRepo.db)
+	validador := taxonomia.NovoValidador(repo)
+
+	// as categorias e os pares da lista antiga vêm da migração
+	if err := validador.ValidarClassificacao(ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque); err != nil {
+		t.Errorf("Esperava financeiro/solicitacao_de_saque válido: %v", err)
+	}
+	if err := validador.ValidarClassificacao(ticket.CategoriaTI, ticket.SubcategoriaSolicitacaoSaque); !errors.Is(err, ticket.ErrSubcategoriaInvalida) {
+		t.Errorf("Esperava ErrSubcategoriaInvalida para ti/solicitacao_de_saque, recebido %v", err)
+	}
+
+	codigo := ticket.Categoria("teste_" + strings.ReplaceAll(uuid.New().String()[:8], "-", ""))
+	c, _ := taxonomia.NovaCategoria(codigo, "Categoria de teste")
+	c.AdicionarSubcategoria("geral", "Geral")
+	if err := repo.Criar(c); err != nil {
+		t.Fatalf("Erro ao criar categoria: %v", err)
+	}
+	if err := repo.Criar(c); !errors.Is(err, taxonomia.ErrCategoriaDuplicada) {
+		t.Errorf("Esperava ErrCategoriaDuplicada, recebido %v", err)
+	}
+
+	// nova subcategoria e desativação de outra
+	c.AdicionarSubcategoria("urgente", "Urgente")
+	geral, _ := c.Subcategoria("geral")
+	geral.Ativa = false
+	if err := repo.Atualizar(c); err != nil {
+		t.Fatalf("Erro ao atualizar categoria: %v", err)
+	}
+
+	buscada, err := repo.Buscar(codigo)
+	if err != nil {
+		t.Fatalf("Erro ao buscar categoria: %v", err)
+	}
+	if buscada.Nome != "Categoria de teste" || len(buscada.Subcategorias) != 2 {
+		t.Errorf("Categoria diferente: %+v", buscada)
+	}
+	if err := validador.ValidarClassificacao(codigo, "geral"); !errors.Is(err, ticket.ErrSubcategoriaInvalida) {
+		t.Errorf("Esperava subcategoria desativada inválida, recebido %v", err)
+	}
+	if err := validador.ValidarClassificacao(codigo, "urgente"); err != nil {
+		t.Errorf("Esperava subcategoria nova válida: %v", err)
+	}
+
+	categorias, err := repo.Listar(true)
+	if err != nil {
+		t.Fatalf("Erro ao listar categorias: %v", err)
+	}
+	encontrada := false
+	for _, c := range categorias {
+		encontrada = encontrada || c.Codigo == codigo
+	}
+	if !encontrada {
+		t.Error("Categoria criada não apareceu na listagem")
+	}
+
+	if _, err := repo.Buscar("inexistente"); !errors.Is(err, taxonomia.ErrCategoriaNaoEncontrada) {
+		t.Errorf("Esperava ErrCategoriaNaoEncontrada, recebido %v", err)
+	}
+}
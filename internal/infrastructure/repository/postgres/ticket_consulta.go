@@ -0,0 +1,483 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/lib/pq"
+)
+
+// colunasTicket são as colunas lidas por scanTicket, na mesma ordem
+const colunasTicket = `
+	id, titulo, merchant, nox_id, cpf, status, categoria,
+	subcategoria, descricao, urgencia, gravidade,
+	aberto_por, COALESCE(responsavel, ''), contato, plataforma,
+	data_abertura, data_inicio, data_conclusao,
+	duracao_total::text, duracao_execucao::text,
+	sla_prazo_primeira_resposta, sla_prazo_resolucao, data_primeira_resposta, sla_violado,
+	duracao_total_corrida::text, duracao_execucao_corrida::text, versao, COALESCE(fila_id::text, ''), campos`
+
+// scanTicket lê uma linha com as colunas de colunasTicket
+func scanTicket(row interface{ Scan(...interface{}) error }) (*ticket.Ticket, error) {
+	t := &ticket.Ticket{}
+	var duracaoTotalStr, duracaoExecucaoStr string
+	var duracaoTotalCorridaStr, duracaoExecucaoCorridaStr string
+	var camposJSON []byte
+
+	err := row.Scan(
+		&t.ID, &t.Titulo, &t.Merchant, &t.NoxID, &t.CPF, &t.Status, &t.Categoria,
+		&t.Subcategoria, &t.Descricao, &t.Urgencia, &t.Gravidade,
+		&t.AbertoPor, &t.Responsavel, &t.Contato, &t.Plataforma,
+		&t.DataAbertura, &t.DataInicio, &t.DataConclusao,
+		&duracaoTotalStr, &duracaoExecucaoStr,
+		&t.SLA.PrazoPrimeiraResposta, &t.SLA.PrazoResolucao, &t.SLA.DataPrimeiraResposta, &t.SLA.Violado,
+		&duracaoTotalCorridaStr, &duracaoExecucaoCorridaStr, &t.Versao, &t.FilaID, &camposJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(camposJSON, &t.Campos); err != nil {
+		return nil, fmt.Errorf("erro ao converter campos: %v", err)
+	}
+
+	// Converte as durações
+	duracoes := []struct {
+		nome    string
+		valor   string
+		destino *time.Duration
+	}{
+		{"duracao_total", duracaoTotalStr, &t.DuracaoTotal},
+		{"duracao_execucao", duracaoExecucaoStr, &t.DuracaoExecucao},
+		{"duracao_total_corrida", duracaoTotalCorridaStr, &t.DuracaoTotalCorrida},
+		{"duracao_execucao_corrida", duracaoExecucaoCorridaStr, &t.DuracaoExecucaoCorrida},
+	}
+	for _, d := range duracoes {
+		*d.destino, err = parsePostgresInterval(d.valor)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao converter %s: %v", d.nome, err)
+		}
+	}
+
+	return t, nil
+}
+
+// consulta acumula as condições do WHERE e seus argumentos posicionais
+type consulta struct {
+	where []string
+	args  []interface{}
+}
+
+// arg adiciona um argumento e retorna o placeholder correspondente
+func (c *consulta) arg(valor interface{}) string {
+	c.args = append(c.args, valor)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *consulta) adicionar(condicao string) {
+	c.where = append(c.where, condicao)
+}
+
+// clausulaWhere monta o WHERE com todas as condições, ou vazio se não houver nenhuma
+func (c *consulta) clausulaWhere() string {
+	if len(c.where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.where, " AND ")
+}
+
+// montarFiltros converte os filtros de domínio em condições SQL
+func montarFiltros(filtros ticket.TicketFiltros) *consulta {
+	c := &consulta{}
+
+	if len(filtros.Status) > 0 {
+		placeholders := make([]string, len(filtros.Status))
+		for i, s := range filtros.Status {
+			placeholders[i] = c.arg(s)
+		}
+		c.adicionar(fmt.Sprintf("status = ANY(ARRAY[%s])", strings.Join(placeholders, ",")))
+	}
+
+	if len(filtros.Categoria) > 0 {
+		condicoes := make([]string, len(filtros.Categoria))
+		for i, cat := range filtros.Categoria {
+			condicoes[i] = "categoria ILIKE " + c.arg(string(cat))
+		}
+		c.adicionar(fmt.Sprintf("(%s)", strings.Join(condicoes, " OR ")))
+	}
+
+	if len(filtros.Subcategoria) > 0 {
+		placeholders := make([]string, len(filtros.Subcategoria))
+		for i, sub := range filtros.Subcategoria {
+			placeholders[i] = c.arg(string(sub))
+		}
+		c.adicionar(fmt.Sprintf("subcategoria = ANY(ARRAY[%s])", strings.Join(placeholders, ",")))
+	}
+
+	if filtros.Responsavel != "" {
+		c.adicionar("responsavel = " + c.arg(filtros.Responsavel))
+	}
+
+	if filtros.AbertoPor != "" {
+		c.adicionar("aberto_por = " + c.arg(filtros.AbertoPor))
+	}
+
+	if filtros.FilaID != "" {
+		c.adicionar("fila_id::text = " + c.arg(filtros.FilaID))
+	}
+
+	if filtros.Merchant != "" {
+		c.adicionar("merchant ILIKE " + c.arg(filtros.Merchant))
+	}
+
+	if filtros.NoxID != "" {
+		c.adicionar("nox_id = " + c.arg(filtros.NoxID))
+	}
+
+	if filtros.Plataforma != "" {
+		c.adicionar("plataforma ILIKE " + c.arg(filtros.Plataforma))
+	}
+
+	// períodos de abertura e de conclusão
+	if !filtros.DataInicio.IsZero() {
+		c.adicionar("data_abertura >= " + c.arg(filtros.DataInicio))
+	}
+	if !filtros.DataFim.IsZero() {
+		c.adicionar("data_abertura <= " + c.arg(filtros.DataFim))
+	}
+	if !filtros.DataConclusaoInicio.IsZero() {
+		c.adicionar("data_conclusao >= " + c.arg(filtros.DataConclusaoInicio))
+	}
+	if !filtros.DataConclusaoFim.IsZero() {
+		c.adicionar("data_conclusao <= " + c.arg(filtros.DataConclusaoFim))
+	}
+
+	// urgência e gravidade, por valor exato ou por faixa
+	faixas := []struct {
+		coluna     string
+		comparador string
+		valor      *int
+	}{
+		{"urgencia", "=", filtros.Urgencia},
+		{"urgencia", ">=", filtros.UrgenciaMin},
+		{"urgencia", "<=", filtros.UrgenciaMax},
+		{"gravidade", "=", filtros.Gravidade},
+		{"gravidade", ">=", filtros.GravidadeMin},
+		{"gravidade", "<=", filtros.GravidadeMax},
+	}
+	for _, f := range faixas {
+		if f.valor != nil {
+			c.adicionar(fmt.Sprintf("%s %s %s", f.coluna, f.comparador, c.arg(*f.valor)))
+		}
+	}
+
+	// o SLA está violado se foi marcado ao responder/concluir, ou se um prazo em aberto já passou
+	if filtros.SLAViolado != nil {
+		condicao := `COALESCE(sla_violado
+			OR (data_primeira_resposta IS NULL AND sla_prazo_primeira_resposta < NOW())
+			OR (data_conclusao IS NULL AND status <> 'cancelado' AND sla_prazo_resolucao < NOW()), FALSE)`
+		if !*filtros.SLAViolado {
+			condicao = "NOT " + condicao
+		}
+		c.adicionar(condicao)
+	}
+
+	// tickets parados: o início da contagem é a entrada no status atual e, se pedido, a última observação
+	inicios := []string{
+		"data_abertura::timestamptz",
+		`(SELECT MAX(ms.data_modificacao::timestamptz) FROM modificacoes ms
+			WHERE ms.ticket_id = tickets.id AND ms.campo_modificado = 'status' AND ms.valor_novo = tickets.status::text)`,
+	}
+	if filtros.ParadosSemObservacao {
+		inicios = append(inicios, "(SELECT MAX(o.data_criacao::timestamptz) FROM observacoes o WHERE o.ticket_id = tickets.id)")
+	}
+	inicio := "GREATEST(" + strings.Join(inicios, ", ") + ")"
+	if !filtros.ParadosAntesDe.IsZero() {
+		c.adicionar(inicio + " <= " + c.arg(filtros.ParadosAntesDe))
+	}
+	if filtros.SemEscalonamento != "" {
+		c.adicionar(fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM modificacoes me
+			WHERE me.ticket_id = tickets.id AND me.campo_modificado = 'escalonamento' AND me.valor_novo = %s
+			AND me.data_modificacao::timestamptz >= %s)`, c.arg(filtros.SemEscalonamento), inicio))
+	}
+
+	// prazos de SLA em aberto que ainda não foram alertados
+	if filtros.PrazoSemAlerta {
+		semAlerta := `NOT EXISTS (SELECT 1 FROM modificacoes ma
+			WHERE ma.ticket_id = tickets.id AND ma.campo_modificado = 'alerta_sla' AND ma.valor_novo = %s)`
+		c.adicionar(fmt.Sprintf(`((sla_prazo_primeira_resposta IS NOT NULL AND data_primeira_resposta IS NULL AND `+semAlerta+`)
+			OR (sla_prazo_resolucao IS NOT NULL AND data_conclusao IS NULL AND status <> 'cancelado' AND `+semAlerta+`))`,
+			c.arg(ticket.PrazoPrimeiraResposta), c.arg(ticket.PrazoResolucao)))
+	}
+
+	// escopo de acesso do usuário
+	if filtros.Escopo != nil {
+		c.adicionar(condicaoEscopo(c, filtros.Escopo))
+	}
+
+	return c
+}
+
+// textos converte uma lista de categorias ou subcategorias em um array text[], nunca nulo
+func textos[T ~string](valores []T) pq.StringArray {
+	resultado := pq.StringArray{}
+	for _, v := range valores {
+		resultado = append(resultado, string(v))
+	}
+	return resultado
+}
+
+// condicaoEscopo monta a condição que deixa apenas os tickets visíveis para o usuário
+func condicaoEscopo(c *consulta, e *ticket.EscopoAcesso) string {
+	condicoes := []string{}
+
+	if e.UsuarioID != "" {
+		u := c.arg(e.UsuarioID)
+		condicoes = append(condicoes, fmt.Sprintf("aberto_por = %s OR responsavel = %s", u, u))
+	}
+	if len(e.Categorias) > 0 {
+		condicoes = append(condicoes, "categoria = ANY("+c.arg(textos(e.Categorias))+"::text[])")
+	}
+	if e.NaoRestritos {
+		condicoes = append(condicoes, fmt.Sprintf(
+			"(categoria <> ALL(%s::text[]) AND COALESCE(subcategoria, '') <> ALL(%s::text[]))",
+			c.arg(textos(e.CategoriasRestritas)), c.arg(textos(e.SubcategoriasRestritas)),
+		))
+	}
+
+	if len(condicoes) == 0 {
+		return "FALSE"
+	}
+	return "(" + strings.Join(condicoes, " OR ") + ")"
+}
+
+// colunaOrdenacao é a expressão SQL de um campo de ordenação e o tipo usado para comparar o cursor
+type colunaOrdenacao struct {
+	expressao string
+	tipo      string
+}
+
+var colunasOrdenacao = map[ticket.CampoOrdenacao]colunaOrdenacao{
+	ticket.OrdenarPorDataAbertura: {"data_abertura", "timestamptz"},
+	ticket.OrdenarPorUrgencia:     {"urgencia", "integer"},
+	ticket.OrdenarPorGravidade:    {"gravidade", "integer"},
+	// tickets sem prazo de SLA ficam por último na ordem crescente
+	ticket.OrdenarPorPrazoSLA: {"COALESCE(sla_prazo_resolucao, 'infinity'::timestamptz)", "timestamptz"},
+}
+
+// ordenacaoPadrao lista primeiro os tickets abertos mais recentemente
+var ordenacaoPadrao = ticket.Ordenacao{Campo: ticket.OrdenarPorDataAbertura, Descendente: true}
+
+// cursor guarda a posição do último ticket de uma página
+type cursor struct {
+	Campo       ticket.CampoOrdenacao `json:"c"`
+	Descendente bool                  `json:"d"`
+	Valor       string                `json:"v"`
+	ID          string                `json:"id"`
+}
+
+func codificarCursor(c cursor) string {
+	conteudo, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(conteudo)
+}
+
+func decodificarCursor(valor string, ordenacao ticket.Ordenacao) (cursor, error) {
+	var c cursor
+	conteudo, err := base64.RawURLEncoding.DecodeString(valor)
+	if err != nil {
+		return c, ticket.ErrCursorInvalido
+	}
+	if err := json.Unmarshal(conteudo, &c); err != nil {
+		return c, ticket.ErrCursorInvalido
+	}
+	// o cursor só vale para a mesma ordenação em que foi gerado
+	if c.Campo != ordenacao.Campo || c.Descendente != ordenacao.Descendente {
+		return c, ticket.ErrCursorInvalido
+	}
+	return c, nil
+}
+
+// valorOrdenacao retorna o valor do campo de ordenação do ticket, no formato aceito pelo Postgres
+func valorOrdenacao(t *ticket.Ticket, campo ticket.CampoOrdenacao) string {
+	switch campo {
+	case ticket.OrdenarPorUrgencia:
+		return strconv.Itoa(t.Urgencia)
+	case ticket.OrdenarPorGravidade:
+		return strconv.Itoa(t.Gravidade)
+	case ticket.OrdenarPorPrazoSLA:
+		if t.SLA.PrazoResolucao == nil {
+			return "infinity"
+		}
+		return t.SLA.PrazoResolucao.Format(time.RFC3339Nano)
+	default:
+		return t.DataAbertura.Format(time.RFC3339Nano)
+	}
+}
+
+// listarPagina executa a listagem paginada e a contagem total, ambas no Postgres. Com completos,
+// os tickets vêm com todos os filhos e a contagem é dispensada
+func listarPagina(db *sql.DB, filtros ticket.TicketFiltros, completos bool) (*ticket.ResultadoLista, error) {
+	ordenacao := filtros.Ordenacao
+	if ordenacao.Campo == "" {
+		ordenacao = ordenacaoPadrao
+	}
+	coluna, ok := colunasOrdenacao[ordenacao.Campo]
+	if !ok {
+		return nil, ticket.ErrOrdenacaoInvalida
+	}
+
+	// 1. total de tickets que atendem aos filtros
+	c := montarFiltros(filtros)
+	var total int
+	if !completos {
+		if err := db.QueryRow("SELECT COUNT(*) FROM tickets"+c.clausulaWhere(), c.args...).Scan(&total); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. posição de início da página: cursor (keyset) ou deslocamento
+	if filtros.Cursor != "" {
+		cur, err := decodificarCursor(filtros.Cursor, ordenacao)
+		if err != nil {
+			return nil, err
+		}
+		comparador := ">"
+		if ordenacao.Descendente {
+			comparador = "<"
+		}
+		c.adicionar(fmt.Sprintf("(%s, id) %s (%s::%s, %s)",
+			coluna.expressao, comparador, c.arg(cur.Valor), coluna.tipo, c.arg(cur.ID)))
+	}
+
+	direcao := "ASC"
+	if ordenacao.Descendente {
+		direcao = "DESC"
+	}
+	colunas, scan := colunasTicket, scanTicket
+	if completos {
+		colunas, scan = colunasTicket+","+colunasFilhos, scanTicketCompleto
+	}
+	query := "SELECT " + colunas + " FROM tickets" + c.clausulaWhere() +
+		fmt.Sprintf(" ORDER BY %s %s, id %s", coluna.expressao, direcao, direcao)
+
+	if filtros.Limite > 0 {
+		query += " LIMIT " + c.arg(filtros.Limite)
+	}
+	if filtros.Cursor == "" && filtros.Deslocamento > 0 {
+		query += " OFFSET " + c.arg(filtros.Deslocamento)
+	}
+
+	// 3. busca a página
+	rows, err := db.Query(query, c.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resultado := &ticket.ResultadoLista{Tickets: []*ticket.Ticket{}, Total: total}
+	for rows.Next() {
+		t, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		resultado.Tickets = append(resultado.Tickets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 4. cursor para a próxima página, se a página veio cheia
+	if filtros.Limite > 0 && len(resultado.Tickets) == filtros.Limite {
+		ultimo := resultado.Tickets[len(resultado.Tickets)-1]
+		resultado.ProximoCursor = codificarCursor(cursor{
+			Campo:       ordenacao.Campo,
+			Descendente: ordenacao.Descendente,
+			Valor:       valorOrdenacao(ultimo, ordenacao.Campo),
+			ID:          ultimo.ID,
+		})
+	}
+
+	return resultado, nil
+}
+
+// configuração de busca textual criada na migration 000007 (português, sem acentos)
+const configuracaoBusca = "portuguese_unaccent"
+
+// pesquisar executa a busca textual com ranking e trechos destacados
+func pesquisar(db *sql.DB, texto string, filtros ticket.TicketFiltros) (*ticket.ResultadoPesquisa, error) {
+	c := montarFiltros(filtros)
+	consultaBusca := fmt.Sprintf("websearch_to_tsquery('%s', %s)", configuracaoBusca, c.arg(texto))
+	c.adicionar("busca @@ " + consultaBusca)
+
+	// 1. total de tickets encontrados
+	var total int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tickets"+c.clausulaWhere(), c.args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	// 2. página ordenada por relevância; os trechos só são gerados para os tickets da página
+	paginacao := ""
+	if filtros.Limite > 0 {
+		paginacao += " LIMIT " + c.arg(filtros.Limite)
+	}
+	if filtros.Deslocamento > 0 {
+		paginacao += " OFFSET " + c.arg(filtros.Deslocamento)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s,
+			pagina.relevancia,
+			ts_headline('%s', titulo, %s, 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+			ts_headline('%s',
+				descricao || ' ' || COALESCE((SELECT string_agg(o.descricao, ' ') FROM observacoes o WHERE o.ticket_id = pagina.id), ''),
+				%s, 'MaxFragments=2, MaxWords=30, MinWords=10, StartSel=<mark>, StopSel=</mark>')
+		FROM (
+			SELECT *, ts_rank_cd(busca, %s) AS relevancia
+			FROM tickets%s
+			ORDER BY relevancia DESC, data_abertura DESC, id
+			%s
+		) pagina
+		ORDER BY pagina.relevancia DESC, pagina.data_abertura DESC, pagina.id`,
+		colunasTicket, configuracaoBusca, consultaBusca, configuracaoBusca, consultaBusca,
+		consultaBusca, c.clausulaWhere(), paginacao,
+	)
+
+	rows, err := db.Query(query, c.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resultado := &ticket.ResultadoPesquisa{Itens: []ticket.ItemPesquisa{}, Total: total}
+	for rows.Next() {
+		var item ticket.ItemPesquisa
+		t, err := scanTicket(linhaComExtras{rows, []interface{}{&item.Relevancia, &item.TituloDestacado, &item.Trecho}})
+		if err != nil {
+			return nil, err
+		}
+		item.Ticket = t
+		resultado.Itens = append(resultado.Itens, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return resultado, nil
+}
+
+// linhaComExtras permite usar scanTicket em consultas com colunas adicionais ao final
+type linhaComExtras struct {
+	row    interface{ Scan(...interface{}) error }
+	extras []interface{}
+}
+
+func (l linhaComExtras) Scan(dest ...interface{}) error {
+	return l.row.Scan(append(dest, l.extras...)...)
+}
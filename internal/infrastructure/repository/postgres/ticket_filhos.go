@@ -0,0 +1,335 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+
+	"github.com/lib/pq"
+)
+
+// colunasFilhos agregam observações, modificações, pausas e aprovações do ticket em colunas JSON,
+// lidas por scanTicketCompleto depois das colunas de colunasTicket, para carregar tudo em uma única ida ao banco
+const colunasFilhos = `
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', o.id, 'usuario_id', o.usuario_id, 'descricao', o.descricao,
+				'data_criacao', o.data_criacao::timestamptz
+			) ORDER BY o.data_criacao)
+			FROM observacoes o WHERE o.ticket_id = tickets.id
+		), '[]'),
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', m.id, 'usuario_id', m.usuario_id, 'campo_modificado', m.campo_modificado,
+				'valor_anterior', m.valor_anterior, 'valor_novo', m.valor_novo,
+				'data_modificacao', m.data_modificacao::timestamptz
+			) ORDER BY m.data_modificacao)
+			FROM modificacoes m WHERE m.ticket_id = tickets.id
+		), '[]'),
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', p.id, 'status', p.status, 'motivo', p.motivo, 'usuario_id', p.usuario_id,
+				'inicio', p.inicio, 'fim', p.fim
+			) ORDER BY p.inicio)
+			FROM pausas p WHERE p.ticket_id = tickets.id
+		), '[]'),
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', a.id, 'etapa', a.etapa, 'decisao', a.decisao, 'comentario', a.comentario,
+				'valor', a.valor, 'usuario_id', a.usuario_id, 'data', a.data
+			) ORDER BY a.data)
+			FROM aprovacoes a WHERE a.ticket_id = tickets.id
+		), '[]')`
+
+// consultaTicketCompleto lê o ticket com todos os filhos
+var consultaTicketCompleto = "SELECT " + colunasTicket + "," + colunasFilhos + " FROM tickets WHERE id = $1"
+
+// formato intermediário dos filhos agregados em JSON
+type observacaoJSON struct {
+	ID          string    `json:"id"`
+	UsuarioID   string    `json:"usuario_id"`
+	Descricao   string    `json:"descricao"`
+	DataCriacao time.Time `json:"data_criacao"`
+}
+
+type modificacaoJSON struct {
+	ID              string    `json:"id"`
+	UsuarioID       string    `json:"usuario_id"`
+	CampoModificado string    `json:"campo_modificado"`
+	ValorAnterior   *string   `json:"valor_anterior"`
+	ValorNovo       *string   `json:"valor_novo"`
+	DataModificacao time.Time `json:"data_modificacao"`
+}
+
+type pausaJSON struct {
+	ID        string        `json:"id"`
+	Status    ticket.Status `json:"status"`
+	Motivo    string        `json:"motivo"`
+	UsuarioID string        `json:"usuario_id"`
+	Inicio    time.Time     `json:"inicio"`
+	Fim       *time.Time    `json:"fim"`
+}
+
+type aprovacaoJSON struct {
+	ID         string                  `json:"id"`
+	Etapa      string                  `json:"etapa"`
+	Decisao    ticket.DecisaoAprovacao `json:"decisao"`
+	Comentario string                  `json:"comentario"`
+	Valor      int64                   `json:"valor"`
+	UsuarioID  string                  `json:"usuario_id"`
+	Data       time.Time               `json:"data"`
+}
+
+// buscarPorID carrega o ticket com todos os filhos
+func buscarPorID(db *sql.DB, id string) (*ticket.Ticket, error) {
+	t, err := scanTicketCompleto(db.QueryRow(consultaTicketCompleto, id))
+	if err == sql.ErrNoRows {
+		return nil, ticket.ErrNaoEncontrado
+	}
+	return t, err
+}
+
+// scanTicketCompleto lê uma linha com as colunas de colunasTicket seguidas das de colunasFilhos
+func scanTicketCompleto(row interface{ Scan(...interface{}) error }) (*ticket.Ticket, error) {
+	var observacoesJSON, modificacoesJSON, pausasJSON, aprovacoesJSON []byte
+
+	t, err := scanTicket(linhaComExtras{
+		row,
+		[]interface{}{&observacoesJSON, &modificacoesJSON, &pausasJSON, &aprovacoesJSON},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Converte as observações
+	var observacoes []observacaoJSON
+	if err := json.Unmarshal(observacoesJSON, &observacoes); err != nil {
+		return nil, fmt.Errorf("erro ao converter observacoes: %v", err)
+	}
+	for _, o := range observacoes {
+		t.Observacoes = append(t.Observacoes, ticket.Observacao{
+			ID:          o.ID,
+			TicketID:    t.ID,
+			UsuarioID:   o.UsuarioID,
+			Descricao:   o.Descricao,
+			DataCriacao: o.DataCriacao,
+		})
+	}
+
+	// Converte as modificações
+	var modificacoes []modificacaoJSON
+	if err := json.Unmarshal(modificacoesJSON, &modificacoes); err != nil {
+		return nil, fmt.Errorf("erro ao converter modificacoes: %v", err)
+	}
+	for _, m := range modificacoes {
+		mod := ticket.Modificacao{
+			ID:              m.ID,
+			TicketID:        t.ID,
+			UsuarioID:       m.UsuarioID,
+			CampoModificado: m.CampoModificado,
+			DataModificacao: m.DataModificacao,
+		}
+		if m.ValorAnterior != nil {
+			mod.ValorAnterior = *m.ValorAnterior
+		}
+		if m.ValorNovo != nil {
+			mod.ValorNovo = *m.ValorNovo
+		}
+		t.Modificacoes = append(t.Modificacoes, mod)
+	}
+
+	// Converte as pausas
+	var pausas []pausaJSON
+	if err := json.Unmarshal(pausasJSON, &pausas); err != nil {
+		return nil, fmt.Errorf("erro ao converter pausas: %v", err)
+	}
+	for _, p := range pausas {
+		t.Pausas = append(t.Pausas, ticket.Pausa{
+			ID:        p.ID,
+			TicketID:  t.ID,
+			Status:    p.Status,
+			Motivo:    p.Motivo,
+			UsuarioID: p.UsuarioID,
+			Inicio:    p.Inicio,
+			Fim:       p.Fim,
+		})
+	}
+
+	// Converte as aprovações
+	var aprovacoes []aprovacaoJSON
+	if err := json.Unmarshal(aprovacoesJSON, &aprovacoes); err != nil {
+		return nil, fmt.Errorf("erro ao converter aprovacoes: %v", err)
+	}
+	for _, a := range aprovacoes {
+		t.Aprovacoes = append(t.Aprovacoes, ticket.Aprovacao{
+			ID:         a.ID,
+			TicketID:   t.ID,
+			Etapa:      a.Etapa,
+			Decisao:    a.Decisao,
+			Comentario: a.Comentario,
+			Valor:      a.Valor,
+			UsuarioID:  a.UsuarioID,
+			Data:       a.Data,
+		})
+	}
+
+	// tudo o que foi carregado já está gravado
+	t.MarcarComoPersistido()
+
+	return t, nil
+}
+
+// salvarFilhos insere as observações, modificações, pausas e aprovações novas e grava o fim das pausas
+// encerradas, com um único comando por tabela independente do tamanho do histórico
+func salvarFilhos(tx *sql.Tx, t *ticket.Ticket) error {
+	if err := inserirObservacoes(tx, t.ID, t.ObservacoesNovas()); err != nil {
+		return err
+	}
+	if err := inserirModificacoes(tx, t.ID, t.ModificacoesNovas()); err != nil {
+		return err
+	}
+	if err := inserirAprovacoes(tx, t.ID, t.AprovacoesNovas()); err != nil {
+		return err
+	}
+	// o fim da pausa encerrada vem antes, porque só pode haver uma pausa em aberto por ticket
+	if err := encerrarPausas(tx, t.ID, t.PausasEncerradas()); err != nil {
+		return err
+	}
+	return inserirPausas(tx, t.ID, t.PausasNovas())
+}
+
+func inserirObservacoes(tx *sql.Tx, ticketID string, observacoes []ticket.Observacao) error {
+	if len(observacoes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(observacoes))
+	usuarios := make([]string, len(observacoes))
+	descricoes := make([]string, len(observacoes))
+	datas := make([]string, len(observacoes))
+	for i, o := range observacoes {
+		ids[i], usuarios[i], descricoes[i], datas[i] = o.ID, o.UsuarioID, o.Descricao, formatarTimestamp(o.DataCriacao)
+	}
+
+	_, err := tx.Exec(
+		`INSERT INTO observacoes (id, ticket_id, usuario_id, descricao, data_criacao)
+		SELECT o.id, $1, o.usuario_id, o.descricao, o.data_criacao
+		FROM unnest($2::uuid[], $3::text[], $4::text[], $5::timestamptz[])
+			AS o(id, usuario_id, descricao, data_criacao)`,
+		ticketID, pq.Array(ids), pq.Array(usuarios), pq.Array(descricoes), pq.Array(datas),
+	)
+	return err
+}
+
+func inserirModificacoes(tx *sql.Tx, ticketID string, modificacoes []ticket.Modificacao) error {
+	if len(modificacoes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(modificacoes))
+	usuarios := make([]string, len(modificacoes))
+	campos := make([]string, len(modificacoes))
+	anteriores := make([]string, len(modificacoes))
+	novos := make([]string, len(modificacoes))
+	datas := make([]string, len(modificacoes))
+	for i, m := range modificacoes {
+		ids[i], usuarios[i], campos[i] = m.ID, m.UsuarioID, m.CampoModificado
+		anteriores[i], novos[i], datas[i] = m.ValorAnterior, m.ValorNovo, formatarTimestamp(m.DataModificacao)
+	}
+
+	_, err := tx.Exec(
+		`INSERT INTO modificacoes (id, ticket_id, usuario_id, campo_modificado, valor_anterior, valor_novo, data_modificacao)
+		SELECT m.id, $1, m.usuario_id, m.campo_modificado, m.valor_anterior, m.valor_novo, m.data_modificacao
+		FROM unnest($2::uuid[], $3::text[], $4::text[], $5::text[], $6::text[], $7::timestamptz[])
+			AS m(id, usuario_id, campo_modificado, valor_anterior, valor_novo, data_modificacao)`,
+		ticketID, pq.Array(ids), pq.Array(usuarios), pq.Array(campos),
+		pq.Array(anteriores), pq.Array(novos), pq.Array(datas),
+	)
+	return err
+}
+
+func inserirAprovacoes(tx *sql.Tx, ticketID string, aprovacoes []ticket.Aprovacao) error {
+	if len(aprovacoes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(aprovacoes))
+	etapas := make([]string, len(aprovacoes))
+	decisoes := make([]string, len(aprovacoes))
+	comentarios := make([]string, len(aprovacoes))
+	valores := make([]int64, len(aprovacoes))
+	usuarios := make([]string, len(aprovacoes))
+	datas := make([]string, len(aprovacoes))
+	for i, a := range aprovacoes {
+		ids[i], etapas[i], decisoes[i], comentarios[i] = a.ID, a.Etapa, string(a.Decisao), a.Comentario
+		valores[i], usuarios[i], datas[i] = a.Valor, a.UsuarioID, formatarTimestamp(a.Data)
+	}
+
+	_, err := tx.Exec(
+		`INSERT INTO aprovacoes (id, ticket_id, etapa, decisao, comentario, valor, usuario_id, data)
+		SELECT a.id, $1, a.etapa, a.decisao, a.comentario, a.valor, a.usuario_id, a.data
+		FROM unnest($2::uuid[], $3::text[], $4::text[], $5::text[], $6::bigint[], $7::text[], $8::timestamptz[])
+			AS a(id, etapa, decisao, comentario, valor, usuario_id, data)`,
+		ticketID, pq.Array(ids), pq.Array(etapas), pq.Array(decisoes), pq.Array(comentarios),
+		pq.Array(valores), pq.Array(usuarios), pq.Array(datas),
+	)
+	return err
+}
+
+func inserirPausas(tx *sql.Tx, ticketID string, pausas []ticket.Pausa) error {
+	if len(pausas) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(pausas))
+	status := make([]string, len(pausas))
+	motivos := make([]string, len(pausas))
+	usuarios := make([]string, len(pausas))
+	inicios := make([]string, len(pausas))
+	fins := make([]sql.NullString, len(pausas))
+	for i, p := range pausas {
+		ids[i], status[i], motivos[i], usuarios[i] = p.ID, string(p.Status), p.Motivo, p.UsuarioID
+		inicios[i] = formatarTimestamp(p.Inicio)
+		if p.Fim != nil {
+			fins[i] = sql.NullString{String: formatarTimestamp(*p.Fim), Valid: true}
+		}
+	}
+
+	_, err := tx.Exec(
+		`INSERT INTO pausas (id, ticket_id, status, motivo, usuario_id, inicio, fim)
+		SELECT p.id, $1, p.status, p.motivo, p.usuario_id, p.inicio, p.fim
+		FROM unnest($2::uuid[], $3::text[], $4::text[], $5::text[], $6::timestamptz[], $7::timestamptz[])
+			AS p(id, status, motivo, usuario_id, inicio, fim)`,
+		ticketID, pq.Array(ids), pq.Array(status), pq.Array(motivos),
+		pq.Array(usuarios), pq.Array(inicios), pq.Array(fins),
+	)
+	return err
+}
+
+func encerrarPausas(tx *sql.Tx, ticketID string, pausas []ticket.Pausa) error {
+	if len(pausas) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(pausas))
+	fins := make([]string, len(pausas))
+	for i, p := range pausas {
+		ids[i], fins[i] = p.ID, formatarTimestamp(*p.Fim)
+	}
+
+	_, err := tx.Exec(
+		`UPDATE pausas SET fim = p.fim
+		FROM unnest($2::uuid[], $3::timestamptz[]) AS p(id, fim)
+		WHERE pausas.id = p.id AND pausas.ticket_id = $1`,
+		ticketID, pq.Array(ids), pq.Array(fins),
+	)
+	return err
+}
+
+// formatarTimestamp converte o horário para o texto aceito em arrays timestamptz
+func formatarTimestamp(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
@@ -4,9 +4,10 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"nox_tickets/internal/domain/ticket"
-	"strings"
+	"sort"
 	"time"
 )
 
@@ -38,6 +39,14 @@ func formatDurationForPostgres(d time.Duration) string {
 	return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
 }
 
+// camposJSON converte os campos personalizados para a coluna jsonb; sem campos, um objeto vazio
+func camposJSON(campos map[string]interface{}) ([]byte, error) {
+	if len(campos) == 0 {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(campos)
+}
+
 type TicketRepository struct {
 	db *sql.DB
 }
@@ -48,6 +57,11 @@ func NewTicketRepository(db *sql.DB) *TicketRepository {
 
 // criar um novo ticket
 func (r *TicketRepository) Create(ticket *ticket.Ticket) error {
+	campos, err := camposJSON(ticket.Campos)
+	if err != nil {
+		return err
+	}
+
 	// inicia uma transação
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -62,231 +76,71 @@ func (r *TicketRepository) Create(ticket *ticket.Ticket) error {
 		subcategoria, descricao, urgencia, gravidade,
 		aberto_por, responsavel, contato, plataforma,
 		data_abertura, data_inicio, data_conclusao,
-		duracao_total, duracao_execucao
+		duracao_total, duracao_execucao,
+		sla_prazo_primeira_resposta, sla_prazo_resolucao, data_primeira_resposta, sla_violado,
+		duracao_total_corrida, duracao_execucao_corrida, versao, fila_id, campos
 		) VALUES (
-		 $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19::interval, $20::interval
+		 $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14, $15, $16, $17, $18, $19::interval, $20::interval,
+		 $21, $22, $23, $24, $25::interval, $26::interval, 1, NULLIF($27, '')::uuid, $28
 		)`,
 		ticket.ID, ticket.Titulo, ticket.Merchant, ticket.NoxID, ticket.CPF, ticket.Status, ticket.Categoria,
 		ticket.Subcategoria, ticket.Descricao, ticket.Urgencia, ticket.Gravidade,
 		ticket.AbertoPor, ticket.Responsavel, ticket.Contato, ticket.Plataforma,
 		ticket.DataAbertura, ticket.DataInicio, ticket.DataConclusao,
 		formatDurationForPostgres(ticket.DuracaoTotal), formatDurationForPostgres(ticket.DuracaoExecucao),
+		ticket.SLA.PrazoPrimeiraResposta, ticket.SLA.PrazoResolucao, ticket.SLA.DataPrimeiraResposta, ticket.SLA.Violado,
+		formatDurationForPostgres(ticket.DuracaoTotalCorrida), formatDurationForPostgres(ticket.DuracaoExecucaoCorrida),
+		ticket.FilaID, campos,
 	)
 	if err != nil {
 		return err
 	}
+	ticket.Versao = 1
 
-	// confirma a transação
-	return tx.Commit()
-}
-
-// buscar ticket por id
-func (r *TicketRepository) GetByID(id string) (*ticket.Ticket, error) {
-	t := &ticket.Ticket{}
-
-	// Variáveis temporárias para armazenar as durações como strings
-	var duracaoTotalStr, duracaoExecucaoStr string
-
-	// Busca o ticket principal
-	err := r.db.QueryRow(`
-        SELECT 
-            id, titulo, merchant, nox_id, cpf, status, categoria,
-            subcategoria, descricao, urgencia, gravidade,
-            aberto_por, responsavel, contato, plataforma,
-            data_abertura, data_inicio, data_conclusao,
-            duracao_total::text, duracao_execucao::text
-        FROM tickets 
-        WHERE id = $1
-    `, id).Scan(
-		&t.ID, &t.Titulo, &t.Merchant, &t.NoxID,
-		&t.CPF, &t.Status, &t.Categoria, &t.Subcategoria,
-		&t.Descricao, &t.Urgencia, &t.Gravidade,
-		&t.AbertoPor, &t.Responsavel, &t.Contato,
-		&t.Plataforma, &t.DataAbertura, &t.DataInicio,
-		&t.DataConclusao, &duracaoTotalStr, &duracaoExecucaoStr,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("ticket não encontrado")
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	// Converte as durações de string para time.Duration
-	if duracaoTotalStr != "" {
-		duration, err := parsePostgresInterval(duracaoTotalStr)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao converter duracao_total: %v", err)
-		}
-		t.DuracaoTotal = duration
-	}
-
-	if duracaoExecucaoStr != "" {
-		duration, err := parsePostgresInterval(duracaoExecucaoStr)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao converter duracao_execucao: %v", err)
-		}
-		t.DuracaoExecucao = duration
-	}
-
-	// Busca as observações
-	rows, err := r.db.Query(`
-		SELECT id, usuario_id, descricao, data_criacao
-		FROM observacoes
-		WHERE ticket_id = $1
-		ORDER BY data_criacao
-	`, id)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var obs ticket.Observacao
-		err := rows.Scan(&obs.ID, &obs.UsuarioID, &obs.Descricao, &obs.DataCriacao)
-		if err != nil {
-			return nil, err
-		}
-		obs.TicketID = id
-		t.Observacoes = append(t.Observacoes, obs)
+	// insere observações, modificações e pausas, se houver
+	if err := salvarFilhos(tx, ticket); err != nil {
+		return err
 	}
 
-	// Busca as modificações
-	rows, err = r.db.Query(`
-		SELECT id, usuario_id, campo_modificado, valor_anterior, valor_novo, data_modificacao
-		FROM modificacoes
-		WHERE ticket_id = $1
-		ORDER BY data_modificacao
-	`, id)
-	if err != nil {
-		return nil, err
+	// grava o evento de criação no outbox, na mesma transação
+	if err := gravarEventos(tx, ticket.EventosPendentes()); err != nil {
+		return err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var mod ticket.Modificacao
-		err := rows.Scan(
-			&mod.ID, &mod.UsuarioID, &mod.CampoModificado,
-			&mod.ValorAnterior, &mod.ValorNovo, &mod.DataModificacao,
-		)
-		if err != nil {
-			return nil, err
-		}
-		mod.TicketID = id
-		t.Modificacoes = append(t.Modificacoes, mod)
+	// confirma a transação
+	if err := tx.Commit(); err != nil {
+		return err
 	}
-
-	return t, nil
+	ticket.MarcarComoPersistido()
+	return nil
 }
 
-// listar tickets
-func (r *TicketRepository) List(filtros ticket.TicketFiltros) ([]*ticket.Ticket, error) {
-	// slice para guardar as condições do WHERE
-	where := []string{}
-	// Slice para guardar os argumentos
-	args := []interface{}{}
-	// contador para os placeholders
-	argCount := 1
-
-	// adiciona filtros se existirem
-	if len(filtros.Status) > 0 {
-		statusStrings := make([]string, len(filtros.Status))
-		for i, s := range filtros.Status {
-			statusStrings[i] = fmt.Sprintf("$%d", argCount)
-			args = append(args, s)
-			argCount++
-		}
-		where = append(where, fmt.Sprintf("status = ANY(ARRAY[%s])", strings.Join(statusStrings, ",")))
-	}
-
-	if len(filtros.Categoria) > 0 {
-		categStrings := make([]string, len(filtros.Categoria))
-		for i, c := range filtros.Categoria {
-			categStrings[i] = fmt.Sprintf("categoria ILIKE $%d", argCount)
-			args = append(args, string(c))
-			argCount++
-		}
-		where = append(where, fmt.Sprintf("(%s)", strings.Join(categStrings, " OR ")))
-	}
-
-	if filtros.Responsavel != "" {
-		where = append(where, fmt.Sprintf("responsavel = $%d", argCount))
-		args = append(args, filtros.Responsavel)
-		argCount++
-	}
+// buscar ticket por id, com observações, modificações e pausas em uma única consulta
+func (r *TicketRepository) GetByID(id string) (*ticket.Ticket, error) {
+	return buscarPorID(r.db, id)
+}
 
-	if filtros.AbertoPor != "" {
-		where = append(where, fmt.Sprintf("aberto_por = $%d", argCount))
-		args = append(args, filtros.AbertoPor)
-		argCount++
-	}
+// listar tickets, com paginação e ordenação feitas no banco
+func (r *TicketRepository) List(filtros ticket.TicketFiltros) (*ticket.ResultadoLista, error) {
+	return listarPagina(r.db, filtros, false)
+}
 
-	// Construir a query
-	query := `
-	    SELECT
-			id, titulo, merchant, nox_id, cpf, status, categoria,
-			subcategoria, descricao, urgencia, gravidade,
-			aberto_por, responsavel, contato, plataforma,
-			data_abertura, data_inicio, data_conclusao,
-			duracao_total, duracao_execucao
-		FROM tickets`
+// listar uma página de tickets completos, com os filhos, sem a contagem total
+func (r *TicketRepository) ListarCompletos(filtros ticket.TicketFiltros) (*ticket.ResultadoLista, error) {
+	return listarPagina(r.db, filtros, true)
+}
 
-	// adiciona as condições WHERE se existirem
-	if len(where) > 0 {
-		query += " WHERE " + strings.Join(where, " AND ")
-	}
+// pesquisar tickets por texto, ordenados por relevância
+func (r *TicketRepository) Pesquisar(consulta string, filtros ticket.TicketFiltros) (*ticket.ResultadoPesquisa, error) {
+	return pesquisar(r.db, consulta, filtros)
+}
 
-	// executa a query
-	rows, err := r.db.Query(query, args...)
+func (r *TicketRepository) Update(t *ticket.Ticket) error {
+	campos, err := camposJSON(t.Campos)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// slice para guardar os resultados
-	tickets := []*ticket.Ticket{}
-
-	// itera sobre os resultados
-	for rows.Next() {
-		t := &ticket.Ticket{}
-		var duracaoTotalStr, duracaoExecucaoStr string
-
-		err := rows.Scan(
-			&t.ID, &t.Titulo, &t.Merchant, &t.NoxID, &t.CPF, &t.Status, &t.Categoria,
-			&t.Subcategoria, &t.Descricao, &t.Urgencia, &t.Gravidade,
-			&t.AbertoPor, &t.Responsavel, &t.Contato, &t.Plataforma,
-			&t.DataAbertura, &t.DataInicio, &t.DataConclusao,
-			&duracaoTotalStr, &duracaoExecucaoStr,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		// Converte as durações
-		t.DuracaoTotal, err = parsePostgresInterval(duracaoTotalStr)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao converter duracao_total: %v", err)
-		}
-
-		t.DuracaoExecucao, err = parsePostgresInterval(duracaoExecucaoStr)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao converter duracao_execucao: %v", err)
-		}
-
-		tickets = append(tickets, t)
-	}
-
-	// verifica se houve erros durante a iteração
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return err
 	}
 
-	// retorna os tickets encontrados
-	return tickets, nil
-}
-
-func (r *TicketRepository) Update(ticket *ticket.Ticket) error {
 	// inicia uma transação
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -294,8 +148,8 @@ func (r *TicketRepository) Update(ticket *ticket.Ticket) error {
 	}
 	defer tx.Rollback() // garante que a transação será revertida em caso de erro
 
-	// atualiza o ticket principal
-	_, err = tx.Exec(
+	// atualiza o ticket principal, somente se ninguém gravou desde a leitura
+	result, err := tx.Exec(
 		`UPDATE tickets SET
 		titulo = $1,
 		merchant = $2,
@@ -308,80 +162,71 @@ func (r *TicketRepository) Update(ticket *ticket.Ticket) error {
 		urgencia = $9,
 		gravidade = $10,
 		aberto_por = $11,
-		responsavel = $12,
+		responsavel = NULLIF($12, ''),
 		contato = $13,
 		plataforma = $14,
 		data_abertura = $15,
 		data_inicio = $16,
 		data_conclusao = $17,
 		duracao_total = $18::interval,
-		duracao_execucao = $19::interval
-		WHERE id = $20
+		duracao_execucao = $19::interval,
+		sla_prazo_primeira_resposta = $20,
+		sla_prazo_resolucao = $21,
+		data_primeira_resposta = $22,
+		sla_violado = $23,
+		duracao_total_corrida = $24::interval,
+		duracao_execucao_corrida = $25::interval,
+		fila_id = NULLIF($26, '')::uuid,
+		campos = $29,
+		versao = versao + 1
+		WHERE id = $27 AND versao = $28
 		`,
-		ticket.Titulo, ticket.Merchant, ticket.NoxID, ticket.CPF, ticket.Status, ticket.Categoria,
-		ticket.Subcategoria, ticket.Descricao, ticket.Urgencia, ticket.Gravidade,
-		ticket.AbertoPor, ticket.Responsavel, ticket.Contato, ticket.Plataforma,
-		ticket.DataAbertura, ticket.DataInicio, ticket.DataConclusao,
-		formatDurationForPostgres(ticket.DuracaoTotal), formatDurationForPostgres(ticket.DuracaoExecucao),
-		ticket.ID,
+		t.Titulo, t.Merchant, t.NoxID, t.CPF, t.Status, t.Categoria,
+		t.Subcategoria, t.Descricao, t.Urgencia, t.Gravidade,
+		t.AbertoPor, t.Responsavel, t.Contato, t.Plataforma,
+		t.DataAbertura, t.DataInicio, t.DataConclusao,
+		formatDurationForPostgres(t.DuracaoTotal), formatDurationForPostgres(t.DuracaoExecucao),
+		t.SLA.PrazoPrimeiraResposta, t.SLA.PrazoResolucao, t.SLA.DataPrimeiraResposta, t.SLA.Violado,
+		formatDurationForPostgres(t.DuracaoTotalCorrida), formatDurationForPostgres(t.DuracaoExecucaoCorrida),
+		t.FilaID, t.ID, t.Versao, campos,
 	)
 	if err != nil {
 		return err
 	}
 
-	// Salva as novas observações
-	for _, obs := range ticket.Observacoes {
-		// Verifica se a observação já existe
-		var exists bool
-		err := tx.QueryRow(
-			"SELECT EXISTS(SELECT 1 FROM observacoes WHERE id = $1)",
-			obs.ID,
-		).Scan(&exists)
-		if err != nil {
+	// nenhuma linha atualizada: o ticket não existe ou a versão mudou
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas == 0 {
+		var existe bool
+		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM tickets WHERE id = $1)", t.ID).Scan(&existe); err != nil {
 			return err
 		}
-
-		// Se não existe, insere
-		if !exists {
-			_, err = tx.Exec(
-				`INSERT INTO observacoes (id, ticket_id, usuario_id, descricao, data_criacao)
-				VALUES ($1, $2, $3, $4, $5)`,
-				obs.ID, ticket.ID, obs.UsuarioID, obs.Descricao, obs.DataCriacao,
-			)
-			if err != nil {
-				return err
-			}
+		if !existe {
+			return ticket.ErrNaoEncontrado
 		}
+		return ticket.ErrConflito
 	}
 
-	// Salva as novas modificações
-	for _, mod := range ticket.Modificacoes {
-		// Verifica se a modificação já existe
-		var exists bool
-		err := tx.QueryRow(
-			"SELECT EXISTS(SELECT 1 FROM modificacoes WHERE id = $1)",
-			mod.ID,
-		).Scan(&exists)
-		if err != nil {
-			return err
-		}
+	// Salva apenas as observações e modificações novas, e as pausas
+	if err := salvarFilhos(tx, t); err != nil {
+		return err
+	}
 
-		// Se não existe, insere
-		if !exists {
-			_, err = tx.Exec(
-				`INSERT INTO modificacoes (id, ticket_id, usuario_id, campo_modificado, valor_anterior, valor_novo, data_modificacao)
-				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
-				mod.ID, ticket.ID, mod.UsuarioID, mod.CampoModificado,
-				mod.ValorAnterior, mod.ValorNovo, mod.DataModificacao,
-			)
-			if err != nil {
-				return err
-			}
-		}
+	// grava os eventos das alterações no outbox, na mesma transação
+	if err := gravarEventos(tx, t.EventosPendentes()); err != nil {
+		return err
 	}
 
 	// confirma a transação
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	t.Versao++
+	t.MarcarComoPersistido()
+	return nil
 }
 
 func (r *TicketRepository) Delete(id string) error {
@@ -392,7 +237,7 @@ func (r *TicketRepository) Delete(id string) error {
 	}
 	defer tx.Rollback() // garante que a transação será revertida em caso de erro
 
-	// deleta primeiro as observacoes e modificacoes (por causa das chaves estrangeiras)
+	// deleta primeiro as observacoes, modificacoes, pausas e aprovacoes (por causa das chaves estrangeiras)
 	_, err = tx.Exec(
 		`DELETE FROM observacoes WHERE ticket_id = $1`,
 		id,
@@ -409,6 +254,22 @@ func (r *TicketRepository) Delete(id string) error {
 		return err
 	}
 
+	_, err = tx.Exec(
+		`DELETE FROM pausas WHERE ticket_id = $1`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`DELETE FROM aprovacoes WHERE ticket_id = $1`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
 	// deleta o ticket principal
 	result, err := tx.Exec(
 		`DELETE FROM tickets WHERE id = $1`,
@@ -424,23 +285,13 @@ func (r *TicketRepository) Delete(id string) error {
 		return err
 	}
 	if rows == 0 {
-		return fmt.Errorf("ticket não encontrado")
+		return ticket.ErrNaoEncontrado
 	}
 
 	// confirma a transação
 	return tx.Commit()
 }
 
-// Adicionar observação
-func (r *TicketRepository) AdicionarObservacao(ticketID string, observacao *ticket.Observacao) error {
-	_, err := r.db.Exec(
-		`INSERT INTO observacoes (ticket_id, usuario_id, descricao, data_criacao)
-		 VALUES ($1, $2, $3, $4)`,
-		ticketID, observacao.UsuarioID, observacao.Descricao, observacao.DataCriacao,
-	)
-	return err
-}
-
 // Listar observações
 func (r *TicketRepository) ListarObservacoes(ticketID string) ([]*ticket.Observacao, error) {
 	rows, err := r.db.Query(
@@ -467,17 +318,6 @@ func (r *TicketRepository) ListarObservacoes(ticketID string) ([]*ticket.Observa
 	return observacoes, nil
 }
 
-// Adicionar modificação
-func (r *TicketRepository) AdicionarModificacao(ticketID string, modificacao *ticket.Modificacao) error {
-	_, err := r.db.Exec(
-		`INSERT INTO modificacoes (ticket_id, usuario_id, campo_modificado, valor_anterior, valor_novo, data_modificacao)
-		 VALUES ($1, $2, $3, $4, $5, $6)`,
-		ticketID, modificacao.UsuarioID, modificacao.CampoModificado,
-		modificacao.ValorAnterior, modificacao.ValorNovo, modificacao.DataModificacao,
-	)
-	return err
-}
-
 // Listar modificações
 func (r *TicketRepository) ListarModificacoes(ticketID string) ([]*ticket.Modificacao, error) {
 	rows, err := r.db.Query(
@@ -507,50 +347,91 @@ func (r *TicketRepository) ListarModificacoes(ticketID string) ([]*ticket.Modifi
 	return modificacoes, nil
 }
 
-// Listar por status
-func (r *TicketRepository) ListarPorStatus(status ticket.Status) ([]*ticket.Ticket, error) {
-	return r.List(ticket.TicketFiltros{Status: []ticket.Status{status}})
-}
-
-// Atualizar status
-func (r *TicketRepository) AtualizarStatus(ticketID string, novoStatus ticket.Status, usuarioID string) error {
-	tx, err := r.db.Begin()
-	if err != nil {
-		return err
+// Listar reclassificações em que a categoria é a de origem ou a de destino. As modificações de categoria e
+// subcategoria de cada ticket candidato são lidas por inteiro, porque o campo que não mudou em uma reclassificação
+// é reconstruído a partir da classificação atual
+func (r *TicketRepository) ListarReclassificacoes(filtro ticket.FiltroReclassificacoes) ([]*ticket.Reclassificacao, error) {
+	// 1. candidatos: tickets que passaram pela categoria e foram reclassificados no período
+	c := &consulta{}
+	c.adicionar("m.campo_modificado IN ('categoria', 'subcategoria')")
+	categoria := c.arg(string(filtro.Categoria))
+	c.adicionar(fmt.Sprintf(
+		`(t.categoria = %s OR EXISTS (
+			SELECT 1 FROM modificacoes mc
+			WHERE mc.ticket_id = t.id AND mc.campo_modificado = 'categoria' AND %s IN (mc.valor_anterior, mc.valor_novo)))`,
+		categoria, categoria,
+	))
+	periodo := "mp.ticket_id = t.id AND mp.campo_modificado IN ('categoria', 'subcategoria')"
+	if !filtro.DataInicio.IsZero() {
+		periodo += " AND mp.data_modificacao >= " + c.arg(filtro.DataInicio)
+	}
+	if !filtro.DataFim.IsZero() {
+		periodo += " AND mp.data_modificacao <= " + c.arg(filtro.DataFim)
+	}
+	c.adicionar("EXISTS (SELECT 1 FROM modificacoes mp WHERE " + periodo + ")")
+	if filtro.Escopo != nil {
+		c.adicionar(condicaoEscopo(c, filtro.Escopo))
 	}
-	defer tx.Rollback()
 
-	// Busca o status atual
-	var statusAtual ticket.Status
-	err = tx.QueryRow("SELECT status FROM tickets WHERE id = $1", ticketID).Scan(&statusAtual)
+	rows, err := r.db.Query(
+		`SELECT t.id, t.titulo, t.categoria, COALESCE(t.subcategoria, ''),
+		        m.usuario_id, m.campo_modificado, COALESCE(m.valor_anterior, ''), COALESCE(m.valor_novo, ''), m.data_modificacao
+		 FROM modificacoes m
+		 JOIN tickets t ON t.id = m.ticket_id`+c.clausulaWhere()+`
+		 ORDER BY t.id, m.data_modificacao`,
+		c.args...,
+	)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	// Atualiza o status
-	_, err = tx.Exec("UPDATE tickets SET status = $1 WHERE id = $2", novoStatus, ticketID)
-	if err != nil {
-		return err
+	// 2. agrupa o histórico por ticket e reconstrói as reclassificações
+	reclassificacoes := []*ticket.Reclassificacao{}
+	var atual *ticket.Ticket
+	var historico []ticket.Modificacao
+	reconstruir := func() {
+		if atual == nil {
+			return
+		}
+		for _, rc := range ticket.ReclassificacoesDoHistorico(atual, historico) {
+			if rc.Envolve(filtro.Categoria) && rc.NoPeriodo(filtro.DataInicio, filtro.DataFim) {
+				reclassificacoes = append(reclassificacoes, rc)
+			}
+		}
 	}
-
-	// Registra a modificação
-	modificacao := &ticket.Modificacao{
-		UsuarioID:       usuarioID,
-		CampoModificado: "status",
-		ValorAnterior:   string(statusAtual),
-		ValorNovo:       string(novoStatus),
-		DataModificacao: time.Now(),
+	for rows.Next() {
+		t := &ticket.Ticket{}
+		m := ticket.Modificacao{}
+		err := rows.Scan(
+			&t.ID, &t.Titulo, &t.Categoria, &t.Subcategoria,
+			&m.UsuarioID, &m.CampoModificado, &m.ValorAnterior, &m.ValorNovo, &m.DataModificacao,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if atual == nil || atual.ID != t.ID {
+			reconstruir()
+			atual, historico = t, nil
+		}
+		m.TicketID = t.ID
+		historico = append(historico, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	reconstruir()
 
-	_, err = tx.Exec(
-		`INSERT INTO modificacoes (ticket_id, usuario_id, campo_modificado, valor_anterior, valor_novo, data_modificacao)
-		 VALUES ($1, $2, $3, $4, $5, $6)`,
-		ticketID, modificacao.UsuarioID, modificacao.CampoModificado,
-		modificacao.ValorAnterior, modificacao.ValorNovo, modificacao.DataModificacao,
-	)
+	// 3. da mais recente para a mais antiga
+	sort.SliceStable(reclassificacoes, func(a, b int) bool { return reclassificacoes[a].Data.After(reclassificacoes[b].Data) })
+	return reclassificacoes, nil
+}
+
+// Listar por status
+func (r *TicketRepository) ListarPorStatus(status ticket.Status) ([]*ticket.Ticket, error) {
+	resultado, err := r.List(ticket.TicketFiltros{Status: []ticket.Status{status}})
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return tx.Commit()
+	return resultado.Tickets, nil
 }
@@ -1,14 +1,21 @@
 package postgres
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"nox_tickets/internal/domain/taxonomia"
 	"nox_tickets/internal/domain/ticket"
 	"nox_tickets/internal/infrastructure/database/postgres"
+
+	"github.com/lib/pq"
 )
 
 // Função auxiliar para criar uma conexão de teste
-func setupTestDB(t *testing.T) *TicketRepository {
+func setupTestDB(t testing.TB) *TicketRepository {
 	config := postgres.Config{
 		Host:     "localhost",
 		Port:     "5432",
@@ -23,9 +30,21 @@ func setupTestDB(t *testing.T) *TicketRepository {
 		t.Fatalf("Erro ao conectar ao banco de teste: %v", err)
 	}
 
+	// os tickets referenciam usuários cadastrados
+	_, err = db.Exec(
+		`INSERT INTO usuarios (id, nome) SELECT u, u FROM unnest($1::text[]) AS u ON CONFLICT (id) DO NOTHING`,
+		pq.Array(usuariosDeTeste),
+	)
+	if err != nil {
+		t.Fatalf("Erro ao cadastrar usuários de teste: %v", err)
+	}
+
 	return NewTicketRepository(db)
 }
 
+// usuários usados como autor ou responsável nos testes
+var usuariosDeTeste = []string{"usuario_teste", "cliente_escopo", "analista"}
+
 // Função auxiliar para criar um ticket de teste
 func createTestTicket() *ticket.Ticket {
 	ticket, _ := ticket.NovoTicket(
@@ -112,11 +131,11 @@ func TestTicketRepository_List(t *testing.T) {
 	}
 
 	// Testa listar sem filtros
-	tickets, err := repo.List(ticket.TicketFiltros{})
+	resultado, err := repo.List(ticket.TicketFiltros{})
 	if err != nil {
 		t.Errorf("Erro ao listar tickets: %v", err)
 	}
-	if len(tickets) < 2 {
+	if len(resultado.Tickets) < 2 {
 		t.Error("Esperava encontrar pelo menos 2 tickets")
 	}
 
@@ -124,17 +143,165 @@ func TestTicketRepository_List(t *testing.T) {
 	filtroStatus := ticket.TicketFiltros{
 		Status: []ticket.Status{ticket.StatusEmCurso},
 	}
-	ticketsFiltrados, err := repo.List(filtroStatus)
+	filtrados, err := repo.List(filtroStatus)
 	if err != nil {
 		t.Errorf("Erro ao listar tickets com filtro: %v", err)
 	}
-	for _, tick := range ticketsFiltrados {
+	for _, tick := range filtrados.Tickets {
 		if tick.Status != ticket.StatusEmCurso {
 			t.Error("Encontrou ticket com status diferente do filtrado")
 		}
 	}
 }
 
+// Teste dos filtros de faixa, período e campos opcionais
+func TestTicketRepository_ListFiltros(t *testing.T) {
+	repo := setupTestDB(t)
+
+	tk := createTestTicket()
+	tk.Urgencia = 4
+	tk.Gravidade = 2
+	tk.SetInformacaoAdicional("Loja Teste", "nox-123", "", "android", "")
+	if err := repo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+
+	min, max := 3, 5
+	filtros := ticket.TicketFiltros{
+		Subcategoria: []ticket.Subcategoria{tk.Subcategoria},
+		NoxID:        "nox-123",
+		Merchant:     "loja teste",
+		Plataforma:   "ANDROID",
+		UrgenciaMin:  &min,
+		UrgenciaMax:  &max,
+		DataInicio:   tk.DataAbertura.Add(-time.Minute),
+		DataFim:      tk.DataAbertura.Add(time.Minute),
+	}
+	resultado, err := repo.List(filtros)
+	if err != nil {
+		t.Fatalf("Erro ao listar tickets com filtros: %v", err)
+	}
+
+	encontrou := false
+	for _, r := range resultado.Tickets {
+		if r.ID == tk.ID {
+			encontrou = true
+		}
+		if r.Urgencia < min || r.Urgencia > max {
+			t.Errorf("Encontrou ticket fora da faixa de urgência: %d", r.Urgencia)
+		}
+	}
+	if !encontrou {
+		t.Error("Esperava encontrar o ticket criado")
+	}
+
+	// faixa de gravidade que exclui o ticket
+	gravidadeMin := 3
+	filtros.GravidadeMin = &gravidadeMin
+	resultado, err = repo.List(filtros)
+	if err != nil {
+		t.Fatalf("Erro ao listar tickets com filtros: %v", err)
+	}
+	for _, r := range resultado.Tickets {
+		if r.ID == tk.ID {
+			t.Error("Ticket não deveria atender ao filtro de gravidade")
+		}
+	}
+}
+
+// Teste da paginação por deslocamento e por cursor
+func TestTicketRepository_ListPaginado(t *testing.T) {
+	repo := setupTestDB(t)
+
+	// Cria alguns tickets com urgências diferentes
+	for i := 1; i <= 5; i++ {
+		tk := createTestTicket()
+		tk.Urgencia = i
+		if err := repo.Create(tk); err != nil {
+			t.Fatalf("Erro ao criar ticket: %v", err)
+		}
+	}
+
+	filtros := ticket.TicketFiltros{
+		Limite:    2,
+		Ordenacao: ticket.Ordenacao{Campo: ticket.OrdenarPorUrgencia, Descendente: true},
+	}
+
+	// Primeira página
+	pagina1, err := repo.List(filtros)
+	if err != nil {
+		t.Fatalf("Erro ao listar primeira página: %v", err)
+	}
+	if len(pagina1.Tickets) != 2 {
+		t.Fatalf("Esperava 2 tickets na página, recebido %d", len(pagina1.Tickets))
+	}
+	if pagina1.Total < 5 {
+		t.Errorf("Esperava total de pelo menos 5 tickets, recebido %d", pagina1.Total)
+	}
+	if pagina1.ProximoCursor == "" {
+		t.Fatal("Esperava cursor para a próxima página")
+	}
+
+	// Segunda página pelo cursor deve continuar a ordem sem repetir tickets
+	filtros.Cursor = pagina1.ProximoCursor
+	pagina2, err := repo.List(filtros)
+	if err != nil {
+		t.Fatalf("Erro ao listar segunda página: %v", err)
+	}
+	ultimo := pagina1.Tickets[1]
+	for _, tk := range pagina2.Tickets {
+		if tk.ID == pagina1.Tickets[0].ID || tk.ID == ultimo.ID {
+			t.Error("Ticket repetido entre as páginas")
+		}
+		if tk.Urgencia > ultimo.Urgencia {
+			t.Errorf("Ordem quebrada: urgência %d depois de %d", tk.Urgencia, ultimo.Urgencia)
+		}
+	}
+
+	// Segunda página pelo deslocamento deve trazer os mesmos tickets
+	filtros.Cursor = ""
+	filtros.Deslocamento = 2
+	porDeslocamento, err := repo.List(filtros)
+	if err != nil {
+		t.Fatalf("Erro ao listar com deslocamento: %v", err)
+	}
+	for i := range porDeslocamento.Tickets {
+		if porDeslocamento.Tickets[i].ID != pagina2.Tickets[i].ID {
+			t.Error("Paginação por deslocamento diferente da paginação por cursor")
+		}
+	}
+}
+
+// Teste da busca textual: encontra pela observação, sem acento e no plural
+func TestTicketRepository_Pesquisar(t *testing.T) {
+	repo := setupTestDB(t)
+
+	tk := createTestTicket()
+	tk.AdicionarObservacao("Saque do merchant retido na conciliação", "analista")
+	if err := repo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+
+	// sem acento e no plural: deve encontrar "conciliação" da observação
+	resultado, err := repo.Pesquisar("conciliacoes", ticket.TicketFiltros{Categoria: []ticket.Categoria{tk.Categoria}})
+	if err != nil {
+		t.Fatalf("Erro ao pesquisar tickets: %v", err)
+	}
+
+	encontrou := false
+	for _, item := range resultado.Itens {
+		if item.Ticket.ID == tk.ID {
+			encontrou = true
+			if !strings.Contains(item.Trecho, "<mark>") {
+				t.Errorf("Esperava trecho destacado, recebido %q", item.Trecho)
+			}
+		}
+	}
+	if !encontrou {
+		t.Error("Esperava encontrar o ticket pela observação")
+	}
+}
+
 // Teste do método Update
 func TestTicketRepository_Update(t *testing.T) {
 	repo := setupTestDB(t)
@@ -195,3 +362,352 @@ func TestTicketRepository_Delete(t *testing.T) {
 		t.Error("Esperava erro ao deletar ticket inexistente")
 	}
 }
+
+// Teste de gravação incremental: só os filhos novos são inseridos
+func TestTicketRepository_UpdateInsereApenasNovos(t *testing.T) {
+	repo := setupTestDB(t)
+
+	tk := createTestTicket()
+	tk.AdicionarObservacao("Primeira observação", "analista")
+	if err := repo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+
+	// salvar duas vezes sem mudanças não pode duplicar nem falhar
+	tk.AdicionarObservacao("Segunda observação", "analista")
+	tk.SetUrgencia(3, "analista")
+	for i := 0; i < 2; i++ {
+		if err := repo.Update(tk); err != nil {
+			t.Fatalf("Erro ao atualizar ticket: %v", err)
+		}
+	}
+
+	salvo, err := repo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+	if len(salvo.Observacoes) != 2 {
+		t.Errorf("Esperava 2 observações, recebido %d", len(salvo.Observacoes))
+	}
+	if len(salvo.Modificacoes) != len(tk.Modificacoes) {
+		t.Errorf("Esperava %d modificações, recebido %d", len(tk.Modificacoes), len(salvo.Modificacoes))
+	}
+	if len(salvo.ObservacoesNovas()) != 0 || len(salvo.ModificacoesNovas()) != 0 {
+		t.Error("Ticket carregado do banco não deveria ter filhos novos")
+	}
+}
+
+// Teste das pausas: a pausa gravada em aberto recebe o fim e a nova é inserida, sem regravar o histórico
+func TestTicketRepository_UpdatePausas(t *testing.T) {
+	repo := setupTestDB(t)
+	maquina := ticket.MaquinaDeEstadosPadrao()
+
+	tk := createTestTicket()
+	if err := maquina.Aplicar(tk, ticket.StatusEmCurso, ticket.ContextoTransicao{UsuarioID: "analista", Responsavel: "analista"}); err != nil {
+		t.Fatalf("Erro ao iniciar atendimento: %v", err)
+	}
+	if err := maquina.Aplicar(tk, ticket.StatusAguardandoCliente, ticket.ContextoTransicao{UsuarioID: "analista", Motivo: "aguardando comprovante"}); err != nil {
+		t.Fatalf("Erro ao pausar: %v", err)
+	}
+	if err := repo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	defer repo.Delete(tk.ID)
+
+	lido, err := repo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+	if err := maquina.Aplicar(lido, ticket.StatusEmCurso, ticket.ContextoTransicao{UsuarioID: "analista"}); err != nil {
+		t.Fatalf("Erro ao retomar: %v", err)
+	}
+	if err := maquina.Aplicar(lido, ticket.StatusPausado, ticket.ContextoTransicao{UsuarioID: "analista", Motivo: "aguardando produto"}); err != nil {
+		t.Fatalf("Erro ao pausar de novo: %v", err)
+	}
+	if err := repo.Update(lido); err != nil {
+		t.Fatalf("Erro ao atualizar ticket: %v", err)
+	}
+
+	salvo, err := repo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao reler ticket: %v", err)
+	}
+	if len(salvo.Pausas) != 2 || salvo.Pausas[0].Fim == nil || salvo.Pausas[1].Fim != nil {
+		t.Errorf("Esperava a primeira pausa encerrada e a segunda aberta, recebido %+v", salvo.Pausas)
+	}
+}
+
+// Teste das aprovações: gravadas uma única vez e relidas com o valor da decisão
+func TestTicketRepository_Aprovacoes(t *testing.T) {
+	repo := setupTestDB(t)
+
+	tk := createTestTicket()
+	if err := repo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	defer repo.Delete(tk.ID)
+
+	if err := tk.RegistrarAprovacao("supervisao", ticket.DecisaoAprovada, "conferido", 150075, "analista"); err != nil {
+		t.Fatalf("Erro ao registrar aprovação: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := repo.Update(tk); err != nil {
+			t.Fatalf("Erro ao atualizar ticket: %v", err)
+		}
+	}
+
+	salvo, err := repo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+	if len(salvo.Aprovacoes) != 1 || len(salvo.AprovacoesNovas()) != 0 {
+		t.Fatalf("Esperava 1 aprovação gravada, recebido %+v", salvo.Aprovacoes)
+	}
+	a := salvo.Aprovacoes[0]
+	if a.Etapa != "supervisao" || a.Decisao != ticket.DecisaoAprovada || a.Valor != 150075 || a.Comentario != "conferido" {
+		t.Errorf("Aprovação relida diferente: %+v", a)
+	}
+}
+
+// criarTicketComHistorico grava um ticket com n observações e n modificações
+func criarTicketComHistorico(b *testing.B, repo *TicketRepository, n int) *ticket.Ticket {
+	tk := createTestTicket()
+	for i := 0; i < n; i++ {
+		tk.AdicionarObservacao(fmt.Sprintf("Observação %d", i), "analista")
+		tk.SetUrgencia(i%5+1, "analista")
+	}
+	if err := repo.Create(tk); err != nil {
+		b.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	return tk
+}
+
+// Benchmark da gravação: o custo não cresce com o tamanho do histórico,
+// porque apenas a observação nova é inserida (antes era um SELECT EXISTS por filho)
+func BenchmarkTicketRepository_Update(b *testing.B) {
+	repo := setupTestDB(b)
+
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("historico=%d", n), func(b *testing.B) {
+			tk := criarTicketComHistorico(b, repo, n)
+			defer repo.Delete(tk.ID)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tk.AdicionarObservacao("Nova observação", "analista")
+				if err := repo.Update(tk); err != nil {
+					b.Fatalf("Erro ao atualizar ticket: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// Benchmark da leitura: ticket e filhos em uma única consulta
+func BenchmarkTicketRepository_GetByID(b *testing.B) {
+	repo := setupTestDB(b)
+
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("historico=%d", n), func(b *testing.B) {
+			tk := criarTicketComHistorico(b, repo, n)
+			defer repo.Delete(tk.ID)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetByID(tk.ID); err != nil {
+					b.Fatalf("Erro ao buscar ticket: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// Teste de concorrência otimista: a segunda gravação com a versão antiga falha
+func TestTicketRepository_UpdateConflito(t *testing.T) {
+	repo := setupTestDB(t)
+
+	tk := createTestTicket()
+	if err := repo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+
+	// dois analistas leem a mesma versão
+	primeiro, err := repo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+	segundo, err := repo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+
+	primeiro.SetUrgencia(4, "analista_1")
+	if err := repo.Update(primeiro); err != nil {
+		t.Fatalf("Erro ao atualizar ticket: %v", err)
+	}
+	if primeiro.Versao != 2 {
+		t.Errorf("Esperava versão 2, recebido %d", primeiro.Versao)
+	}
+
+	segundo.SetUrgencia(2, "analista_2")
+	if err := repo.Update(segundo); !errors.Is(err, ticket.ErrConflito) {
+		t.Errorf("Esperava ErrConflito, recebido %v", err)
+	}
+}
+
+// Teste do escopo de acesso: tickets restritos só aparecem para quem tem concessão
+func TestTicketRepository_ListEscopo(t *testing.T) {
+	repo := setupTestDB(t)
+
+	restrito, _ := ticket.NovoTicket("Ticket KYC", "Documentos do cliente", ticket.CategoriaOnboarding, ticket.SubcategoriaKYC, "cliente_escopo")
+	if err := repo.Create(restrito); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+
+	escopo := &ticket.EscopoAcesso{
+		NaoRestritos:           true,
+		CategoriasRestritas:    []ticket.Categoria{ticket.CategoriaCompliance},
+		SubcategoriasRestritas: []ticket.Subcategoria{ticket.SubcategoriaKYC, ticket.SubcategoriaFraude},
+	}
+	resultado, err := repo.List(ticket.TicketFiltros{Escopo: escopo, AbertoPor: "cliente_escopo"})
+	if err != nil {
+		t.Fatalf("Erro ao listar tickets: %v", err)
+	}
+	if len(resultado.Tickets) != 0 {
+		t.Errorf("Ticket restrito não deveria aparecer, recebido %d tickets", len(resultado.Tickets))
+	}
+
+	// o próprio solicitante vê o ticket
+	escopo.UsuarioID = "cliente_escopo"
+	resultado, err = repo.List(ticket.TicketFiltros{Escopo: escopo, AbertoPor: "cliente_escopo"})
+	if err != nil {
+		t.Fatalf("Erro ao listar tickets: %v", err)
+	}
+	if len(resultado.Tickets) != 1 {
+		t.Errorf("Esperava 1 ticket, recebido %d", len(resultado.Tickets))
+	}
+}
+
+// Teste do relatório de reclassificações: a mudança aparece na categoria de origem e na de destino
+func TestTicketRepository_ListarReclassificacoes(t *testing.T) {
+	repo := setupTestDB(t)
+	validador := taxonomia.NovoValidador(NewTaxonomiaRepository(repo.db))
+	inicio := time.Now().Add(-time.Second)
+
+	testTicket := createTestTicket()
+	if err := repo.Create(testTicket); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	if err := testTicket.Reclassificar(ticket.CategoriaTI, ticket.SubcategoriaBug, validador, "analista"); err != nil {
+		t.Fatalf("Erro ao reclassificar ticket: %v", err)
+	}
+	if err := repo.Update(testTicket); err != nil {
+		t.Fatalf("Erro ao atualizar ticket: %v", err)
+	}
+
+	for _, categoria := range []ticket.Categoria{ticket.CategoriaFinanceiro, ticket.CategoriaTI} {
+		reclassificacoes, err := repo.ListarReclassificacoes(ticket.FiltroReclassificacoes{Categoria: categoria, DataInicio: inicio})
+		if err != nil {
+			t.Fatalf("Erro ao listar reclassificações: %v", err)
+		}
+		var encontrada *ticket.Reclassificacao
+		for _, r := range reclassificacoes {
+			if r.TicketID == testTicket.ID {
+				encontrada = r
+			}
+		}
+		if encontrada == nil {
+			t.Fatalf("Reclassificação não apareceu no relatório de %s", categoria)
+		}
+		if encontrada.CategoriaAnterior != ticket.CategoriaFinanceiro || encontrada.SubcategoriaAnterior != ticket.SubcategoriaBug ||
+			encontrada.CategoriaNova != ticket.CategoriaTI || encontrada.SubcategoriaNova != ticket.SubcategoriaBug ||
+			encontrada.UsuarioID != "analista" {
+			t.Errorf("Reclassificação diferente: %+v", encontrada)
+		}
+	}
+
+	// o escopo deixa de fora os tickets que o usuário não vê
+	reclassificacoes, err := repo.ListarReclassificacoes(ticket.FiltroReclassificacoes{
+		Categoria:  ticket.CategoriaTI,
+		DataInicio: inicio,
+		Escopo:     &ticket.EscopoAcesso{UsuarioID: "cliente_escopo"},
+	})
+	if err != nil {
+		t.Fatalf("Erro ao listar reclassificações: %v", err)
+	}
+	for _, r := range reclassificacoes {
+		if r.TicketID == testTicket.ID {
+			t.Error("Ticket fora do escopo apareceu no relatório")
+		}
+	}
+}
+
+// Teste do método ListarCompletos com os filtros das rotinas em segundo plano
+func TestTicketRepository_ListarCompletos(t *testing.T) {
+	repo := setupTestDB(t)
+	inicio := time.Now().Add(-time.Second)
+
+	testTicket := createTestTicket()
+	testTicket.DefinirPrazosSLA(time.Now().Add(time.Hour), time.Now().Add(8*time.Hour))
+	if err := repo.Create(testTicket); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	if err := testTicket.AdicionarObservacao("Cliente retornou", "usuario_teste"); err != nil {
+		t.Fatalf("Erro ao adicionar observação: %v", err)
+	}
+	testTicket.RegistrarEscalonamento("regra_teste", "sistema")
+	testTicket.RegistrarAlertaSLA(ticket.PrazoPrimeiraResposta, "sistema")
+	if err := repo.Update(testTicket); err != nil {
+		t.Fatalf("Erro ao atualizar ticket: %v", err)
+	}
+
+	encontrado := func(filtros ticket.TicketFiltros) *ticket.Ticket {
+		filtros.DataInicio = inicio
+		resultado, err := repo.ListarCompletos(filtros)
+		if err != nil {
+			t.Fatalf("Erro ao listar tickets completos: %v", err)
+		}
+		for _, tk := range resultado.Tickets {
+			if tk.ID == testTicket.ID {
+				return tk
+			}
+		}
+		return nil
+	}
+
+	// o ticket vem com os filhos, sem precisar de GetByID
+	tk := encontrado(ticket.TicketFiltros{Status: []ticket.Status{ticket.StatusAberto}})
+	if tk == nil || len(tk.Observacoes) != 1 || len(tk.Modificacoes) != len(testTicket.Modificacoes) {
+		t.Fatalf("Esperava o ticket com observação e modificações, recebido %+v", tk)
+	}
+
+	// parado desde a abertura, ou desde a observação quando ela conta
+	depois := testTicket.Observacoes[0].DataCriacao.Add(time.Minute)
+	entre := testTicket.Observacoes[0].DataCriacao.Add(-time.Millisecond)
+	casos := []struct {
+		nome     string
+		filtros  ticket.TicketFiltros
+		esperado bool
+	}{
+		{"parado antes do limite", ticket.TicketFiltros{ParadosAntesDe: depois}, true},
+		{"aberto depois do limite", ticket.TicketFiltros{ParadosAntesDe: inicio}, false},
+		{"observação depois do limite", ticket.TicketFiltros{ParadosAntesDe: entre, ParadosSemObservacao: true}, false},
+		{"regra já aplicada", ticket.TicketFiltros{ParadosAntesDe: depois, SemEscalonamento: "regra_teste"}, false},
+		{"outra regra", ticket.TicketFiltros{ParadosAntesDe: depois, SemEscalonamento: "outra_regra"}, true},
+		{"resolução sem alerta", ticket.TicketFiltros{PrazoSemAlerta: true}, true},
+	}
+	for _, c := range casos {
+		if (encontrado(c.filtros) != nil) != c.esperado {
+			t.Errorf("%s: esperava encontrado = %v", c.nome, c.esperado)
+		}
+	}
+
+	// com os dois prazos alertados, o ticket sai da rotina de alertas
+	testTicket.RegistrarAlertaSLA(ticket.PrazoResolucao, "sistema")
+	if err := repo.Update(testTicket); err != nil {
+		t.Fatalf("Erro ao atualizar ticket: %v", err)
+	}
+	if encontrado(ticket.TicketFiltros{PrazoSemAlerta: true}) != nil {
+		t.Error("Não esperava o ticket com todos os prazos alertados")
+	}
+}
@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"nox_tickets/internal/domain/usuario"
+
+	"github.com/lib/pq"
+)
+
+// código do PostgreSQL para violação de unicidade
+const codigoViolacaoUnica = "23505"
+
+type UsuarioRepository struct {
+	db *sql.DB
+}
+
+func NewUsuarioRepository(db *sql.DB) *UsuarioRepository {
+	return &UsuarioRepository{db: db}
+}
+
+// colunasUsuario são as colunas lidas por scanUsuario, na mesma ordem; as equipes vêm agregadas em um array
+const colunasUsuario = `
+	u.id, u.nome, COALESCE(u.email, ''), u.ativo, u.habilidades, u.data_criacao,
+	COALESCE((SELECT array_agg(ue.equipe_id::text ORDER BY ue.equipe_id) FROM usuarios_equipes ue WHERE ue.usuario_id = u.id), '{}')`
+
+func scanUsuario(row interface{ Scan(...interface{}) error }) (*usuario.Usuario, error) {
+	u := &usuario.Usuario{}
+	var habilidades, equipes pq.StringArray
+	if err := row.Scan(&u.ID, &u.Nome, &u.Email, &u.Ativo, &habilidades, &u.DataCriacao, &equipes); err != nil {
+		return nil, err
+	}
+	u.Habilidades = []string(habilidades)
+	u.Equipes = []string(equipes)
+	return u, nil
+}
+
+// criar um novo usuário, com as equipes
+func (r *UsuarioRepository) CriarUsuario(u *usuario.Usuario) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`INSERT INTO usuarios (id, nome, email, ativo, habilidades, data_criacao) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)`,
+		u.ID, u.Nome, u.Email, u.Ativo, textos(u.Habilidades), u.DataCriacao,
+	)
+	if violacaoUnica(err) {
+		return usuario.ErrUsuarioDuplicado
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := salvarEquipesDoUsuario(tx, u); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// buscar usuário por id
+func (r *UsuarioRepository) BuscarUsuario(id string) (*usuario.Usuario, error) {
+	u, err := scanUsuario(r.db.QueryRow("SELECT "+colunasUsuario+" FROM usuarios u WHERE u.id = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usuario.ErrUsuarioNaoEncontrado
+	}
+	return u, err
+}
+
+// buscar usuário pelo e-mail; os e-mails são gravados em minúsculas
+func (r *UsuarioRepository) BuscarUsuarioPorEmail(email string) (*usuario.Usuario, error) {
+	u, err := scanUsuario(r.db.QueryRow("SELECT "+colunasUsuario+" FROM usuarios u WHERE u.email = $1", strings.ToLower(strings.TrimSpace(email))))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usuario.ErrUsuarioNaoEncontrado
+	}
+	return u, err
+}
+
+// listar usuários, por nome
+func (r *UsuarioRepository) ListarUsuarios(filtros usuario.FiltrosUsuario) ([]*usuario.Usuario, error) {
+	condicoes := []string{}
+	args := []interface{}{}
+	if filtros.ApenasAtivos {
+		condicoes = append(condicoes, "u.ativo")
+	}
+	if filtros.EquipeID != "" {
+		args = append(args, filtros.EquipeID)
+		condicoes = append(condicoes, "EXISTS (SELECT 1 FROM usuarios_equipes ue WHERE ue.usuario_id = u.id AND ue.equipe_id::text = $1)")
+	}
+
+	query := "SELECT " + colunasUsuario + " FROM usuarios u"
+	if len(condicoes) > 0 {
+		query += " WHERE " + strings.Join(condicoes, " AND ")
+	}
+	query += " ORDER BY u.nome, u.id"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usuarios := []*usuario.Usuario{}
+	for rows.Next() {
+		u, err := scanUsuario(rows)
+		if err != nil {
+			return nil, err
+		}
+		usuarios = append(usuarios, u)
+	}
+	return usuarios, rows.Err()
+}
+
+// atualizar os dados e as equipes do usuário
+func (r *UsuarioRepository) AtualizarUsuario(u *usuario.Usuario) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`UPDATE usuarios SET nome = $1, email = NULLIF($2, ''), ativo = $3, habilidades = $4 WHERE id = $5`,
+		u.Nome, u.Email, u.Ativo, textos(u.Habilidades), u.ID,
+	)
+	if violacaoUnica(err) {
+		return usuario.ErrUsuarioDuplicado
+	}
+	if err != nil {
+		return err
+	}
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas == 0 {
+		return usuario.ErrUsuarioNaoEncontrado
+	}
+
+	// substitui as equipes do usuário
+	if _, err := tx.Exec("DELETE FROM usuarios_equipes WHERE usuario_id = $1", u.ID); err != nil {
+		return err
+	}
+	if err := salvarEquipesDoUsuario(tx, u); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// salvarEquipesDoUsuario insere as equipes do usuário de uma vez; equipes inexistentes
+// violam a chave estrangeira e viram ErrEquipeInvalida
+func salvarEquipesDoUsuario(tx *sql.Tx, u *usuario.Usuario) error {
+	if len(u.Equipes) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(
+		`INSERT INTO usuarios_equipes (usuario_id, equipe_id)
+		 SELECT $1, e FROM unnest($2::uuid[]) AS e
+		 ON CONFLICT DO NOTHING`,
+		u.ID, pq.Array(u.Equipes),
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && (pqErr.Code == "23503" || pqErr.Code == "22P02") {
+		return usuario.ErrEquipeInvalida
+	}
+	return err
+}
+
+// criar uma nova equipe
+func (r *UsuarioRepository) CriarEquipe(e *usuario.Equipe) error {
+	_, err := r.db.Exec(
+		`INSERT INTO equipes (id, nome, descricao, ativa, data_criacao) VALUES ($1, $2, $3, $4, $5)`,
+		e.ID, e.Nome, e.Descricao, e.Ativa, e.DataCriacao,
+	)
+	if violacaoUnica(err) {
+		return usuario.ErrEquipeDuplicada
+	}
+	return err
+}
+
+// buscar equipe por id
+func (r *UsuarioRepository) BuscarEquipe(id string) (*usuario.Equipe, error) {
+	e := &usuario.Equipe{}
+	err := r.db.QueryRow(
+		`SELECT id, nome, descricao, ativa, data_criacao FROM equipes WHERE id::text = $1`,
+		id,
+	).Scan(&e.ID, &e.Nome, &e.Descricao, &e.Ativa, &e.DataCriacao)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usuario.ErrEquipeNaoEncontrada
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// listar equipes, por nome
+func (r *UsuarioRepository) ListarEquipes(apenasAtivas bool) ([]*usuario.Equipe, error) {
+	rows, err := r.db.Query(
+		`SELECT id, nome, descricao, ativa, data_criacao FROM equipes
+		 WHERE ativa OR NOT $1
+		 ORDER BY nome`,
+		apenasAtivas,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	equipes := []*usuario.Equipe{}
+	for rows.Next() {
+		e := &usuario.Equipe{}
+		if err := rows.Scan(&e.ID, &e.Nome, &e.Descricao, &e.Ativa, &e.DataCriacao); err != nil {
+			return nil, err
+		}
+		equipes = append(equipes, e)
+	}
+	return equipes, rows.Err()
+}
+
+// atualizar os dados da equipe
+func (r *UsuarioRepository) AtualizarEquipe(e *usuario.Equipe) error {
+	result, err := r.db.Exec(
+		`UPDATE equipes SET nome = $1, descricao = $2, ativa = $3 WHERE id::text = $4`,
+		e.Nome, e.Descricao, e.Ativa, e.ID,
+	)
+	if violacaoUnica(err) {
+		return usuario.ErrEquipeDuplicada
+	}
+	if err != nil {
+		return err
+	}
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas == 0 {
+		return usuario.ErrEquipeNaoEncontrada
+	}
+	return nil
+}
+
+func violacaoUnica(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == codigoViolacaoUnica
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"nox_tickets/internal/domain/usuario"
+)
+
+func setupUsuarioRepository(t *testing.T) (*UsuarioRepository, *TicketRepository) {
+	ticketRepo := setupTestDB(t)
+	return NewUsuarioRepository(ticketRepo.db), ticketRepo
+}
+
+// Teste do cadastro de usuário com equipe
+func TestUsuarioRepository_CriarEBuscar(t *testing.T) {
+	repo, _ := setupUsuarioRepository(t)
+
+	equipe, _ := usuario.NovaEquipe("Financeiro", "Atendimento financeiro")
+	equipe.Nome += " " + equipe.ID[:8] // nome único entre execuções
+	if err := repo.CriarEquipe(equipe); err != nil {
+		t.Fatalf("Erro ao criar equipe: %v", err)
+	}
+
+	u, _ := usuario.NovoUsuario("", "Analista Financeiro", "")
+	u.Equipes = []string{equipe.ID}
+	if err := repo.CriarUsuario(u); err != nil {
+		t.Fatalf("Erro ao criar usuário: %v", err)
+	}
+
+	salvo, err := repo.BuscarUsuario(u.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar usuário: %v", err)
+	}
+	if salvo.Nome != u.Nome || !salvo.Ativo || !salvo.PertenceA(equipe.ID) {
+		t.Errorf("Usuário diferente do esperado: %+v", salvo)
+	}
+
+	if err := repo.CriarUsuario(u); !errors.Is(err, usuario.ErrUsuarioDuplicado) {
+		t.Errorf("Esperava ErrUsuarioDuplicado, recebido %v", err)
+	}
+	if _, err := repo.BuscarUsuario("nao-existe"); !errors.Is(err, usuario.ErrUsuarioNaoEncontrado) {
+		t.Errorf("Esperava ErrUsuarioNaoEncontrado, recebido %v", err)
+	}
+}
+
+// Teste da chave estrangeira: o responsável precisa ser um usuário cadastrado
+func TestTicketRepository_ResponsavelReferenciaUsuario(t *testing.T) {
+	_, ticketRepo := setupUsuarioRepository(t)
+
+	tk := createTestTicket()
+	tk.Responsavel = "responsavel-inexistente"
+	if err := ticketRepo.Create(tk); err == nil {
+		t.Error("Esperava erro ao criar ticket com responsável inexistente")
+	}
+
+	// sem responsável, a coluna fica nula e o ticket volta com responsável vazio
+	tk = createTestTicket()
+	if err := ticketRepo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	salvo, err := ticketRepo.GetByID(tk.ID)
+	if err != nil {
+		t.Fatalf("Erro ao buscar ticket: %v", err)
+	}
+	if salvo.Responsavel != "" {
+		t.Errorf("Esperava responsável vazio, recebido %q", salvo.Responsavel)
+	}
+}
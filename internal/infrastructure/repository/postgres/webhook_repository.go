@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/webhook"
+
+	"github.com/lib/pq"
+)
+
+// reservaEntrega é por quanto tempo as entregas retornadas por Pendentes ficam reservadas
+// para quem as buscou, para que outra instância do worker não as envie ao mesmo tempo
+const reservaEntrega = 5 * time.Minute
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// colunasAssinatura são as colunas lidas por scanAssinatura, na mesma ordem
+const colunasAssinatura = `id, url, segredo, eventos, categorias, ativo, criado_por, data_criacao`
+
+func scanAssinatura(row interface{ Scan(...interface{}) error }) (*webhook.Assinatura, error) {
+	a := &webhook.Assinatura{}
+	var eventos, categorias pq.StringArray
+	if err := row.Scan(&a.ID, &a.URL, &a.Segredo, &eventos, &categorias, &a.Ativa, &a.CriadoPor, &a.DataCriacao); err != nil {
+		return nil, err
+	}
+	for _, e := range eventos {
+		a.Eventos = append(a.Eventos, ticket.TipoEvento(e))
+	}
+	for _, c := range categorias {
+		a.Categorias = append(a.Categorias, ticket.Categoria(c))
+	}
+	return a, nil
+}
+
+// criar uma nova assinatura
+func (r *WebhookRepository) CriarAssinatura(a *webhook.Assinatura) error {
+	_, err := r.db.Exec(
+		`INSERT INTO webhooks (`+colunasAssinatura+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		a.ID, a.URL, a.Segredo, textos(a.Eventos), textos(a.Categorias), a.Ativa, a.CriadoPor, a.DataCriacao,
+	)
+	return err
+}
+
+// buscar assinatura por id
+func (r *WebhookRepository) BuscarAssinatura(id string) (*webhook.Assinatura, error) {
+	a, err := scanAssinatura(r.db.QueryRow("SELECT "+colunasAssinatura+" FROM webhooks WHERE id::text = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, webhook.ErrAssinaturaNaoEncontrada
+	}
+	return a, err
+}
+
+// listar assinaturas, das mais antigas às mais novas
+func (r *WebhookRepository) ListarAssinaturas(apenasAtivas bool) ([]*webhook.Assinatura, error) {
+	rows, err := r.db.Query(
+		"SELECT "+colunasAssinatura+" FROM webhooks WHERE ativo OR NOT $1 ORDER BY data_criacao",
+		apenasAtivas,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assinaturas := []*webhook.Assinatura{}
+	for rows.Next() {
+		a, err := scanAssinatura(rows)
+		if err != nil {
+			return nil, err
+		}
+		assinaturas = append(assinaturas, a)
+	}
+	return assinaturas, rows.Err()
+}
+
+// atualizar a assinatura
+func (r *WebhookRepository) AtualizarAssinatura(a *webhook.Assinatura) error {
+	result, err := r.db.Exec(
+		`UPDATE webhooks SET url = $1, segredo = $2, eventos = $3, categorias = $4, ativo = $5 WHERE id::text = $6`,
+		a.URL, a.Segredo, textos(a.Eventos), textos(a.Categorias), a.Ativa, a.ID,
+	)
+	if err != nil {
+		return err
+	}
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas == 0 {
+		return webhook.ErrAssinaturaNaoEncontrada
+	}
+	return nil
+}
+
+// Enfileirar grava as novas entregas pendentes em uma única transação; a entrega
+// de um evento que o webhook já recebeu é ignorada, pois o relay pode repetir eventos
+func (r *WebhookRepository) Enfileirar(entregas []*webhook.Entrega) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(
+		`INSERT INTO webhook_entregas (id, webhook_id, evento_id, tipo, ticket_id, payload, status, tentativas, proxima_tentativa, data_criacao)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (webhook_id, evento_id) DO NOTHING`,
+	)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range entregas {
+		_, err := stmt.Exec(e.ID, e.AssinaturaID, e.EventoID, e.Tipo, e.TicketID, e.Payload,
+			e.Status, e.Tentativas, e.ProximaTentativa, e.DataCriacao)
+		if err != nil {
+			return fmt.Errorf("erro ao enfileirar entrega: %v", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// colunasEntrega são as colunas lidas por scanEntrega, na mesma ordem
+const colunasEntrega = `id, webhook_id, evento_id, tipo, ticket_id, payload, status, tentativas,
+	proxima_tentativa, ultimo_erro, data_criacao, data_conclusao`
+
+func scanEntrega(row interface{ Scan(...interface{}) error }) (*webhook.Entrega, error) {
+	e := &webhook.Entrega{}
+	err := row.Scan(&e.ID, &e.AssinaturaID, &e.EventoID, &e.Tipo, &e.TicketID, &e.Payload, &e.Status, &e.Tentativas,
+		&e.ProximaTentativa, &e.UltimoErro, &e.DataCriacao, &e.DataConclusao)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Pendentes reserva e retorna as entregas vencidas. A reserva adia a próxima tentativa,
+// e SKIP LOCKED evita que duas instâncias peguem a mesma entrega.
+func (r *WebhookRepository) Pendentes(agora time.Time, limite int) ([]*webhook.Entrega, error) {
+	rows, err := r.db.Query(
+		`UPDATE webhook_entregas SET proxima_tentativa = $1
+		 WHERE id IN (
+			SELECT id FROM webhook_entregas
+			WHERE status = $2 AND proxima_tentativa <= $3
+			ORDER BY proxima_tentativa
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+colunasEntrega,
+		agora.Add(reservaEntrega), webhook.EntregaPendente, agora, limite,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entregas := []*webhook.Entrega{}
+	for rows.Next() {
+		e, err := scanEntrega(rows)
+		if err != nil {
+			return nil, err
+		}
+		entregas = append(entregas, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// o RETURNING não garante a ordem
+	sort.Slice(entregas, func(i, j int) bool { return entregas[i].DataCriacao.Before(entregas[j].DataCriacao) })
+	return entregas, nil
+}
+
+// SalvarTentativa grava a tentativa no log, atualiza a entrega e, se ela foi descartada, copia para a dead-letter
+func (r *WebhookRepository) SalvarTentativa(e *webhook.Entrega, t webhook.Tentativa) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`INSERT INTO webhook_tentativas (entrega_id, numero, status_http, erro, duracao_ms, data) VALUES ($1, $2, $3, $4, $5, $6)`,
+		e.ID, t.Numero, t.StatusHTTP, t.Erro, t.Duracao.Milliseconds(), t.Data,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar tentativa: %v", err)
+	}
+
+	_, err = tx.Exec(
+		`UPDATE webhook_entregas SET status = $1, tentativas = $2, proxima_tentativa = $3, ultimo_erro = $4, data_conclusao = $5
+		 WHERE id = $6`,
+		e.Status, e.Tentativas, e.ProximaTentativa, e.UltimoErro, e.DataConclusao, e.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar entrega: %v", err)
+	}
+
+	if e.Status == webhook.EntregaDescartada {
+		_, err = tx.Exec(
+			`INSERT INTO webhook_dead_letter (entrega_id, webhook_id, tipo, payload, tentativas, ultimo_erro, data_descarte)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 ON CONFLICT (entrega_id) DO NOTHING`,
+			e.ID, e.AssinaturaID, e.Tipo, e.Payload, e.Tentativas, e.UltimoErro, t.Data,
+		)
+		if err != nil {
+			return fmt.Errorf("erro ao mover entrega para a dead-letter: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListarEntregas retorna as entregas mais recentes da assinatura com as tentativas de cada uma
+func (r *WebhookRepository) ListarEntregas(filtros webhook.FiltrosEntrega) ([]*webhook.Entrega, error) {
+	condicoes := []string{"webhook_id::text = $1"}
+	args := []interface{}{filtros.AssinaturaID}
+	if filtros.Status != "" {
+		args = append(args, filtros.Status)
+		condicoes = append(condicoes, fmt.Sprintf("status = $%d", len(args)))
+	}
+	query := "SELECT " + colunasEntrega + " FROM webhook_entregas WHERE " + strings.Join(condicoes, " AND ") +
+		" ORDER BY data_criacao DESC"
+	if filtros.Limite > 0 {
+		args = append(args, filtros.Limite)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entregas := []*webhook.Entrega{}
+	porID := map[string]*webhook.Entrega{}
+	ids := []string{}
+	for rows.Next() {
+		e, err := scanEntrega(rows)
+		if err != nil {
+			return nil, err
+		}
+		entregas = append(entregas, e)
+		porID[e.ID] = e
+		ids = append(ids, e.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return entregas, nil
+	}
+
+	// tentativas de todas as entregas em uma única consulta
+	tentativas, err := r.db.Query(
+		`SELECT entrega_id, numero, status_http, erro, duracao_ms, data
+		 FROM webhook_tentativas WHERE entrega_id::text = ANY($1) ORDER BY entrega_id, numero`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer tentativas.Close()
+
+	for tentativas.Next() {
+		var entregaID string
+		var t webhook.Tentativa
+		var duracaoMs int64
+		if err := tentativas.Scan(&entregaID, &t.Numero, &t.StatusHTTP, &t.Erro, &duracaoMs, &t.Data); err != nil {
+			return nil, err
+		}
+		t.Duracao = time.Duration(duracaoMs) * time.Millisecond
+		porID[entregaID].Historico = append(porID[entregaID].Historico, t)
+	}
+	return entregas, tentativas.Err()
+}
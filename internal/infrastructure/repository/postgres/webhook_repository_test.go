@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/webhook"
+
+	"github.com/google/uuid"
+)
+
+// Teste da fila de entregas: reserva das pendentes, log de tentativas e dead-letter
+func TestWebhookRepository_FilaDeEntregas(t *testing.T) {
+	ticketRepo := setupTestDB(t)
+	repo := NewWebhookRepository(ticketRepo.db)
+
+	a, _ := webhook.NovaAssinatura("https://exemplo.com/webhook", nil, []ticket.Categoria{ticket.CategoriaFinanceiro}, "usuario_teste")
+	if err := repo.CriarAssinatura(a); err != nil {
+		t.Fatalf("Erro ao criar assinatura: %v", err)
+	}
+
+	tk := createTestTicket()
+	if err := ticketRepo.Create(tk); err != nil {
+		t.Fatalf("Erro ao criar ticket: %v", err)
+	}
+	nova := &webhook.Entrega{
+		ID:               uuid.New().String(),
+		AssinaturaID:     a.ID,
+		EventoID:         uuid.New().String(),
+		Tipo:             ticket.EventoTicketCriado,
+		TicketID:         tk.ID,
+		Payload:          []byte(`{"tipo":"ticket.criado"}`),
+		Status:           webhook.EntregaPendente,
+		ProximaTentativa: time.Now(),
+		DataCriacao:      time.Now(),
+	}
+	if err := repo.Enfileirar([]*webhook.Entrega{nova}); err != nil {
+		t.Fatalf("Erro ao enfileirar entrega: %v", err)
+	}
+
+	// a entrega pendente é reservada por quem a busca
+	agora := time.Now()
+	pendentes, err := repo.Pendentes(agora.Add(time.Second), 1000)
+	if err != nil {
+		t.Fatalf("Erro ao buscar pendentes: %v", err)
+	}
+	var entrega *webhook.Entrega
+	for _, e := range pendentes {
+		if e.AssinaturaID == a.ID {
+			entrega = e
+		}
+	}
+	if entrega == nil {
+		t.Fatal("Entrega da assinatura não retornada como pendente")
+	}
+	novamente, _ := repo.Pendentes(agora.Add(time.Second), 1000)
+	for _, e := range novamente {
+		if e.ID == entrega.ID {
+			t.Error("Entrega reservada não deveria ser retornada de novo")
+		}
+	}
+
+	// esgota as tentativas
+	for entrega.Status == webhook.EntregaPendente {
+		tentativa := entrega.RegistrarTentativa(webhook.Tentativa{StatusHTTP: 500, Data: time.Now()})
+		if err := repo.SalvarTentativa(entrega, tentativa); err != nil {
+			t.Fatalf("Erro ao salvar tentativa: %v", err)
+		}
+	}
+
+	entregas, err := repo.ListarEntregas(webhook.FiltrosEntrega{AssinaturaID: a.ID, Status: webhook.EntregaDescartada})
+	if err != nil {
+		t.Fatalf("Erro ao listar entregas: %v", err)
+	}
+	if len(entregas) != 1 || len(entregas[0].Historico) != webhook.MaxTentativas {
+		t.Fatalf("Esperava uma entrega descartada com %d tentativas, recebido %+v", webhook.MaxTentativas, entregas)
+	}
+
+	var naDeadLetter int
+	ticketRepo.db.QueryRow("SELECT COUNT(*) FROM webhook_dead_letter WHERE entrega_id = $1", entrega.ID).Scan(&naDeadLetter)
+	if naDeadLetter != 1 {
+		t.Error("Esperava a entrega na dead-letter")
+	}
+
+	a.Ativa = false
+	repo.AtualizarAssinatura(a)
+}
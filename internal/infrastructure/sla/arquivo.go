@@ -0,0 +1,110 @@
+package sla
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"nox_tickets/internal/domain/sla"
+	"nox_tickets/internal/domain/ticket"
+)
+
+// arquivoSLA é o formato do arquivo de configuração das políticas de SLA: os prazos de cada prioridade
+// da matriz urgência × gravidade e as políticas por categoria, subcategoria e célula da matriz
+type arquivoSLA struct {
+	Prioridades map[string]arquivoPrazos `json:"prioridades"`
+	Politicas   []arquivoPolitica        `json:"politicas"`
+}
+
+// arquivoPrazos usa a notação de duração do Go (ex.: "30m", "24h"), contada em tempo útil
+type arquivoPrazos struct {
+	PrimeiraResposta string `json:"primeira_resposta"`
+	Resolucao        string `json:"resolucao"`
+}
+
+// arquivoPolitica descreve uma política; campos omitidos funcionam como curinga
+type arquivoPolitica struct {
+	Nome         string `json:"nome"`
+	Categoria    string `json:"categoria,omitempty"`
+	Subcategoria string `json:"subcategoria,omitempty"`
+	Urgencia     int    `json:"urgencia,omitempty"`
+	Gravidade    int    `json:"gravidade,omitempty"`
+	arquivoPrazos
+}
+
+// CarregarArquivo lê as políticas de SLA do arquivo JSON: a matriz de prioridades e, por cima dela,
+// as políticas mais específicas
+func CarregarArquivo(caminho string) ([]sla.Politica, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de SLA: %v", err)
+	}
+
+	var arquivo arquivoSLA
+	if err := json.Unmarshal(conteudo, &arquivo); err != nil {
+		return nil, fmt.Errorf("erro ao interpretar arquivo de SLA: %v", err)
+	}
+
+	// 1. prazos da matriz por prioridade
+	prazos := make(sla.PrazosPorPrioridade)
+	for chave, p := range arquivo.Prioridades {
+		prioridade, err := strconv.Atoi(chave)
+		if err != nil || prioridade < 1 || prioridade > 4 {
+			return nil, fmt.Errorf("prioridade inválida %q, use de 1 a 4", chave)
+		}
+		primeiraResposta, resolucao, err := p.duracoes()
+		if err != nil {
+			return nil, fmt.Errorf("prioridade %d: %v", prioridade, err)
+		}
+		prazos[prioridade] = [2]time.Duration{primeiraResposta, resolucao}
+	}
+	politicas := sla.Matriz(prazos)
+	for _, p := range politicas {
+		if err := p.Validar(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. políticas por categoria, subcategoria e célula da matriz
+	nomes := make(map[string]bool)
+	for _, p := range arquivo.Politicas {
+		primeiraResposta, resolucao, err := p.duracoes()
+		if err != nil {
+			return nil, fmt.Errorf("política %s: %v", p.Nome, err)
+		}
+		politica := sla.Politica{
+			Nome:             p.Nome,
+			Categoria:        ticket.Categoria(strings.ToLower(p.Categoria)),
+			Subcategoria:     ticket.Subcategoria(strings.ToLower(p.Subcategoria)),
+			Urgencia:         p.Urgencia,
+			Gravidade:        p.Gravidade,
+			PrimeiraResposta: primeiraResposta,
+			Resolucao:        resolucao,
+		}
+		if err := politica.Validar(); err != nil {
+			return nil, err
+		}
+		if nomes[politica.Nome] {
+			return nil, fmt.Errorf("política %s duplicada", politica.Nome)
+		}
+		nomes[politica.Nome] = true
+		politicas = append(politicas, politica)
+	}
+
+	return politicas, nil
+}
+
+func (p arquivoPrazos) duracoes() (time.Duration, time.Duration, error) {
+	primeiraResposta, err := time.ParseDuration(p.PrimeiraResposta)
+	if err != nil {
+		return 0, 0, fmt.Errorf("prazo de primeira resposta inválido %q", p.PrimeiraResposta)
+	}
+	resolucao, err := time.ParseDuration(p.Resolucao)
+	if err != nil {
+		return 0, 0, fmt.Errorf("prazo de resolução inválido %q", p.Resolucao)
+	}
+	return primeiraResposta, resolucao, nil
+}
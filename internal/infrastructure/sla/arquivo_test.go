@@ -0,0 +1,71 @@
+package sla
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nox_tickets/internal/domain/sla"
+	"nox_tickets/internal/domain/ticket"
+)
+
+func escreverArquivo(t *testing.T, conteudo string) string {
+	caminho := filepath.Join(t.TempDir(), "sla.json")
+	if err := os.WriteFile(caminho, []byte(conteudo), 0o600); err != nil {
+		t.Fatalf("Erro ao escrever arquivo: %v", err)
+	}
+	return caminho
+}
+
+// Teste da política por categoria: vence a matriz nos tickets da categoria e não afeta os demais
+func TestCarregarArquivo_PoliticaPorCategoria(t *testing.T) {
+	caminho := escreverArquivo(t, `{
+		"prioridades": {"4": {"primeira_resposta": "12h", "resolucao": "48h"}},
+		"politicas": [
+			{"nome": "financeiro", "categoria": "Financeiro", "primeira_resposta": "2h", "resolucao": "8h"}
+		]
+	}`)
+	politicas, err := CarregarArquivo(caminho)
+	if err != nil {
+		t.Fatalf("Erro ao carregar arquivo: %v", err)
+	}
+	motor := sla.NovoMotor(politicas, nil)
+
+	saque, _ := ticket.NovoTicket("Saque", "Solicitação de saque", ticket.CategoriaFinanceiro, ticket.SubcategoriaSolicitacaoSaque, "cliente")
+	motor.Aplicar(saque)
+	if got := saque.SLA.PrazoResolucao.Sub(saque.DataAbertura); got != 8*time.Hour {
+		t.Errorf("Esperava a resolução da categoria (8h), recebido %s", got)
+	}
+
+	// fora da categoria vale a matriz, com a prioridade 4 configurada e as demais no padrão
+	bug, _ := ticket.NovoTicket("Bug", "Erro no sistema", ticket.CategoriaTI, ticket.SubcategoriaBug, "cliente")
+	bug.Urgencia, bug.Gravidade = 1, 1
+	motor.Aplicar(bug)
+	if got := bug.SLA.PrazoResolucao.Sub(bug.DataAbertura); got != 48*time.Hour {
+		t.Errorf("Esperava a resolução da prioridade 4 (48h), recebido %s", got)
+	}
+	bug.Urgencia, bug.Gravidade = 5, 5
+	motor.Aplicar(bug)
+	if got := bug.SLA.PrazoResolucao.Sub(bug.DataAbertura); got != 4*time.Hour {
+		t.Errorf("Esperava a resolução padrão da prioridade 1 (4h), recebido %s", got)
+	}
+}
+
+func TestCarregarArquivo_Invalido(t *testing.T) {
+	casos := map[string]string{
+		"prioridade inexistente":     `{"prioridades": {"5": {"primeira_resposta": "1h", "resolucao": "2h"}}}`,
+		"prazo inválido":             `{"politicas": [{"nome": "x", "categoria": "ti", "primeira_resposta": "1 hora", "resolucao": "2h"}]}`,
+		"resolução antes":            `{"politicas": [{"nome": "x", "categoria": "ti", "primeira_resposta": "4h", "resolucao": "2h"}]}`,
+		"subcategoria sem categoria": `{"politicas": [{"nome": "x", "subcategoria": "bug", "primeira_resposta": "1h", "resolucao": "2h"}]}`,
+		"nome repetido": `{"politicas": [
+			{"nome": "x", "categoria": "ti", "primeira_resposta": "1h", "resolucao": "2h"},
+			{"nome": "x", "categoria": "financeiro", "primeira_resposta": "1h", "resolucao": "2h"}
+		]}`,
+	}
+	for nome, conteudo := range casos {
+		if _, err := CarregarArquivo(escreverArquivo(t, conteudo)); err == nil {
+			t.Errorf("%s: esperava erro", nome)
+		}
+	}
+}
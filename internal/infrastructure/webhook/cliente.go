@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ClienteHTTP envia as entregas de webhook por HTTP POST
+type ClienteHTTP struct {
+	http *http.Client
+}
+
+// NovoClienteHTTP cria o cliente com o tempo limite de cada envio
+func NovoClienteHTTP(timeout time.Duration) *ClienteHTTP {
+	return &ClienteHTTP{http: &http.Client{Timeout: timeout}}
+}
+
+// Enviar faz o POST do corpo JSON com os cabeçalhos informados e retorna o status da resposta
+func (c *ClienteHTTP) Enviar(ctx context.Context, url string, cabecalhos map[string]string, corpo []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(corpo))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nox-tickets-webhook/1.0")
+	for nome, valor := range cabecalhos {
+		req.Header.Set(nome, valor)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// descarta a resposta para a conexão poder ser reaproveitada
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	ticketUseCase "nox_tickets/internal/application/usecases/ticket"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	ErrMultipartInvalido = ticketDomain.NovoErroValidacao("", "multipart_invalido", "o envio de anexos deve ser multipart/form-data")
+	ErrArquivoAusente    = ticketDomain.NovoErroValidacao("arquivo", "arquivo_obrigatorio", "nenhum arquivo enviado no campo \"arquivo\"")
+	ErrEnvioGrande       = ticketDomain.NovoErroValidacao("arquivo", "envio_muito_grande", "o envio ultrapassa o tamanho máximo da requisição")
+	ErrArquivosDemais    = ticketDomain.NovoErroValidacao("arquivo", "arquivos_demais", "número máximo de arquivos por envio ultrapassado")
+)
+
+const (
+	// tamanho máximo dos campos de texto do formulário de envio
+	maxCampoFormulario = 1 << 10
+
+	// arquivos por envio; o corpo da requisição é limitado a esse número de arquivos do tamanho máximo
+	maxArquivosPorEnvio = 10
+
+	// prazo para receber ou enviar o conteúdo dos anexos, no lugar do prazo curto das demais rotas,
+	// para que clientes lentos não sejam cortados no meio da transferência
+	prazoTransferencia = 10 * time.Minute
+)
+
+// AnexoHandler contém os handlers dos arquivos anexados aos tickets
+type AnexoHandler struct {
+	adicionarAnexoUseCase *ticketUseCase.AdicionarAnexoUseCase
+	listarAnexosUseCase   *ticketUseCase.ListarAnexosUseCase
+	baixarAnexoUseCase    *ticketUseCase.BaixarAnexoUseCase
+	removerAnexoUseCase   *ticketUseCase.RemoverAnexoUseCase
+	tamanhoMaximoEnvio    int64
+}
+
+// NewAnexoHandler cria uma nova instancia de AnexoHandler
+func NewAnexoHandler(
+	adicionarAnexoUseCase *ticketUseCase.AdicionarAnexoUseCase,
+	listarAnexosUseCase *ticketUseCase.ListarAnexosUseCase,
+	baixarAnexoUseCase *ticketUseCase.BaixarAnexoUseCase,
+	removerAnexoUseCase *ticketUseCase.RemoverAnexoUseCase,
+	tamanhoMaximoArquivo int64,
+) *AnexoHandler {
+	return &AnexoHandler{
+		adicionarAnexoUseCase: adicionarAnexoUseCase,
+		listarAnexosUseCase:   listarAnexosUseCase,
+		baixarAnexoUseCase:    baixarAnexoUseCase,
+		removerAnexoUseCase:   removerAnexoUseCase,
+		// os arquivos mais os cabeçalhos das partes e os campos de texto
+		tamanhoMaximoEnvio: maxArquivosPorEnvio*tamanhoMaximoArquivo + 1<<20,
+	}
+}
+
+// Response com os metadados de um anexo
+type AnexoResponse struct {
+	ID           string `json:"id"`
+	TicketID     string `json:"ticket_id"`
+	ObservacaoID string `json:"observacao_id,omitempty"`
+	Nome         string `json:"nome"`
+	TipoMIME     string `json:"tipo_mime"`
+	Tamanho      int64  `json:"tamanho"`
+	SHA256       string `json:"sha256"`
+	EnviadoPor   string `json:"enviado_por"`
+	DataCriacao  string `json:"data_criacao"`
+}
+
+// Response do envio interrompido por um erro depois de gravados alguns arquivos (207)
+type EnvioParcialResponse struct {
+	Anexos  []AnexoResponse `json:"anexos"`  // arquivos gravados
+	Arquivo string          `json:"arquivo"` // arquivo em que o envio parou; ele e os seguintes não foram gravados
+	Erro    ErroResponse    `json:"erro"`
+}
+
+func novosAnexosResponse(outputs []ticketUseCase.AnexoOutput) []AnexoResponse {
+	resp := make([]AnexoResponse, 0, len(outputs))
+	for _, o := range outputs {
+		resp = append(resp, AnexoResponse(o))
+	}
+	return resp
+}
+
+func responderAnexos(w http.ResponseWriter, status int, outputs []ticketUseCase.AnexoOutput) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(novosAnexosResponse(outputs))
+}
+
+// responderErroEnvio responde o erro do envio. Se arquivos já foram gravados, eles continuam no ticket,
+// e a resposta é 207 com os gravados e o erro, para que o cliente reenvie só o restante
+func responderErroEnvio(w http.ResponseWriter, enviados []ticketUseCase.AnexoOutput, arquivo string, err error) {
+	var grande *http.MaxBytesError
+	if errors.As(err, &grande) {
+		err = ErrEnvioGrande
+	}
+	if len(enviados) == 0 {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMultiStatus)
+	json.NewEncoder(w).Encode(EnvioParcialResponse{
+		Anexos:  novosAnexosResponse(enviados),
+		Arquivo: arquivo,
+		Erro:    novoErroResponse(err),
+	})
+}
+
+// estenderPrazo troca os prazos de leitura e escrita do servidor pelo prazo da transferência de anexos
+func estenderPrazo(w http.ResponseWriter) {
+	rc := http.NewResponseController(w)
+	prazo := time.Now().Add(prazoTransferencia)
+	if err := rc.SetReadDeadline(prazo); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("erro ao estender o prazo de leitura: %v", err)
+	}
+	if err := rc.SetWriteDeadline(prazo); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("erro ao estender o prazo de escrita: %v", err)
+	}
+}
+
+// Adicionar é o handler para enviar arquivos ao ticket. O corpo é multipart/form-data com um ou
+// mais campos "arquivo" e, opcionalmente, antes deles, o campo "observacao_id". Os arquivos são
+// lidos direto da requisição, sem passar por arquivos temporários, e gravados um a um.
+func (h *AnexoHandler) Adicionar(w http.ResponseWriter, r *http.Request) {
+	estenderPrazo(w)
+	r.Body = http.MaxBytesReader(w, r.Body, h.tamanhoMaximoEnvio)
+
+	leitor, err := r.MultipartReader()
+	if err != nil {
+		responderErro(w, ErrMultipartInvalido)
+		return
+	}
+
+	var observacaoID string
+	var enviados []ticketUseCase.AnexoOutput
+	for {
+		parte, err := leitor.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			responderErroEnvio(w, enviados, "", corpoInvalidoOuGrande(err))
+			return
+		}
+
+		switch parte.FormName() {
+		case "observacao_id":
+			valor, err := io.ReadAll(io.LimitReader(parte, maxCampoFormulario))
+			if err != nil {
+				responderErroEnvio(w, enviados, "", corpoInvalidoOuGrande(err))
+				return
+			}
+			observacaoID = string(valor)
+		case "arquivo":
+			if len(enviados) == maxArquivosPorEnvio {
+				responderErroEnvio(w, enviados, parte.FileName(), ErrArquivosDemais)
+				return
+			}
+			output, err := h.adicionarAnexoUseCase.Execute(r.Context(), ticketUseCase.AdicionarAnexoInput{
+				ID:           chi.URLParam(r, "id"),
+				ObservacaoID: observacaoID,
+				Nome:         parte.FileName(),
+				Conteudo:     parte,
+			})
+			if err != nil {
+				responderErroEnvio(w, enviados, parte.FileName(), err)
+				return
+			}
+			enviados = append(enviados, *output)
+		}
+		parte.Close()
+	}
+
+	if len(enviados) == 0 {
+		responderErro(w, ErrArquivoAusente)
+		return
+	}
+	responderAnexos(w, http.StatusCreated, enviados)
+}
+
+// corpoInvalidoOuGrande mantém o erro do limite da requisição, que vira ErrEnvioGrande na resposta
+func corpoInvalidoOuGrande(err error) error {
+	var grande *http.MaxBytesError
+	if errors.As(err, &grande) {
+		return err
+	}
+	return corpoInvalido(err)
+}
+
+// Listar é o handler para obter os anexos de um ticket
+func (h *AnexoHandler) Listar(w http.ResponseWriter, r *http.Request) {
+	outputs, err := h.listarAnexosUseCase.Execute(r.Context(), ticketUseCase.ListarAnexosInput{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderAnexos(w, http.StatusOK, outputs)
+}
+
+// Baixar é o handler para obter o conteúdo de um anexo. O arquivo vai sempre como download,
+// com o tipo detectado no envio, para que o navegador não o interprete como página.
+func (h *AnexoHandler) Baixar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.baixarAnexoUseCase.Execute(r.Context(), ticketUseCase.AnexoInput{
+		ID:      chi.URLParam(r, "id"),
+		AnexoID: chi.URLParam(r, "anexoID"),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	defer output.Conteudo.Close()
+
+	estenderPrazo(w)
+	w.Header().Set("Content-Type", output.TipoMIME)
+	w.Header().Set("Content-Length", strconv.FormatInt(output.Tamanho, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": output.Nome}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("X-Nox-SHA256", output.SHA256)
+	io.Copy(w, output.Conteudo)
+}
+
+// Remover é o handler para remover um anexo do ticket
+func (h *AnexoHandler) Remover(w http.ResponseWriter, r *http.Request) {
+	err := h.removerAnexoUseCase.Execute(r.Context(), ticketUseCase.AnexoInput{
+		ID:      chi.URLParam(r, "id"),
+		AnexoID: chi.URLParam(r, "anexoID"),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
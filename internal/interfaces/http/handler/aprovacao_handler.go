@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	ticketUseCase "nox_tickets/internal/application/usecases/ticket"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AprovacaoHandler contém os handlers da aprovação de tickets (ex.: solicitações de saque)
+type AprovacaoHandler struct {
+	buscarAprovacoesUseCase *ticketUseCase.BuscarAprovacoesUseCase
+	decidirAprovacaoUseCase *ticketUseCase.DecidirAprovacaoUseCase
+}
+
+// NewAprovacaoHandler cria uma nova instancia de AprovacaoHandler
+func NewAprovacaoHandler(
+	buscarAprovacoesUseCase *ticketUseCase.BuscarAprovacoesUseCase,
+	decidirAprovacaoUseCase *ticketUseCase.DecidirAprovacaoUseCase,
+) *AprovacaoHandler {
+	return &AprovacaoHandler{
+		buscarAprovacoesUseCase: buscarAprovacoesUseCase,
+		decidirAprovacaoUseCase: decidirAprovacaoUseCase,
+	}
+}
+
+// Request para aprovar ou rejeitar a etapa pendente
+type DecidirAprovacaoRequest struct {
+	Decisao    ticketDomain.DecisaoAprovacao `json:"decisao"`
+	Comentario string                        `json:"comentario"`
+}
+
+// Response com a situação da aprovação e as decisões do ticket
+type AprovacoesResponse struct {
+	TicketID       string                     `json:"ticket_id"`
+	Exigida        bool                       `json:"exigida"`
+	Valor          int64                      `json:"valor"` // em centavos
+	ValorInformado bool                       `json:"valor_informado"`
+	Aprovada       bool                       `json:"aprovada"`
+	Rejeitada      bool                       `json:"rejeitada"`
+	EtapaPendente  string                     `json:"etapa_pendente,omitempty"`
+	Etapas         []EtapaAprovacaoResponse   `json:"etapas"`
+	Decisoes       []DecisaoAprovacaoResponse `json:"decisoes"`
+}
+
+// Response com uma etapa da aprovação
+type EtapaAprovacaoResponse struct {
+	Nome        string   `json:"nome"`
+	Papeis      []string `json:"papeis"`
+	Aprovacoes  int      `json:"aprovacoes"`
+	Aprovadores []string `json:"aprovadores"`
+	Concluida   bool     `json:"concluida"`
+}
+
+// Response com uma decisão; "vigente" é falso quando o valor do ticket mudou depois dela
+type DecisaoAprovacaoResponse struct {
+	ID         string                        `json:"id"`
+	Etapa      string                        `json:"etapa"`
+	Decisao    ticketDomain.DecisaoAprovacao `json:"decisao"`
+	Comentario string                        `json:"comentario,omitempty"`
+	Valor      int64                         `json:"valor"` // em centavos
+	UsuarioID  string                        `json:"usuario_id"`
+	Data       string                        `json:"data"`
+	Vigente    bool                          `json:"vigente"`
+}
+
+func responderAprovacoes(w http.ResponseWriter, output *ticketUseCase.AprovacoesOutput) {
+	resp := AprovacoesResponse{
+		TicketID:       output.TicketID,
+		Exigida:        output.Exigida,
+		Valor:          output.Valor,
+		ValorInformado: output.ValorInformado,
+		Aprovada:       output.Aprovada,
+		Rejeitada:      output.Rejeitada,
+		EtapaPendente:  output.EtapaPendente,
+		Etapas:         make([]EtapaAprovacaoResponse, 0, len(output.Etapas)),
+		Decisoes:       make([]DecisaoAprovacaoResponse, 0, len(output.Decisoes)),
+	}
+	for _, e := range output.Etapas {
+		aprovadores := e.Aprovadores
+		if aprovadores == nil {
+			aprovadores = []string{}
+		}
+		resp.Etapas = append(resp.Etapas, EtapaAprovacaoResponse{
+			Nome:        e.Nome,
+			Papeis:      e.Papeis,
+			Aprovacoes:  e.Aprovacoes,
+			Aprovadores: aprovadores,
+			Concluida:   e.Concluida,
+		})
+	}
+	for _, d := range output.Decisoes {
+		resp.Decisoes = append(resp.Decisoes, DecisaoAprovacaoResponse(d))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Buscar é o handler para obter a situação da aprovação do ticket
+func (h *AprovacaoHandler) Buscar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarAprovacoesUseCase.Execute(r.Context(), ticketUseCase.BuscarAprovacoesInput{
+		ID: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderAprovacoes(w, output)
+}
+
+// Decidir é o handler para aprovar ou rejeitar a etapa pendente da aprovação
+func (h *AprovacaoHandler) Decidir(w http.ResponseWriter, r *http.Request) {
+	var req DecidirAprovacaoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.decidirAprovacaoUseCase.Execute(r.Context(), ticketUseCase.DecidirAprovacaoInput{
+		ID:         chi.URLParam(r, "id"),
+		Decisao:    req.Decisao,
+		Comentario: req.Comentario,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderAprovacoes(w, output)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	usuarioUseCase "nox_tickets/internal/application/usecases/usuario"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EquipeHandler contém os handlers das equipes
+type EquipeHandler struct {
+	criarEquipeUseCase     *usuarioUseCase.CriarEquipeUseCase
+	buscarEquipeUseCase    *usuarioUseCase.BuscarEquipeUseCase
+	listarEquipesUseCase   *usuarioUseCase.ListarEquipesUseCase
+	atualizarEquipeUseCase *usuarioUseCase.AtualizarEquipeUseCase
+}
+
+// NewEquipeHandler cria uma nova instancia de EquipeHandler
+func NewEquipeHandler(
+	criarEquipeUseCase *usuarioUseCase.CriarEquipeUseCase,
+	buscarEquipeUseCase *usuarioUseCase.BuscarEquipeUseCase,
+	listarEquipesUseCase *usuarioUseCase.ListarEquipesUseCase,
+	atualizarEquipeUseCase *usuarioUseCase.AtualizarEquipeUseCase,
+) *EquipeHandler {
+	return &EquipeHandler{
+		criarEquipeUseCase:     criarEquipeUseCase,
+		buscarEquipeUseCase:    buscarEquipeUseCase,
+		listarEquipesUseCase:   listarEquipesUseCase,
+		atualizarEquipeUseCase: atualizarEquipeUseCase,
+	}
+}
+
+// Request para criar uma equipe
+type CriarEquipeRequest struct {
+	Nome      string `json:"nome"`
+	Descricao string `json:"descricao,omitempty"`
+}
+
+// Request para atualizar uma equipe; campos ausentes não são alterados
+type AtualizarEquipeRequest struct {
+	Nome      *string `json:"nome,omitempty"`
+	Descricao *string `json:"descricao,omitempty"`
+	Ativa     *bool   `json:"ativa,omitempty"`
+}
+
+// Response com os dados de uma equipe
+type EquipeResponse struct {
+	ID          string `json:"id"`
+	Nome        string `json:"nome"`
+	Descricao   string `json:"descricao,omitempty"`
+	Ativa       bool   `json:"ativa"`
+	DataCriacao string `json:"data_criacao"`
+}
+
+func novaEquipeResponse(output *usuarioUseCase.EquipeOutput) EquipeResponse {
+	return EquipeResponse{
+		ID:          output.ID,
+		Nome:        output.Nome,
+		Descricao:   output.Descricao,
+		Ativa:       output.Ativa,
+		DataCriacao: output.DataCriacao,
+	}
+}
+
+// Criar é o handler para cadastrar uma equipe
+func (h *EquipeHandler) Criar(w http.ResponseWriter, r *http.Request) {
+	var req CriarEquipeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.criarEquipeUseCase.Execute(r.Context(), usuarioUseCase.CriarEquipeInput{
+		Nome:      req.Nome,
+		Descricao: req.Descricao,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(novaEquipeResponse(output))
+}
+
+// Buscar é o handler para obter uma equipe pelo ID
+func (h *EquipeHandler) Buscar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarEquipeUseCase.Execute(r.Context(), usuarioUseCase.BuscarEquipeInput{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(novaEquipeResponse(output))
+}
+
+// Listar é o handler para listar as equipes; aceita ativas=true
+func (h *EquipeHandler) Listar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listarEquipesUseCase.Execute(r.Context(), usuarioUseCase.ListarEquipesInput{
+		ApenasAtivas: r.URL.Query().Get("ativas") == "true",
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := make([]EquipeResponse, len(output))
+	for i, e := range output {
+		resp[i] = novaEquipeResponse(e)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Atualizar é o handler para alterar uma equipe
+func (h *EquipeHandler) Atualizar(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarEquipeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarEquipeUseCase.Execute(r.Context(), usuarioUseCase.AtualizarEquipeInput{
+		ID:        chi.URLParam(r, "id"),
+		Nome:      req.Nome,
+		Descricao: req.Descricao,
+		Ativa:     req.Ativa,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(novaEquipeResponse(output))
+}
+
+// Desativar é o handler do DELETE: a equipe é desativada e deixa de aceitar novos membros
+func (h *EquipeHandler) Desativar(w http.ResponseWriter, r *http.Request) {
+	inativa := false
+	_, err := h.atualizarEquipeUseCase.Execute(r.Context(), usuarioUseCase.AtualizarEquipeInput{
+		ID:    chi.URLParam(r, "id"),
+		Ativa: &inativa,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
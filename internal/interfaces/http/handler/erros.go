@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	ticketUseCase "nox_tickets/internal/application/usecases/ticket"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+)
+
+// ErroResponse é o corpo de todas as respostas de erro da API
+type ErroResponse struct {
+	Codigo   string              `json:"codigo"`
+	Mensagem string              `json:"mensagem"`
+	Campos   []CampoErroResponse `json:"campos,omitempty"`
+
+	// estado atual do ticket, nos conflitos de versão (409 e 412)
+	Atual *BuscarTicketResponse `json:"atual,omitempty"`
+}
+
+// CampoErroResponse detalha o campo que falhou na validação
+type CampoErroResponse struct {
+	Campo    string `json:"campo"`
+	Mensagem string `json:"mensagem"`
+}
+
+// corpoInvalido embrulha os erros de leitura do JSON da requisição
+func corpoInvalido(err error) error {
+	return ticketDomain.NovoErroValidacao("", "corpo_invalido", "corpo da requisição inválido: "+err.Error())
+}
+
+// statusDoErro mapeia o tipo do erro do domínio para o status HTTP
+func statusDoErro(err error) int {
+	// a versão do If-Match é uma pré-condição da requisição, e não um conflito na gravação
+	if errors.Is(err, ticketDomain.ErrVersaoDesatualizada) {
+		return http.StatusPreconditionFailed
+	}
+
+	e, ok := ticketDomain.ComoErro(err)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	switch e.Tipo {
+	case ticketDomain.TipoValidacao:
+		return http.StatusBadRequest
+	case ticketDomain.TipoNaoEncontrado:
+		return http.StatusNotFound
+	case ticketDomain.TipoConflito:
+		return http.StatusConflict
+	case ticketDomain.TipoTransicaoInvalida:
+		return http.StatusUnprocessableEntity
+	case ticketDomain.TipoProibido:
+		return http.StatusForbidden
+	case ticketDomain.TipoNaoAutenticado:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// novoErroResponse monta o corpo da resposta; erros desconhecidos não expõem detalhes internos
+func novoErroResponse(err error) ErroResponse {
+	e, ok := ticketDomain.ComoErro(err)
+	if !ok {
+		log.Printf("erro interno: %v", err)
+		return ErroResponse{Codigo: "erro_interno", Mensagem: "erro interno do servidor"}
+	}
+
+	resp := ErroResponse{Codigo: e.Codigo, Mensagem: err.Error()}
+	if e.Campo != "" {
+		resp.Campos = []CampoErroResponse{{Campo: e.Campo, Mensagem: e.Mensagem}}
+	}
+	for _, d := range e.Detalhes {
+		resp.Campos = append(resp.Campos, CampoErroResponse{Campo: d.Campo, Mensagem: d.Mensagem})
+	}
+	return resp
+}
+
+// responderErro escreve a resposta de erro com o status correspondente
+func responderErro(w http.ResponseWriter, err error) {
+	escreverErro(w, statusDoErro(err), novoErroResponse(err))
+}
+
+// responderErroTicket é o responderErro das operações que alteram um ticket:
+// nos conflitos de versão, devolve também o estado atual para o cliente refazer a edição
+func (h *TicketHandler) responderErroTicket(w http.ResponseWriter, r *http.Request, id string, err error) {
+	status := statusDoErro(err)
+	resp := novoErroResponse(err)
+
+	if status == http.StatusConflict || status == http.StatusPreconditionFailed {
+		atual, errBusca := h.buscarTicketUseCase.Execute(r.Context(), ticketUseCase.BuscarTicketInput{ID: id})
+		if errBusca != nil {
+			responderErro(w, errBusca)
+			return
+		}
+		estado := novoBuscarTicketResponse(atual)
+		resp.Atual = &estado
+		w.Header().Set("ETag", etag(atual.Versao))
+	}
+
+	escreverErro(w, status, resp)
+}
+
+func escreverErro(w http.ResponseWriter, status int, resp ErroResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
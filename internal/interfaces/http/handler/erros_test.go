@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ticketDomain "nox_tickets/internal/domain/ticket"
+)
+
+func TestResponderErro_Status(t *testing.T) {
+	casos := []struct {
+		err    error
+		status int
+		codigo string
+	}{
+		{ticketDomain.ErrNaoEncontrado, http.StatusNotFound, "ticket_nao_encontrado"},
+		{ticketDomain.ErrCategoriaInvalida, http.StatusBadRequest, "categoria_invalida"},
+		{fmt.Errorf("%w: de aberto para finalizado", ticketDomain.ErrTransicaoInvalida), http.StatusUnprocessableEntity, "transicao_invalida"},
+		{ticketDomain.ErrConflito, http.StatusConflict, "conflito_de_versao"},
+		{fmt.Errorf("%w: esperada 1, atual 2", ticketDomain.ErrVersaoDesatualizada), http.StatusPreconditionFailed, "versao_desatualizada"},
+		{ticketDomain.ErrProibido, http.StatusForbidden, "acesso_negado"},
+		{errors.New("falha no banco"), http.StatusInternalServerError, "erro_interno"},
+	}
+
+	for _, c := range casos {
+		w := httptest.NewRecorder()
+		responderErro(w, c.err)
+
+		if w.Code != c.status {
+			t.Errorf("%v: esperava status %d, recebido %d", c.err, c.status, w.Code)
+		}
+		var resp ErroResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Erro ao ler resposta: %v", err)
+		}
+		if resp.Codigo != c.codigo {
+			t.Errorf("%v: esperava código %s, recebido %s", c.err, c.codigo, resp.Codigo)
+		}
+	}
+}
+
+func TestResponderErro_Campo(t *testing.T) {
+	w := httptest.NewRecorder()
+	responderErro(w, ticketDomain.ErrUrgenciaInvalida)
+
+	var resp ErroResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Erro ao ler resposta: %v", err)
+	}
+	if len(resp.Campos) != 1 || resp.Campos[0].Campo != "urgencia" {
+		t.Errorf("Esperava detalhe do campo urgencia, recebido %+v", resp.Campos)
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	filaUseCase "nox_tickets/internal/application/usecases/fila"
+	filaDomain "nox_tickets/internal/domain/fila"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FilaHandler contém os handlers das filas de atendimento
+type FilaHandler struct {
+	criarFilaUseCase     *filaUseCase.CriarFilaUseCase
+	listarFilasUseCase   *filaUseCase.ListarFilasUseCase
+	atualizarFilaUseCase *filaUseCase.AtualizarFilaUseCase
+}
+
+// NewFilaHandler cria uma nova instancia de FilaHandler
+func NewFilaHandler(
+	criarFilaUseCase *filaUseCase.CriarFilaUseCase,
+	listarFilasUseCase *filaUseCase.ListarFilasUseCase,
+	atualizarFilaUseCase *filaUseCase.AtualizarFilaUseCase,
+) *FilaHandler {
+	return &FilaHandler{
+		criarFilaUseCase:     criarFilaUseCase,
+		listarFilasUseCase:   listarFilasUseCase,
+		atualizarFilaUseCase: atualizarFilaUseCase,
+	}
+}
+
+// Request para criar uma fila
+type CriarFilaRequest struct {
+	Nome                 string                    `json:"nome"`
+	Categoria            ticketDomain.Categoria    `json:"categoria,omitempty"`
+	Subcategoria         ticketDomain.Subcategoria `json:"subcategoria,omitempty"`
+	EquipeID             string                    `json:"equipe_id"`
+	Estrategia           filaDomain.Estrategia     `json:"estrategia"`
+	AtribuicaoAutomatica bool                      `json:"atribuicao_automatica"`
+}
+
+// Request para atualizar uma fila; campos ausentes não são alterados
+type AtualizarFilaRequest struct {
+	Nome                 *string                    `json:"nome,omitempty"`
+	Categoria            *ticketDomain.Categoria    `json:"categoria,omitempty"`
+	Subcategoria         *ticketDomain.Subcategoria `json:"subcategoria,omitempty"`
+	EquipeID             *string                    `json:"equipe_id,omitempty"`
+	Estrategia           *filaDomain.Estrategia     `json:"estrategia,omitempty"`
+	AtribuicaoAutomatica *bool                      `json:"atribuicao_automatica,omitempty"`
+	Ativa                *bool                      `json:"ativa,omitempty"`
+}
+
+// Response com os dados de uma fila
+type FilaResponse struct {
+	ID                   string                    `json:"id"`
+	Nome                 string                    `json:"nome"`
+	Categoria            ticketDomain.Categoria    `json:"categoria,omitempty"`
+	Subcategoria         ticketDomain.Subcategoria `json:"subcategoria,omitempty"`
+	EquipeID             string                    `json:"equipe_id"`
+	Estrategia           filaDomain.Estrategia     `json:"estrategia"`
+	AtribuicaoAutomatica bool                      `json:"atribuicao_automatica"`
+	Ativa                bool                      `json:"ativa"`
+	DataCriacao          string                    `json:"data_criacao"`
+}
+
+func novaFilaResponse(output *filaUseCase.FilaOutput) FilaResponse {
+	return FilaResponse(*output)
+}
+
+// Criar é o handler para cadastrar uma fila
+func (h *FilaHandler) Criar(w http.ResponseWriter, r *http.Request) {
+	var req CriarFilaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.criarFilaUseCase.Execute(r.Context(), filaUseCase.CriarFilaInput(req))
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(novaFilaResponse(output))
+}
+
+// Listar é o handler para listar as filas; aceita ativas=true
+func (h *FilaHandler) Listar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listarFilasUseCase.Execute(r.Context(), filaUseCase.ListarFilasInput{
+		ApenasAtivas: r.URL.Query().Get("ativas") == "true",
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := make([]FilaResponse, len(output))
+	for i, f := range output {
+		resp[i] = novaFilaResponse(f)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Atualizar é o handler para alterar a configuração de uma fila
+func (h *FilaHandler) Atualizar(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarFilaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarFilaUseCase.Execute(r.Context(), filaUseCase.AtualizarFilaInput{
+		ID:                   chi.URLParam(r, "id"),
+		Nome:                 req.Nome,
+		Categoria:            req.Categoria,
+		Subcategoria:         req.Subcategoria,
+		EquipeID:             req.EquipeID,
+		Estrategia:           req.Estrategia,
+		AtribuicaoAutomatica: req.AtribuicaoAutomatica,
+		Ativa:                req.Ativa,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(novaFilaResponse(output))
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	ticketDomain "nox_tickets/internal/domain/ticket"
+)
+
+// lerFiltros converte a query string em filtros de ticket.
+//
+// Parâmetros aceitos:
+//   - status, categoria, subcategoria: vários valores, separados por vírgula ou repetindo o parâmetro
+//   - responsavel, aberto_por, merchant, nox_id, plataforma
+//   - abertura_de, abertura_ate, conclusao_de, conclusao_ate: AAAA-MM-DD ou RFC 3339
+//   - urgencia, urgencia_min, urgencia_max, gravidade, gravidade_min, gravidade_max: 1 a 5
+//   - sla_violado: true ou false
+func lerFiltros(query url.Values) (ticketDomain.TicketFiltros, error) {
+	var filtros ticketDomain.TicketFiltros
+
+	// 1. filtros com vários valores
+	for _, s := range valoresMultiplos(query, "status") {
+		filtros.Status = append(filtros.Status, ticketDomain.Status(s))
+	}
+	for _, c := range valoresMultiplos(query, "categoria") {
+		filtros.Categoria = append(filtros.Categoria, ticketDomain.Categoria(c))
+	}
+	for _, s := range valoresMultiplos(query, "subcategoria") {
+		filtros.Subcategoria = append(filtros.Subcategoria, ticketDomain.Subcategoria(s))
+	}
+
+	// 2. filtros de texto
+	filtros.Responsavel = query.Get("responsavel")
+	filtros.AbertoPor = query.Get("aberto_por")
+	filtros.FilaID = query.Get("fila")
+	filtros.Merchant = query.Get("merchant")
+	filtros.NoxID = query.Get("nox_id")
+	filtros.Plataforma = query.Get("plataforma")
+
+	// 3. períodos
+	datas := []struct {
+		parametro string
+		fimDoDia  bool
+		destino   *time.Time
+	}{
+		{"abertura_de", false, &filtros.DataInicio},
+		{"abertura_ate", true, &filtros.DataFim},
+		{"conclusao_de", false, &filtros.DataConclusaoInicio},
+		{"conclusao_ate", true, &filtros.DataConclusaoFim},
+	}
+	for _, d := range datas {
+		valor := query.Get(d.parametro)
+		if valor == "" {
+			continue
+		}
+		data, err := parseData(valor, d.fimDoDia)
+		if err != nil {
+			return filtros, filtroInvalido(d.parametro, fmt.Sprintf("%s inválido: %s", d.parametro, valor))
+		}
+		*d.destino = data
+	}
+
+	// 4. urgência e gravidade
+	inteiros := []struct {
+		parametro string
+		destino   **int
+	}{
+		{"urgencia", &filtros.Urgencia},
+		{"urgencia_min", &filtros.UrgenciaMin},
+		{"urgencia_max", &filtros.UrgenciaMax},
+		{"gravidade", &filtros.Gravidade},
+		{"gravidade_min", &filtros.GravidadeMin},
+		{"gravidade_max", &filtros.GravidadeMax},
+	}
+	for _, i := range inteiros {
+		valor := query.Get(i.parametro)
+		if valor == "" {
+			continue
+		}
+		n, err := strconv.Atoi(valor)
+		if err != nil || n < 1 || n > 5 {
+			return filtros, filtroInvalido(i.parametro, fmt.Sprintf("%s deve ser um número de 1 a 5", i.parametro))
+		}
+		*i.destino = &n
+	}
+
+	// 5. SLA
+	if violado := query.Get("sla_violado"); violado != "" {
+		v, err := strconv.ParseBool(violado)
+		if err != nil {
+			return filtros, filtroInvalido("sla_violado", "sla_violado deve ser true ou false")
+		}
+		filtros.SLAViolado = &v
+	}
+
+	return filtros, nil
+}
+
+// valoresMultiplos junta os valores repetidos e separados por vírgula de um parâmetro
+func valoresMultiplos(query url.Values, parametro string) []string {
+	valores := []string{}
+	for _, v := range query[parametro] {
+		for _, parte := range strings.Split(v, ",") {
+			if parte = strings.TrimSpace(parte); parte != "" {
+				valores = append(valores, parte)
+			}
+		}
+	}
+	return valores
+}
+
+// parseData aceita AAAA-MM-DD ou RFC 3339. Datas sem horário no limite final incluem o dia inteiro
+func parseData(valor string, fimDoDia bool) (time.Time, error) {
+	if data, err := time.Parse(time.RFC3339, valor); err == nil {
+		return data, nil
+	}
+	data, err := time.ParseInLocation(time.DateOnly, valor, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if fimDoDia {
+		data = data.Add(24*time.Hour - time.Nanosecond)
+	}
+	return data, nil
+}
+
+// filtroInvalido cria o erro de validação de um parâmetro da query string
+func filtroInvalido(parametro, mensagem string) error {
+	return ticketDomain.NovoErroValidacao(parametro, "filtro_invalido", mensagem)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	formularioUseCase "nox_tickets/internal/application/usecases/formulario"
+	formularioDomain "nox_tickets/internal/domain/formulario"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FormularioHandler contém os handlers dos formulários por categoria e subcategoria
+type FormularioHandler struct {
+	buscarFormularioUseCase *formularioUseCase.BuscarFormularioUseCase
+	salvarFormularioUseCase *formularioUseCase.SalvarFormularioUseCase
+}
+
+// NewFormularioHandler cria uma nova instancia de FormularioHandler
+func NewFormularioHandler(
+	buscarFormularioUseCase *formularioUseCase.BuscarFormularioUseCase,
+	salvarFormularioUseCase *formularioUseCase.SalvarFormularioUseCase,
+) *FormularioHandler {
+	return &FormularioHandler{
+		buscarFormularioUseCase: buscarFormularioUseCase,
+		salvarFormularioUseCase: salvarFormularioUseCase,
+	}
+}
+
+// Response com o formulário; esquema é o JSON Schema usado pelo frontend para renderizar os campos
+type FormularioResponse struct {
+	Categoria       ticketDomain.Categoria    `json:"categoria"`
+	Subcategoria    ticketDomain.Subcategoria `json:"subcategoria"`
+	Esquema         *formularioDomain.Esquema `json:"esquema"`
+	Campos          []string                  `json:"campos"`
+	DataAtualizacao string                    `json:"data_atualizacao"`
+}
+
+func responderFormulario(w http.ResponseWriter, output *formularioUseCase.FormularioOutput) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(FormularioResponse(*output))
+}
+
+// Buscar é o handler para obter o formulário da categoria e subcategoria; a rota sem subcategoria
+// é a das categorias que não têm subcategorias
+func (h *FormularioHandler) Buscar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarFormularioUseCase.Execute(r.Context(), formularioUseCase.BuscarFormularioInput{
+		Categoria:    ticketDomain.Categoria(chi.URLParam(r, "categoria")),
+		Subcategoria: ticketDomain.Subcategoria(chi.URLParam(r, "subcategoria")),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderFormulario(w, output)
+}
+
+// Salvar é o handler para criar ou substituir o formulário; o corpo é o esquema
+func (h *FormularioHandler) Salvar(w http.ResponseWriter, r *http.Request) {
+	var esquema formularioDomain.Esquema
+	if err := json.NewDecoder(r.Body).Decode(&esquema); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.salvarFormularioUseCase.Execute(r.Context(), formularioUseCase.SalvarFormularioInput{
+		Categoria:    ticketDomain.Categoria(chi.URLParam(r, "categoria")),
+		Subcategoria: ticketDomain.Subcategoria(chi.URLParam(r, "subcategoria")),
+		Esquema:      &esquema,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderFormulario(w, output)
+}
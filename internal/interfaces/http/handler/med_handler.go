@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	medUseCase "nox_tickets/internal/application/usecases/med"
+	medDomain "nox_tickets/internal/domain/med"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MEDHandler contém os handlers dos casos MED (Mecanismo Especial de Devolução do Pix)
+type MEDHandler struct {
+	abrirCasoUseCase         *medUseCase.AbrirCasoUseCase
+	buscarCasoUseCase        *medUseCase.BuscarCasoUseCase
+	corrigirCasoUseCase      *medUseCase.CorrigirCasoUseCase
+	atualizarSituacaoUseCase *medUseCase.AtualizarSituacaoUseCase
+	listarCasosUseCase       *medUseCase.ListarCasosUseCase
+}
+
+// NewMEDHandler cria uma nova instancia de MEDHandler
+func NewMEDHandler(
+	abrirCasoUseCase *medUseCase.AbrirCasoUseCase,
+	buscarCasoUseCase *medUseCase.BuscarCasoUseCase,
+	corrigirCasoUseCase *medUseCase.CorrigirCasoUseCase,
+	atualizarSituacaoUseCase *medUseCase.AtualizarSituacaoUseCase,
+	listarCasosUseCase *medUseCase.ListarCasosUseCase,
+) *MEDHandler {
+	return &MEDHandler{
+		abrirCasoUseCase:         abrirCasoUseCase,
+		buscarCasoUseCase:        buscarCasoUseCase,
+		corrigirCasoUseCase:      corrigirCasoUseCase,
+		atualizarSituacaoUseCase: atualizarSituacaoUseCase,
+		listarCasosUseCase:       listarCasosUseCase,
+	}
+}
+
+// Request com os dados da transação e da notificação de infração; valores em centavos e
+// datas em RFC 3339 (ou AAAA-MM-DD)
+type DadosMEDRequest struct {
+	EndToEndID      string                 `json:"end_to_end_id"`
+	IDNotificacao   string                 `json:"id_notificacao"`
+	Valor           int64                  `json:"valor"`
+	Pagador         medDomain.Participante `json:"pagador"`
+	Recebedor       medDomain.Participante `json:"recebedor"`
+	DataTransacao   string                 `json:"data_transacao"`
+	DataNotificacao string                 `json:"data_notificacao"`
+}
+
+// Request para corrigir os dados do caso em análise
+type CorrigirMEDRequest struct {
+	DadosMEDRequest
+	Justificativa string `json:"justificativa"`
+}
+
+// Request para mudar a situação do caso
+type AtualizarSituacaoMEDRequest struct {
+	Situacao      medDomain.Situacao `json:"situacao"`
+	Valor         int64              `json:"valor,omitempty"`
+	Justificativa string             `json:"justificativa"`
+}
+
+// Response com o caso MED; o histórico vem apenas no caso de um ticket
+type CasoMEDResponse struct {
+	TicketID         string                 `json:"ticket_id"`
+	EndToEndID       string                 `json:"end_to_end_id"`
+	IDNotificacao    string                 `json:"id_notificacao"`
+	Valor            int64                  `json:"valor"`
+	Pagador          medDomain.Participante `json:"pagador"`
+	Recebedor        medDomain.Participante `json:"recebedor"`
+	DataTransacao    string                 `json:"data_transacao"`
+	DataNotificacao  string                 `json:"data_notificacao"`
+	Situacao         medDomain.Situacao     `json:"situacao"`
+	ValorBloqueado   int64                  `json:"valor_bloqueado"`
+	ValorDevolvido   int64                  `json:"valor_devolvido"`
+	PrazoAnalise     string                 `json:"prazo_analise"`
+	PrazoDevolucao   string                 `json:"prazo_devolucao"`
+	PrazoVigente     string                 `json:"prazo_vigente,omitempty"`
+	Vencido          bool                   `json:"vencido"`
+	DataBloqueio     string                 `json:"data_bloqueio,omitempty"`
+	DataConclusao    string                 `json:"data_conclusao,omitempty"`
+	Historico        []RegistroMEDResponse  `json:"historico,omitempty"`
+	HistoricoIntegro *bool                  `json:"historico_integro,omitempty"`
+}
+
+// Response com uma entrada do histórico do caso
+type RegistroMEDResponse struct {
+	Sequencia        int                `json:"sequencia"`
+	Acao             medDomain.Acao     `json:"acao"`
+	SituacaoAnterior medDomain.Situacao `json:"situacao_anterior,omitempty"`
+	SituacaoNova     medDomain.Situacao `json:"situacao_nova"`
+	Valor            int64              `json:"valor,omitempty"`
+	Justificativa    string             `json:"justificativa,omitempty"`
+	Dados            json.RawMessage    `json:"dados"`
+	ForaDoPrazo      bool               `json:"fora_do_prazo"`
+	UsuarioID        string             `json:"usuario_id"`
+	Data             string             `json:"data"`
+	HashAnterior     string             `json:"hash_anterior,omitempty"`
+	Hash             string             `json:"hash"`
+}
+
+func novoCasoMEDResponse(o medUseCase.CasoOutput, comHistorico bool) CasoMEDResponse {
+	resp := CasoMEDResponse{
+		TicketID:        o.TicketID,
+		EndToEndID:      o.EndToEndID,
+		IDNotificacao:   o.IDNotificacao,
+		Valor:           o.Valor,
+		Pagador:         o.Pagador,
+		Recebedor:       o.Recebedor,
+		DataTransacao:   o.DataTransacao,
+		DataNotificacao: o.DataNotificacao,
+		Situacao:        o.Situacao,
+		ValorBloqueado:  o.ValorBloqueado,
+		ValorDevolvido:  o.ValorDevolvido,
+		PrazoAnalise:    o.PrazoAnalise,
+		PrazoDevolucao:  o.PrazoDevolucao,
+		PrazoVigente:    o.PrazoVigente,
+		Vencido:         o.Vencido,
+		DataBloqueio:    o.DataBloqueio,
+		DataConclusao:   o.DataConclusao,
+	}
+	if !comHistorico {
+		return resp
+	}
+	resp.Historico = make([]RegistroMEDResponse, 0, len(o.Historico))
+	for _, r := range o.Historico {
+		resp.Historico = append(resp.Historico, RegistroMEDResponse{
+			Sequencia:        r.Sequencia,
+			Acao:             r.Acao,
+			SituacaoAnterior: r.SituacaoAnterior,
+			SituacaoNova:     r.SituacaoNova,
+			Valor:            r.Valor,
+			Justificativa:    r.Justificativa,
+			Dados:            json.RawMessage(r.Dados),
+			ForaDoPrazo:      r.ForaDoPrazo,
+			UsuarioID:        r.UsuarioID,
+			Data:             r.Data,
+			HashAnterior:     r.HashAnterior,
+			Hash:             r.Hash,
+		})
+	}
+	integro := o.HistoricoIntegro
+	resp.HistoricoIntegro = &integro
+	return resp
+}
+
+func responderCasoMED(w http.ResponseWriter, status int, output *medUseCase.CasoOutput) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(novoCasoMEDResponse(*output, true))
+}
+
+// dados converte o request nos dados do caso, lendo as datas
+func (req DadosMEDRequest) dados() (medDomain.Dados, error) {
+	dados := medDomain.Dados{
+		EndToEndID:    req.EndToEndID,
+		IDNotificacao: req.IDNotificacao,
+		Valor:         req.Valor,
+		Pagador:       req.Pagador,
+		Recebedor:     req.Recebedor,
+	}
+	datas := []struct {
+		campo   string
+		valor   string
+		destino *time.Time
+	}{
+		{"data_transacao", req.DataTransacao, &dados.DataTransacao},
+		{"data_notificacao", req.DataNotificacao, &dados.DataNotificacao},
+	}
+	for _, d := range datas {
+		if d.valor == "" {
+			continue
+		}
+		data, err := parseData(d.valor, false)
+		if err != nil {
+			return dados, ticketDomain.NovoErroValidacao(d.campo, "data_invalida", fmt.Sprintf("%s inválida: %s", d.campo, d.valor))
+		}
+		*d.destino = data
+	}
+	return dados, nil
+}
+
+// Abrir é o handler para abrir o caso MED de um ticket da categoria meds
+func (h *MEDHandler) Abrir(w http.ResponseWriter, r *http.Request) {
+	var req DadosMEDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+	dados, err := req.dados()
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	output, err := h.abrirCasoUseCase.Execute(r.Context(), medUseCase.AbrirCasoInput{
+		TicketID: chi.URLParam(r, "id"),
+		Dados:    dados,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCasoMED(w, http.StatusCreated, output)
+}
+
+// Buscar é o handler para obter o caso MED do ticket com o histórico
+func (h *MEDHandler) Buscar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarCasoUseCase.Execute(r.Context(), medUseCase.BuscarCasoInput{
+		TicketID: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCasoMED(w, http.StatusOK, output)
+}
+
+// Corrigir é o handler para substituir os dados do caso enquanto ele está em análise
+func (h *MEDHandler) Corrigir(w http.ResponseWriter, r *http.Request) {
+	var req CorrigirMEDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+	dados, err := req.dados()
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	output, err := h.corrigirCasoUseCase.Execute(r.Context(), medUseCase.CorrigirCasoInput{
+		TicketID:      chi.URLParam(r, "id"),
+		Dados:         dados,
+		Justificativa: req.Justificativa,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCasoMED(w, http.StatusOK, output)
+}
+
+// AtualizarSituacao é o handler para bloquear os fundos, devolver ou rejeitar o caso
+func (h *MEDHandler) AtualizarSituacao(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarSituacaoMEDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarSituacaoUseCase.Execute(r.Context(), medUseCase.AtualizarSituacaoInput{
+		TicketID:      chi.URLParam(r, "id"),
+		Situacao:      req.Situacao,
+		Valor:         req.Valor,
+		Justificativa: req.Justificativa,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCasoMED(w, http.StatusOK, output)
+}
+
+// Listar é o handler para listar os casos MED pelo prazo vigente.
+//
+// Parâmetros aceitos:
+//   - situacao: vários valores, separados por vírgula ou repetindo o parâmetro
+//   - vencidos: true para apenas os casos com o prazo da etapa atual vencido
+//   - prazo_ate: AAAA-MM-DD ou RFC 3339, casos cujo prazo vigente vence até a data
+func (h *MEDHandler) Listar(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var input medUseCase.ListarCasosInput
+	for _, s := range valoresMultiplos(query, "situacao") {
+		input.Situacoes = append(input.Situacoes, medDomain.Situacao(s))
+	}
+	if v := query.Get("vencidos"); v != "" {
+		vencidos, err := strconv.ParseBool(v)
+		if err != nil {
+			responderErro(w, filtroInvalido("vencidos", "vencidos deve ser true ou false"))
+			return
+		}
+		input.Vencidos = vencidos
+	}
+	if v := query.Get("prazo_ate"); v != "" {
+		prazo, err := parseData(v, true)
+		if err != nil {
+			responderErro(w, filtroInvalido("prazo_ate", "prazo_ate inválido: "+v))
+			return
+		}
+		input.PrazoAte = &prazo
+	}
+
+	outputs, err := h.listarCasosUseCase.Execute(r.Context(), input)
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	resp := make([]CasoMEDResponse, 0, len(outputs))
+	for _, o := range outputs {
+		resp = append(resp, novoCasoMEDResponse(o, false))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
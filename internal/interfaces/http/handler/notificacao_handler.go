@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	notificacaoUseCase "nox_tickets/internal/application/usecases/notificacao"
+	ticketUseCase "nox_tickets/internal/application/usecases/ticket"
+	notificacaoDomain "nox_tickets/internal/domain/notificacao"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NotificacaoHandler contém os handlers dos seguidores de tickets, das preferências de notificação e do log de envios
+type NotificacaoHandler struct {
+	listarSeguidoresUseCase      *ticketUseCase.ListarSeguidoresUseCase
+	seguirTicketUseCase          *ticketUseCase.SeguirTicketUseCase
+	deixarDeSeguirTicketUseCase  *ticketUseCase.DeixarDeSeguirTicketUseCase
+	buscarPreferenciasUseCase    *notificacaoUseCase.BuscarPreferenciasUseCase
+	atualizarPreferenciasUseCase *notificacaoUseCase.AtualizarPreferenciasUseCase
+	listarNotificacoesUseCase    *notificacaoUseCase.ListarNotificacoesUseCase
+}
+
+// NewNotificacaoHandler cria uma nova instancia de NotificacaoHandler
+func NewNotificacaoHandler(
+	listarSeguidoresUseCase *ticketUseCase.ListarSeguidoresUseCase,
+	seguirTicketUseCase *ticketUseCase.SeguirTicketUseCase,
+	deixarDeSeguirTicketUseCase *ticketUseCase.DeixarDeSeguirTicketUseCase,
+	buscarPreferenciasUseCase *notificacaoUseCase.BuscarPreferenciasUseCase,
+	atualizarPreferenciasUseCase *notificacaoUseCase.AtualizarPreferenciasUseCase,
+	listarNotificacoesUseCase *notificacaoUseCase.ListarNotificacoesUseCase,
+) *NotificacaoHandler {
+	return &NotificacaoHandler{
+		listarSeguidoresUseCase:      listarSeguidoresUseCase,
+		seguirTicketUseCase:          seguirTicketUseCase,
+		deixarDeSeguirTicketUseCase:  deixarDeSeguirTicketUseCase,
+		buscarPreferenciasUseCase:    buscarPreferenciasUseCase,
+		atualizarPreferenciasUseCase: atualizarPreferenciasUseCase,
+		listarNotificacoesUseCase:    listarNotificacoesUseCase,
+	}
+}
+
+// Request para seguir um ticket; sem usuario_id, o próprio usuário passa a seguir
+type SeguirTicketRequest struct {
+	UsuarioID string `json:"usuario_id,omitempty"`
+}
+
+// Response com os seguidores de um ticket
+type SeguidoresResponse struct {
+	TicketID   string   `json:"ticket_id"`
+	Seguidores []string `json:"seguidores"`
+}
+
+// Response com as preferências de notificação, indicando se cada tipo é enviado
+type PreferenciasResponse struct {
+	UsuarioID    string                          `json:"usuario_id"`
+	Notificacoes map[notificacaoDomain.Tipo]bool `json:"notificacoes"`
+}
+
+// Request para ativar ou desativar tipos de notificação; os tipos ausentes não são alterados
+type AtualizarPreferenciasRequest struct {
+	Notificacoes map[notificacaoDomain.Tipo]bool `json:"notificacoes"`
+}
+
+// Response de um envio do log de notificações
+type NotificacaoResponse struct {
+	ID        string                        `json:"id"`
+	EventoID  string                        `json:"evento_id"`
+	TicketID  string                        `json:"ticket_id"`
+	UsuarioID string                        `json:"usuario_id"`
+	Email     string                        `json:"email"`
+	Tipo      notificacaoDomain.Tipo        `json:"tipo"`
+	Assunto   string                        `json:"assunto"`
+	Status    notificacaoDomain.StatusEnvio `json:"status"`
+	Erro      string                        `json:"erro,omitempty"`
+	Data      string                        `json:"data"`
+}
+
+func responderSeguidores(w http.ResponseWriter, output *ticketUseCase.SeguidoresOutput) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SeguidoresResponse(*output))
+}
+
+func responderPreferencias(w http.ResponseWriter, output *notificacaoUseCase.PreferenciasOutput) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PreferenciasResponse(*output))
+}
+
+// ListarSeguidores é o handler para obter os seguidores de um ticket
+func (h *NotificacaoHandler) ListarSeguidores(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listarSeguidoresUseCase.Execute(r.Context(), ticketUseCase.ListarSeguidoresInput{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderSeguidores(w, output)
+}
+
+// Seguir é o handler para passar a seguir um ticket; o corpo é opcional
+func (h *NotificacaoHandler) Seguir(w http.ResponseWriter, r *http.Request) {
+	var req SeguirTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.seguirTicketUseCase.Execute(r.Context(), ticketUseCase.SeguirTicketInput{
+		ID:        chi.URLParam(r, "id"),
+		UsuarioID: req.UsuarioID,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderSeguidores(w, output)
+}
+
+// DeixarDeSeguir é o handler para remover um seguidor do ticket
+func (h *NotificacaoHandler) DeixarDeSeguir(w http.ResponseWriter, r *http.Request) {
+	_, err := h.deixarDeSeguirTicketUseCase.Execute(r.Context(), ticketUseCase.SeguirTicketInput{
+		ID:        chi.URLParam(r, "id"),
+		UsuarioID: chi.URLParam(r, "usuarioID"),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BuscarPreferencias é o handler para obter as preferências de notificação de um usuário
+func (h *NotificacaoHandler) BuscarPreferencias(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarPreferenciasUseCase.Execute(r.Context(), notificacaoUseCase.BuscarPreferenciasInput{
+		UsuarioID: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderPreferencias(w, output)
+}
+
+// AtualizarPreferencias é o handler para ativar ou desativar notificações de um usuário
+func (h *NotificacaoHandler) AtualizarPreferencias(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarPreferenciasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarPreferenciasUseCase.Execute(r.Context(), notificacaoUseCase.AtualizarPreferenciasInput{
+		UsuarioID:    chi.URLParam(r, "id"),
+		Notificacoes: req.Notificacoes,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderPreferencias(w, output)
+}
+
+// ListarNotificacoes é o handler do log de notificações; aceita usuario=<id>, ticket=<id> e limite=<n>
+func (h *NotificacaoHandler) ListarNotificacoes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := notificacaoUseCase.ListarNotificacoesInput{
+		UsuarioID: query.Get("usuario"),
+		TicketID:  query.Get("ticket"),
+	}
+	if valor := query.Get("limite"); valor != "" {
+		limite, err := strconv.Atoi(valor)
+		if err != nil || limite < 1 {
+			responderErro(w, filtroInvalido("limite", "limite deve ser um número positivo"))
+			return
+		}
+		input.Limite = limite
+	}
+
+	output, err := h.listarNotificacoesUseCase.Execute(r.Context(), input)
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := make([]NotificacaoResponse, len(output))
+	for i, n := range output {
+		resp[i] = NotificacaoResponse(*n)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	taxonomiaUseCase "nox_tickets/internal/application/usecases/taxonomia"
+	ticketUseCase "nox_tickets/internal/application/usecases/ticket"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TaxonomiaHandler contém os handlers das categorias e subcategorias de tickets
+type TaxonomiaHandler struct {
+	criarCategoriaUseCase        *taxonomiaUseCase.CriarCategoriaUseCase
+	listarCategoriasUseCase      *taxonomiaUseCase.ListarCategoriasUseCase
+	buscarCategoriaUseCase       *taxonomiaUseCase.BuscarCategoriaUseCase
+	atualizarCategoriaUseCase    *taxonomiaUseCase.AtualizarCategoriaUseCase
+	adicionarSubcategoriaUseCase *taxonomiaUseCase.AdicionarSubcategoriaUseCase
+	atualizarSubcategoriaUseCase *taxonomiaUseCase.AtualizarSubcategoriaUseCase
+	relatorioReclassificacoes    *ticketUseCase.RelatorioReclassificacoesUseCase
+}
+
+// NewTaxonomiaHandler cria uma nova instancia de TaxonomiaHandler
+func NewTaxonomiaHandler(
+	criarCategoriaUseCase *taxonomiaUseCase.CriarCategoriaUseCase,
+	listarCategoriasUseCase *taxonomiaUseCase.ListarCategoriasUseCase,
+	buscarCategoriaUseCase *taxonomiaUseCase.BuscarCategoriaUseCase,
+	atualizarCategoriaUseCase *taxonomiaUseCase.AtualizarCategoriaUseCase,
+	adicionarSubcategoriaUseCase *taxonomiaUseCase.AdicionarSubcategoriaUseCase,
+	atualizarSubcategoriaUseCase *taxonomiaUseCase.AtualizarSubcategoriaUseCase,
+	relatorioReclassificacoes *ticketUseCase.RelatorioReclassificacoesUseCase,
+) *TaxonomiaHandler {
+	return &TaxonomiaHandler{
+		criarCategoriaUseCase:        criarCategoriaUseCase,
+		listarCategoriasUseCase:      listarCategoriasUseCase,
+		buscarCategoriaUseCase:       buscarCategoriaUseCase,
+		atualizarCategoriaUseCase:    atualizarCategoriaUseCase,
+		adicionarSubcategoriaUseCase: adicionarSubcategoriaUseCase,
+		atualizarSubcategoriaUseCase: atualizarSubcategoriaUseCase,
+		relatorioReclassificacoes:    relatorioReclassificacoes,
+	}
+}
+
+// Request de uma subcategoria, na criação da categoria ou ao adicioná-la
+type SubcategoriaRequest struct {
+	Codigo ticketDomain.Subcategoria `json:"codigo"`
+	Nome   string                    `json:"nome"`
+}
+
+// Request para criar uma categoria com as subcategorias
+type CriarCategoriaRequest struct {
+	Codigo        ticketDomain.Categoria `json:"codigo"`
+	Nome          string                 `json:"nome"`
+	Subcategorias []SubcategoriaRequest  `json:"subcategorias,omitempty"`
+}
+
+// Request para atualizar uma categoria ou subcategoria; campos ausentes não são alterados
+type AtualizarTaxonomiaRequest struct {
+	Nome  *string `json:"nome,omitempty"`
+	Ativa *bool   `json:"ativa,omitempty"`
+}
+
+// Response de uma subcategoria
+type SubcategoriaResponse struct {
+	Codigo ticketDomain.Subcategoria `json:"codigo"`
+	Nome   string                    `json:"nome"`
+	Ativa  bool                      `json:"ativa"`
+}
+
+// Response com os dados de uma categoria
+type CategoriaResponse struct {
+	Codigo        ticketDomain.Categoria `json:"codigo"`
+	Nome          string                 `json:"nome"`
+	Ativa         bool                   `json:"ativa"`
+	Subcategorias []SubcategoriaResponse `json:"subcategorias"`
+	DataCriacao   string                 `json:"data_criacao"`
+}
+
+func novaCategoriaResponse(output *taxonomiaUseCase.CategoriaOutput) CategoriaResponse {
+	subcategorias := make([]SubcategoriaResponse, len(output.Subcategorias))
+	for i, s := range output.Subcategorias {
+		subcategorias[i] = SubcategoriaResponse(s)
+	}
+	return CategoriaResponse{
+		Codigo:        output.Codigo,
+		Nome:          output.Nome,
+		Ativa:         output.Ativa,
+		Subcategorias: subcategorias,
+		DataCriacao:   output.DataCriacao,
+	}
+}
+
+func responderCategoria(w http.ResponseWriter, status int, output *taxonomiaUseCase.CategoriaOutput) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(novaCategoriaResponse(output))
+}
+
+// Criar é o handler para cadastrar uma categoria
+func (h *TaxonomiaHandler) Criar(w http.ResponseWriter, r *http.Request) {
+	var req CriarCategoriaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	input := taxonomiaUseCase.CriarCategoriaInput{Codigo: req.Codigo, Nome: req.Nome}
+	for _, s := range req.Subcategorias {
+		input.Subcategorias = append(input.Subcategorias, taxonomiaUseCase.SubcategoriaInput(s))
+	}
+	output, err := h.criarCategoriaUseCase.Execute(r.Context(), input)
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCategoria(w, http.StatusCreated, output)
+}
+
+// Listar é o handler para listar as categorias com as subcategorias; aceita ativas=true
+func (h *TaxonomiaHandler) Listar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listarCategoriasUseCase.Execute(r.Context(), taxonomiaUseCase.ListarCategoriasInput{
+		ApenasAtivas: r.URL.Query().Get("ativas") == "true",
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := make([]CategoriaResponse, len(output))
+	for i, c := range output {
+		resp[i] = novaCategoriaResponse(c)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Buscar é o handler para obter uma categoria
+func (h *TaxonomiaHandler) Buscar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarCategoriaUseCase.Execute(r.Context(), taxonomiaUseCase.BuscarCategoriaInput{
+		Codigo: ticketDomain.Categoria(chi.URLParam(r, "codigo")),
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCategoria(w, http.StatusOK, output)
+}
+
+// Atualizar é o handler para renomear, desativar ou reativar uma categoria
+func (h *TaxonomiaHandler) Atualizar(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarTaxonomiaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarCategoriaUseCase.Execute(r.Context(), taxonomiaUseCase.AtualizarCategoriaInput{
+		Codigo: ticketDomain.Categoria(chi.URLParam(r, "codigo")),
+		Nome:   req.Nome,
+		Ativa:  req.Ativa,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCategoria(w, http.StatusOK, output)
+}
+
+// AdicionarSubcategoria é o handler para incluir uma subcategoria na categoria
+func (h *TaxonomiaHandler) AdicionarSubcategoria(w http.ResponseWriter, r *http.Request) {
+	var req SubcategoriaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.adicionarSubcategoriaUseCase.Execute(r.Context(), taxonomiaUseCase.AdicionarSubcategoriaInput{
+		Categoria: ticketDomain.Categoria(chi.URLParam(r, "codigo")),
+		Codigo:    req.Codigo,
+		Nome:      req.Nome,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCategoria(w, http.StatusCreated, output)
+}
+
+// AtualizarSubcategoria é o handler para renomear, desativar ou reativar uma subcategoria
+func (h *TaxonomiaHandler) AtualizarSubcategoria(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarTaxonomiaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarSubcategoriaUseCase.Execute(r.Context(), taxonomiaUseCase.AtualizarSubcategoriaInput{
+		Categoria: ticketDomain.Categoria(chi.URLParam(r, "codigo")),
+		Codigo:    ticketDomain.Subcategoria(chi.URLParam(r, "subcategoria")),
+		Nome:      req.Nome,
+		Ativa:     req.Ativa,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	responderCategoria(w, http.StatusOK, output)
+}
+
+// Response de uma reclassificação do relatório
+type ReclassificacaoResponse struct {
+	TicketID             string                    `json:"ticket_id"`
+	Titulo               string                    `json:"titulo"`
+	UsuarioID            string                    `json:"usuario_id"`
+	Data                 string                    `json:"data"`
+	CategoriaAnterior    ticketDomain.Categoria    `json:"categoria_anterior"`
+	SubcategoriaAnterior ticketDomain.Subcategoria `json:"subcategoria_anterior"`
+	CategoriaNova        ticketDomain.Categoria    `json:"categoria_nova"`
+	SubcategoriaNova     ticketDomain.Subcategoria `json:"subcategoria_nova"`
+}
+
+// Response da quantidade de reclassificações entre duas classificações
+type ParReclassificacaoResponse struct {
+	De         string `json:"de"`
+	Para       string `json:"para"`
+	Quantidade int    `json:"quantidade"`
+}
+
+// Response do relatório de reclassificações da categoria
+type RelatorioReclassificacoesResponse struct {
+	Categoria        ticketDomain.Categoria       `json:"categoria"`
+	DataInicio       string                       `json:"de"`
+	DataFim          string                       `json:"ate"`
+	Entradas         int                          `json:"entradas"`
+	Saidas           int                          `json:"saidas"`
+	Internas         int                          `json:"internas"`
+	Pares            []ParReclassificacaoResponse `json:"pares"`
+	Reclassificacoes []ReclassificacaoResponse    `json:"reclassificacoes"`
+}
+
+// Reclassificacoes é o handler do relatório de reclassificações da categoria; aceita de e ate
+// (AAAA-MM-DD ou RFC 3339), por padrão os últimos 30 dias
+func (h *TaxonomiaHandler) Reclassificacoes(w http.ResponseWriter, r *http.Request) {
+	input := ticketUseCase.RelatorioReclassificacoesInput{
+		Categoria: ticketDomain.Categoria(chi.URLParam(r, "codigo")),
+	}
+	datas := []struct {
+		parametro string
+		fimDoDia  bool
+		destino   *time.Time
+	}{
+		{"de", false, &input.DataInicio},
+		{"ate", true, &input.DataFim},
+	}
+	for _, d := range datas {
+		valor := r.URL.Query().Get(d.parametro)
+		if valor == "" {
+			continue
+		}
+		data, err := parseData(valor, d.fimDoDia)
+		if err != nil {
+			responderErro(w, filtroInvalido(d.parametro, fmt.Sprintf("%s inválido: %s", d.parametro, valor)))
+			return
+		}
+		*d.destino = data
+	}
+
+	output, err := h.relatorioReclassificacoes.Execute(r.Context(), input)
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := RelatorioReclassificacoesResponse{
+		Categoria:        output.Categoria,
+		DataInicio:       output.DataInicio,
+		DataFim:          output.DataFim,
+		Entradas:         output.Entradas,
+		Saidas:           output.Saidas,
+		Internas:         output.Internas,
+		Pares:            make([]ParReclassificacaoResponse, len(output.Pares)),
+		Reclassificacoes: make([]ReclassificacaoResponse, len(output.Reclassificacoes)),
+	}
+	for i, p := range output.Pares {
+		resp.Pares[i] = ParReclassificacaoResponse(p)
+	}
+	for i, rc := range output.Reclassificacoes {
+		resp.Reclassificacoes[i] = ReclassificacaoResponse(rc)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -16,6 +16,7 @@ type TicketHandler struct {
 	criarTicketUseCase         *ticketUseCase.CriarTicketUseCase
 	buscarTicketUseCase        *ticketUseCase.BuscarTicketUseCase
 	listarTicketsUseCase       *ticketUseCase.ListarTicketsUseCase
+	pesquisarTicketsUseCase    *ticketUseCase.PesquisarTicketsUseCase
 	atualizarTicketUseCase     *ticketUseCase.AtualizarTicketUseCase
 	atualizarStatusUseCase     *ticketUseCase.AtualizarStatusUseCase
 	adicionarObservacaoUseCase *ticketUseCase.AdicionarObservacaoUseCase
@@ -26,6 +27,7 @@ func NewTicketHandler(
 	criarTicketUseCase *ticketUseCase.CriarTicketUseCase,
 	buscarTicketUseCase *ticketUseCase.BuscarTicketUseCase,
 	listarTicketsUseCase *ticketUseCase.ListarTicketsUseCase,
+	pesquisarTicketsUseCase *ticketUseCase.PesquisarTicketsUseCase,
 	atualizarTicketUseCase *ticketUseCase.AtualizarTicketUseCase,
 	atualizarStatusUseCase *ticketUseCase.AtualizarStatusUseCase,
 	adicionarObservacaoUseCase *ticketUseCase.AdicionarObservacaoUseCase,
@@ -34,6 +36,7 @@ func NewTicketHandler(
 		criarTicketUseCase:         criarTicketUseCase,
 		buscarTicketUseCase:        buscarTicketUseCase,
 		listarTicketsUseCase:       listarTicketsUseCase,
+		pesquisarTicketsUseCase:    pesquisarTicketsUseCase,
 		atualizarTicketUseCase:     atualizarTicketUseCase,
 		atualizarStatusUseCase:     atualizarStatusUseCase,
 		adicionarObservacaoUseCase: adicionarObservacaoUseCase,
@@ -46,7 +49,6 @@ type CriarTicketRequest struct {
 	Descricao    string                    `json:"descricao"`
 	Categoria    ticketDomain.Categoria    `json:"categoria"`
 	Subcategoria ticketDomain.Subcategoria `json:"subcategoria"`
-	AbertoPor    string                    `json:"aberto_por"`
 	Urgencia     int                       `json:"urgencia"`
 	Gravidade    int                       `json:"gravidade"`
 	// campos opcionais
@@ -56,6 +58,9 @@ type CriarTicketRequest struct {
 	Plataforma  string `json:"plataforma,omitempty"`
 	Contato     string `json:"contato,omitempty"`
 	Responsavel string `json:"responsavel,omitempty"`
+
+	// campos personalizados do formulário da categoria/subcategoria (GET /formularios)
+	Campos map[string]interface{} `json:"campos,omitempty"`
 }
 
 type CriarTicketResponse struct {
@@ -66,6 +71,8 @@ type CriarTicketResponse struct {
 	Titulo       string                    `json:"titulo"`
 	Categoria    ticketDomain.Categoria    `json:"categoria"`
 	Subcategoria ticketDomain.Subcategoria `json:"subcategoria"`
+	FilaID       string                    `json:"fila_id,omitempty"`
+	Responsavel  string                    `json:"responsavel,omitempty"`
 }
 
 // Criar é o handler para criar um novo ticket
@@ -73,7 +80,7 @@ func (h *TicketHandler) Criar(w http.ResponseWriter, r *http.Request) {
 	// ler o JSON da requisição
 	var req CriarTicketRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		responderErro(w, corpoInvalido(err))
 		return
 	}
 
@@ -83,7 +90,6 @@ func (h *TicketHandler) Criar(w http.ResponseWriter, r *http.Request) {
 		Descricao:    req.Descricao,
 		Categoria:    req.Categoria,
 		Subcategoria: req.Subcategoria,
-		AbertoPor:    req.AbertoPor,
 		Urgencia:     req.Urgencia,
 		Gravidade:    req.Gravidade,
 		Merchant:     req.Merchant,
@@ -92,12 +98,13 @@ func (h *TicketHandler) Criar(w http.ResponseWriter, r *http.Request) {
 		Plataforma:   req.Plataforma,
 		Contato:      req.Contato,
 		Responsavel:  req.Responsavel,
+		Campos:       req.Campos,
 	}
 
 	// execute o use case
-	output, err := h.criarTicketUseCase.Execute(input)
+	output, err := h.criarTicketUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		responderErro(w, err)
 		return
 	}
 
@@ -110,6 +117,8 @@ func (h *TicketHandler) Criar(w http.ResponseWriter, r *http.Request) {
 		Titulo:       output.Titulo,
 		Categoria:    output.Categoria,
 		Subcategoria: output.Subcategoria,
+		FilaID:       output.FilaID,
+		Responsavel:  output.Responsavel,
 	}
 
 	// enviar a resposta
@@ -136,8 +145,49 @@ type BuscarTicketResponse struct {
 	Plataforma   *string                   `json:"plataforma,omitempty"`
 	Contato      *string                   `json:"contato,omitempty"`
 	Responsavel  *string                   `json:"responsavel,omitempty"`
+	FilaID       string                    `json:"fila_id,omitempty"`
+	Campos       map[string]interface{}    `json:"campos"`
 	Observacoes  []ObservacaoResponse      `json:"observacoes,omitempty"`
 	Modificacoes []ModificacaoResponse     `json:"modificacoes,omitempty"`
+
+	// durações em tempo útil e em tempo corrido
+	DuracaoTotal           string `json:"duracao_total"`
+	DuracaoExecucao        string `json:"duracao_execucao"`
+	DuracaoTotalCorrida    string `json:"duracao_total_corrida"`
+	DuracaoExecucaoCorrida string `json:"duracao_execucao_corrida"`
+
+	Execucao             ExecucaoResponse      `json:"execucao"`
+	SLA                  SLAResponse           `json:"sla"`
+	TransicoesPermitidas []ticketDomain.Status `json:"transicoes_permitidas"`
+	Versao               int                   `json:"versao"`
+}
+
+type ExecucaoResponse struct {
+	TempoAtivo  string          `json:"tempo_ativo"`
+	TempoEspera string          `json:"tempo_espera"`
+	Pausas      []PausaResponse `json:"pausas"`
+}
+
+type PausaResponse struct {
+	ID        string              `json:"id"`
+	Status    ticketDomain.Status `json:"status"`
+	Motivo    string              `json:"motivo"`
+	UsuarioID string              `json:"usuario_id"`
+	Inicio    string              `json:"inicio"`
+	Fim       string              `json:"fim,omitempty"`
+	Duracao   string              `json:"duracao"`
+}
+
+type SLAResponse struct {
+	PrazoPrimeiraResposta         string `json:"prazo_primeira_resposta,omitempty"`
+	PrazoResolucao                string `json:"prazo_resolucao,omitempty"`
+	DataPrimeiraResposta          string `json:"data_primeira_resposta,omitempty"`
+	Violado                       bool   `json:"violado"`
+	TempoRestantePrimeiraResposta string `json:"tempo_restante_primeira_resposta,omitempty"`
+	TempoRestanteResolucao        string `json:"tempo_restante_resolucao,omitempty"`
+
+	TempoRestantePrimeiraRespostaCorrido string `json:"tempo_restante_primeira_resposta_corrido,omitempty"`
+	TempoRestanteResolucaoCorrido        string `json:"tempo_restante_resolucao_corrido,omitempty"`
 }
 
 type ObservacaoResponse struct {
@@ -162,12 +212,27 @@ func (h *TicketHandler) Buscar(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	// executar o use case
-	output, err := h.buscarTicketUseCase.Execute(ticketUseCase.BuscarTicketInput{ID: id})
+	output, err := h.buscarTicketUseCase.Execute(r.Context(), ticketUseCase.BuscarTicketInput{ID: id})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		responderErro(w, err)
 		return
 	}
 
+	// o cliente já tem a versão atual
+	if versaoConfere(r.Header.Get("If-None-Match"), output.Versao) {
+		w.Header().Set("ETag", etag(output.Versao))
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// enviar a resposta
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(output.Versao))
+	json.NewEncoder(w).Encode(novoBuscarTicketResponse(output))
+}
+
+// novoBuscarTicketResponse converte o output do use case para a resposta HTTP
+func novoBuscarTicketResponse(output *ticketUseCase.BuscarTicketOutput) BuscarTicketResponse {
 	// converter output para response
 	resp := BuscarTicketResponse{
 		ID:           output.ID,
@@ -184,6 +249,26 @@ func (h *TicketHandler) Buscar(w http.ResponseWriter, r *http.Request) {
 		NoxID:        output.NoxID,
 		CPF:          output.CPF,
 		Plataforma:   output.Plataforma,
+		FilaID:       output.FilaID,
+		Campos:       output.Campos,
+
+		DuracaoTotal:           output.DuracaoTotal,
+		DuracaoExecucao:        output.DuracaoExecucao,
+		DuracaoTotalCorrida:    output.DuracaoTotalCorrida,
+		DuracaoExecucaoCorrida: output.DuracaoExecucaoCorrida,
+
+		Execucao: ExecucaoResponse{
+			TempoAtivo:  output.Execucao.TempoAtivo,
+			TempoEspera: output.Execucao.TempoEspera,
+			Pausas:      []PausaResponse{},
+		},
+		SLA:                  SLAResponse(output.SLA),
+		TransicoesPermitidas: output.TransicoesPermitidas,
+		Versao:               output.Versao,
+	}
+
+	if resp.Campos == nil {
+		resp.Campos = map[string]interface{}{}
 	}
 
 	// Adicionar campos opcionais apenas se não estiverem vazios
@@ -194,6 +279,11 @@ func (h *TicketHandler) Buscar(w http.ResponseWriter, r *http.Request) {
 		resp.Responsavel = &output.Responsavel
 	}
 
+	// converter pausas
+	for _, p := range output.Execucao.Pausas {
+		resp.Execucao.Pausas = append(resp.Execucao.Pausas, PausaResponse(p))
+	}
+
 	// converter observações
 	for _, obs := range output.Observacoes {
 		resp.Observacoes = append(resp.Observacoes, ObservacaoResponse{
@@ -216,18 +306,17 @@ func (h *TicketHandler) Buscar(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	// enviar a resposta
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	return resp
 }
 
 // Request para listar tickets
 type ListarTicketsRequest struct {
-	Status      *ticketDomain.Status    `json:"status,omitempty"`
-	Categoria   *ticketDomain.Categoria `json:"categoria,omitempty"`
-	Responsavel *string                 `json:"responsavel,omitempty"`
-	Pagina      int                     `json:"pagina"`
-	PorPagina   int                     `json:"por_pagina"`
+	Filtros    ticketDomain.TicketFiltros  `json:"-"`
+	Pagina     int                         `json:"pagina"`
+	PorPagina  int                         `json:"por_pagina"`
+	Cursor     string                      `json:"cursor,omitempty"`
+	OrdenarPor ticketDomain.CampoOrdenacao `json:"ordenar_por,omitempty"`
+	Ordem      string                      `json:"ordem,omitempty"`
 }
 
 // Listar é o handler para listar tickets
@@ -249,19 +338,85 @@ func (h *TicketHandler) Listar(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// ordenação: ordenar_por=data_abertura|urgencia|gravidade|prazo_sla e ordem=asc|desc
+	req.Cursor = r.URL.Query().Get("cursor")
+	req.OrdenarPor = ticketDomain.CampoOrdenacao(r.URL.Query().Get("ordenar_por"))
+	req.Ordem = r.URL.Query().Get("ordem")
+	if err := ticketDomain.ValidarCampoOrdenacao(req.OrdenarPor); err != nil {
+		responderErro(w, err)
+		return
+	}
+	if req.Ordem != "" && req.Ordem != "asc" && req.Ordem != "desc" {
+		responderErro(w, ticketDomain.NovoErroValidacao("ordem", "ordem_invalida", "ordem deve ser asc ou desc"))
+		return
+	}
+
+	// filtros: status, categoria, datas, urgência/gravidade etc. (ver lerFiltros)
+	filtros, err := lerFiltros(r.URL.Query())
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+	req.Filtros = filtros
+
 	// converter request para input do use case
 	input := ticketUseCase.ListarTicketsInput{
-		Status:         req.Status,
-		Categoria:      req.Categoria,
-		Responsavel:    req.Responsavel,
+		Filtros:        req.Filtros,
 		Pagina:         req.Pagina,
 		ItensPorPagina: req.PorPagina,
+		Cursor:         req.Cursor,
+		Ordenacao: ticketDomain.Ordenacao{
+			Campo:       req.OrdenarPor,
+			Descendente: req.Ordem == "desc",
+		},
+	}
+
+	// executar o use case
+	output, err := h.listarTicketsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	// enviar resposta
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// Pesquisar é o handler da busca textual (GET /tickets/search?q=)
+func (h *TicketHandler) Pesquisar(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	consulta := query.Get("q")
+	if consulta == "" {
+		responderErro(w, ticketUseCase.ErrConsultaVazia)
+		return
+	}
+
+	pagina, porPagina := 1, 10 // valores padrão
+	if p, err := strconv.Atoi(query.Get("pagina")); err == nil && p > 0 {
+		pagina = p
+	}
+	if l, err := strconv.Atoi(query.Get("por_pagina")); err == nil && l > 0 {
+		porPagina = l
+	}
+
+	// aceita os mesmos filtros da listagem
+	filtros, err := lerFiltros(query)
+	if err != nil {
+		responderErro(w, err)
+		return
 	}
 
 	// executar o use case
-	output, err := h.listarTicketsUseCase.Execute(input)
+	output, err := h.pesquisarTicketsUseCase.Execute(r.Context(), ticketUseCase.PesquisarTicketsInput{
+		Consulta:       consulta,
+		Filtros:        filtros,
+		Pagina:         pagina,
+		ItensPorPagina: porPagina,
+	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		responderErro(w, err)
 		return
 	}
 
@@ -272,17 +427,20 @@ func (h *TicketHandler) Listar(w http.ResponseWriter, r *http.Request) {
 
 // Request para atualizar ticket
 type AtualizarTicketRequest struct {
-	Titulo     *string                 `json:"titulo,omitempty"`
-	Descricao  *string                 `json:"descricao,omitempty"`
-	Categoria  *ticketDomain.Categoria `json:"categoria,omitempty"`
-	Urgencia   *int                    `json:"urgencia,omitempty"`
-	Gravidade  *int                    `json:"gravidade,omitempty"`
-	Merchant   *string                 `json:"merchant,omitempty"`
-	NoxID      *string                 `json:"nox_id,omitempty"`
-	CPF        *string                 `json:"cpf,omitempty"`
-	Plataforma *string                 `json:"plataforma,omitempty"`
-	Contato    *string                 `json:"contato,omitempty"`
-	UsuarioID  string                  `json:"usuario_id"`
+	Titulo       *string                    `json:"titulo,omitempty"`
+	Descricao    *string                    `json:"descricao,omitempty"`
+	Categoria    *ticketDomain.Categoria    `json:"categoria,omitempty"`
+	Subcategoria *ticketDomain.Subcategoria `json:"subcategoria,omitempty"`
+	Urgencia     *int                       `json:"urgencia,omitempty"`
+	Gravidade    *int                       `json:"gravidade,omitempty"`
+	Merchant     *string                    `json:"merchant,omitempty"`
+	NoxID        *string                    `json:"nox_id,omitempty"`
+	CPF          *string                    `json:"cpf,omitempty"`
+	Plataforma   *string                    `json:"plataforma,omitempty"`
+	Contato      *string                    `json:"contato,omitempty"`
+
+	// campos personalizados alterados; null remove o campo
+	Campos map[string]interface{} `json:"campos,omitempty"`
 }
 
 // Atualizar é o handler para atualizar um ticket
@@ -293,43 +451,53 @@ func (h *TicketHandler) Atualizar(w http.ResponseWriter, r *http.Request) {
 	// ler o JSON da requisição
 	var req AtualizarTicketRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		responderErro(w, corpoInvalido(err))
 		return
 	}
 
 	// converter request para input do use case
 	input := ticketUseCase.AtualizarTicketInput{
-		ID:         id,
-		Titulo:     req.Titulo,
-		Descricao:  req.Descricao,
-		Categoria:  req.Categoria,
-		Urgencia:   req.Urgencia,
-		Gravidade:  req.Gravidade,
-		Merchant:   req.Merchant,
-		NoxID:      req.NoxID,
-		CPF:        req.CPF,
-		Plataforma: req.Plataforma,
-		Contato:    req.Contato,
-		UsuarioID:  req.UsuarioID,
+		ID:           id,
+		Titulo:       req.Titulo,
+		Descricao:    req.Descricao,
+		Categoria:    req.Categoria,
+		Subcategoria: req.Subcategoria,
+		Urgencia:     req.Urgencia,
+		Gravidade:    req.Gravidade,
+		Merchant:     req.Merchant,
+		NoxID:        req.NoxID,
+		CPF:          req.CPF,
+		Plataforma:   req.Plataforma,
+		Contato:      req.Contato,
+		Campos:       req.Campos,
+	}
+
+	// If-Match: só atualiza se o cliente estiver editando a versão atual
+	versao, err := lerIfMatch(r)
+	if err != nil {
+		responderErro(w, err)
+		return
 	}
+	input.VersaoEsperada = versao
 
 	// executar o use case
-	output, err := h.atualizarTicketUseCase.Execute(input)
+	output, err := h.atualizarTicketUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.responderErroTicket(w, r, id, err)
 		return
 	}
 
 	// enviar resposta
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(output.Versao))
 	json.NewEncoder(w).Encode(output)
 }
 
 // Request para atualizar status
 type AtualizarStatusRequest struct {
 	Status      ticketDomain.Status `json:"status"`
-	UsuarioID   string              `json:"usuario_id"`
 	Responsavel *string             `json:"responsavel,omitempty"`
+	Motivo      *string             `json:"motivo,omitempty"`
 }
 
 // AtualizarStatus é o handler para atualizar o status de um ticket
@@ -340,7 +508,7 @@ func (h *TicketHandler) AtualizarStatus(w http.ResponseWriter, r *http.Request)
 	// ler o JSON da requisição
 	var req AtualizarStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		responderErro(w, corpoInvalido(err))
 		return
 	}
 
@@ -348,26 +516,34 @@ func (h *TicketHandler) AtualizarStatus(w http.ResponseWriter, r *http.Request)
 	input := ticketUseCase.AtualizarStatusTicketInput{
 		ID:          id,
 		Status:      req.Status,
-		UsuarioID:   req.UsuarioID,
 		Responsavel: req.Responsavel,
+		Motivo:      req.Motivo,
+	}
+
+	// If-Match: só muda o status se o cliente estiver vendo a versão atual
+	versao, err := lerIfMatch(r)
+	if err != nil {
+		responderErro(w, err)
+		return
 	}
+	input.VersaoEsperada = versao
 
 	// executar o use case
-	output, err := h.atualizarStatusUseCase.Execute(input)
+	output, err := h.atualizarStatusUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.responderErroTicket(w, r, id, err)
 		return
 	}
 
 	// enviar resposta
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(output.Versao))
 	json.NewEncoder(w).Encode(output)
 }
 
 // Request para adicionar observação
 type AdicionarObservacaoRequest struct {
 	Descricao string `json:"descricao"`
-	UsuarioID string `json:"usuario_id"`
 }
 
 // AdicionarObservacao é o handler para adicionar uma observação a um ticket
@@ -378,7 +554,7 @@ func (h *TicketHandler) AdicionarObservacao(w http.ResponseWriter, r *http.Reque
 	// ler o JSON da requisição
 	var req AdicionarObservacaoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		responderErro(w, corpoInvalido(err))
 		return
 	}
 
@@ -386,13 +562,12 @@ func (h *TicketHandler) AdicionarObservacao(w http.ResponseWriter, r *http.Reque
 	input := ticketUseCase.AdicionarObservacaoInput{
 		ID:        id,
 		Descricao: req.Descricao,
-		UsuarioID: req.UsuarioID,
 	}
 
 	// executar o use case
-	output, err := h.adicionarObservacaoUseCase.Execute(input)
+	output, err := h.adicionarObservacaoUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		responderErro(w, err)
 		return
 	}
 
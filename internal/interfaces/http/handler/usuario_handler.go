@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	usuarioUseCase "nox_tickets/internal/application/usecases/usuario"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// UsuarioHandler contém os handlers do diretório de usuários
+type UsuarioHandler struct {
+	criarUsuarioUseCase     *usuarioUseCase.CriarUsuarioUseCase
+	buscarUsuarioUseCase    *usuarioUseCase.BuscarUsuarioUseCase
+	listarUsuariosUseCase   *usuarioUseCase.ListarUsuariosUseCase
+	atualizarUsuarioUseCase *usuarioUseCase.AtualizarUsuarioUseCase
+}
+
+// NewUsuarioHandler cria uma nova instancia de UsuarioHandler
+func NewUsuarioHandler(
+	criarUsuarioUseCase *usuarioUseCase.CriarUsuarioUseCase,
+	buscarUsuarioUseCase *usuarioUseCase.BuscarUsuarioUseCase,
+	listarUsuariosUseCase *usuarioUseCase.ListarUsuariosUseCase,
+	atualizarUsuarioUseCase *usuarioUseCase.AtualizarUsuarioUseCase,
+) *UsuarioHandler {
+	return &UsuarioHandler{
+		criarUsuarioUseCase:     criarUsuarioUseCase,
+		buscarUsuarioUseCase:    buscarUsuarioUseCase,
+		listarUsuariosUseCase:   listarUsuariosUseCase,
+		atualizarUsuarioUseCase: atualizarUsuarioUseCase,
+	}
+}
+
+// Request para criar um usuário
+type CriarUsuarioRequest struct {
+	ID          string   `json:"id,omitempty"`
+	Nome        string   `json:"nome"`
+	Email       string   `json:"email,omitempty"`
+	Equipes     []string `json:"equipes,omitempty"`
+	Habilidades []string `json:"habilidades,omitempty"`
+}
+
+// Request para atualizar um usuário; campos ausentes não são alterados
+type AtualizarUsuarioRequest struct {
+	Nome        *string   `json:"nome,omitempty"`
+	Email       *string   `json:"email,omitempty"`
+	Ativo       *bool     `json:"ativo,omitempty"`
+	Equipes     *[]string `json:"equipes,omitempty"`
+	Habilidades *[]string `json:"habilidades,omitempty"`
+}
+
+// Response com os dados de um usuário
+type UsuarioResponse struct {
+	ID          string   `json:"id"`
+	Nome        string   `json:"nome"`
+	Email       string   `json:"email,omitempty"`
+	Ativo       bool     `json:"ativo"`
+	Equipes     []string `json:"equipes"`
+	Habilidades []string `json:"habilidades"`
+	DataCriacao string   `json:"data_criacao"`
+}
+
+func novoUsuarioResponse(output *usuarioUseCase.UsuarioOutput) UsuarioResponse {
+	equipes := output.Equipes
+	if equipes == nil {
+		equipes = []string{}
+	}
+	habilidades := output.Habilidades
+	if habilidades == nil {
+		habilidades = []string{}
+	}
+	return UsuarioResponse{
+		ID:          output.ID,
+		Nome:        output.Nome,
+		Email:       output.Email,
+		Ativo:       output.Ativo,
+		Equipes:     equipes,
+		Habilidades: habilidades,
+		DataCriacao: output.DataCriacao,
+	}
+}
+
+// Criar é o handler para cadastrar um usuário
+func (h *UsuarioHandler) Criar(w http.ResponseWriter, r *http.Request) {
+	var req CriarUsuarioRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.criarUsuarioUseCase.Execute(r.Context(), usuarioUseCase.CriarUsuarioInput{
+		ID:          req.ID,
+		Nome:        req.Nome,
+		Email:       req.Email,
+		Equipes:     req.Equipes,
+		Habilidades: req.Habilidades,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(novoUsuarioResponse(output))
+}
+
+// Buscar é o handler para obter um usuário pelo ID
+func (h *UsuarioHandler) Buscar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarUsuarioUseCase.Execute(r.Context(), usuarioUseCase.BuscarUsuarioInput{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(novoUsuarioResponse(output))
+}
+
+// Listar é o handler para listar os usuários; aceita equipe=<id> e ativos=true
+func (h *UsuarioHandler) Listar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listarUsuariosUseCase.Execute(r.Context(), usuarioUseCase.ListarUsuariosInput{
+		EquipeID:     r.URL.Query().Get("equipe"),
+		ApenasAtivos: r.URL.Query().Get("ativos") == "true",
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := make([]UsuarioResponse, len(output))
+	for i, u := range output {
+		resp[i] = novoUsuarioResponse(u)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Atualizar é o handler para alterar um usuário
+func (h *UsuarioHandler) Atualizar(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarUsuarioRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarUsuarioUseCase.Execute(r.Context(), usuarioUseCase.AtualizarUsuarioInput{
+		ID:          chi.URLParam(r, "id"),
+		Nome:        req.Nome,
+		Email:       req.Email,
+		Ativo:       req.Ativo,
+		Equipes:     req.Equipes,
+		Habilidades: req.Habilidades,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(novoUsuarioResponse(output))
+}
+
+// Desativar é o handler do DELETE: o usuário é desativado, e não apagado, porque os tickets o referenciam
+func (h *UsuarioHandler) Desativar(w http.ResponseWriter, r *http.Request) {
+	inativo := false
+	_, err := h.atualizarUsuarioUseCase.Execute(r.Context(), usuarioUseCase.AtualizarUsuarioInput{
+		ID:    chi.URLParam(r, "id"),
+		Ativo: &inativo,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	ticketDomain "nox_tickets/internal/domain/ticket"
+)
+
+var errIfMatchInvalido = ticketDomain.NovoErroValidacao("If-Match", "if_match_invalido", "cabeçalho If-Match inválido")
+
+// etag monta a ETag forte a partir da versão do ticket
+func etag(versao int) string {
+	return `"` + strconv.Itoa(versao) + `"`
+}
+
+// lerVersao extrai a versão de uma ETag, aceitando também o formato fraco (W/"3")
+func lerVersao(tag string) (int, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, errIfMatchInvalido
+	}
+	versao, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil {
+		return 0, errIfMatchInvalido
+	}
+	return versao, nil
+}
+
+// versaoConfere indica se alguma das ETags do cabeçalho (If-None-Match) é a versão informada
+func versaoConfere(cabecalho string, versao int) bool {
+	for _, tag := range strings.Split(cabecalho, ",") {
+		if strings.TrimSpace(tag) == "*" {
+			return true
+		}
+		if v, err := lerVersao(tag); err == nil && v == versao {
+			return true
+		}
+	}
+	return false
+}
+
+// lerIfMatch retorna a versão esperada pelo cliente, ou nil quando o cabeçalho não foi enviado ou é "*"
+func lerIfMatch(r *http.Request) (*int, error) {
+	cabecalho := strings.TrimSpace(r.Header.Get("If-Match"))
+	if cabecalho == "" || cabecalho == "*" {
+		return nil, nil
+	}
+	versao, err := lerVersao(cabecalho)
+	if err != nil {
+		return nil, err
+	}
+	return &versao, nil
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	webhookUseCase "nox_tickets/internal/application/usecases/webhook"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+	webhookDomain "nox_tickets/internal/domain/webhook"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WebhookHandler contém os handlers das assinaturas de webhook e do log de entregas
+type WebhookHandler struct {
+	criarWebhookUseCase     *webhookUseCase.CriarWebhookUseCase
+	buscarWebhookUseCase    *webhookUseCase.BuscarWebhookUseCase
+	listarWebhooksUseCase   *webhookUseCase.ListarWebhooksUseCase
+	atualizarWebhookUseCase *webhookUseCase.AtualizarWebhookUseCase
+	listarEntregasUseCase   *webhookUseCase.ListarEntregasUseCase
+}
+
+// NewWebhookHandler cria uma nova instancia de WebhookHandler
+func NewWebhookHandler(
+	criarWebhookUseCase *webhookUseCase.CriarWebhookUseCase,
+	buscarWebhookUseCase *webhookUseCase.BuscarWebhookUseCase,
+	listarWebhooksUseCase *webhookUseCase.ListarWebhooksUseCase,
+	atualizarWebhookUseCase *webhookUseCase.AtualizarWebhookUseCase,
+	listarEntregasUseCase *webhookUseCase.ListarEntregasUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		criarWebhookUseCase:     criarWebhookUseCase,
+		buscarWebhookUseCase:    buscarWebhookUseCase,
+		listarWebhooksUseCase:   listarWebhooksUseCase,
+		atualizarWebhookUseCase: atualizarWebhookUseCase,
+		listarEntregasUseCase:   listarEntregasUseCase,
+	}
+}
+
+// Request para cadastrar um webhook; eventos e categorias vazios recebem tudo
+type CriarWebhookRequest struct {
+	URL        string                    `json:"url"`
+	Eventos    []ticketDomain.TipoEvento `json:"eventos,omitempty"`
+	Categorias []ticketDomain.Categoria  `json:"categorias,omitempty"`
+}
+
+// Request para atualizar um webhook; campos ausentes não são alterados
+type AtualizarWebhookRequest struct {
+	URL            *string                    `json:"url,omitempty"`
+	Eventos        *[]ticketDomain.TipoEvento `json:"eventos,omitempty"`
+	Categorias     *[]ticketDomain.Categoria  `json:"categorias,omitempty"`
+	Ativo          *bool                      `json:"ativo,omitempty"`
+	RenovarSegredo bool                       `json:"renovar_segredo,omitempty"`
+}
+
+// Response com os dados de um webhook; o segredo só aparece na criação e ao renovar
+type WebhookResponse struct {
+	ID          string                    `json:"id"`
+	URL         string                    `json:"url"`
+	Segredo     string                    `json:"segredo,omitempty"`
+	Eventos     []ticketDomain.TipoEvento `json:"eventos"`
+	Categorias  []ticketDomain.Categoria  `json:"categorias"`
+	Ativo       bool                      `json:"ativo"`
+	CriadoPor   string                    `json:"criado_por"`
+	DataCriacao string                    `json:"data_criacao"`
+}
+
+// Response de uma entrega, com o log das tentativas
+type EntregaResponse struct {
+	ID               string                      `json:"id"`
+	EventoID         string                      `json:"evento_id"`
+	Tipo             ticketDomain.TipoEvento     `json:"tipo"`
+	TicketID         string                      `json:"ticket_id"`
+	Status           webhookDomain.StatusEntrega `json:"status"`
+	Tentativas       int                         `json:"tentativas"`
+	ProximaTentativa string                      `json:"proxima_tentativa,omitempty"`
+	UltimoErro       string                      `json:"ultimo_erro,omitempty"`
+	DataCriacao      string                      `json:"data_criacao"`
+	DataConclusao    string                      `json:"data_conclusao,omitempty"`
+	Payload          json.RawMessage             `json:"payload"`
+	Historico        []TentativaResponse         `json:"historico"`
+}
+
+// Response de uma tentativa de entrega
+type TentativaResponse struct {
+	Numero     int    `json:"numero"`
+	StatusHTTP int    `json:"status_http,omitempty"`
+	Erro       string `json:"erro,omitempty"`
+	DuracaoMs  int64  `json:"duracao_ms"`
+	Data       string `json:"data"`
+}
+
+func novoWebhookResponse(output *webhookUseCase.WebhookOutput) WebhookResponse {
+	resp := WebhookResponse(*output)
+	if resp.Eventos == nil {
+		resp.Eventos = []ticketDomain.TipoEvento{}
+	}
+	if resp.Categorias == nil {
+		resp.Categorias = []ticketDomain.Categoria{}
+	}
+	return resp
+}
+
+func novaEntregaResponse(output *webhookUseCase.EntregaOutput) EntregaResponse {
+	resp := EntregaResponse{
+		ID:               output.ID,
+		EventoID:         output.EventoID,
+		Tipo:             output.Tipo,
+		TicketID:         output.TicketID,
+		Status:           output.Status,
+		Tentativas:       output.Tentativas,
+		ProximaTentativa: output.ProximaTentativa,
+		UltimoErro:       output.UltimoErro,
+		DataCriacao:      output.DataCriacao,
+		DataConclusao:    output.DataConclusao,
+		Payload:          json.RawMessage(output.Payload),
+		Historico:        make([]TentativaResponse, len(output.Historico)),
+	}
+	for i, t := range output.Historico {
+		resp.Historico[i] = TentativaResponse(t)
+	}
+	return resp
+}
+
+// Criar é o handler para cadastrar um webhook
+func (h *WebhookHandler) Criar(w http.ResponseWriter, r *http.Request) {
+	var req CriarWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.criarWebhookUseCase.Execute(r.Context(), webhookUseCase.CriarWebhookInput(req))
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(novoWebhookResponse(output))
+}
+
+// Buscar é o handler para obter um webhook
+func (h *WebhookHandler) Buscar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.buscarWebhookUseCase.Execute(r.Context(), webhookUseCase.BuscarWebhookInput{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(novoWebhookResponse(output))
+}
+
+// Listar é o handler para listar os webhooks; aceita ativos=true
+func (h *WebhookHandler) Listar(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listarWebhooksUseCase.Execute(r.Context(), webhookUseCase.ListarWebhooksInput{
+		ApenasAtivos: r.URL.Query().Get("ativos") == "true",
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := make([]WebhookResponse, len(output))
+	for i, a := range output {
+		resp[i] = novoWebhookResponse(a)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Atualizar é o handler para alterar um webhook
+func (h *WebhookHandler) Atualizar(w http.ResponseWriter, r *http.Request) {
+	var req AtualizarWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderErro(w, corpoInvalido(err))
+		return
+	}
+
+	output, err := h.atualizarWebhookUseCase.Execute(r.Context(), webhookUseCase.AtualizarWebhookInput{
+		ID:             chi.URLParam(r, "id"),
+		URL:            req.URL,
+		Eventos:        req.Eventos,
+		Categorias:     req.Categorias,
+		Ativo:          req.Ativo,
+		RenovarSegredo: req.RenovarSegredo,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(novoWebhookResponse(output))
+}
+
+// Desativar é o handler para parar de enviar eventos a um webhook
+func (h *WebhookHandler) Desativar(w http.ResponseWriter, r *http.Request) {
+	inativo := false
+	_, err := h.atualizarWebhookUseCase.Execute(r.Context(), webhookUseCase.AtualizarWebhookInput{
+		ID:    chi.URLParam(r, "id"),
+		Ativo: &inativo,
+	})
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListarEntregas é o handler do log de entregas de um webhook; aceita status=<pendente|entregue|descartada> e limite=<n>
+func (h *WebhookHandler) ListarEntregas(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := webhookUseCase.ListarEntregasInput{
+		WebhookID: chi.URLParam(r, "id"),
+		Status:    webhookDomain.StatusEntrega(query.Get("status")),
+	}
+	if valor := query.Get("limite"); valor != "" {
+		limite, err := strconv.Atoi(valor)
+		if err != nil || limite < 1 {
+			responderErro(w, filtroInvalido("limite", "limite deve ser um número positivo"))
+			return
+		}
+		input.Limite = limite
+	}
+
+	output, err := h.listarEntregasUseCase.Execute(r.Context(), input)
+	if err != nil {
+		responderErro(w, err)
+		return
+	}
+
+	resp := make([]EntregaResponse, len(output))
+	for i, e := range output {
+		resp[i] = novaEntregaResponse(e)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,50 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"nox_tickets/internal/domain/auth"
+	"nox_tickets/internal/interfaces/http/handler"
+)
+
+// ValidadorDeToken verifica o token da requisição e identifica o usuário
+type ValidadorDeToken interface {
+	Validar(token string) (auth.Principal, error)
+}
+
+// Autenticacao exige um token Bearer válido e coloca o usuário autenticado no contexto da requisição
+func Autenticacao(validador ValidadorDeToken) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 1. extrai o token do cabeçalho Authorization
+			cabecalho := r.Header.Get("Authorization")
+			token, ok := strings.CutPrefix(cabecalho, "Bearer ")
+			if !ok || strings.TrimSpace(token) == "" {
+				naoAutenticado(w, "token de acesso ausente")
+				return
+			}
+
+			// 2. valida o token
+			principal, err := validador.Validar(strings.TrimSpace(token))
+			if err != nil {
+				naoAutenticado(w, err.Error())
+				return
+			}
+
+			// 3. segue com o usuário no contexto
+			next.ServeHTTP(w, r.WithContext(auth.ComPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func naoAutenticado(w http.ResponseWriter, mensagem string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="nox_tickets"`)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(handler.ErroResponse{
+		Codigo:   "nao_autenticado",
+		Mensagem: mensagem,
+	})
+}
@@ -10,7 +10,7 @@ import (
 )
 
 // newRouter cria e configura um novo router
-func NewRouter(ticketHandler *handler.TicketHandler) *chi.Mux {
+func NewRouter(ticketHandler *handler.TicketHandler, usuarioHandler *handler.UsuarioHandler, equipeHandler *handler.EquipeHandler, filaHandler *handler.FilaHandler, webhookHandler *handler.WebhookHandler, notificacaoHandler *handler.NotificacaoHandler, anexoHandler *handler.AnexoHandler, taxonomiaHandler *handler.TaxonomiaHandler, formularioHandler *handler.FormularioHandler, medHandler *handler.MEDHandler, aprovacaoHandler *handler.AprovacaoHandler, validador ValidadorDeToken) *chi.Mux {
 	r := chi.NewRouter()
 
 	// adiciona middleware de loggind
@@ -23,14 +23,19 @@ func NewRouter(ticketHandler *handler.TicketHandler) *chi.Mux {
 		w.Write([]byte("OK"))
 	})
 
-	// rotas de tickets
+	// rotas de tickets (exigem autenticação)
 	r.Route("/tickets", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
 		// POST /tickets - criar novo ticket
 		r.Post("/", ticketHandler.Criar)
 
 		// GET /tickets - listar tickets
 		r.Get("/", ticketHandler.Listar)
 
+		// GET /tickets/search?q= - busca textual em título, descrição e observações
+		r.Get("/search", ticketHandler.Pesquisar)
+
 		// Rotas que precisam do ID do ticket
 		r.Route("/{id}", func(r chi.Router) {
 			// GET /tickets/{id} - obter ticket por ID
@@ -44,8 +49,197 @@ func NewRouter(ticketHandler *handler.TicketHandler) *chi.Mux {
 
 			// POST /tickets/{id}/observacoes - adicionar observações ao ticket
 			r.Post("/observacoes", ticketHandler.AdicionarObservacao)
+
+			// GET /tickets/{id}/seguidores - listar quem segue o ticket
+			r.Get("/seguidores", notificacaoHandler.ListarSeguidores)
+
+			// POST /tickets/{id}/seguidores - seguir o ticket (ou incluir outro usuário)
+			r.Post("/seguidores", notificacaoHandler.Seguir)
+
+			// DELETE /tickets/{id}/seguidores/{usuarioID} - deixar de seguir o ticket
+			r.Delete("/seguidores/{usuarioID}", notificacaoHandler.DeixarDeSeguir)
+
+			// GET /tickets/{id}/anexos - listar os arquivos do ticket
+			r.Get("/anexos", anexoHandler.Listar)
+
+			// POST /tickets/{id}/anexos - enviar arquivos (multipart/form-data)
+			r.Post("/anexos", anexoHandler.Adicionar)
+
+			// GET /tickets/{id}/anexos/{anexoID} - baixar o arquivo
+			r.Get("/anexos/{anexoID}", anexoHandler.Baixar)
+
+			// DELETE /tickets/{id}/anexos/{anexoID} - remover o arquivo
+			r.Delete("/anexos/{anexoID}", anexoHandler.Remover)
+
+			// POST /tickets/{id}/med - abrir o caso MED do ticket (categoria meds)
+			r.Post("/med", medHandler.Abrir)
+
+			// GET /tickets/{id}/med - obter o caso MED com prazos e histórico
+			r.Get("/med", medHandler.Buscar)
+
+			// PUT /tickets/{id}/med - corrigir os dados do caso em análise
+			r.Put("/med", medHandler.Corrigir)
+
+			// PATCH /tickets/{id}/med/situacao - bloquear os fundos, devolver ou rejeitar
+			r.Patch("/med/situacao", medHandler.AtualizarSituacao)
+
+			// GET /tickets/{id}/aprovacoes - situação da aprovação (ex.: solicitação de saque) e decisões
+			r.Get("/aprovacoes", aprovacaoHandler.Buscar)
+
+			// POST /tickets/{id}/aprovacoes - aprovar ou rejeitar a etapa pendente
+			r.Post("/aprovacoes", aprovacaoHandler.Decidir)
+		})
+	})
+
+	// rotas do diretório de usuários (exigem autenticação; alterações apenas para admin)
+	r.Route("/usuarios", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// POST /usuarios - cadastrar usuário
+		r.Post("/", usuarioHandler.Criar)
+
+		// GET /usuarios?equipe=&ativos=true - listar usuários
+		r.Get("/", usuarioHandler.Listar)
+
+		r.Route("/{id}", func(r chi.Router) {
+			// GET /usuarios/{id} - obter usuário
+			r.Get("/", usuarioHandler.Buscar)
+
+			// PUT /usuarios/{id} - atualizar usuário
+			r.Put("/", usuarioHandler.Atualizar)
+
+			// DELETE /usuarios/{id} - desativar usuário
+			r.Delete("/", usuarioHandler.Desativar)
+
+			// GET /usuarios/{id}/notificacoes - preferências de notificação
+			r.Get("/notificacoes", notificacaoHandler.BuscarPreferencias)
+
+			// PUT /usuarios/{id}/notificacoes - ativar ou desativar notificações
+			r.Put("/notificacoes", notificacaoHandler.AtualizarPreferencias)
+		})
+	})
+
+	// rotas de equipes (exigem autenticação; alterações apenas para admin)
+	r.Route("/equipes", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// POST /equipes - cadastrar equipe
+		r.Post("/", equipeHandler.Criar)
+
+		// GET /equipes?ativas=true - listar equipes
+		r.Get("/", equipeHandler.Listar)
+
+		r.Route("/{id}", func(r chi.Router) {
+			// GET /equipes/{id} - obter equipe
+			r.Get("/", equipeHandler.Buscar)
+
+			// PUT /equipes/{id} - atualizar equipe
+			r.Put("/", equipeHandler.Atualizar)
+
+			// DELETE /equipes/{id} - desativar equipe
+			r.Delete("/", equipeHandler.Desativar)
+		})
+	})
+
+	// rotas das filas de atendimento (exigem autenticação; alterações apenas para admin)
+	r.Route("/filas", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// POST /filas - cadastrar fila
+		r.Post("/", filaHandler.Criar)
+
+		// GET /filas?ativas=true - listar filas
+		r.Get("/", filaHandler.Listar)
+
+		// PUT /filas/{id} - atualizar fila
+		r.Put("/{id}", filaHandler.Atualizar)
+	})
+
+	// rotas da taxonomia de categorias e subcategorias (exigem autenticação; alterações apenas para admin)
+	r.Route("/categorias", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// POST /categorias - cadastrar categoria com as subcategorias
+		r.Post("/", taxonomiaHandler.Criar)
+
+		// GET /categorias?ativas=true - listar categorias e subcategorias
+		r.Get("/", taxonomiaHandler.Listar)
+
+		r.Route("/{codigo}", func(r chi.Router) {
+			// GET /categorias/{codigo} - obter categoria
+			r.Get("/", taxonomiaHandler.Buscar)
+
+			// PUT /categorias/{codigo} - renomear, desativar ou reativar categoria
+			r.Put("/", taxonomiaHandler.Atualizar)
+
+			// POST /categorias/{codigo}/subcategorias - adicionar subcategoria
+			r.Post("/subcategorias", taxonomiaHandler.AdicionarSubcategoria)
+
+			// PUT /categorias/{codigo}/subcategorias/{subcategoria} - renomear, desativar ou reativar subcategoria
+			r.Put("/subcategorias/{subcategoria}", taxonomiaHandler.AtualizarSubcategoria)
+
+			// GET /categorias/{codigo}/reclassificacoes?de=&ate= - relatório de reclassificações da categoria
+			r.Get("/reclassificacoes", taxonomiaHandler.Reclassificacoes)
+		})
+	})
+
+	// rotas dos formulários por categoria e subcategoria (exigem autenticação; alterações apenas para admin)
+	r.Route("/formularios/{categoria}", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// GET /formularios/{categoria} - formulário das categorias sem subcategorias
+		r.Get("/", formularioHandler.Buscar)
+
+		// PUT /formularios/{categoria} - criar ou substituir o formulário da categoria sem subcategorias
+		r.Put("/", formularioHandler.Salvar)
+
+		// GET /formularios/{categoria}/{subcategoria} - formulário para renderizar a abertura e a edição de tickets
+		r.Get("/{subcategoria}", formularioHandler.Buscar)
+
+		// PUT /formularios/{categoria}/{subcategoria} - criar ou substituir o formulário
+		r.Put("/{subcategoria}", formularioHandler.Salvar)
+	})
+
+	// casos MED pelo prazo vigente (exigem autenticação)
+	r.Route("/meds", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// GET /meds?situacao=&vencidos=true&prazo_ate= - acompanhamento dos prazos
+		r.Get("/", medHandler.Listar)
+	})
+
+	// rotas dos webhooks (apenas para admin)
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// POST /webhooks - cadastrar webhook
+		r.Post("/", webhookHandler.Criar)
+
+		// GET /webhooks?ativos=true - listar webhooks
+		r.Get("/", webhookHandler.Listar)
+
+		r.Route("/{id}", func(r chi.Router) {
+			// GET /webhooks/{id} - obter webhook
+			r.Get("/", webhookHandler.Buscar)
+
+			// PUT /webhooks/{id} - atualizar webhook
+			r.Put("/", webhookHandler.Atualizar)
+
+			// DELETE /webhooks/{id} - desativar webhook
+			r.Delete("/", webhookHandler.Desativar)
+
+			// GET /webhooks/{id}/entregas?status=&limite= - log de entregas
+			r.Get("/entregas", webhookHandler.ListarEntregas)
 		})
 	})
 
+	// log de notificações enviadas (apenas para admin)
+	r.Route("/notificacoes", func(r chi.Router) {
+		r.Use(Autenticacao(validador))
+
+		// GET /notificacoes?usuario=&ticket=&limite= - log de envios
+		r.Get("/", notificacaoHandler.ListarNotificacoes)
+	})
+
 	return r
 }
@@ -3,18 +3,64 @@ package http
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	emailUseCase "nox_tickets/internal/application/usecases/email"
+	filaUseCase "nox_tickets/internal/application/usecases/fila"
+	formularioUseCase "nox_tickets/internal/application/usecases/formulario"
+	medUseCase "nox_tickets/internal/application/usecases/med"
+	notificacaoUseCase "nox_tickets/internal/application/usecases/notificacao"
+	taxonomiaUseCase "nox_tickets/internal/application/usecases/taxonomia"
 	"nox_tickets/internal/application/usecases/ticket"
+	usuarioUseCase "nox_tickets/internal/application/usecases/usuario"
+	webhookUseCase "nox_tickets/internal/application/usecases/webhook"
+	"nox_tickets/internal/domain/acesso"
+	anexoDomain "nox_tickets/internal/domain/anexo"
+	emailDomain "nox_tickets/internal/domain/email"
+	"nox_tickets/internal/domain/escalonamento"
+	"nox_tickets/internal/domain/evento"
+	"nox_tickets/internal/domain/fila"
+	"nox_tickets/internal/domain/formulario"
+	"nox_tickets/internal/domain/med"
+	notificacaoDomain "nox_tickets/internal/domain/notificacao"
+	"nox_tickets/internal/domain/sla"
+	"nox_tickets/internal/domain/taxonomia"
+	ticketDomain "nox_tickets/internal/domain/ticket"
+	"nox_tickets/internal/domain/usuario"
+	"nox_tickets/internal/domain/webhook"
+	arquivoaprovacao "nox_tickets/internal/infrastructure/aprovacao"
+	"nox_tickets/internal/infrastructure/armazenamento"
+	arquivocalendario "nox_tickets/internal/infrastructure/calendario"
 	dbpostgres "nox_tickets/internal/infrastructure/database/postgres"
+	emailinfra "nox_tickets/internal/infrastructure/email"
+	arquivoescalonamento "nox_tickets/internal/infrastructure/escalonamento"
+	"nox_tickets/internal/infrastructure/jwt"
+	"nox_tickets/internal/infrastructure/notificacao"
 	repopostgres "nox_tickets/internal/infrastructure/repository/postgres"
+	arquivosla "nox_tickets/internal/infrastructure/sla"
+	clientewebhook "nox_tickets/internal/infrastructure/webhook"
 	"nox_tickets/internal/interfaces/http/handler"
 	"nox_tickets/internal/interfaces/http/router"
+	"nox_tickets/internal/interfaces/worker"
 )
 
+// tamanho máximo de um e-mail recebido pelo servidor SMTP, com os anexos
+const tamanhoMaximoEmail = 25 << 20
+
 type Server struct {
-	server *http.Server
+	server       *http.Server
+	escalonador  *worker.Escalonador
+	alertaSLA    *worker.AlertaSLA
+	relay        *worker.Relay
+	entregador   *worker.Entregador
+	caixaEntrada *worker.CaixaEntrada
+	servidorSMTP *emailinfra.ServidorSMTP // nil quando o recebimento por SMTP está desativado
+	cancelar     context.CancelFunc
 }
 
 // NewServer cria uma nova instancia do servidor HTTP
@@ -35,31 +81,340 @@ func NewServer(port string) *Server {
 		panic(fmt.Sprintf("Erro ao criar conexão com o banco de dados: %v", err))
 	}
 
-	// 2. criar o repositório do pacote repository/postgres
+	// 2. criar os repositórios do pacote repository/postgres
 	ticketRepo := repopostgres.NewTicketRepository(db)
+	usuarioRepo := repopostgres.NewUsuarioRepository(db)
+	filaRepo := repopostgres.NewFilaRepository(db)
+	webhookRepo := repopostgres.NewWebhookRepository(db)
+	outboxRepo := repopostgres.NewOutboxRepository(db)
+	notificacaoRepo := repopostgres.NewNotificacaoRepository(db)
+	emailRepo := repopostgres.NewEmailRepository(db)
+	anexoRepo := repopostgres.NewAnexoRepository(db)
+	taxonomiaRepo := repopostgres.NewTaxonomiaRepository(db)
+	formularioRepo := repopostgres.NewFormularioRepository(db)
+	medRepo := repopostgres.NewMEDRepository(db)
+
+	// 3. carregar o calendário de dias úteis (expediente, fuso e feriados)
+	caminhoCalendario := os.Getenv("NOX_CALENDARIO")
+	if caminhoCalendario == "" {
+		caminhoCalendario = "configs/calendario.json"
+	}
+	regioes := []string{}
+	if r := os.Getenv("NOX_CALENDARIO_REGIOES"); r != "" {
+		regioes = strings.Split(r, ",")
+	}
+	calendario, err := arquivocalendario.CarregarArquivo(caminhoCalendario, regioes...)
+	if err != nil {
+		panic(fmt.Sprintf("Erro ao carregar calendário: %v", err))
+	}
+
+	// políticas de SLA: prazos da matriz urgência × gravidade e políticas por categoria e subcategoria
+	caminhoSLA := os.Getenv("NOX_SLA")
+	if caminhoSLA == "" {
+		caminhoSLA = "configs/sla.json"
+	}
+	politicasSLA, err := arquivosla.CarregarArquivo(caminhoSLA)
+	if err != nil {
+		panic(fmt.Sprintf("Erro ao carregar políticas de SLA: %v", err))
+	}
+
+	// 4. carregar as regras de escalonamento e os intervalos entre as execuções dos workers
+	caminhoEscalonamento := os.Getenv("NOX_ESCALONAMENTO")
+	if caminhoEscalonamento == "" {
+		caminhoEscalonamento = "configs/escalonamento.json"
+	}
+	regras, err := arquivoescalonamento.CarregarArquivo(caminhoEscalonamento)
+	if err != nil {
+		panic(fmt.Sprintf("Erro ao carregar regras de escalonamento: %v", err))
+	}
+	intervaloEscalonamento := time.Minute
+	if v := os.Getenv("NOX_ESCALONAMENTO_INTERVALO"); v != "" {
+		intervaloEscalonamento, err = time.ParseDuration(v)
+		if err != nil || intervaloEscalonamento <= 0 {
+			panic(fmt.Sprintf("Intervalo de escalonamento inválido: %q", v))
+		}
+	}
+
+	// prazos dos casos MED, contados da notificação de infração
+	prazosMED := med.PrazosPadrao()
+	prazosConfiguraveis := []struct {
+		variavel string
+		destino  *time.Duration
+	}{
+		{"NOX_MED_PRAZO_ANALISE", &prazosMED.Analise},
+		{"NOX_MED_PRAZO_DEVOLUCAO", &prazosMED.Devolucao},
+	}
+	for _, p := range prazosConfiguraveis {
+		if v := os.Getenv(p.variavel); v != "" {
+			prazo, err := time.ParseDuration(v)
+			if err != nil || prazo <= 0 {
+				panic(fmt.Sprintf("Prazo inválido em %s: %q", p.variavel, v))
+			}
+			*p.destino = prazo
+		}
+	}
+
+	// regras de aprovação (ex.: solicitações de saque), exigidas antes da conclusão dos tickets
+	caminhoAprovacao := os.Getenv("NOX_APROVACAO")
+	if caminhoAprovacao == "" {
+		caminhoAprovacao = "configs/aprovacao.json"
+	}
+	politicaAprovacao, err := arquivoaprovacao.CarregarArquivo(caminhoAprovacao)
+	if err != nil {
+		panic(fmt.Sprintf("Erro ao carregar regras de aprovação: %v", err))
+	}
+
+	antecedenciaAlertaSLA := time.Hour
+	if v := os.Getenv("NOX_ALERTA_SLA_ANTECEDENCIA"); v != "" {
+		antecedenciaAlertaSLA, err = time.ParseDuration(v)
+		if err != nil || antecedenciaAlertaSLA <= 0 {
+			panic(fmt.Sprintf("Antecedência do alerta de SLA inválida: %q", v))
+		}
+	}
+
+	intervaloWebhooks := 5 * time.Second
+	if v := os.Getenv("NOX_WEBHOOKS_INTERVALO"); v != "" {
+		intervaloWebhooks, err = time.ParseDuration(v)
+		if err != nil || intervaloWebhooks <= 0 {
+			panic(fmt.Sprintf("Intervalo de entrega de webhooks inválido: %q", v))
+		}
+	}
+
+	intervaloOutbox := 2 * time.Second
+	if v := os.Getenv("NOX_OUTBOX_INTERVALO"); v != "" {
+		intervaloOutbox, err = time.ParseDuration(v)
+		if err != nil || intervaloOutbox <= 0 {
+			panic(fmt.Sprintf("Intervalo do relay do outbox inválido: %q", v))
+		}
+	}
+
+	// 5. configurar o envio das notificações por e-mail; sem servidor SMTP, os e-mails ficam desativados
+	var enviador *notificacao.EnviadorSMTP
+	if host := os.Getenv("NOX_SMTP_HOST"); host != "" {
+		remetente := os.Getenv("NOX_SMTP_REMETENTE")
+		if remetente == "" {
+			remetente = "NOX Tickets <nox-tickets@localhost>"
+		}
+		enviador, err = notificacao.NovoEnviadorSMTP(notificacao.ConfigSMTP{
+			Host:      host,
+			Porta:     os.Getenv("NOX_SMTP_PORTA"),
+			Usuario:   os.Getenv("NOX_SMTP_USUARIO"),
+			Senha:     os.Getenv("NOX_SMTP_SENHA"),
+			Remetente: remetente,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("Erro ao configurar o envio de e-mails: %v", err))
+		}
+	}
+	templates, err := notificacao.CarregarTemplates(calendario.Fuso())
+	if err != nil {
+		panic(fmt.Sprintf("Erro ao carregar templates de notificação: %v", err))
+	}
 
-	// 3. criar os use cases
-	criarTicketUseCase := ticket.NewCriarTicketUseCase(ticketRepo)
-	buscarTicketUseCase := ticket.NewBuscarTicketUseCase(ticketRepo)
-	listarTicketsUseCase := ticket.NewListarTicketsUseCase(ticketRepo)
-	atualizarTicketUseCase := ticket.NewAtualizarTicketUseCase(ticketRepo)
-	atualizarStatusUseCase := ticket.NewAtualizarStatusUseCase(ticketRepo)
-	adicionarObservacaoUseCase := ticket.NewAdicionarObservacaoUseCase(ticketRepo)
+	// 6. configurar o armazenamento dos anexos, no disco ou em um serviço compatível com S3,
+	// e o recebimento de e-mails, pelo maildir e/ou pelo servidor SMTP; sem nenhum dos dois,
+	// o recebimento fica desativado
+	var storage anexoDomain.Storage
+	switch tipo := os.Getenv("NOX_ANEXOS_STORAGE"); tipo {
+	case "", "local":
+		diretorioAnexos := os.Getenv("NOX_ANEXOS_DIR")
+		if diretorioAnexos == "" {
+			diretorioAnexos = "dados/anexos"
+		}
+		storage, err = armazenamento.NovoLocal(diretorioAnexos)
+	case "s3":
+		storage, err = armazenamento.NovoS3(armazenamento.ConfigS3{
+			Endpoint:     os.Getenv("NOX_S3_ENDPOINT"),
+			Regiao:       os.Getenv("NOX_S3_REGIAO"),
+			Bucket:       os.Getenv("NOX_S3_BUCKET"),
+			ChaveAcesso:  os.Getenv("NOX_S3_CHAVE_ACESSO"),
+			ChaveSecreta: os.Getenv("NOX_S3_CHAVE_SECRETA"),
+		})
+	default:
+		err = fmt.Errorf("tipo de armazenamento desconhecido: %q", tipo)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("Erro ao configurar o armazenamento de anexos: %v", err))
+	}
+	limitesAnexos := anexoDomain.LimitesPadrao()
+	if v := os.Getenv("NOX_ANEXOS_TAMANHO_MAXIMO"); v != "" {
+		limitesAnexos.TamanhoMaximo, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || limitesAnexos.TamanhoMaximo <= 0 {
+			panic(fmt.Sprintf("Tamanho máximo de anexo inválido: %q", v))
+		}
+	}
+	if v := os.Getenv("NOX_ANEXOS_TIPOS"); v != "" {
+		limitesAnexos.TiposPermitidos = nil
+		for _, tipo := range strings.Split(v, ",") {
+			if tipo = strings.TrimSpace(tipo); tipo != "" && tipo != "*" {
+				limitesAnexos.TiposPermitidos = append(limitesAnexos.TiposPermitidos, tipo)
+			}
+		}
+	}
+
+	var maildir *emailinfra.Maildir
+	var roteamentoEmail *emailDomain.Roteamento
+	caminhoMaildir, enderecoSMTP := os.Getenv("NOX_EMAIL_MAILDIR"), os.Getenv("NOX_EMAIL_SMTP_ENDERECO")
+	if caminhoMaildir != "" || enderecoSMTP != "" {
+		caminhoCaixas := os.Getenv("NOX_EMAIL_CAIXAS")
+		if caminhoCaixas == "" {
+			caminhoCaixas = "configs/email.json"
+		}
+		roteamentoEmail, err = emailinfra.CarregarArquivo(caminhoCaixas)
+		if err != nil {
+			panic(fmt.Sprintf("Erro ao carregar caixas de e-mail: %v", err))
+		}
+	}
+	if caminhoMaildir != "" {
+		maildir, err = emailinfra.NovoMaildir(caminhoMaildir)
+		if err != nil {
+			panic(fmt.Sprintf("Erro ao abrir o maildir: %v", err))
+		}
+	}
+	intervaloEmail := 30 * time.Second
+	if v := os.Getenv("NOX_EMAIL_INTERVALO"); v != "" {
+		intervaloEmail, err = time.ParseDuration(v)
+		if err != nil || intervaloEmail <= 0 {
+			panic(fmt.Sprintf("Intervalo de leitura do maildir inválido: %q", v))
+		}
+	}
+
+	// 7. criar os use cases
+	diretorio := usuario.NovoDiretorio(usuarioRepo)
+	validadorTaxonomia := taxonomia.NovoValidador(taxonomiaRepo)
+	validadorFormularios := formulario.NovoValidador(formularioRepo)
+	if roteamentoEmail != nil {
+		if err := roteamentoEmail.Validar(validadorTaxonomia); err != nil {
+			panic(fmt.Sprintf("Erro nas caixas de e-mail: %v", err))
+		}
+	}
+	if err := sla.ValidarClassificacoes(politicasSLA, validadorTaxonomia); err != nil {
+		panic(fmt.Sprintf("Erro nas políticas de SLA: %v", err))
+	}
+	if err := politicaAprovacao.ValidarClassificacoes(validadorTaxonomia); err != nil {
+		panic(fmt.Sprintf("Erro nas regras de aprovação: %v", err))
+	}
+	maquinaDeEstados := ticketDomain.MaquinaDeEstadosPadrao().
+		ComCalendario(calendario).
+		ComResponsaveis(diretorio).
+		ComGuarda(ticketDomain.StatusFinalizado, politicaAprovacao.Guarda())
+	motorSLA := sla.NovoMotor(politicasSLA, calendario)
+	roteador := fila.NovoRoteador(filaRepo, maquinaDeEstados)
+	autorizador := acesso.NovoAutorizador(acesso.PoliticaPadrao())
+	criarTicketUseCase := ticket.NewCriarTicketUseCase(ticketRepo, motorSLA, maquinaDeEstados, diretorio, roteador, validadorTaxonomia, validadorFormularios, autorizador)
+	buscarTicketUseCase := ticket.NewBuscarTicketUseCase(ticketRepo, maquinaDeEstados, motorSLA, autorizador)
+	listarTicketsUseCase := ticket.NewListarTicketsUseCase(ticketRepo, motorSLA, autorizador)
+	pesquisarTicketsUseCase := ticket.NewPesquisarTicketsUseCase(ticketRepo, motorSLA, autorizador)
+	atualizarTicketUseCase := ticket.NewAtualizarTicketUseCase(ticketRepo, motorSLA, roteador, validadorTaxonomia, validadorFormularios, autorizador)
+	atualizarStatusUseCase := ticket.NewAtualizarStatusUseCase(ticketRepo, maquinaDeEstados, autorizador)
+	adicionarObservacaoUseCase := ticket.NewAdicionarObservacaoUseCase(ticketRepo, autorizador)
+	arquivista := anexoDomain.NovoArquivista(storage, anexoRepo, limitesAnexos)
+	adicionarAnexoUseCase := ticket.NewAdicionarAnexoUseCase(ticketRepo, arquivista, autorizador)
+	motorEscalonamento := escalonamento.NovoMotor(regras, roteador)
+	// os eventos gravados no outbox são publicados no barramento, onde os webhooks os recebem
+	barramento := evento.NovoBarramento()
+	barramento.Assinar(webhook.NovoDespachante(webhookRepo).Receber)
+	if enviador != nil {
+		barramento.Assinar(notificacaoDomain.NovoNotificador(notificacaoRepo, usuarioRepo, templates, enviador).Receber)
+	} else {
+		log.Printf("NOX_SMTP_HOST não informado: notificações por e-mail desativadas")
+	}
+	relay := evento.NovoRelay(outboxRepo, barramento)
+	entregarWebhooksUseCase := webhookUseCase.NewEntregarWebhooksUseCase(webhookRepo, clientewebhook.NovoClienteHTTP(10*time.Second), 50)
+	escalonarTicketsUseCase := ticket.NewEscalonarTicketsUseCase(ticketRepo, motorEscalonamento, motorSLA)
+	alertarSLAUseCase := ticket.NewAlertarSLAUseCase(ticketRepo, motorSLA, antecedenciaAlertaSLA)
+	receberEmailUseCase := emailUseCase.NewReceberEmailUseCase(emailRepo, diretorio, roteamentoEmail, criarTicketUseCase, adicionarObservacaoUseCase, adicionarAnexoUseCase)
 
-	// 4. criar os handlers
+	// 8. criar os handlers
 	ticketHandler := handler.NewTicketHandler(
 		criarTicketUseCase,
 		buscarTicketUseCase,
 		listarTicketsUseCase,
+		pesquisarTicketsUseCase,
 		atualizarTicketUseCase,
 		atualizarStatusUseCase,
 		adicionarObservacaoUseCase,
 	)
+	usuarioHandler := handler.NewUsuarioHandler(
+		usuarioUseCase.NewCriarUsuarioUseCase(usuarioRepo, autorizador),
+		usuarioUseCase.NewBuscarUsuarioUseCase(usuarioRepo),
+		usuarioUseCase.NewListarUsuariosUseCase(usuarioRepo),
+		usuarioUseCase.NewAtualizarUsuarioUseCase(usuarioRepo, autorizador),
+	)
+	equipeHandler := handler.NewEquipeHandler(
+		usuarioUseCase.NewCriarEquipeUseCase(usuarioRepo, autorizador),
+		usuarioUseCase.NewBuscarEquipeUseCase(usuarioRepo),
+		usuarioUseCase.NewListarEquipesUseCase(usuarioRepo),
+		usuarioUseCase.NewAtualizarEquipeUseCase(usuarioRepo, autorizador),
+	)
+	webhookHandler := handler.NewWebhookHandler(
+		webhookUseCase.NewCriarWebhookUseCase(webhookRepo, diretorio, validadorTaxonomia, autorizador),
+		webhookUseCase.NewBuscarWebhookUseCase(webhookRepo, autorizador),
+		webhookUseCase.NewListarWebhooksUseCase(webhookRepo, autorizador),
+		webhookUseCase.NewAtualizarWebhookUseCase(webhookRepo, validadorTaxonomia, autorizador),
+		webhookUseCase.NewListarEntregasUseCase(webhookRepo, autorizador),
+	)
+	notificacaoHandler := handler.NewNotificacaoHandler(
+		ticket.NewListarSeguidoresUseCase(ticketRepo, notificacaoRepo, autorizador),
+		ticket.NewSeguirTicketUseCase(ticketRepo, notificacaoRepo, diretorio, autorizador),
+		ticket.NewDeixarDeSeguirTicketUseCase(ticketRepo, notificacaoRepo, autorizador),
+		notificacaoUseCase.NewBuscarPreferenciasUseCase(notificacaoRepo, usuarioRepo, autorizador),
+		notificacaoUseCase.NewAtualizarPreferenciasUseCase(notificacaoRepo, usuarioRepo, autorizador),
+		notificacaoUseCase.NewListarNotificacoesUseCase(notificacaoRepo, autorizador),
+	)
+	anexoHandler := handler.NewAnexoHandler(
+		adicionarAnexoUseCase,
+		ticket.NewListarAnexosUseCase(ticketRepo, anexoRepo, autorizador),
+		ticket.NewBaixarAnexoUseCase(ticketRepo, anexoRepo, arquivista, autorizador),
+		ticket.NewRemoverAnexoUseCase(ticketRepo, anexoRepo, arquivista, autorizador),
+		limitesAnexos.TamanhoMaximo,
+	)
+	taxonomiaHandler := handler.NewTaxonomiaHandler(
+		taxonomiaUseCase.NewCriarCategoriaUseCase(taxonomiaRepo, autorizador),
+		taxonomiaUseCase.NewListarCategoriasUseCase(taxonomiaRepo),
+		taxonomiaUseCase.NewBuscarCategoriaUseCase(taxonomiaRepo),
+		taxonomiaUseCase.NewAtualizarCategoriaUseCase(taxonomiaRepo, autorizador),
+		taxonomiaUseCase.NewAdicionarSubcategoriaUseCase(taxonomiaRepo, autorizador),
+		taxonomiaUseCase.NewAtualizarSubcategoriaUseCase(taxonomiaRepo, autorizador),
+		ticket.NewRelatorioReclassificacoesUseCase(ticketRepo, autorizador),
+	)
+	formularioHandler := handler.NewFormularioHandler(
+		formularioUseCase.NewBuscarFormularioUseCase(validadorFormularios, validadorTaxonomia),
+		formularioUseCase.NewSalvarFormularioUseCase(formularioRepo, validadorTaxonomia, autorizador),
+	)
+	medHandler := handler.NewMEDHandler(
+		medUseCase.NewAbrirCasoUseCase(ticketRepo, medRepo, prazosMED, autorizador),
+		medUseCase.NewBuscarCasoUseCase(ticketRepo, medRepo, autorizador),
+		medUseCase.NewCorrigirCasoUseCase(ticketRepo, medRepo, prazosMED, autorizador),
+		medUseCase.NewAtualizarSituacaoUseCase(ticketRepo, medRepo, autorizador),
+		medUseCase.NewListarCasosUseCase(medRepo, autorizador),
+	)
+	aprovacaoHandler := handler.NewAprovacaoHandler(
+		ticket.NewBuscarAprovacoesUseCase(ticketRepo, politicaAprovacao, autorizador),
+		ticket.NewDecidirAprovacaoUseCase(ticketRepo, politicaAprovacao, autorizador),
+	)
+	filaHandler := handler.NewFilaHandler(
+		filaUseCase.NewCriarFilaUseCase(filaRepo, validadorTaxonomia, autorizador),
+		filaUseCase.NewListarFilasUseCase(filaRepo),
+		filaUseCase.NewAtualizarFilaUseCase(filaRepo, validadorTaxonomia, autorizador),
+	)
 
-	// 5. criar o router com os handlers
-	r := router.NewRouter(ticketHandler)
+	// 9. configurar a validação dos tokens JWT (segredo HS256 e/ou arquivo JWKS com chaves RS256)
+	validador, err := jwt.NovoValidador(jwt.Config{
+		Segredo:     os.Getenv("NOX_JWT_SEGREDO"),
+		ArquivoJWKS: os.Getenv("NOX_JWT_JWKS"),
+		Emissor:     os.Getenv("NOX_JWT_EMISSOR"),
+		Audiencia:   os.Getenv("NOX_JWT_AUDIENCIA"),
+	})
+	if err != nil {
+		panic(fmt.Sprintf("Erro ao configurar autenticação: %v", err))
+	}
 
-	// 6. criar o servidor HTTP
+	// 10. criar o router com os handlers
+	r := router.NewRouter(ticketHandler, usuarioHandler, equipeHandler, filaHandler, webhookHandler, notificacaoHandler, anexoHandler, taxonomiaHandler, formularioHandler, medHandler, aprovacaoHandler, validador)
+
+	// 11. criar o servidor HTTP; os prazos valem para as rotas comuns, e o envio e o download
+	// de anexos estendem os próprios prazos na requisição
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", port),
 		Handler:      r,
@@ -67,17 +422,52 @@ func NewServer(port string) *Server {
 		WriteTimeout: 10 * time.Second,
 	}
 
-	return &Server{
-		server: srv,
+	servidor := &Server{
+		server:       srv,
+		escalonador:  worker.NewEscalonador(escalonarTicketsUseCase, intervaloEscalonamento),
+		alertaSLA:    worker.NewAlertaSLA(alertarSLAUseCase, intervaloEscalonamento),
+		relay:        worker.NewRelay(relay, intervaloOutbox),
+		entregador:   worker.NewEntregador(entregarWebhooksUseCase, intervaloWebhooks),
+		caixaEntrada: worker.NewCaixaEntrada(receberEmailUseCase, maildir, intervaloEmail),
+	}
+	if enderecoSMTP != "" {
+		dominio, _ := os.Hostname()
+		if dominio == "" {
+			dominio = "localhost"
+		}
+		servidor.servidorSMTP = emailinfra.NovoServidorSMTP(enderecoSMTP, dominio, tamanhoMaximoEmail, servidor.caixaEntrada.Receber)
 	}
+	if roteamentoEmail == nil {
+		log.Printf("NOX_EMAIL_MAILDIR e NOX_EMAIL_SMTP_ENDERECO não informados: recebimento de e-mails desativado")
+	}
+	return servidor
 }
 
-// Start inicia o servidor HTTP
+// Start inicia os workers de escalonamento, de alertas de SLA, do outbox, de webhooks e da
+// caixa de entrada, o servidor SMTP, quando configurado, e o servidor HTTP
 func (s *Server) Start() error {
+	ctx, cancelar := context.WithCancel(context.Background())
+	s.cancelar = cancelar
+	go s.escalonador.Iniciar(ctx)
+	go s.alertaSLA.Iniciar(ctx)
+	go s.relay.Iniciar(ctx)
+	go s.entregador.Iniciar(ctx)
+	go s.caixaEntrada.Iniciar(ctx)
+	if s.servidorSMTP != nil {
+		go func() {
+			if err := s.servidorSMTP.Iniciar(ctx); err != nil {
+				log.Printf("erro no servidor SMTP de recebimento: %v", err)
+			}
+		}()
+	}
+
 	return s.server.ListenAndServe()
 }
 
-// Shutdown desliga o servidor graciosamente
+// Shutdown para os workers e desliga o servidor graciosamente
 func (s *Server) Shutdown(ctx context.Context) error {
+	if s.cancelar != nil {
+		s.cancelar()
+	}
 	return s.server.Shutdown(ctx)
 }
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"nox_tickets/internal/application/usecases/ticket"
+)
+
+// AlertaSLA verifica periodicamente em segundo plano os prazos de SLA prestes a vencer
+type AlertaSLA struct {
+	useCase   *ticket.AlertarSLAUseCase
+	intervalo time.Duration
+}
+
+// NewAlertaSLA cria o worker dos alertas de SLA
+func NewAlertaSLA(useCase *ticket.AlertarSLAUseCase, intervalo time.Duration) *AlertaSLA {
+	return &AlertaSLA{useCase: useCase, intervalo: intervalo}
+}
+
+// Iniciar roda a verificação a cada intervalo até o contexto ser cancelado
+func (a *AlertaSLA) Iniciar(ctx context.Context) {
+	ticker := time.NewTicker(a.intervalo)
+	defer ticker.Stop()
+
+	for {
+		a.executar(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *AlertaSLA) executar(ctx context.Context) {
+	output, err := a.useCase.Execute(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("erro nos alertas de SLA: %v", err)
+		}
+		return
+	}
+	for _, t := range output.Alertados {
+		log.Printf("ticket %s com prazos de SLA prestes a vencer: %v", t.ID, t.Prazos)
+	}
+	for _, err := range output.Erros {
+		log.Printf("erro nos alertas de SLA: %v", err)
+	}
+}
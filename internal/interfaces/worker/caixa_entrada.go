@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	emailUseCase "nox_tickets/internal/application/usecases/email"
+	emailDomain "nox_tickets/internal/domain/email"
+	"nox_tickets/internal/infrastructure/email"
+)
+
+// CaixaEntrada transforma os e-mails recebidos em tickets e observações. Lê periodicamente
+// o maildir, quando configurado, e recebe as mensagens do servidor SMTP por Receber.
+type CaixaEntrada struct {
+	useCase   *emailUseCase.ReceberEmailUseCase
+	maildir   *email.Maildir
+	intervalo time.Duration
+}
+
+// NewCaixaEntrada cria o worker da caixa de entrada; maildir pode ser nil quando as
+// mensagens chegam só por SMTP
+func NewCaixaEntrada(useCase *emailUseCase.ReceberEmailUseCase, maildir *email.Maildir, intervalo time.Duration) *CaixaEntrada {
+	return &CaixaEntrada{useCase: useCase, maildir: maildir, intervalo: intervalo}
+}
+
+// Iniciar lê o maildir a cada intervalo até o contexto ser cancelado
+func (c *CaixaEntrada) Iniciar(ctx context.Context) {
+	if c.maildir == nil {
+		return
+	}
+	ticker := time.NewTicker(c.intervalo)
+	defer ticker.Stop()
+
+	for {
+		c.lerMaildir(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Receber processa uma mensagem; o erro indica falha temporária
+func (c *CaixaEntrada) Receber(ctx context.Context, bruto []byte) error {
+	_, err := c.processar(ctx, bruto)
+	return err
+}
+
+// processar executa o caso de uso e registra o resultado no log
+func (c *CaixaEntrada) processar(ctx context.Context, bruto []byte) (*emailUseCase.ReceberEmailOutput, error) {
+	output, err := c.useCase.Execute(ctx, emailUseCase.ReceberEmailInput{Bruto: bruto})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("erro ao processar e-mail recebido: %v", err)
+		}
+		return nil, err
+	}
+
+	switch {
+	case output.Duplicado:
+		log.Printf("e-mail %s já processado", output.MessageID)
+	case output.Resultado == emailDomain.ResultadoRejeitado:
+		log.Printf("e-mail %s rejeitado: %s", output.MessageID, output.Motivo)
+	default:
+		log.Printf("e-mail %s: %s no ticket %s, %d anexo(s)", output.MessageID, output.Resultado, output.TicketID, output.Anexos)
+		if output.Motivo != "" {
+			log.Printf("e-mail %s: %s", output.MessageID, output.Motivo)
+		}
+	}
+	return output, nil
+}
+
+// lerMaildir processa as mensagens novas; as que falham temporariamente ficam em new/
+// para a próxima rodada
+func (c *CaixaEntrada) lerMaildir(ctx context.Context) {
+	nomes, err := c.maildir.Novas()
+	if err != nil {
+		log.Printf("erro ao ler o maildir: %v", err)
+		return
+	}
+
+	for _, nome := range nomes {
+		if ctx.Err() != nil {
+			return
+		}
+		bruto, err := c.maildir.Ler(nome)
+		if err != nil {
+			log.Printf("erro ao ler o e-mail %s do maildir: %v", nome, err)
+			continue
+		}
+		output, err := c.processar(ctx, bruto)
+		if err != nil {
+			continue
+		}
+		if err := c.maildir.Concluir(nome, output.Resultado == emailDomain.ResultadoRejeitado); err != nil {
+			log.Printf("erro ao mover o e-mail %s no maildir: %v", nome, err)
+		}
+	}
+}
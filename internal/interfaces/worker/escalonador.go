@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"nox_tickets/internal/application/usecases/ticket"
+)
+
+// Escalonador executa o escalonamento de tickets periodicamente em segundo plano
+type Escalonador struct {
+	useCase   *ticket.EscalonarTicketsUseCase
+	intervalo time.Duration
+}
+
+// NewEscalonador cria o worker de escalonamento
+func NewEscalonador(useCase *ticket.EscalonarTicketsUseCase, intervalo time.Duration) *Escalonador {
+	return &Escalonador{useCase: useCase, intervalo: intervalo}
+}
+
+// Iniciar roda o escalonamento a cada intervalo até o contexto ser cancelado
+func (e *Escalonador) Iniciar(ctx context.Context) {
+	ticker := time.NewTicker(e.intervalo)
+	defer ticker.Stop()
+
+	for {
+		e.executar(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Escalonador) executar(ctx context.Context) {
+	output, err := e.useCase.Execute(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("erro no escalonamento de tickets: %v", err)
+		}
+		return
+	}
+	for _, t := range output.Escalonados {
+		log.Printf("ticket %s escalonado pelas regras %v", t.ID, t.Regras)
+	}
+	for _, err := range output.Erros {
+		log.Printf("erro no escalonamento: %v", err)
+	}
+}
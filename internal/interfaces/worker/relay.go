@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"nox_tickets/internal/domain/evento"
+)
+
+// loteRelay é o máximo de eventos publicados por rodada
+const loteRelay = 100
+
+// Relay publica periodicamente os eventos gravados no outbox
+type Relay struct {
+	relay     *evento.Relay
+	intervalo time.Duration
+}
+
+// NewRelay cria o worker do relay do outbox
+func NewRelay(relay *evento.Relay, intervalo time.Duration) *Relay {
+	return &Relay{relay: relay, intervalo: intervalo}
+}
+
+// Iniciar publica os eventos a cada intervalo até o contexto ser cancelado.
+// Uma rodada que publica um lote cheio é seguida de outra logo em seguida, para esvaziar o outbox.
+func (r *Relay) Iniciar(ctx context.Context) {
+	ticker := time.NewTicker(r.intervalo)
+	defer ticker.Stop()
+
+	for {
+		for r.executar(ctx) == loteRelay && ctx.Err() == nil {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) executar(ctx context.Context) int {
+	resultado, err := r.relay.Repassar(ctx, loteRelay)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("erro no relay do outbox: %v", err)
+		}
+		return 0
+	}
+	for _, err := range resultado.Falhas {
+		log.Printf("erro ao publicar evento: %v", err)
+	}
+	return resultado.Publicados + len(resultado.Falhas)
+}
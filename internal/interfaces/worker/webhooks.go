@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"nox_tickets/internal/application/usecases/webhook"
+)
+
+// Entregador envia periodicamente as entregas pendentes da fila de webhooks
+type Entregador struct {
+	useCase   *webhook.EntregarWebhooksUseCase
+	intervalo time.Duration
+}
+
+// NewEntregador cria o worker de entrega de webhooks
+func NewEntregador(useCase *webhook.EntregarWebhooksUseCase, intervalo time.Duration) *Entregador {
+	return &Entregador{useCase: useCase, intervalo: intervalo}
+}
+
+// Iniciar roda as entregas a cada intervalo até o contexto ser cancelado
+func (e *Entregador) Iniciar(ctx context.Context) {
+	ticker := time.NewTicker(e.intervalo)
+	defer ticker.Stop()
+
+	for {
+		e.executar(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Entregador) executar(ctx context.Context) {
+	output, err := e.useCase.Execute(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("erro na entrega de webhooks: %v", err)
+		}
+		return
+	}
+	if output.Descartadas > 0 {
+		log.Printf("%d entregas de webhook foram para a dead-letter", output.Descartadas)
+	}
+	for _, err := range output.Erros {
+		log.Printf("erro na entrega de webhooks: %v", err)
+	}
+}